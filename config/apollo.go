@@ -1,7 +1,9 @@
 package config
 
 import (
+	"encoding/json"
 	"go-zrbc/pkg/xlog"
+	"strings"
 
 	"github.com/apolloconfig/agollo/v4"
 	"github.com/apolloconfig/agollo/v4/env"
@@ -42,5 +44,41 @@ func GetConfigFromApollo(gConfig *Config) {
 	gConfig.LogLevel = client.GetStringValue("go.log_level", "")
 	gConfig.LogFile = client.GetStringValue("go.log_file", "")
 	gConfig.Agent = client.GetStringValue("go.agent", "")
+	if rateLimits := client.GetStringValue("go.rate_limits", ""); rateLimits != "" {
+		if err := json.Unmarshal([]byte(rateLimits), &gConfig.RateLimits); err != nil {
+			xlog.Errorf("error to parse go.rate_limits, err:%+v", err)
+		}
+	}
+	gConfig.AlertUrl = client.GetStringValue("go.alert_url", "")
+	gConfig.WMAlertUrl = client.GetStringValue("go.wm_alert_url", "")
+	if alertWebhooks := client.GetStringValue("go.alert_webhooks", ""); alertWebhooks != "" {
+		if err := json.Unmarshal([]byte(alertWebhooks), &gConfig.AlertWebhooks); err != nil {
+			xlog.Errorf("error to parse go.alert_webhooks, err:%+v", err)
+		}
+	}
+	if admins := client.GetStringValue("go.admins", ""); admins != "" {
+		if err := json.Unmarshal([]byte(admins), &gConfig.Admins); err != nil {
+			xlog.Errorf("error to parse go.admins, err:%+v", err)
+		}
+	}
+	if brokers := client.GetStringValue("go.kafka.brokers", ""); brokers != "" {
+		gConfig.Kafka.Brokers = strings.Split(brokers, ",")
+	}
+	gConfig.Kafka.GroupID = client.GetStringValue("go.kafka.group_id", "")
+	if topics := client.GetStringValue("go.kafka.game_event_topics", ""); topics != "" {
+		gConfig.Kafka.GameEventTopics = strings.Split(topics, ",")
+	}
+	gConfig.WsDevMode = client.GetBoolValue("go.ws_dev_mode", false)
+	if policies := client.GetStringValue("go.ws_session_policies", ""); policies != "" {
+		if err := json.Unmarshal([]byte(policies), &gConfig.WsSessionPolicies); err != nil {
+			xlog.Errorf("error to parse go.ws_session_policies, err:%+v", err)
+		}
+	}
+	gConfig.WsDrainTimeout = client.GetIntValue("go.ws_drain_timeout", 0)
+	gConfig.ES.URL = client.GetStringValue("go.es.url", "")
+	gConfig.ES.IndexerInterval = client.GetIntValue("go.es.indexer_interval", 0)
+	gConfig.ReportExport.Bucket = client.GetStringValue("go.report_export.bucket", "")
+	gConfig.ReportExport.MaxRows = client.GetIntValue("go.report_export.max_rows", 0)
+	gConfig.ReportExport.ExpireHours = client.GetIntValue("go.report_export.expire_hours", 0)
 	xlog.Info("load apollo config end")
 }
@@ -29,10 +29,72 @@ type Config struct {
 	SMSSupplier    int    `json:"sms_supplier"` // 短信验证码供应商
 	SMSModeID      string `json:"sms_mode_id"`  // 短信验证码模板id
 	ES             ES     `json:"es"`
+	// 限流规则, 代理商可在rate_limit_rule表另行设定
+	RateLimits []RateLimitRule `json:"rate_limits"`
+	// 告警推送地址, AlertUrl/WMAlertUrl 以json格式一併推送
+	AlertWebhooks []AlertWebhook `json:"alert_webhooks"`
+	// 后台操作员, 以 Authorization: Bearer <key> 登入
+	Admins []Admin `json:"admins"`
+	Kafka  Kafka   `json:"kafka"`
+	// 开发模式: 大厅长连接接受帐密与测试用万用token, 正式环境勿开
+	WsDevMode bool `json:"ws_dev_mode"`
+	// 大厅长连接单一登入, 未设定的代理商只保留会员最新的连接
+	WsSessionPolicies []WsSessionPolicy `json:"ws_session_policies"`
+	// 大厅长连接下线时等待连接离开的秒数, 0 为60秒
+	WsDrainTimeout int `json:"ws_drain_timeout"`
+	// 报表导出, 档案上传至S3
+	ReportExport ReportExport `json:"report_export"`
+}
+
+type ReportExport struct {
+	Bucket string `json:"bucket"`
+	// 单一任务最多笔数, 0 为100万笔
+	MaxRows int `json:"max_rows"`
+	// 任务与档案保留的小时数, 0 为24小时. 任务过期后由服务删除档案,
+	// bucket 另需对 report_export/ 设置晚一天过期的生命周期规则, 清掉漏删的档案
+	ExpireHours int `json:"expire_hours"`
+}
+
+type WsSessionPolicy struct {
+	AgentID       int64 `json:"agent_id"` // 0 为全部代理商
+	SingleSession bool  `json:"single_session"`
+}
+
+type Kafka struct {
+	Brokers []string `json:"brokers"`
+	GroupID string   `json:"group_id"`
+	// 荷官端/游戏服务事件, 推送至大厅长连接
+	GameEventTopics []string `json:"game_event_topics"`
+	// 本次启动从此时间(unix秒)重新消费, 0 为接续已提交的offset. 只由 --kafka-replay-from 设定,
+	// 写进设定档会让每次重启都重播
+	ReplayFrom int64 `json:"-"`
+}
+
+type AlertWebhook struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Format string `json:"format"`  // json:告警原文, telegram:Bot sendMessage
+	ChatID string `json:"chat_id"` // telegram 群组
+}
+
+type Admin struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type RateLimitRule struct {
+	VendorID  string `json:"vendor_id"` // 空值为全部代理商
+	Command   string `json:"command"`
+	Scope     string `json:"scope"`     // vendor:每代理商, member:每会员
+	Algorithm string `json:"algorithm"` // window:滑动窗口, bucket:令牌桶
+	Limit     int    `json:"limit"`
+	Window    int    `json:"window"` // 秒
 }
 
 type ES struct {
 	URL string `json:"url"`
+	// indexer 轮询 bet01/bet02/in_out_m 的间隔秒数, 0 为5秒
+	IndexerInterval int `json:"indexer_interval"`
 }
 
 type Redis struct {
@@ -5,16 +5,20 @@ import (
 
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AgentDao interface {
 	QueryByID(tx *gorm.DB, id int64) (*Agent, error)
+	QueryByIDForUpdate(tx *gorm.DB, id int64) (*Agent, error)
 	QueryByVendorID(tx *gorm.DB, vendorID string) (*Agent, error)
 	CreateAgent(tx *gorm.DB, agent *Agent) (int64, error)
 	DeleteByID(tx *gorm.DB, uniqueID int64) error
 	UpdateAgent(tx *gorm.DB, agent *Agent) error
 	UpdatesAgent(tx *gorm.DB, agentID int64, data map[string]interface{}) error
 	GetMaxMemberCountForAgent(tx *gorm.DB, agentID int64) (int, error)
+	UpdateCash(tx *gorm.DB, agentID int64, money decimal.Decimal) error
+	UpdateCredit(tx *gorm.DB, agentID int64, money decimal.Decimal) error
 }
 
 type agentDao struct{}
@@ -32,6 +36,15 @@ func (dao *agentDao) QueryByID(tx *gorm.DB, id int64) (*Agent, error) {
 	return &ret, nil
 }
 
+func (dao *agentDao) QueryByIDForUpdate(tx *gorm.DB, id int64) (*Agent, error) {
+	ret := Agent{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ret, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
 func (dao *agentDao) QueryByVendorID(tx *gorm.DB, vendorID string) (*Agent, error) {
 	ret := Agent{}
 	err := tx.Where("age002 = ?", vendorID).First(&ret).Error
@@ -61,6 +74,35 @@ func (dao *agentDao) UpdatesAgent(tx *gorm.DB, agentID int64, data map[string]in
 	return tx.Table(TableNameAgent).Where("age001 = ?", agentID).Updates(data).Error
 }
 
+// UpdateCash adds money (may be negative) to the agent cash in a single conditional statement,
+// returning ErrInsufficientCash instead of letting the balance go below zero
+func (dao *agentDao) UpdateCash(tx *gorm.DB, agentID int64, money decimal.Decimal) error {
+	result := tx.Table(TableNameAgent).
+		Where("age001 = ? AND cash + ? >= 0", agentID, money).
+		Update("cash", gorm.Expr("cash + ?", money))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrInsufficientCash
+	}
+	return nil
+}
+
+// UpdateCredit adds money (may be negative) to the agent credit the same way UpdateCash does for cash
+func (dao *agentDao) UpdateCredit(tx *gorm.DB, agentID int64, money decimal.Decimal) error {
+	result := tx.Table(TableNameAgent).
+		Where("age001 = ? AND credit + ? >= 0", agentID, money).
+		Update("credit", gorm.Expr("credit + ?", money))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrInsufficientCash
+	}
+	return nil
+}
+
 // GetMemberCount gets the total member count for an agent and the max allowed
 func (dao *agentDao) GetMaxMemberCountForAgent(tx *gorm.DB, agentID int64) (int, error) {
 	var maxCount = 0
@@ -6,6 +6,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// 代理商验签方式
+const (
+	SignModePlain = 0 // signature直接带密钥
+	SignModeHmac  = 1 // signature为HMAC-SHA256签名
+)
+
 type AgentsLoginPassDao interface {
 	QueryByID(tx *gorm.DB, id int64) (*AgentsLoginPass, error)
 	QueryByAidAndVendorID(tx *gorm.DB, aid int64, vendorID string) (*AgentsLoginPass, error)
@@ -100,6 +106,7 @@ type AgentsLoginPass struct {
 	PrefixSwitch string    `gorm:"column:prefix_switch;not null;default:N;comment:前綴碼開關" json:"prefix_switch"` // 前綴碼開關
 	OpenGameURL  string    `gorm:"column:openGame_url;not null;comment:指定網址" json:"openGame_url"`              // 指定網址
 	Subdomain    string    `gorm:"column:subdomain;not null" json:"subdomain"`
+	SignMode     int       `gorm:"column:sign_mode;not null;default:0;comment:0:明文密鑰,1:HMAC簽名" json:"sign_mode"` // 0:明文密鑰,1:HMAC簽名
 }
 
 // TableName AgentsLoginPass's table name
@@ -10,13 +10,32 @@ import (
 type AlertMessageDao interface {
 	QueryByID(tx *gorm.DB, id int64) (*AlertMessage, error)
 	QueryByMid(tx *gorm.DB, mid int64) ([]*AlertMessage, error)
+	QueryUnhandledByMids(tx *gorm.DB, mids []int64) ([]*AlertMessage, error)
 	Create(tx *gorm.DB, alertMessage *AlertMessage) (int64, error)
 	DeleteByID(tx *gorm.DB, id int64) error
 	Update(tx *gorm.DB, alertMessage *AlertMessage) error
 	Updates(tx *gorm.DB, id int64, data map[string]interface{}) error
-	QueryUnhandledMessages(tx *gorm.DB) ([]*AlertMessage, error)
+	QueryUnhandledMessages(tx *gorm.DB, afterID int64, limit int) ([]*AlertMessage, error)
+	ExistsSince(tx *gorm.DB, mid int64, ruleCode string, since time.Time) (bool, error)
+	UpdateStatus(tx *gorm.DB, id int64, from []int, to int, data map[string]interface{}) (int64, error)
+	QueryPendingPush(tx *gorm.DB, now int64, limit int) ([]*AlertMessage, error)
+	ClaimPush(tx *gorm.DB, id, now, until int64) (bool, error)
 }
 
+// 告警处理状态
+const (
+	AlertStatusUnhandled    = 0 // 未处理
+	AlertStatusHandled      = 1 // 已处理
+	AlertStatusAcknowledged = 2 // 已确认,处理中
+)
+
+// 告警推送状态
+const (
+	AlertPushPending = 0 // 待推送
+	AlertPushSent    = 1 // 已推送
+	AlertPushFailed  = 2 // 重试用尽,推送失败
+)
+
 type alertMessageDao struct{}
 
 // NewAlertMessageDao creates a new instance of AlertMessageDao
@@ -62,15 +81,70 @@ func (dao *alertMessageDao) Updates(tx *gorm.DB, id int64, data map[string]inter
 	return tx.Table(TableNameAlertMessage).Where("id = ?", id).Updates(data).Error
 }
 
-func (dao *alertMessageDao) QueryUnhandledMessages(tx *gorm.DB) ([]*AlertMessage, error) {
+// QueryUnhandledMessages returns up to limit open alerts with an id above afterID, oldest first
+func (dao *alertMessageDao) QueryUnhandledMessages(tx *gorm.DB, afterID int64, limit int) ([]*AlertMessage, error) {
+	var messages []*AlertMessage
+	err := tx.Where("status != ? AND id > ?", AlertStatusHandled, afterID).Order("id").Limit(limit).Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ExistsSince reports whether ruleCode already alerted on the member at or after since
+func (dao *alertMessageDao) ExistsSince(tx *gorm.DB, mid int64, ruleCode string, since time.Time) (bool, error) {
+	var count int64
+	err := tx.Table(TableNameAlertMessage).Where("mid = ? AND rule_code = ? AND unierrorTime >= ?", mid, ruleCode, since.Unix()).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// QueryUnhandledByMids returns the open alerts of the given members, latest first
+func (dao *alertMessageDao) QueryUnhandledByMids(tx *gorm.DB, mids []int64) ([]*AlertMessage, error) {
 	var messages []*AlertMessage
-	err := tx.Where("status = ?", 0).Find(&messages).Error
+	err := tx.Where("mid IN ? AND status != ?", mids, AlertStatusHandled).Order("id DESC").Find(&messages).Error
 	if err != nil {
 		return nil, err
 	}
 	return messages, nil
 }
 
+// UpdateStatus moves an alert from one of the from statuses to to, it reports how many rows moved
+func (dao *alertMessageDao) UpdateStatus(tx *gorm.DB, id int64, from []int, to int, data map[string]interface{}) (int64, error) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["status"] = to
+	result := tx.Table(TableNameAlertMessage).Where("id = ? AND status IN ?", id, from).Updates(data)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// QueryPendingPush returns the alerts waiting to be pushed whose next attempt is due at now
+func (dao *alertMessageDao) QueryPendingPush(tx *gorm.DB, now int64, limit int) ([]*AlertMessage, error) {
+	var messages []*AlertMessage
+	err := tx.Where("push_status = ? AND next_push_time <= ?", AlertPushPending, now).Order("id").Limit(limit).Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ClaimPush pushes the next attempt of a due alert back to until, so only the node that claimed it sends it
+func (dao *alertMessageDao) ClaimPush(tx *gorm.DB, id, now, until int64) (bool, error) {
+	result := tx.Table(TableNameAlertMessage).
+		Where("id = ? AND push_status = ? AND next_push_time <= ?", id, AlertPushPending, now).
+		Update("next_push_time", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 const TableNameAlertMessage = "alertMessage"
 
 // AlertMessage mapped from table <alertMessage>
@@ -80,8 +154,18 @@ type AlertMessage struct {
 	Message      string    `gorm:"column:message;not null" json:"message"`
 	ErrorTime    time.Time `gorm:"column:errorTime;not null" json:"errorTime"`
 	UnierrorTime int64     `gorm:"column:unierrorTime;not null" json:"unierrorTime"`
-	Operator     string    `gorm:"column:operator;not null;comment:操作者" json:"operator"`    // 操作者
-	Status       int       `gorm:"column:status;not null;comment:0:未處理1:已處理" json:"status"` // 0:未處理1:已處理
+	Operator     string    `gorm:"column:operator;not null;comment:操作者" json:"operator"`                             // 操作者
+	Status       int       `gorm:"column:status;not null;comment:0:未處理1:已處理2:已確認" json:"status"`                     // 0:未處理1:已處理2:已確認
+	RuleCode     string    `gorm:"column:rule_code;not null;comment:觸發的風控規則" json:"ruleCode"`                        // 觸發的風控規則
+	Action       string    `gorm:"column:action;not null;comment:規則處理方式" json:"action"`                              // 規則處理方式
+	PushStatus   int       `gorm:"column:push_status;not null;default:0;comment:0:待推送1:已推送2:推送失敗" json:"pushStatus"` // 0:待推送1:已推送2:推送失敗
+	PushRetries  int       `gorm:"column:push_retries;not null;default:0;comment:推送失敗次數" json:"pushRetries"`         // 推送失敗次數
+	NextPushTime int64     `gorm:"column:next_push_time;not null;default:0;comment:下次推送時間" json:"nextPushTime"`      // 下次推送時間
+	PushError    string    `gorm:"column:push_error;not null;default:'';comment:最後推送錯誤" json:"pushError"`            // 最後推送錯誤
+	PushedHooks  string    `gorm:"column:pushed_hooks;not null;default:'';comment:已送達的webhook" json:"pushedHooks"`   // 已送達的webhook
+	AckTime      int64     `gorm:"column:ack_time;not null;default:0;comment:確認時間" json:"ackTime"`                   // 確認時間
+	ResolveTime  int64     `gorm:"column:resolve_time;not null;default:0;comment:處理時間" json:"resolveTime"`           // 處理時間
+	Remark       string    `gorm:"column:remark;not null;default:'';comment:處理備註" json:"remark"`                     // 處理備註
 }
 
 // TableName AlertMessage's table name
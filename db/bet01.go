@@ -17,6 +17,10 @@ type Bet01Dao interface {
 	Update(tx *gorm.DB, bet01 *Bet01) error
 	Updates(tx *gorm.DB, id int64, data map[string]interface{}) error
 	GetBet01ListForUnsettledReport(tx *gorm.DB, date time.Time) ([]*Bet01Summary, error)
+	SumStakeByArea(tx *gorm.DB, memberID int64, gameID int, round decimal.Decimal, subRound int) (map[string]decimal.Decimal, error)
+	GetBet01ListForIndex(tx *gorm.DB, after *ReportCursor, until time.Time, limit int) ([]*Bet01Extra, error)
+	GetBet01ListForIndexSince(tx *gorm.DB, since time.Time, upTo *ReportCursor) ([]*Bet01Extra, error)
+	GetBet01ListForIndexByIDs(tx *gorm.DB, ids []int64) ([]*Bet01Extra, error)
 }
 
 type bet01Dao struct{}
@@ -54,6 +58,27 @@ func (dao *bet01Dao) Updates(tx *gorm.DB, id int64, data map[string]interface{})
 	return tx.Table(TableNameBet01).Where("bet01 = ?", id).Updates(data).Error
 }
 
+// SumStakeByArea sums the open stakes of a member in one round, keyed by bet content (bet09)
+func (dao *bet01Dao) SumStakeByArea(tx *gorm.DB, memberID int64, gameID int, round decimal.Decimal, subRound int) (map[string]decimal.Decimal, error) {
+	var rows []struct {
+		Content string          `gorm:"column:content"`
+		Stake   decimal.Decimal `gorm:"column:stake"`
+	}
+	err := tx.Table(TableNameBet01).
+		Select("bet09 AS content, SUM(bet13) AS stake").
+		Where("bet05 = ? AND bet02 = ? AND bet03 = ? AND bet04 = ? AND bet30 = ?", memberID, gameID, round, subRound, "N").
+		Group("bet09").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]decimal.Decimal, len(rows))
+	for _, row := range rows {
+		ret[row.Content] = row.Stake
+	}
+	return ret, nil
+}
+
 type Bet01Summary struct {
 	BetID      int64           `gorm:"column:betId" json:"betId"`
 	GID        int             `gorm:"column:gid" json:"gid"`
@@ -92,6 +117,59 @@ func (dao *bet01Dao) GetBet01ListForUnsettledReport(tx *gorm.DB, date time.Time)
 	return ret, nil
 }
 
+// GetBet01ListForIndex gets at most limit bets changed after the cursor and no later than until, ordered by updatetime then bet01
+func (dao *bet01Dao) GetBet01ListForIndex(tx *gorm.DB, after *ReportCursor, until time.Time, limit int) ([]*Bet01Extra, error) {
+	var ret = []*Bet01Extra{}
+	conn := bet01IndexQuery(tx).Where("bet01.updatetime <= ?", until.Format("2006-01-02 15:04:05"))
+	conn = reportPage(conn, "bet01.updatetime", "bet01.bet01", after, limit)
+
+	err := conn.Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetBet01ListForIndexSince gets the bets changed at or after since up to and including the cursor, ordered by updatetime then bet01
+func (dao *bet01Dao) GetBet01ListForIndexSince(tx *gorm.DB, since time.Time, upTo *ReportCursor) ([]*Bet01Extra, error) {
+	var ret = []*Bet01Extra{}
+	conn := indexWindow(bet01IndexQuery(tx), "bet01.updatetime", "bet01.bet01", since, upTo)
+	err := conn.Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetBet01ListForIndexByIDs gets the bets of ids, to index them again once they settle
+func (dao *bet01Dao) GetBet01ListForIndexByIDs(tx *gorm.DB, ids []int64) ([]*Bet01Extra, error) {
+	var ret = []*Bet01Extra{}
+	if len(ids) == 0 {
+		return ret, nil
+	}
+	err := bet01IndexQuery(tx).Where("bet01.bet01 IN (?)", ids).Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// bet01IndexQuery selects bet01 with the names and the settled state its report document carries
+func bet01IndexQuery(tx *gorm.DB) *gorm.DB {
+	return tx.Table(TableNameBet01).
+		Joins("LEFT JOIN game_type ON game_type.Code = bet01.bet02").Joins("LEFT JOIN member ON bet01.bet05 = member.mem001").
+		Select("bet01.*, game_type.cnname as gname, member.mem002 as user, " +
+			"EXISTS (SELECT 1 FROM bet02 WHERE bet02.bet01 = bet01.bet01) as settled")
+}
+
+// Bet01Extra is a bet01 with the names and the settled state of its report document
+type Bet01Extra struct {
+	Bet01
+	GName   string `gorm:"column:gname" json:"gname"`
+	User    string `gorm:"column:user" json:"user"`
+	Settled bool   `gorm:"column:settled" json:"settled"`
+}
+
 // Bet01 mapped from table <bet01>
 type Bet01 struct {
 	Bet01        int64           `gorm:"column:bet01;primaryKey;comment:注單編號" json:"bet01"`                    // 注單編號
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestBet01_SumStakeByArea(t *testing.T) {
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "bet01.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE bet01 (bet01 INTEGER PRIMARY KEY, bet02 INTEGER, bet03 DECIMAL(15,0), bet04 INTEGER, bet05 INTEGER, bet09 TEXT, bet13 DECIMAL(15,4), bet30 TEXT)",
+		"INSERT INTO bet01 VALUES (1, 101, 100, 1, 7, '1', 100, 'N')",
+		"INSERT INTO bet01 VALUES (2, 101, 100, 1, 7, '1', 50, 'N')",
+		"INSERT INTO bet01 VALUES (3, 101, 100, 1, 7, '2', 20, 'N')",
+		// cancelled, another round, another member
+		"INSERT INTO bet01 VALUES (4, 101, 100, 1, 7, '2', 30, 'Y')",
+		"INSERT INTO bet01 VALUES (5, 101, 100, 2, 7, '1', 40, 'N')",
+		"INSERT INTO bet01 VALUES (6, 101, 100, 1, 8, '1', 60, 'N')",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+
+	got, err := NewBet01Dao().SumStakeByArea(tx, 7, 101, decimal.NewFromInt(100), 1)
+	if err != nil {
+		t.Fatalf("sum err:(%+v)", err)
+	}
+	if len(got) != 2 || !got["1"].Equal(decimal.NewFromInt(150)) || !got["2"].Equal(decimal.NewFromInt(20)) {
+		t.Fatalf("stakes:(%+v)", got)
+	}
+}
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -15,9 +18,17 @@ type Bet02Dao interface {
 	Updates(tx *gorm.DB, bet01 int64, data map[string]interface{}) error
 	GetAgentWinloss(tx *gorm.DB, agentID int64) (decimal.Decimal, error)
 	GetBet02List(tx *gorm.DB, agentID int64, startTime, endTime int64) ([]int64, error)
-	GetBet02ListForDateTimeReport(tx *gorm.DB, memberID, agentID, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) ([]*Bet02Extra, error)
-	GetBet02ListForTipReport(tx *gorm.DB, memberID, agentID, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) ([]*Bet02Extra, error)
+	GetBet02ListForDateTimeReport(tx *gorm.DB, memberID, agentID, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string, after *ReportCursor, limit int) ([]*Bet02Extra, error)
+	GetBet02CountForDateTimeReport(tx *gorm.DB, memberID, agentID, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) ([]*Bet02Count, error)
+	GetBet02ListForTipReport(tx *gorm.DB, memberID, agentID, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string, after *ReportCursor, limit int) ([]*Bet02Extra, error)
 	GetBet02ListForReportDetail(tx *gorm.DB, betID int64) (*Bet02Extra, error)
+	GetBet02ListForIndex(tx *gorm.DB, after *ReportCursor, until time.Time, limit int) ([]*Bet02Extra, error)
+	GetBet02ListForIndexSince(tx *gorm.DB, since time.Time, upTo *ReportCursor) ([]*Bet02Extra, error)
+	GetBet02ListForIndexByIDs(tx *gorm.DB, ids []int64) ([]*Bet02Extra, error)
+	SumBet02ForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) (*ReconcileTotals, error)
+	GetBet02RowsForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) ([]*ReconcileRow, error)
+	GetBet02AgentsForReconcile(tx *gorm.DB, start, end time.Time) ([]int64, error)
+	SumBetSince(tx *gorm.DB, memberID int64, since time.Time) (decimal.Decimal, error)
 }
 
 type bet02Dao struct{}
@@ -91,13 +102,124 @@ func (dao *bet02Dao) GetBet02List(tx *gorm.DB, agentID int64, startTime, endTime
 	return memberIDs, nil
 }
 
-// GetMemberReport gets member report data based on where clause and action
-func (dao *bet02Dao) GetBet02ListForDateTimeReport(tx *gorm.DB, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) ([]*Bet02Extra, error) {
+// GetBet02ListForDateTimeReport gets one page of the date time report, at most limit bets after the cursor ordered by the report time then bet01
+func (dao *bet02Dao) GetBet02ListForDateTimeReport(tx *gorm.DB, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string, after *ReportCursor, limit int) ([]*Bet02Extra, error) {
 	var ret = []*Bet02Extra{}
 	conn := tx.Table("bet02").Joins("LEFT JOIN game_info ON bet02 = game_info.gi001 AND bet03 = game_info.gi002 AND bet04 = game_info.gi003").
 		Joins("LEFT JOIN member ON bet05 = member.mem001").Joins("LEFT JOIN game_type ON game_type.Code = bet02").
 		Select("bet02.*, game_info.gi007 as result, member.mem002 as user, game_type.cnname as gname")
+	conn = dateTimeReportWhere(conn, memberID, agentID, startTime, endTime, dataType, timeType, gameNo1, gameNo2)
+	timeColumn := "bet02.bet08"
+	if timeType == 1 {
+		timeColumn = "bet02.updatetime"
+	}
+	conn = reportPage(conn, timeColumn, "bet02.bet01", after, limit)
+
+	err := conn.Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetBet02ListForIndex gets at most limit bets changed after the cursor and no later than until, ordered by updatetime then bet01
+func (dao *bet02Dao) GetBet02ListForIndex(tx *gorm.DB, after *ReportCursor, until time.Time, limit int) ([]*Bet02Extra, error) {
+	var ret = []*Bet02Extra{}
+	conn := bet02IndexQuery(tx).Where("bet02.updatetime <= ?", until.Format("2006-01-02 15:04:05"))
+	conn = reportPage(conn, "bet02.updatetime", "bet02.bet01", after, limit)
+
+	err := conn.Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetBet02ListForIndexSince gets the bets changed at or after since up to and including the cursor, ordered by updatetime then bet01
+func (dao *bet02Dao) GetBet02ListForIndexSince(tx *gorm.DB, since time.Time, upTo *ReportCursor) ([]*Bet02Extra, error) {
+	var ret = []*Bet02Extra{}
+	conn := indexWindow(bet02IndexQuery(tx), "bet02.updatetime", "bet02.bet01", since, upTo)
+	err := conn.Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// bet02IndexQuery selects bet02 with the columns of its report document
+func bet02IndexQuery(tx *gorm.DB) *gorm.DB {
+	return tx.Table("bet02").Joins("LEFT JOIN game_info ON bet02 = game_info.gi001 AND bet03 = game_info.gi002 AND bet04 = game_info.gi003").
+		Joins("LEFT JOIN member ON bet05 = member.mem001").Joins("LEFT JOIN game_type ON game_type.Code = bet02").
+		Select("bet02.*, game_info.gi007 as result, member.mem002 as user, game_type.cnname as gname")
+}
+
+// GetBet02ListForIndexByIDs gets the bets of ids with the columns GetBet02ListForIndex reads
+func (dao *bet02Dao) GetBet02ListForIndexByIDs(tx *gorm.DB, ids []int64) ([]*Bet02Extra, error) {
+	var ret = []*Bet02Extra{}
+	if len(ids) == 0 {
+		return ret, nil
+	}
+	err := bet02IndexQuery(tx).Where("bet02.bet01 IN ?", ids).Order("bet02.bet01").Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// SumBet02ForReconcile sums the bets of an agent placed in [start, end)
+func (dao *bet02Dao) SumBet02ForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) (*ReconcileTotals, error) {
+	ret := &ReconcileTotals{}
+	err := tx.Table("bet02").
+		Select("COUNT(*) as count, COALESCE(SUM(bet13), 0) as bet, COALESCE(SUM(bet17), 0) as result, COALESCE(SUM(bet16), 0) as water").
+		Where("bet22 = ? AND bet08 >= ? AND bet08 < ?", agentID, start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05")).
+		Scan(ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetBet02RowsForReconcile gets the id and updatetime of the bets SumBet02ForReconcile sums
+func (dao *bet02Dao) GetBet02RowsForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) ([]*ReconcileRow, error) {
+	var ret = []*ReconcileRow{}
+	err := tx.Table("bet02").Select("bet01 as id, updatetime").
+		Where("bet22 = ? AND bet08 >= ? AND bet08 < ?", agentID, start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05")).
+		Order("bet01").Scan(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetBet02AgentsForReconcile gets the agents with bets placed in [start, end)
+func (dao *bet02Dao) GetBet02AgentsForReconcile(tx *gorm.DB, start, end time.Time) ([]int64, error) {
+	var ret = []int64{}
+	err := tx.Table("bet02").Distinct("bet22").
+		Where("bet08 >= ? AND bet08 < ?", start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05")).
+		Order("bet22").Pluck("bet22", &ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetBet02CountForDateTimeReport sums the bets GetBet02ListForDateTimeReport would return, one row per game type
+func (dao *bet02Dao) GetBet02CountForDateTimeReport(tx *gorm.DB, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) ([]*Bet02Count, error) {
+	var ret = []*Bet02Count{}
+	conn := tx.Table("bet02").Joins("LEFT JOIN game_type ON game_type.Code = bet02").
+		Select("bet02 as gid, MAX(game_type.cnname) as gname, COUNT(*) as count, SUM(bet13) as bet, SUM(bet41) as valid_bet, " +
+			"SUM(bet16) as water, SUM(bet17) as result, SUM(bet14 - bet13) as win_loss")
+	conn = dateTimeReportWhere(conn, memberID, agentID, startTime, endTime, dataType, timeType, gameNo1, gameNo2)
+
+	err := conn.Group("bet02").Order("bet02").Scan(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
 
+// dateTimeReportWhere applies the filters shared by the date time report list and its totals
+func dateTimeReportWhere(conn *gorm.DB, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) *gorm.DB {
 	if memberID != 0 {
 		conn = conn.Where("bet05 = ?", memberID)
 	} else {
@@ -122,15 +244,33 @@ func (dao *bet02Dao) GetBet02ListForDateTimeReport(tx *gorm.DB, memberID int64,
 	} else {
 		conn = conn.Where("bet08 BETWEEN ? AND ?", time.Unix(startTime, 0).Format("2006-01-02 15:04:05"), time.Unix(endTime, 0).Format("2006-01-02 15:04:05"))
 	}
+	return conn
+}
 
-	err := conn.Find(&ret).Error
-	if err != nil {
-		return nil, err
+// reportPage orders a bet report by timeColumn then idColumn and keeps the limit rows after the cursor, limit 0 keeps them all
+func reportPage(conn *gorm.DB, timeColumn, idColumn string, after *ReportCursor, limit int) *gorm.DB {
+	if after != nil {
+		at := time.UnixMilli(after.Time).Format("2006-01-02 15:04:05")
+		conn = conn.Where(fmt.Sprintf("(%s > ? OR (%s = ? AND %s > ?))", timeColumn, timeColumn, idColumn), at, at, after.Bet01)
 	}
-	return ret, nil
+	conn = conn.Order(timeColumn).Order(idColumn)
+	if limit > 0 {
+		conn = conn.Limit(limit)
+	}
+	return conn
+}
+
+// indexWindow keeps the rows of a bet report changed at or after since up to and including the cursor, ordered by
+// timeColumn then idColumn
+func indexWindow(conn *gorm.DB, timeColumn, idColumn string, since time.Time, upTo *ReportCursor) *gorm.DB {
+	at := time.UnixMilli(upTo.Time).Format("2006-01-02 15:04:05")
+	return conn.Where(fmt.Sprintf("%s >= ?", timeColumn), since.Format("2006-01-02 15:04:05")).
+		Where(fmt.Sprintf("(%s < ? OR (%s = ? AND %s <= ?))", timeColumn, timeColumn, idColumn), at, at, upTo.Bet01).
+		Order(timeColumn).Order(idColumn)
 }
 
-func (dao *bet02Dao) GetBet02ListForTipReport(tx *gorm.DB, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) ([]*Bet02Extra, error) {
+// GetBet02ListForTipReport gets one page of the tip report, at most limit tips after the cursor ordered by bet08 then bet01
+func (dao *bet02Dao) GetBet02ListForTipReport(tx *gorm.DB, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string, after *ReportCursor, limit int) ([]*Bet02Extra, error) {
 	var ret = []*Bet02Extra{}
 	conn := tx.Table("bet02").Joins("LEFT JOIN game_type ON game_type.Code = bet02").Joins("LEFT JOIN member ON bet05 = member.mem001").Select("bet02.*, game_type.cnname as gname, member.mem002 as user").Where("category = 2")
 
@@ -149,6 +289,7 @@ func (dao *bet02Dao) GetBet02ListForTipReport(tx *gorm.DB, memberID int64, agent
 	}
 	// Add time range filters
 	conn = conn.Where("bet08 BETWEEN ? AND ?", time.Unix(startTime, 0).Format("2006-01-02 15:04:05"), time.Unix(endTime, 0).Format("2006-01-02 15:04:05"))
+	conn = reportPage(conn, "bet02.bet08", "bet02.bet01", after, limit)
 
 	err := conn.Find(&ret).Error
 	if err != nil {
@@ -157,6 +298,17 @@ func (dao *bet02Dao) GetBet02ListForTipReport(tx *gorm.DB, memberID int64, agent
 	return ret, nil
 }
 
+// SumBetSince sums the bet amount a member placed at or after since
+func (dao *bet02Dao) SumBetSince(tx *gorm.DB, memberID int64, since time.Time) (decimal.Decimal, error) {
+	var sum decimal.Decimal
+	err := tx.Table(TableNameBet02).Select("COALESCE(SUM(bet13), 0)").
+		Where("bet05 = ? AND bet08 >= ?", memberID, since.Format("2006-01-02 15:04:05")).Scan(&sum).Error
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return sum, nil
+}
+
 func (dao *bet02Dao) GetBet02ListForReportDetail(tx *gorm.DB, betID int64) (*Bet02Extra, error) {
 	var ret = &Bet02Extra{}
 	if err := tx.Table("bet02").Joins("LEFT JOIN game_info ON bet02 = game_info.gi001 AND bet03 = game_info.gi002 AND bet04 = game_info.gi003").
@@ -226,6 +378,67 @@ type Bet02 struct {
 	Updatetime     time.Time       `gorm:"column:updatetime;not null;default:current_timestamp();comment:更新時間" json:"updatetime"` // 更新時間
 }
 
+const (
+	// ReportPageSize is the most bets a page of the date time or tip report after the first holds
+	ReportPageSize = 5000
+	// ReportFirstPageSize is the most bets of the first page, the cap vendors that never send a page token always had
+	ReportFirstPageSize = 10000
+)
+
+// Sources a report scan reads from, the whole scan stays on the source of its first page
+const (
+	ReportSourceES = "es"
+	ReportSourceDB = "db"
+)
+
+// ReportPageLimit is the size of the page after the cursor, a full page means another one may follow
+func ReportPageLimit(after *ReportCursor) int {
+	if after == nil {
+		return ReportFirstPageSize
+	}
+	return ReportPageSize
+}
+
+// ReportCursor is where a page of a bet02 report ended, the report time in unix millis and the bet01 of its last bet.
+// ES search_after and the MySQL keyset both resume right after it; Source keeps the scan on the store it started on,
+// the two may lag each other and a scan that switched could skip or repeat bets.
+type ReportCursor struct {
+	Time   int64  `json:"t"`
+	Bet01  int64  `json:"id"`
+	Source string `json:"s,omitempty"`
+}
+
+// Token encodes the cursor as the opaque page token handed to vendors, the nil cursor of a finished report is empty
+func (c *ReportCursor) Token() string {
+	if c == nil {
+		return ""
+	}
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseReportCursor decodes a page token, the empty token is the first page and yields nil
+func ParseReportCursor(token string) (*ReportCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var c ReportCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	if c.Bet01 <= 0 {
+		return nil, fmt.Errorf("page token without a bet")
+	}
+	if c.Source != "" && c.Source != ReportSourceES && c.Source != ReportSourceDB {
+		return nil, fmt.Errorf("page token of unknown source %q", c.Source)
+	}
+	return &c, nil
+}
+
 type Bet02Extra struct {
 	Bet02
 	Result string `gorm:"column:result;not null;comment:結果" json:"result"`
@@ -233,6 +446,40 @@ type Bet02Extra struct {
 	GName  string `gorm:"column:gname;not null;comment:遊戲名稱" json:"gname"`
 }
 
+// Bet02Count is the per game type total of a bet02 report
+type Bet02Count struct {
+	GID      int             `gorm:"column:gid" json:"gid"`
+	GName    string          `gorm:"column:gname" json:"gname"`
+	Count    int64           `gorm:"column:count" json:"count"`
+	Bet      decimal.Decimal `gorm:"column:bet" json:"bet"`
+	ValidBet decimal.Decimal `gorm:"column:valid_bet" json:"validBet"`
+	Water    decimal.Decimal `gorm:"column:water" json:"water"`
+	Result   decimal.Decimal `gorm:"column:result" json:"result"`
+	WinLoss  decimal.Decimal `gorm:"column:win_loss" json:"winLoss"`
+}
+
+// ReconcileTotals is what the consistency check compares between a table and its report index. Bet, Result
+// and Water are the bet02 bet13, bet17 and bet16, Money the in_out_m iom004.
+type ReconcileTotals struct {
+	Count  int64           `gorm:"column:count" json:"count"`
+	Bet    decimal.Decimal `gorm:"column:bet" json:"bet"`
+	Result decimal.Decimal `gorm:"column:result" json:"result"`
+	Water  decimal.Decimal `gorm:"column:water" json:"water"`
+	Money  decimal.Decimal `gorm:"column:money" json:"money"`
+}
+
+// Equal reports whether both totals agree to the 4 decimals the tables keep
+func (t *ReconcileTotals) Equal(o *ReconcileTotals) bool {
+	return t.Count == o.Count && t.Bet.Round(4).Equal(o.Bet.Round(4)) && t.Result.Round(4).Equal(o.Result.Round(4)) &&
+		t.Water.Round(4).Equal(o.Water.Round(4)) && t.Money.Round(4).Equal(o.Money.Round(4))
+}
+
+// ReconcileRow is a row id and the time it last changed
+type ReconcileRow struct {
+	ID         int64     `gorm:"column:id"`
+	Updatetime time.Time `gorm:"column:updatetime"`
+}
+
 // TableName Bet02's table name
 func (*Bet02) TableName() string {
 	return TableNameBet02
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newReportDB opens a sqlite with the bet02 columns the date time report filters and sums
+func newReportDB(t *testing.T) *gorm.DB {
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "report.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE game_type (Code INTEGER PRIMARY KEY, cnname TEXT NOT NULL)",
+		"CREATE TABLE bet02 (bet01 INTEGER PRIMARY KEY, bet02 INTEGER, bet03 TEXT, bet04 INTEGER, bet05 INTEGER, bet08 DATETIME, bet09 TEXT, " +
+			"bet13 DECIMAL(15,4), bet14 DECIMAL(15,4), bet16 DECIMAL(15,4), bet17 DECIMAL(15,4), bet22 INTEGER, bet41 DECIMAL(15,4), updatetime DATETIME)",
+		"CREATE TABLE game_info (gi001 INTEGER, gi002 TEXT, gi003 INTEGER, gi007 TEXT)",
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT)",
+		"INSERT INTO game_type (Code, cnname) VALUES (101, '百家乐'), (102, '龙虎')",
+		"INSERT INTO member (mem001, mem002) VALUES (1, 'tom'), (2, 'jerry')",
+		// member 1 of agent 9: two baccarat bets, one dragon tiger bet, one tip and one bet outside the period
+		"INSERT INTO bet02 VALUES (1, 101, '100', 1, 1, '2024-01-01 10:00:00', 'Banker', 100, 195, 1, 95, 9, 100, '2024-01-01 10:01:00')",
+		"INSERT INTO bet02 VALUES (2, 101, '100', 2, 1, '2024-01-01 11:00:00', 'Player', 50, 0, 0.5, -50, 9, 50, '2024-01-01 11:01:00')",
+		"INSERT INTO bet02 VALUES (3, 102, '200', 1, 1, '2024-01-01 12:00:00', 'Dragon', 20, 40, 0.2, 20, 9, 20, '2024-01-01 12:01:00')",
+		"INSERT INTO bet02 VALUES (4, 101, '100', 3, 1, '2024-01-01 13:00:00', 'Tip_1_', 10, 0, 0, -10, 9, 0, '2024-01-01 13:01:00')",
+		"INSERT INTO bet02 VALUES (5, 101, '100', 4, 1, '2024-01-03 10:00:00', 'Banker', 30, 0, 0, -30, 9, 30, '2024-01-03 10:01:00')",
+		// member 2 of another agent
+		"INSERT INTO bet02 VALUES (6, 101, '100', 1, 2, '2024-01-01 10:00:00', 'Banker', 70, 0, 0, -70, 8, 70, '2024-01-01 10:01:00')",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	return tx
+}
+
+func TestBet02_GetBet02CountForDateTimeReport(t *testing.T) {
+	tx := newReportDB(t)
+	dao := NewBet02Dao()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local).Unix()
+	end := start + 86400
+
+	counts, err := dao.GetBet02CountForDateTimeReport(tx, 0, 9, start, end, 0, 0, "", "")
+	if err != nil {
+		t.Fatalf("count err:(%+v)", err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("counts:(%+v), want 2 game types", counts)
+	}
+	bac, dt := counts[0], counts[1]
+	if bac.GID != 101 || bac.GName != "百家乐" || bac.Count != 2 {
+		t.Fatalf("baccarat count:(%+v)", bac)
+	}
+	if !bac.Bet.Equal(decimal.NewFromInt(150)) || !bac.ValidBet.Equal(decimal.NewFromInt(150)) ||
+		!bac.Water.Equal(decimal.RequireFromString("1.5")) || !bac.Result.Equal(decimal.NewFromInt(45)) ||
+		!bac.WinLoss.Equal(decimal.NewFromInt(45)) {
+		t.Fatalf("baccarat totals:(%+v)", bac)
+	}
+	if dt.GID != 102 || dt.Count != 1 || !dt.Bet.Equal(decimal.NewFromInt(20)) {
+		t.Fatalf("dragon tiger count:(%+v)", dt)
+	}
+
+	// Tips only, counted the same way the list filters them
+	counts, err = dao.GetBet02CountForDateTimeReport(tx, 1, 9, start, end, 1, 0, "", "")
+	if err != nil {
+		t.Fatalf("count tips err:(%+v)", err)
+	}
+	if len(counts) != 1 || counts[0].Count != 1 || !counts[0].Bet.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("tip counts:(%+v)", counts)
+	}
+
+	// Nothing in the period yields no rows rather than a zero row
+	counts, err = dao.GetBet02CountForDateTimeReport(tx, 0, 9, end+86400*5, end+86400*6, 0, 0, "", "")
+	if err != nil {
+		t.Fatalf("count empty err:(%+v)", err)
+	}
+	if len(counts) != 0 {
+		t.Fatalf("empty period counts:(%+v)", counts)
+	}
+}
+
+func TestBet02_GetBet02ListForDateTimeReport_Pages(t *testing.T) {
+	tx := newReportDB(t)
+	dao := NewBet02Dao()
+	// bet 7 shares the bet time of bet 1, bet01 orders them
+	if err := tx.Exec("INSERT INTO bet02 VALUES (7, 102, '200', 2, 1, '2024-01-01 10:00:00', 'Tiger', 5, 0, 0, -5, 9, 5, '2024-01-01 12:30:00')").Error; err != nil {
+		t.Fatalf("insert err:(%+v)", err)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local).Unix()
+	end := start + 3*86400
+
+	var (
+		got   []int64
+		after *ReportCursor
+	)
+	for page := 0; ; page++ {
+		list, err := dao.GetBet02ListForDateTimeReport(tx, 0, 9, start, end, 0, 0, "", "", after, 2)
+		if err != nil {
+			t.Fatalf("page %d err:(%+v)", page, err)
+		}
+		for _, bet := range list {
+			got = append(got, bet.Bet01)
+		}
+		if len(list) < 2 {
+			break
+		}
+		last := list[len(list)-1]
+		if last.User != "tom" {
+			t.Fatalf("bet:(%+v), want the member joined", last)
+		}
+		// sqlite reads the wall clock as UTC where MySQL with loc=Local reads it local
+		at := time.Date(last.Bet08.Year(), last.Bet08.Month(), last.Bet08.Day(), last.Bet08.Hour(), last.Bet08.Minute(), last.Bet08.Second(), 0, time.Local)
+		// the cursor goes through its token like a vendor sends it back
+		after, err = ParseReportCursor((&ReportCursor{Time: at.UnixMilli(), Bet01: last.Bet01}).Token())
+		if err != nil {
+			t.Fatalf("parse token err:(%+v)", err)
+		}
+	}
+	want := []int64{1, 7, 2, 3, 5}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("paged bets:(%v), want:(%v)", got, want)
+	}
+
+	// Settle time orders bet 7 after bet 3
+	list, err := dao.GetBet02ListForDateTimeReport(tx, 1, 9, start, end, 0, 1, "", "", nil, 0)
+	if err != nil {
+		t.Fatalf("settle time err:(%+v)", err)
+	}
+	got = got[:0]
+	for _, bet := range list {
+		got = append(got, bet.Bet01)
+	}
+	if want := []int64{1, 2, 3, 7, 5}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("settle time bets:(%v), want:(%v)", got, want)
+	}
+}
+
+func TestParseReportCursor(t *testing.T) {
+	if c, err := ParseReportCursor(""); c != nil || err != nil {
+		t.Fatalf("empty token:(%+v), err:(%+v)", c, err)
+	}
+	c, err := ParseReportCursor((&ReportCursor{Time: 1704103200000, Bet01: 42, Source: ReportSourceES}).Token())
+	if err != nil || c.Time != 1704103200000 || c.Bet01 != 42 || c.Source != ReportSourceES {
+		t.Fatalf("cursor:(%+v), err:(%+v)", c, err)
+	}
+	if token := (*ReportCursor)(nil).Token(); token != "" {
+		t.Fatalf("token of the last page:%q", token)
+	}
+	unknown := (&ReportCursor{Time: 1704103200000, Bet01: 42, Source: "file"}).Token()
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "e30", unknown} {
+		if _, err := ParseReportCursor(token); err == nil {
+			t.Fatalf("token %q parsed", token)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BetSerialDao interface {
+	QueryByMemberSerial(tx *gorm.DB, mid int64, serial int) (*BetSerial, error)
+	Create(tx *gorm.DB, record *BetSerial) (int64, error)
+}
+
+type betSerialDao struct{}
+
+func NewBetSerialDao() BetSerialDao {
+	return &betSerialDao{}
+}
+
+func (dao *betSerialDao) QueryByMemberSerial(tx *gorm.DB, mid int64, serial int) (*BetSerial, error) {
+	ret := BetSerial{}
+	err := tx.Where("mid = ? AND serial = ?", mid, serial).First(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (dao *betSerialDao) Create(tx *gorm.DB, record *BetSerial) (int64, error) {
+	err := tx.Table(TableNameBetSerial).Create(record).Error
+	if err != nil {
+		return 0, err
+	}
+	return record.ID, nil
+}
+
+const TableNameBetSerial = "bet_serial"
+
+// BetSerial mapped from table <bet_serial>, the answer given to a websocket bet so a resent
+// serial gets it again instead of a second debit
+type BetSerial struct {
+	ID         int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	Mid        int64     `gorm:"column:mid;not null;comment:会员ID" json:"mid"`       // 会员ID
+	Serial     int       `gorm:"column:serial;not null;comment:下注序号" json:"serial"` // 下注序号
+	Result     string    `gorm:"column:result;not null;comment:下注结果" json:"result"` // 下注结果
+	CreateTime time.Time `gorm:"column:create_time;not null" json:"createTime"`
+}
+
+// TableName BetSerial's table name
+func (*BetSerial) TableName() string {
+	return TableNameBetSerial
+}
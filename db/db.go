@@ -1,6 +1,7 @@
 package db
 
 import (
+	"errors"
 	"go-zrbc/config"
 	"go-zrbc/pkg/xlog"
 	"log"
@@ -12,6 +13,9 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// ErrInsufficientCash is returned by conditional cash updates that would leave a negative balance
+var ErrInsufficientCash = errors.New("insufficient cash")
+
 func NewDBHandler() *gorm.DB {
 	newLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer
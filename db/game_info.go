@@ -12,6 +12,7 @@ type GameInfoDao interface {
 	QueryByID(tx *gorm.DB, gi001 int, gi002 int64, gi003 int) (*GameInfo, error)
 	QueryByGameType(tx *gorm.DB, gi001 int) ([]*GameInfo, error)
 	QueryByStatus(tx *gorm.DB, gi013 int) ([]*GameInfo, error)
+	QueryLatestByTable(tx *gorm.DB, gi011 int) (*GameInfo, error)
 	Create(tx *gorm.DB, gameInfo *GameInfo) error
 	Delete(tx *gorm.DB, gi001 int, gi002 int64, gi003 int) error
 	Update(tx *gorm.DB, gameInfo *GameInfo) error
@@ -51,6 +52,16 @@ func (dao *gameInfoDao) QueryByStatus(tx *gorm.DB, gi013 int) ([]*GameInfo, erro
 	return gameInfos, nil
 }
 
+// QueryLatestByTable returns the round last opened on table gi011
+func (dao *gameInfoDao) QueryLatestByTable(tx *gorm.DB, gi011 int) (*GameInfo, error) {
+	ret := GameInfo{}
+	err := tx.Where("gi011 = ?", gi011).Order("gi004 DESC, gi002 DESC, gi003 DESC").First(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
 func (dao *gameInfoDao) Create(tx *gorm.DB, gameInfo *GameInfo) error {
 	return tx.Table("game_info").Create(gameInfo).Error
 }
@@ -14,10 +14,20 @@ type InOutMDao interface {
 	DeleteByID(tx *gorm.DB, id int64) error
 	Update(tx *gorm.DB, record *InOutM) error
 	Updates(tx *gorm.DB, id int64, data map[string]interface{}) error
-	CountTransactionsInLastMinute(tx *gorm.DB, memberID int64) (int64, error)
+	GetTransferStatsSince(tx *gorm.DB, memberID int64, since time.Time) (*TransferStats, error)
+	CountOtherAgentsSince(tx *gorm.DB, memberID, agentID int64, since time.Time) (int64, error)
 	GetLastTransaction(tx *gorm.DB, memberID int64, orderNum string) (*InOutM, error)
 	DealInsRecord(tx *gorm.DB, code string, site, alv, aid, mid int64, ioamt decimal.Decimal, memo string, cash decimal.Decimal) (int64, error)
 	GetInOutMs(tx *gorm.DB, mids []int64, orderID, order string, startTime, endTime int64) ([]*InOutM, error)
+	QueryByAgentAndOrder(tx *gorm.DB, agentID int64, orderNum string) (*InOutM, error)
+	QueryRecentByMember(tx *gorm.DB, memberID int64, limit int) ([]*InOutM, error)
+	GetInOutMListForIndex(tx *gorm.DB, afterID int64, until time.Time, limit int) ([]*InOutM, error)
+	GetInOutMListForIndexSince(tx *gorm.DB, since time.Time, maxID int64) ([]*InOutM, error)
+	GetInOutMListByIDs(tx *gorm.DB, ids []int64) ([]*InOutM, error)
+	SumInOutMForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) (*ReconcileTotals, error)
+	GetInOutMRowsForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) ([]*ReconcileRow, error)
+	GetInOutMAgentsForReconcile(tx *gorm.DB, start, end time.Time) ([]int64, error)
+	GetInOutMListForExport(tx *gorm.DB, agentID, memberID int64, start, end time.Time, afterID int64, limit int) ([]*InOutM, error)
 }
 
 type inOutMDao struct{}
@@ -51,6 +61,19 @@ func (dao *inOutMDao) GetLastTransaction(tx *gorm.DB, memberID int64, orderNum s
 	return &result, nil
 }
 
+// QueryByAgentAndOrder gets the latest transfer an agent made with the given order number
+func (dao *inOutMDao) QueryByAgentAndOrder(tx *gorm.DB, agentID int64, orderNum string) (*InOutM, error) {
+	ret := InOutM{}
+	err := tx.Table(TableNameInOutM).
+		Where("iom007 = ? AND iom008 = ? AND iom005 in ('121','122')", agentID, orderNum).
+		Order("iom002 DESC").
+		First(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
 func (dao *inOutMDao) QueryByID(tx *gorm.DB, id int64) (*InOutM, error) {
 	ret := InOutM{}
 	err := tx.First(&ret, id).Error
@@ -89,26 +112,47 @@ func (dao *inOutMDao) Updates(tx *gorm.DB, id int64, data map[string]interface{}
 	return tx.Table(TableNameInOutM).Where("iom001 = ?", id).Updates(data).Error
 }
 
-// CountTransactionsInLastMinute counts the number of transactions for a member in the last minute
-func (dao *inOutMDao) CountTransactionsInLastMinute(tx *gorm.DB, memberID int64) (int64, error) {
+// QueryRecentByMember returns the latest limit wallet records of a member
+func (dao *inOutMDao) QueryRecentByMember(tx *gorm.DB, memberID int64, limit int) ([]*InOutM, error) {
+	var records []*InOutM
+	err := tx.Where("iom003 = ?", memberID).Order("iom002 DESC, iom001 DESC").Limit(limit).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// TransferStats sums the wallet transfers (121/122) of a member since a point in time
+type TransferStats struct {
+	Count   int64           `gorm:"column:count"`
+	Amount  decimal.Decimal `gorm:"column:amount"`  // 加扣点金额绝对值合计
+	Deposit decimal.Decimal `gorm:"column:deposit"` // 加点合计
+}
+
+// GetTransferStatsSince sums the transfers of a member made at or after since
+func (dao *inOutMDao) GetTransferStatsSince(tx *gorm.DB, memberID int64, since time.Time) (*TransferStats, error) {
+	ret := TransferStats{}
+	err := tx.Table(TableNameInOutM).
+		Select("COUNT(1) AS count, COALESCE(SUM(ABS(iom004)), 0) AS amount, "+
+			"COALESCE(SUM(CASE WHEN iom005 = '121' THEN iom004 ELSE 0 END), 0) AS deposit").
+		Where("iom003 = ? AND iom002 >= ? AND iom005 IN ('121','122')", memberID, since.Format("2006-01-02 15:04:05")).
+		Scan(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// CountOtherAgentsSince counts the agents other than agentID that moved the wallet of a member at or after since
+func (dao *inOutMDao) CountOtherAgentsSince(tx *gorm.DB, memberID, agentID int64, since time.Time) (int64, error) {
 	var count int64
-	lastTime := time.Now().Add(-1 * time.Minute)
-	nowTime := time.Now()
-
-	err := tx.Raw(`
-		SELECT count(1) 
-		FROM in_out_m 
-		WHERE iom003 = ? 
-		AND iom002 BETWEEN ? AND ? 
-		AND iom005 in (121,122)`,
-		memberID,
-		lastTime.Format("2006-01-02 15:04:05"),
-		nowTime.Format("2006-01-02 15:04:05"),
-	).Scan(&count).Error
+	err := tx.Table(TableNameInOutM).
+		Select("COUNT(DISTINCT iom007)").
+		Where("iom003 = ? AND iom007 != ? AND iom002 >= ? AND iom005 IN ('121','122')", memberID, agentID, since.Format("2006-01-02 15:04:05")).
+		Scan(&count).Error
 	if err != nil {
 		return 0, err
 	}
-
 	return count, nil
 }
 
@@ -143,6 +187,93 @@ func (dao *inOutMDao) GetInOutMs(tx *gorm.DB, mIDs []int64, orderID, order strin
 	return ret, nil
 }
 
+// GetInOutMListForIndex gets at most limit records after afterID written no later than until, ordered by iom001
+func (dao *inOutMDao) GetInOutMListForIndex(tx *gorm.DB, afterID int64, until time.Time, limit int) ([]*InOutM, error) {
+	var ret []*InOutM
+	err := tx.Table(TableNameInOutM).Where("iom001 > ? AND iom002 <= ?", afterID, until.Format("2006-01-02 15:04:05")).
+		Order("iom001").Limit(limit).Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetInOutMListForIndexSince gets the records up to maxID written since since ordered by iom001, the tail reads
+// them again for a transaction that committed after later records were read
+func (dao *inOutMDao) GetInOutMListForIndexSince(tx *gorm.DB, since time.Time, maxID int64) ([]*InOutM, error) {
+	var ret []*InOutM
+	err := tx.Table(TableNameInOutM).Where("iom001 <= ? AND iom002 >= ?", maxID, since.Format("2006-01-02 15:04:05")).
+		Order("iom001").Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetInOutMListByIDs gets the records of ids ordered by iom001
+func (dao *inOutMDao) GetInOutMListByIDs(tx *gorm.DB, ids []int64) ([]*InOutM, error) {
+	var ret []*InOutM
+	if len(ids) == 0 {
+		return ret, nil
+	}
+	err := tx.Table(TableNameInOutM).Where("iom001 IN ?", ids).Order("iom001").Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// SumInOutMForReconcile sums the records of an agent written in [start, end)
+func (dao *inOutMDao) SumInOutMForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) (*ReconcileTotals, error) {
+	ret := &ReconcileTotals{}
+	err := tx.Table(TableNameInOutM).Select("COUNT(*) as count, COALESCE(SUM(iom004), 0) as money").
+		Where("iom007 = ? AND iom002 >= ? AND iom002 < ?", agentID, start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05")).
+		Scan(ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetInOutMRowsForReconcile gets the id and time of the records SumInOutMForReconcile sums
+func (dao *inOutMDao) GetInOutMRowsForReconcile(tx *gorm.DB, agentID int64, start, end time.Time) ([]*ReconcileRow, error) {
+	var ret = []*ReconcileRow{}
+	err := tx.Table(TableNameInOutM).Select("iom001 as id, iom002 as updatetime").
+		Where("iom007 = ? AND iom002 >= ? AND iom002 < ?", agentID, start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05")).
+		Order("iom001").Scan(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetInOutMAgentsForReconcile gets the agents with records written in [start, end)
+func (dao *inOutMDao) GetInOutMAgentsForReconcile(tx *gorm.DB, start, end time.Time) ([]int64, error) {
+	var ret = []int64{}
+	err := tx.Table(TableNameInOutM).Distinct("iom007").
+		Where("iom002 >= ? AND iom002 < ?", start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05")).
+		Order("iom007").Pluck("iom007", &ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetInOutMListForExport gets at most limit transfers of an agent, or of memberID only, written in [start, end)
+// after afterID, ordered by iom001. The op codes are those of GetInOutMs.
+func (dao *inOutMDao) GetInOutMListForExport(tx *gorm.DB, agentID, memberID int64, start, end time.Time, afterID int64, limit int) ([]*InOutM, error) {
+	var ret []*InOutM
+	conn := tx.Table(TableNameInOutM).Where("iom005 in ('121','122','501','502','504')").
+		Where("iom007 = ? AND iom002 >= ? AND iom002 < ? AND iom001 > ?", agentID, start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"), afterID)
+	if memberID > 0 {
+		conn = conn.Where("iom003 = ?", memberID)
+	}
+	if err := conn.Order("iom001").Limit(limit).Find(&ret).Error; err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 const TableNameInOutM = "in_out_m"
 
 // InOutM mapped from table <in_out_m>
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestInOutM_TransferStatsSince(t *testing.T) {
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "in_out_m.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE in_out_m (iom001 INTEGER PRIMARY KEY, iom002 TEXT, iom003 INTEGER, iom004 DECIMAL(15,4), iom005 TEXT, iom007 INTEGER)",
+		// member 1: a deposit and a withdrawal via agent 9, a deposit via agent 8, a bonus and a transfer before the window
+		"INSERT INTO in_out_m VALUES (1, '2024-01-01 10:00:10', 1, 500, '121', 9)",
+		"INSERT INTO in_out_m VALUES (2, '2024-01-01 10:00:20', 1, -200, '122', 9)",
+		"INSERT INTO in_out_m VALUES (3, '2024-01-01 10:00:30', 1, 100, '121', 8)",
+		"INSERT INTO in_out_m VALUES (4, '2024-01-01 10:00:40', 1, 50, '501', 7)",
+		"INSERT INTO in_out_m VALUES (5, '2024-01-01 09:58:00', 1, 900, '121', 6)",
+		// member 2
+		"INSERT INTO in_out_m VALUES (6, '2024-01-01 10:00:30', 2, 100, '121', 5)",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	dao := NewInOutMDao()
+	since := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+
+	stats, err := dao.GetTransferStatsSince(tx, 1, since)
+	if err != nil {
+		t.Fatalf("stats err:(%+v)", err)
+	}
+	if stats.Count != 3 || !stats.Amount.Equal(decimal.NewFromInt(800)) || !stats.Deposit.Equal(decimal.NewFromInt(600)) {
+		t.Fatalf("stats:(%+v), want 3 transfers of 800 with 600 deposited", stats)
+	}
+
+	others, err := dao.CountOtherAgentsSince(tx, 1, 9, since)
+	if err != nil {
+		t.Fatalf("count other agents err:(%+v)", err)
+	}
+	if others != 1 {
+		t.Fatalf("other agents:(%d), want 1", others)
+	}
+
+	stats, err = dao.GetTransferStatsSince(tx, 3, since)
+	if err != nil {
+		t.Fatalf("stats empty err:(%+v)", err)
+	}
+	if stats.Count != 0 || !stats.Amount.IsZero() || !stats.Deposit.IsZero() {
+		t.Fatalf("empty stats:(%+v)", stats)
+	}
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// 洗码风控规则类型
+const (
+	LaunderRuleCount           = "count"            // 窗口内转帐次数超过门槛
+	LaunderRuleAmount          = "amount"           // 窗口内转帐金额(含本笔)超过门槛
+	LaunderRuleLargeTransfer   = "large_transfer"   // 单笔转帐金额达到门槛
+	LaunderRuleDepositWithdraw = "deposit_withdraw" // 窗口内加点后扣点,下注额低於加点额乘以门槛
+	LaunderRuleAgentSwitch     = "agent_switch"     // 窗口内经手代理数(含本笔)超过门槛
+)
+
+// 触发规则後的处理
+const (
+	LaunderActionAlert  = "alert"  // 只写告警
+	LaunderActionReject = "reject" // 告警并拒绝本笔转帐
+	LaunderActionLock   = "lock"   // 告警、拒绝并锁定会员
+)
+
+type LaunderRuleDao interface {
+	QueryByAids(tx *gorm.DB, aids []int64) ([]*LaunderRule, error)
+}
+
+type launderRuleDao struct{}
+
+func NewLaunderRuleDao() LaunderRuleDao {
+	return &launderRuleDao{}
+}
+
+// QueryByAids returns the rules of the given agents, disabled ones included since they switch off an inherited rule
+func (dao *launderRuleDao) QueryByAids(tx *gorm.DB, aids []int64) ([]*LaunderRule, error) {
+	var ret []*LaunderRule
+	err := tx.Where("aid IN ?", aids).Order("id").Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+const TableNameLaunderRule = "launder_rule"
+
+// LaunderRule mapped from table <launder_rule>
+type LaunderRule struct {
+	ID         int64           `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	Aid        int64           `gorm:"column:aid;not null;default:0;comment:代理ID,0為全部代理" json:"aid"`                          // 代理ID,0為全部代理
+	Code       string          `gorm:"column:code;not null;comment:規則代碼,代理以相同代碼覆蓋預設規則" json:"code"`                           // 規則代碼,代理以相同代碼覆蓋預設規則
+	Type       string          `gorm:"column:type;not null;comment:規則類型" json:"type"`                                         // 規則類型
+	Threshold  decimal.Decimal `gorm:"column:threshold;not null;comment:門檻" json:"threshold"`                                 // 門檻
+	WindowSec  int             `gorm:"column:window_sec;not null;default:0;comment:統計秒數" json:"windowSec"`                    // 統計秒數
+	Action     string          `gorm:"column:action;not null;default:alert;comment:alert:告警,reject:拒絕,lock:鎖定" json:"action"` // alert:告警,reject:拒絕,lock:鎖定
+	Message    string          `gorm:"column:message;not null;comment:告警訊息模板" json:"message"`                                 // 告警訊息模板
+	Status     int             `gorm:"column:status;not null;default:1;comment:0:停用,1:啟用" json:"status"`                      // 0:停用,1:啟用
+	CreateTime time.Time       `gorm:"column:create_time;not null" json:"createTime"`
+	UpdateTime time.Time       `gorm:"column:update_time;not null" json:"updateTime"`
+}
+
+// TableName LaunderRule's table name
+func (*LaunderRule) TableName() string {
+	return TableNameLaunderRule
+}
@@ -10,6 +10,7 @@ import (
 type MemLoginDao interface {
 	QueryByID(tx *gorm.DB, memID int64) (*MemLogin, error)
 	QueryBySID(tx *gorm.DB, sid string) (*MemLogin, error)
+	QueryByIDs(tx *gorm.DB, memIDs []int64) ([]*MemLogin, error)
 	Create(tx *gorm.DB, memLogin *MemLogin) error
 	DeleteByID(tx *gorm.DB, memID int64) error
 	Update(tx *gorm.DB, memLogin *MemLogin) error
@@ -44,6 +45,15 @@ func (dao *memLoginDao) QueryBySID(tx *gorm.DB, sid string) (*MemLogin, error) {
 	return &ret, nil
 }
 
+func (dao *memLoginDao) QueryByIDs(tx *gorm.DB, memIDs []int64) ([]*MemLogin, error) {
+	var ret []*MemLogin
+	err := tx.Where("mlg001 IN ?", memIDs).Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 func (dao *memLoginDao) Create(tx *gorm.DB, memLogin *MemLogin) error {
 	return tx.Create(memLogin).Error
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // MemberAccountInfo represents basic member account information
@@ -25,6 +26,9 @@ type UserDao interface {
 	QueryByAgentID(tx *gorm.DB, agentID int64) ([]*Member, error)
 	GetMemberAccountInfo(tx *gorm.DB, memberID int64) (*MemberAccountInfo, error)
 	QueryByAccounts(tx *gorm.DB, accounts []string, agentID int64) ([]*Member, error)
+	QueryByIDForUpdate(tx *gorm.DB, id int64) (*Member, error)
+	UpdateCash(tx *gorm.DB, userID int64, money decimal.Decimal) error
+	QueryTradeLocked(tx *gorm.DB, afterID int64, limit int) ([]*Member, error)
 }
 
 type userDao struct{}
@@ -117,6 +121,41 @@ func (dao *userDao) QueryByAccounts(tx *gorm.DB, accounts []string, agentID int6
 	return members, nil
 }
 
+// QueryByIDForUpdate reads a member and holds its row lock until tx ends
+func (dao *userDao) QueryByIDForUpdate(tx *gorm.DB, id int64) (*Member, error) {
+	ret := Member{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ret, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// UpdateCash adds money (may be negative) to the member cash in a single conditional statement,
+// returning ErrInsufficientCash instead of letting the balance go below zero
+func (dao *userDao) UpdateCash(tx *gorm.DB, userID int64, money decimal.Decimal) error {
+	result := tx.Table("member").
+		Where("mem001 = ? AND cash + ? >= 0", userID, money).
+		Update("cash", gorm.Expr("cash + ?", money))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrInsufficientCash
+	}
+	return nil
+}
+
+// QueryTradeLocked returns up to limit members whose transfers are locked (mem020 = 'Y') with an id above afterID
+func (dao *userDao) QueryTradeLocked(tx *gorm.DB, afterID int64, limit int) ([]*Member, error) {
+	var members []*Member
+	err := tx.Where("mem020 = ? AND mem001 > ?", "Y", afterID).Order("mem001").Limit(limit).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
 const TableNameMember = "member"
 
 // Member mapped from table <member>
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 锁定复核决定
+const (
+	LockDecisionUnlock = "unlock" // 解除交易锁定
+	LockDecisionKeep   = "keep"   // 维持锁定
+)
+
+type MemberLockAuditDao interface {
+	Create(tx *gorm.DB, audit *MemberLockAudit) (int64, error)
+	QueryByMid(tx *gorm.DB, mid int64) ([]*MemberLockAudit, error)
+	QueryLastUnlock(tx *gorm.DB, mid int64) (*MemberLockAudit, error)
+}
+
+type memberLockAuditDao struct{}
+
+func NewMemberLockAuditDao() MemberLockAuditDao {
+	return &memberLockAuditDao{}
+}
+
+func (dao *memberLockAuditDao) Create(tx *gorm.DB, audit *MemberLockAudit) (int64, error) {
+	err := tx.Table(TableNameMemberLockAudit).Create(audit).Error
+	if err != nil {
+		return 0, err
+	}
+	return audit.ID, nil
+}
+
+// QueryByMid returns the decisions on a member, latest first
+func (dao *memberLockAuditDao) QueryByMid(tx *gorm.DB, mid int64) ([]*MemberLockAudit, error) {
+	var ret []*MemberLockAudit
+	err := tx.Where("mid = ?", mid).Order("id DESC").Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// QueryLastUnlock returns the latest unlock decision on a member, nil when it was never unlocked
+func (dao *memberLockAuditDao) QueryLastUnlock(tx *gorm.DB, mid int64) (*MemberLockAudit, error) {
+	var ret []*MemberLockAudit
+	err := tx.Where("mid = ? AND decision = ?", mid, LockDecisionUnlock).Order("id DESC").Limit(1).Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+	return ret[0], nil
+}
+
+const TableNameMemberLockAudit = "member_lock_audit"
+
+// MemberLockAudit mapped from table <member_lock_audit>
+type MemberLockAudit struct {
+	ID         int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	Mid        int64     `gorm:"column:mid;not null;comment:會員ID" json:"mid"`                            // 會員ID
+	Operator   string    `gorm:"column:operator;not null;comment:操作者" json:"operator"`                   // 操作者
+	Decision   string    `gorm:"column:decision;not null;comment:unlock:解除鎖定,keep:維持鎖定" json:"decision"` // unlock:解除鎖定,keep:維持鎖定
+	Reason     string    `gorm:"column:reason;not null;comment:原因" json:"reason"`                        // 原因
+	AlertIDs   string    `gorm:"column:alert_ids;not null;comment:一併結案的告警" json:"alertIds"`              // 一併結案的告警
+	CreateTime time.Time `gorm:"column:create_time;not null" json:"createTime"`
+}
+
+// TableName MemberLockAudit's table name
+func (*MemberLockAudit) TableName() string {
+	return TableNameMemberLockAudit
+}
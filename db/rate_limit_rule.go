@@ -0,0 +1,47 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RateLimitRuleDao interface {
+	QueryEnabled(tx *gorm.DB) ([]*RateLimitRule, error)
+}
+
+type rateLimitRuleDao struct{}
+
+func NewRateLimitRuleDao() RateLimitRuleDao {
+	return &rateLimitRuleDao{}
+}
+
+func (dao *rateLimitRuleDao) QueryEnabled(tx *gorm.DB) ([]*RateLimitRule, error) {
+	var ret []*RateLimitRule
+	err := tx.Where("status = ?", 1).Order("id").Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+const TableNameRateLimitRule = "rate_limit_rule"
+
+// RateLimitRule mapped from table <rate_limit_rule>
+type RateLimitRule struct {
+	ID         int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	VendorID   string    `gorm:"column:vendor_id;not null;comment:代理商ID,空值為全部代理商" json:"vendorId"`                         // 代理商ID,空值為全部代理商
+	Command    string    `gorm:"column:command;not null;comment:指令" json:"command"`                                        // 指令
+	Scope      string    `gorm:"column:scope;not null;default:vendor;comment:vendor:每代理商,member:每會員" json:"scope"`         // vendor:每代理商,member:每會員
+	Algorithm  string    `gorm:"column:algorithm;not null;default:window;comment:window:滑動窗口,bucket:令牌桶" json:"algorithm"` // window:滑動窗口,bucket:令牌桶
+	Quota      int       `gorm:"column:quota;not null;comment:窗口內次數,0為不限" json:"quota"`                                    // 窗口內次數,0為不限
+	WindowSec  int       `gorm:"column:window_sec;not null;comment:窗口秒數" json:"windowSec"`                                 // 窗口秒數
+	Status     int       `gorm:"column:status;not null;default:1;comment:0:停用,1:啟用" json:"status"`                         // 0:停用,1:啟用
+	CreateTime time.Time `gorm:"column:create_time;not null" json:"createTime"`
+	UpdateTime time.Time `gorm:"column:update_time;not null" json:"updateTime"`
+}
+
+// TableName RateLimitRule's table name
+func (*RateLimitRule) TableName() string {
+	return TableNameRateLimitRule
+}
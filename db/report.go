@@ -0,0 +1,285 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Bet02Report is a settled bet as the reports read it, decoded from bet02_report_index or built from bet02.
+// Documents written before the indexer are decoded too: numbers may come as numeric strings, times as unix
+// millis, RFC3339 or MySQL datetimes, and a null leaves the zero value.
+type Bet02Report struct {
+	Bet01      int64           `json:"bet01"`
+	Bet02      int             `json:"bet02"`
+	Bet03      decimal.Decimal `json:"bet03"`
+	Bet04      int             `json:"bet04"`
+	Bet05      int             `json:"bet05"`
+	Bet07      time.Time       `json:"bet07"`
+	Bet08      time.Time       `json:"bet08"`
+	Bet09      string          `json:"bet09"`
+	Bet12      decimal.Decimal `json:"bet12"`
+	Bet13      decimal.Decimal `json:"bet13"`
+	Bet14      decimal.Decimal `json:"bet14"`
+	Bet16      decimal.Decimal `json:"bet16"`
+	Bet17      decimal.Decimal `json:"bet17"`
+	Bet22      int             `json:"bet22"`
+	Bet38      string          `json:"bet38"`
+	Bet39      int             `json:"bet39"`
+	Bet41      decimal.Decimal `json:"bet41"`
+	Category   int             `json:"category"`
+	Commission int             `json:"commission"`
+	IP         string          `json:"ip"`
+	Updatetime time.Time       `json:"updatetime"`
+	Result     string          `json:"result"`
+	Username   string          `json:"username"`
+	GameName   string          `json:"gameName"`
+}
+
+func NewBet02Report(bet *Bet02Extra) *Bet02Report {
+	return &Bet02Report{
+		Bet01:      bet.Bet01,
+		Bet02:      bet.Bet02.Bet02,
+		Bet03:      bet.Bet03,
+		Bet04:      bet.Bet04,
+		Bet05:      bet.Bet05,
+		Bet07:      bet.Bet07,
+		Bet08:      bet.Bet08,
+		Bet09:      bet.Bet09,
+		Bet12:      bet.Bet12,
+		Bet13:      bet.Bet13,
+		Bet14:      bet.Bet14,
+		Bet16:      bet.Bet16,
+		Bet17:      bet.Bet17,
+		Bet22:      bet.Bet22,
+		Bet38:      bet.Bet38,
+		Bet39:      bet.Bet39,
+		Bet41:      bet.Bet41,
+		Category:   bet.Category,
+		Commission: bet.Commission,
+		IP:         bet.IP,
+		Updatetime: bet.Updatetime,
+		Result:     bet.Result,
+		Username:   bet.User,
+		GameName:   bet.GName,
+	}
+}
+
+func (r *Bet02Report) UnmarshalJSON(b []byte) error {
+	d, err := newReportDecoder(b)
+	if err != nil {
+		return err
+	}
+	*r = Bet02Report{
+		Bet01:      d.integer("bet01"),
+		Bet02:      int(d.integer("bet02")),
+		Bet03:      d.number("bet03"),
+		Bet04:      int(d.integer("bet04")),
+		Bet05:      int(d.integer("bet05")),
+		Bet07:      d.datetime("bet07"),
+		Bet08:      d.datetime("bet08"),
+		Bet09:      d.text("bet09"),
+		Bet12:      d.number("bet12"),
+		Bet13:      d.number("bet13"),
+		Bet14:      d.number("bet14"),
+		Bet16:      d.number("bet16"),
+		Bet17:      d.number("bet17"),
+		Bet22:      int(d.integer("bet22")),
+		Bet38:      d.text("bet38"),
+		Bet39:      int(d.integer("bet39")),
+		Bet41:      d.number("bet41"),
+		Category:   int(d.integer("category")),
+		Commission: int(d.integer("commission")),
+		IP:         d.text("ip"),
+		Updatetime: d.datetime("updatetime"),
+		Result:     d.text("result"),
+		Username:   d.text("username"),
+		GameName:   d.text("gameName"),
+	}
+	return d.err
+}
+
+// Cursor is the keyset position of the bet in a report ordered by bet08, or by updatetime for timeType 1
+func (r *Bet02Report) Cursor(timeType int) *ReportCursor {
+	at := r.Bet08
+	if timeType == 1 {
+		at = r.Updatetime
+	}
+	return &ReportCursor{Time: at.UnixMilli(), Bet01: r.Bet01}
+}
+
+// InOutMReport is a balance change as the reports read it, decoded from in_out_m_index or built from in_out_m.
+// It decodes like Bet02Report.
+type InOutMReport struct {
+	Iom001 int64           `json:"iom001"`
+	Iom002 time.Time       `json:"iom002"`
+	Iom003 int64           `json:"iom003"`
+	Iom004 decimal.Decimal `json:"iom004"`
+	Iom005 string          `json:"iom005"`
+	Iom006 int64           `json:"iom006"`
+	Iom007 int64           `json:"iom007"`
+	Iom008 string          `json:"iom008"`
+	Iom009 int64           `json:"iom009"`
+	Iom010 decimal.Decimal `json:"iom010"`
+}
+
+func NewInOutMReport(record *InOutM) *InOutMReport {
+	return &InOutMReport{
+		Iom001: record.Iom001,
+		Iom002: record.Iom002,
+		Iom003: record.Iom003,
+		Iom004: record.Iom004,
+		Iom005: record.Iom005,
+		Iom006: record.Iom006,
+		Iom007: record.Iom007,
+		Iom008: record.Iom008,
+		Iom009: record.Iom009,
+		Iom010: record.Iom010,
+	}
+}
+
+func (r *InOutMReport) UnmarshalJSON(b []byte) error {
+	d, err := newReportDecoder(b)
+	if err != nil {
+		return err
+	}
+	*r = InOutMReport{
+		Iom001: d.integer("iom001"),
+		Iom002: d.datetime("iom002"),
+		Iom003: d.integer("iom003"),
+		Iom004: d.number("iom004"),
+		Iom005: d.text("iom005"),
+		Iom006: d.integer("iom006"),
+		Iom007: d.integer("iom007"),
+		Iom008: d.text("iom008"),
+		Iom009: d.integer("iom009"),
+		Iom010: d.number("iom010"),
+	}
+	return d.err
+}
+
+// DecodeBet01Summary decodes a bet01_report_index document to the unsettled report row, it decodes like
+// Bet02Report. Round and SubRound are the event and eventChild, as GetBet01ListForUnsettledReport selects them.
+func DecodeBet01Summary(b []byte) (*Bet01Summary, error) {
+	d, err := newReportDecoder(b)
+	if err != nil {
+		return nil, err
+	}
+	item := &Bet01Summary{
+		BetID:      d.integer("betId"),
+		GID:        int(d.integer("gid")),
+		Event:      d.number("event"),
+		EventChild: int(d.integer("eventChild")),
+		ID:         int(d.integer("userId")),
+		BetTime:    d.datetime("betTime"),
+		BetResult:  d.text("betResult"),
+		Bet:        d.number("bet"),
+		AID:        int(d.integer("aid")),
+		TableID:    int(d.integer("tableId")),
+		Commission: int(d.integer("commission")),
+		GName:      d.text("gameName"),
+		User:       d.text("username"),
+	}
+	item.Round = item.Event
+	item.SubRound = item.EventChild
+	return item, d.err
+}
+
+// reportDecoder reads the fields of a report document whatever json type they were written as and keeps the
+// first field it could not read
+type reportDecoder struct {
+	fields map[string]json.RawMessage
+	err    error
+}
+
+func newReportDecoder(b []byte) (*reportDecoder, error) {
+	d := &reportDecoder{}
+	if err := json.Unmarshal(b, &d.fields); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// scalar returns the field as text, a json string unquoted, and false for a missing or null field
+func (d *reportDecoder) scalar(name string) (string, bool) {
+	raw, ok := d.fields[name]
+	if !ok {
+		return "", false
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", false
+	}
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			d.fail(name, err)
+			return "", false
+		}
+		return strings.TrimSpace(s), true
+	}
+	return string(raw), true
+}
+
+func (d *reportDecoder) fail(name string, err error) {
+	if d.err == nil {
+		d.err = fmt.Errorf("report field %s: %v", name, err)
+	}
+}
+
+func (d *reportDecoder) text(name string) string {
+	s, _ := d.scalar(name)
+	return s
+}
+
+func (d *reportDecoder) number(name string) decimal.Decimal {
+	s, ok := d.scalar(name)
+	if !ok || s == "" {
+		return decimal.Zero
+	}
+	v, err := decimal.NewFromString(s)
+	if err != nil {
+		d.fail(name, err)
+		return decimal.Zero
+	}
+	return v
+}
+
+// integer reads a whole number, one written as a float like 12.0 included
+func (d *reportDecoder) integer(name string) int64 {
+	v := d.number(name)
+	if !v.IsInteger() {
+		d.fail(name, fmt.Errorf("%s is not an integer", v))
+		return 0
+	}
+	return v.IntPart()
+}
+
+// datetime reads unix millis, RFC3339 or a MySQL datetime or date in the local zone
+func (d *reportDecoder) datetime(name string) time.Time {
+	s, ok := d.scalar(name)
+	if !ok || s == "" {
+		return time.Time{}
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms)
+	}
+	if ms, err := decimal.NewFromString(s); err == nil {
+		return time.UnixMilli(ms.IntPart())
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t
+		}
+	}
+	d.fail(name, fmt.Errorf("unknown time %q", s))
+	return time.Time{}
+}
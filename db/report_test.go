@@ -0,0 +1,97 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBet02Report_UnmarshalJSON(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("CST", 8*3600)
+	t.Cleanup(func() { time.Local = local })
+	at := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+
+	for _, tc := range []struct {
+		name string
+		doc  string
+	}{
+		{"indexer", `{"bet01":1,"bet02":101,"bet03":"20240101","bet08":1704074400000,"bet13":"100.5","bet14":"195","updatetime":1704074400000}`},
+		{"floats", `{"bet01":1.0,"bet02":101.0,"bet03":20240101,"bet08":1.7040744e12,"bet13":100.5,"bet14":195,"updatetime":"1704074400000"}`},
+		{"strings", `{"bet01":"1","bet02":"101","bet03":"20240101","bet08":"2024-01-01 10:00:00","bet13":"100.5000","bet14":"195.0000","updatetime":"2024-01-01T02:00:00Z"}`},
+	} {
+		var r Bet02Report
+		if err := json.Unmarshal([]byte(tc.doc), &r); err != nil {
+			t.Fatalf("%s: unmarshal err:(%+v)", tc.name, err)
+		}
+		if r.Bet01 != 1 || r.Bet02 != 101 || r.Bet03.String() != "20240101" || !r.Bet08.Equal(at) || !r.Updatetime.Equal(at) {
+			t.Fatalf("%s: report:(%+v)", tc.name, r)
+		}
+		if r.Bet13.String() != "100.5" || r.Bet14.Sub(r.Bet13).String() != "94.5" {
+			t.Fatalf("%s: money bet13:%s bet14:%s", tc.name, r.Bet13, r.Bet14)
+		}
+	}
+
+	// A null or missing field is its zero value
+	var r Bet02Report
+	if err := json.Unmarshal([]byte(`{"bet01":2,"bet13":null,"bet38":null,"bet08":null,"gameName":null}`), &r); err != nil {
+		t.Fatalf("unmarshal nulls err:(%+v)", err)
+	}
+	if r.Bet01 != 2 || !r.Bet13.IsZero() || r.Bet38 != "" || !r.Bet08.IsZero() || r.GameName != "" {
+		t.Fatalf("report of nulls:(%+v)", r)
+	}
+
+	for _, doc := range []string{
+		`{"bet01":"abc"}`,
+		`{"bet01":1.5}`,
+		`{"bet13":"1,000"}`,
+		`{"bet08":"yesterday"}`,
+		`[1]`,
+	} {
+		if err := json.Unmarshal([]byte(doc), &r); err == nil {
+			t.Fatalf("unmarshal %s, want an error", doc)
+		}
+	}
+}
+
+func TestInOutMReport_UnmarshalJSON(t *testing.T) {
+	var r InOutMReport
+	doc := `{"iom001":7,"iom002":"2024-01-01T09:59:00+08:00","iom003":"1","iom004":1000.5,"iom005":"121","iom007":9,"iom008":"order-7","iom010":"1000.5000"}`
+	if err := json.Unmarshal([]byte(doc), &r); err != nil {
+		t.Fatalf("unmarshal err:(%+v)", err)
+	}
+	if r.Iom001 != 7 || r.Iom003 != 1 || r.Iom004.String() != "1000.5" || !r.Iom010.Equal(r.Iom004) || r.Iom008 != "order-7" {
+		t.Fatalf("report:(%+v)", r)
+	}
+	if r.Iom002.Unix() != time.Date(2024, 1, 1, 1, 59, 0, 0, time.UTC).Unix() {
+		t.Fatalf("iom002:%s", r.Iom002)
+	}
+}
+
+func TestDecodeBet01Summary(t *testing.T) {
+	// The document as es.NewBet01Doc writes it, a bet amount a float64 would round
+	doc := `{"betId":9007199254740993,"gid":101,"event":"20240101","eventChild":3,"userId":1,"bet07":"2024-01-01","betTime":1704074400000,` +
+		`"betResult":"Banker","bet":"0.1000","aid":9,"tableId":5,"commission":1,"bet30":"N","is_settled":false,"username":"tom","gameName":"百家乐"}`
+	item, err := DecodeBet01Summary([]byte(doc))
+	if err != nil {
+		t.Fatalf("decode err:(%+v)", err)
+	}
+	if item.BetID != 9007199254740993 || item.GID != 101 || item.ID != 1 || item.AID != 9 || item.TableID != 5 || item.Commission != 1 {
+		t.Fatalf("summary:(%+v)", item)
+	}
+	if item.Event.String() != "20240101" || !item.Round.Equal(item.Event) || item.EventChild != 3 || item.SubRound != 3 {
+		t.Fatalf("summary round:(%+v)", item)
+	}
+	if item.Bet.String() != "0.1" || item.BetTime.UnixMilli() != 1704074400000 || item.User != "tom" || item.GName != "百家乐" || item.BetResult != "Banker" {
+		t.Fatalf("summary:(%+v)", item)
+	}
+
+	// Older documents with numbers written as floats decode the same
+	item, err = DecodeBet01Summary([]byte(`{"betId":1.0,"event":20240101,"bet":100.5,"betTime":"2024-01-01T02:00:00Z"}`))
+	if err != nil || item.BetID != 1 || item.Event.String() != "20240101" || item.Bet.String() != "100.5" || item.BetTime.UnixMilli() != 1704074400000 {
+		t.Fatalf("float summary:(%+v), err:(%+v)", item, err)
+	}
+	if _, err := DecodeBet01Summary([]byte(`{"betId":"abc"}`)); err == nil {
+		t.Fatalf("decoded a bad bet id")
+	}
+}
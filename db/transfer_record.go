@@ -0,0 +1,113 @@
+package db
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// 转帐单状态
+const (
+	TransferStatusPending   = 0 // 处理中
+	TransferStatusCommitted = 1 // 已完成
+	TransferStatusFailed    = 2 // 失败
+)
+
+type TransferRecordDao interface {
+	QueryByID(tx *gorm.DB, id int64) (*TransferRecord, error)
+	QueryByVendorOrder(tx *gorm.DB, vendorID, orderNum string) (*TransferRecord, error)
+	Create(tx *gorm.DB, record *TransferRecord) (int64, error)
+	Updates(tx *gorm.DB, id int64, data map[string]interface{}) error
+	UpdateStatus(tx *gorm.DB, id int64, from, to int, data map[string]interface{}) (int64, error)
+	QueryPendingBefore(tx *gorm.DB, before time.Time, limit int) ([]*TransferRecord, error)
+}
+
+type transferRecordDao struct{}
+
+func NewTransferRecordDao() TransferRecordDao {
+	return &transferRecordDao{}
+}
+
+func (dao *transferRecordDao) QueryByID(tx *gorm.DB, id int64) (*TransferRecord, error) {
+	ret := TransferRecord{}
+	err := tx.First(&ret, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (dao *transferRecordDao) QueryByVendorOrder(tx *gorm.DB, vendorID, orderNum string) (*TransferRecord, error) {
+	ret := TransferRecord{}
+	err := tx.Where("vendor_id = ? AND order_num = ?", vendorID, orderNum).First(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (dao *transferRecordDao) Create(tx *gorm.DB, record *TransferRecord) (int64, error) {
+	err := tx.Table(TableNameTransferRecord).Create(record).Error
+	if err != nil {
+		return 0, err
+	}
+	return record.ID, nil
+}
+
+func (dao *transferRecordDao) Updates(tx *gorm.DB, id int64, data map[string]interface{}) error {
+	return tx.Table(TableNameTransferRecord).Where("id = ?", id).Updates(data).Error
+}
+
+// UpdateStatus moves a record from one status to another and reports how many rows changed,
+// so callers can tell whether they actually own the transition.
+func (dao *transferRecordDao) UpdateStatus(tx *gorm.DB, id int64, from, to int, data map[string]interface{}) (int64, error) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["status"] = to
+	data["update_time"] = time.Now()
+	result := tx.Table(TableNameTransferRecord).Where("id = ? AND status = ?", id, from).Updates(data)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// QueryPendingBefore returns the oldest records still pending that were last touched before the given time
+func (dao *transferRecordDao) QueryPendingBefore(tx *gorm.DB, before time.Time, limit int) ([]*TransferRecord, error) {
+	var ret []*TransferRecord
+	err := tx.Where("status = ? AND update_time < ?", TransferStatusPending, before).
+		Order("update_time").
+		Limit(limit).
+		Find(&ret).Error
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+const TableNameTransferRecord = "transfer_record"
+
+// TransferRecord mapped from table <transfer_record>
+type TransferRecord struct {
+	ID         int64           `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	VendorID   string          `gorm:"column:vendor_id;not null;comment:代理商ID" json:"vendorId"`               // 代理商ID
+	OrderNum   string          `gorm:"column:order_num;not null;comment:代理商订单号" json:"orderNum"`              // 代理商订单号
+	Aid        int64           `gorm:"column:aid;not null;comment:代理ID" json:"aid"`                           // 代理ID
+	Mid        int64           `gorm:"column:mid;not null;comment:会员ID" json:"mid"`                           // 会员ID
+	User       string          `gorm:"column:user;not null;comment:会员帐号" json:"user"`                         // 会员帐号
+	Money      decimal.Decimal `gorm:"column:money;not null;comment:加扣点金额" json:"money"`                      // 加扣点金额
+	Status     int             `gorm:"column:status;not null;default:0;comment:0:处理中1:已完成2:失败" json:"status"` // 0:处理中1:已完成2:失败
+	Iom001     int64           `gorm:"column:iom001;not null;default:0;comment:in_out_m流水号" json:"iom001"`    // in_out_m流水号
+	BeforeCash decimal.Decimal `gorm:"column:before_cash;not null;comment:异动前余额" json:"beforeCash"`           // 异动前余额
+	AfterCash  decimal.Decimal `gorm:"column:after_cash;not null;comment:异动后余额" json:"afterCash"`             // 异动后余额
+	Message    string          `gorm:"column:message;not null;comment:失败原因" json:"message"`                   // 失败原因
+	CreateTime time.Time       `gorm:"column:create_time;not null" json:"createTime"`
+	UpdateTime time.Time       `gorm:"column:update_time;not null" json:"updateTime"`
+}
+
+// TableName TransferRecord's table name
+func (*TransferRecord) TableName() string {
+	return TableNameTransferRecord
+}
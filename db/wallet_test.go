@@ -0,0 +1,124 @@
+package db
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newWalletDB opens a file backed sqlite as a stand-in for MySQL, with only the columns the cash updates touch
+func newWalletDB(t *testing.T) *gorm.DB {
+	dsn := filepath.Join(t.TempDir(), "wallet.db") + "?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"
+	tx, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, cash DECIMAL(15,4) NOT NULL DEFAULT 0)",
+		"CREATE TABLE agent (age001 INTEGER PRIMARY KEY, cash DECIMAL(15,4) NOT NULL DEFAULT 0)",
+		"INSERT INTO member (mem001, cash) VALUES (1, 100)",
+		"INSERT INTO agent (age001, cash) VALUES (1, 100)",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	return tx
+}
+
+func queryCash(t *testing.T, tx *gorm.DB, table, pk string) decimal.Decimal {
+	var cash decimal.Decimal
+	if err := tx.Table(table).Where(pk+" = ?", 1).Select("cash").Scan(&cash).Error; err != nil {
+		t.Fatalf("query %s cash err:(%+v)", table, err)
+	}
+	return cash
+}
+
+// concurrentDebit fires n debits of 10 at once and returns how many went through
+func concurrentDebit(t *testing.T, n int, update func(money decimal.Decimal) error) int {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		applied int
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := update(decimal.NewFromInt(-10))
+			if err != nil && err != ErrInsufficientCash {
+				t.Errorf("update cash err:(%+v)", err)
+				return
+			}
+			if err == nil {
+				mu.Lock()
+				applied++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return applied
+}
+
+func TestWallet_MemberConcurrentDebit(t *testing.T) {
+	tx := newWalletDB(t)
+	dao := NewMemberDao()
+
+	applied := concurrentDebit(t, 25, func(money decimal.Decimal) error {
+		return dao.UpdateCash(tx, 1, money)
+	})
+	if applied != 10 {
+		t.Fatalf("applied debits:(%d), want 10", applied)
+	}
+	if cash := queryCash(t, tx, "member", "mem001"); !cash.IsZero() {
+		t.Fatalf("member cash:(%s), want 0", cash)
+	}
+}
+
+func TestWallet_AgentConcurrentDebit(t *testing.T) {
+	tx := newWalletDB(t)
+	dao := NewAgentDao()
+
+	applied := concurrentDebit(t, 25, func(money decimal.Decimal) error {
+		return dao.UpdateCash(tx, 1, money)
+	})
+	if applied != 10 {
+		t.Fatalf("applied debits:(%d), want 10", applied)
+	}
+	if cash := queryCash(t, tx, "agent", "age001"); !cash.IsZero() {
+		t.Fatalf("agent cash:(%s), want 0", cash)
+	}
+}
+
+func TestWallet_UpdateCashInsideTransaction(t *testing.T) {
+	tx := newWalletDB(t)
+	memberDao := NewMemberDao()
+	agentDao := NewAgentDao()
+
+	// An overdrawn agent must roll back the member credit made in the same transaction
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		if err := memberDao.UpdateCash(tx, 1, decimal.NewFromInt(150)); err != nil {
+			return err
+		}
+		return agentDao.UpdateCash(tx, 1, decimal.NewFromInt(-150))
+	})
+	if err != ErrInsufficientCash {
+		t.Fatalf("transaction err:(%+v), want ErrInsufficientCash", err)
+	}
+	if cash := queryCash(t, tx, "member", "mem001"); !cash.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("member cash:(%s), want 100", cash)
+	}
+
+	if err := memberDao.UpdateCash(tx, 1, decimal.RequireFromString("-100.0000")); err != nil {
+		t.Fatalf("debit to zero err:(%+v)", err)
+	}
+	if err := memberDao.UpdateCash(tx, 1, decimal.RequireFromString("-0.0001")); err != ErrInsufficientCash {
+		t.Fatalf("debit below zero err:(%+v), want ErrInsufficientCash", err)
+	}
+}
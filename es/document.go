@@ -0,0 +1,233 @@
+package es
+
+import (
+	"encoding/json"
+	"go-zrbc/db"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// The report indices are aliases, a full reindex builds a new index and swaps the alias over
+const (
+	Bet01ReportIndex = "bet01_report_index"
+	Bet02ReportIndex = "bet02_report_index"
+	InOutMIndex      = "in_out_m_index"
+)
+
+// Bet02Doc is a settled bet in bet02_report_index, times are unix millis
+type Bet02Doc struct {
+	Bet01      int64           `json:"bet01"`
+	Bet02      int             `json:"bet02"`
+	Bet03      decimal.Decimal `json:"bet03"`
+	Bet04      int             `json:"bet04"`
+	Bet05      int             `json:"bet05"`
+	Bet07      string          `json:"bet07"`
+	Bet08      int64           `json:"bet08"`
+	Bet09      string          `json:"bet09"`
+	Bet12      decimal.Decimal `json:"bet12"`
+	Bet13      decimal.Decimal `json:"bet13"`
+	Bet14      decimal.Decimal `json:"bet14"`
+	Bet16      decimal.Decimal `json:"bet16"`
+	Bet17      decimal.Decimal `json:"bet17"`
+	Bet22      int             `json:"bet22"`
+	Bet38      string          `json:"bet38"`
+	Bet39      int             `json:"bet39"`
+	Bet41      decimal.Decimal `json:"bet41"`
+	Category   int             `json:"category"`
+	Commission int             `json:"commission"`
+	IP         string          `json:"ip"`
+	Updatetime int64           `json:"updatetime"`
+	Result     string          `json:"result"`
+	Username   string          `json:"username"`
+	GameName   string          `json:"gameName"`
+}
+
+func NewBet02Doc(bet *db.Bet02Extra) *Bet02Doc {
+	return &Bet02Doc{
+		Bet01:      bet.Bet01,
+		Bet02:      bet.Bet02.Bet02,
+		Bet03:      bet.Bet03,
+		Bet04:      bet.Bet04,
+		Bet05:      bet.Bet05,
+		Bet07:      bet.Bet07.Format("2006-01-02"),
+		Bet08:      bet.Bet08.UnixMilli(),
+		Bet09:      bet.Bet09,
+		Bet12:      bet.Bet12,
+		Bet13:      bet.Bet13,
+		Bet14:      bet.Bet14,
+		Bet16:      bet.Bet16,
+		Bet17:      bet.Bet17,
+		Bet22:      bet.Bet22,
+		Bet38:      bet.Bet38,
+		Bet39:      bet.Bet39,
+		Bet41:      bet.Bet41,
+		Category:   bet.Category,
+		Commission: bet.Commission,
+		IP:         bet.IP,
+		Updatetime: bet.Updatetime.UnixMilli(),
+		Result:     bet.Result,
+		Username:   bet.User,
+		GameName:   bet.GName,
+	}
+}
+
+func (d *Bet02Doc) DocID() string {
+	return strconv.FormatInt(d.Bet01, 10)
+}
+
+// Bet01Doc is a placed bet in bet01_report_index, IsSettled turns true once its bet02 is written
+type Bet01Doc struct {
+	BetID      int64           `json:"betId"`
+	GID        int             `json:"gid"`
+	Event      decimal.Decimal `json:"event"`
+	EventChild int             `json:"eventChild"`
+	UserID     int             `json:"userId"`
+	Bet07      string          `json:"bet07"`
+	BetTime    int64           `json:"betTime"`
+	BetResult  string          `json:"betResult"`
+	Bet        decimal.Decimal `json:"bet"`
+	AID        int             `json:"aid"`
+	TableID    int             `json:"tableId"`
+	Commission int             `json:"commission"`
+	Bet30      string          `json:"bet30"`
+	IsSettled  bool            `json:"is_settled"`
+	Updatetime int64           `json:"updatetime"`
+	Username   string          `json:"username"`
+	GameName   string          `json:"gameName"`
+}
+
+func NewBet01Doc(bet *db.Bet01Extra) *Bet01Doc {
+	return &Bet01Doc{
+		BetID:      bet.Bet01.Bet01,
+		GID:        bet.Bet02,
+		Event:      bet.Bet03,
+		EventChild: bet.Bet04,
+		UserID:     bet.Bet05,
+		Bet07:      bet.Bet07.Format("2006-01-02"),
+		BetTime:    bet.Bet08.UnixMilli(),
+		BetResult:  bet.Bet09,
+		Bet:        bet.Bet13,
+		AID:        bet.Bet19,
+		TableID:    bet.Bet31,
+		Commission: bet.Commission,
+		Bet30:      bet.Bet30,
+		IsSettled:  bet.Settled,
+		Updatetime: bet.Updatetime.UnixMilli(),
+		Username:   bet.User,
+		GameName:   bet.GName,
+	}
+}
+
+func (d *Bet01Doc) DocID() string {
+	return strconv.FormatInt(d.BetID, 10)
+}
+
+// InOutMDoc is a balance change in in_out_m_index, money goes as exact json numbers
+type InOutMDoc struct {
+	Iom001 int64       `json:"iom001"`
+	Iom002 string      `json:"iom002"`
+	Iom003 int64       `json:"iom003"`
+	Iom004 json.Number `json:"iom004"`
+	Iom005 string      `json:"iom005"`
+	Iom006 int64       `json:"iom006"`
+	Iom007 int64       `json:"iom007"`
+	Iom008 string      `json:"iom008"`
+	Iom009 int64       `json:"iom009"`
+	Iom010 json.Number `json:"iom010"`
+}
+
+func NewInOutMDoc(record *db.InOutM) *InOutMDoc {
+	return &InOutMDoc{
+		Iom001: record.Iom001,
+		Iom002: record.Iom002.Format(time.RFC3339),
+		Iom003: record.Iom003,
+		Iom004: json.Number(record.Iom004.String()),
+		Iom005: record.Iom005,
+		Iom006: record.Iom006,
+		Iom007: record.Iom007,
+		Iom008: record.Iom008,
+		Iom009: record.Iom009,
+		Iom010: json.Number(record.Iom010.String()),
+	}
+}
+
+func (d *InOutMDoc) DocID() string {
+	return strconv.FormatInt(d.Iom001, 10)
+}
+
+// Index bodies of a full reindex. Money is a scaled_float so the report can sum it, the source keeps the exact value.
+const (
+	Bet02ReportMapping = `{
+	"mappings": {
+		"properties": {
+			"bet01": {"type": "long"},
+			"bet02": {"type": "integer"},
+			"bet03": {"type": "keyword"},
+			"bet04": {"type": "integer"},
+			"bet05": {"type": "long"},
+			"bet07": {"type": "date", "format": "yyyy-MM-dd||strict_date_optional_time||epoch_millis"},
+			"bet08": {"type": "date", "format": "strict_date_optional_time||epoch_millis"},
+			"bet09": {"type": "keyword"},
+			"bet12": {"type": "scaled_float", "scaling_factor": 10000},
+			"bet13": {"type": "scaled_float", "scaling_factor": 10000},
+			"bet14": {"type": "scaled_float", "scaling_factor": 10000},
+			"bet16": {"type": "scaled_float", "scaling_factor": 10000},
+			"bet17": {"type": "scaled_float", "scaling_factor": 10000},
+			"bet22": {"type": "long"},
+			"bet38": {"type": "keyword"},
+			"bet39": {"type": "integer"},
+			"bet41": {"type": "scaled_float", "scaling_factor": 10000},
+			"category": {"type": "integer"},
+			"commission": {"type": "integer"},
+			"ip": {"type": "keyword"},
+			"updatetime": {"type": "date", "format": "strict_date_optional_time||epoch_millis"},
+			"result": {"type": "keyword"},
+			"username": {"type": "keyword"},
+			"gameName": {"type": "keyword"}
+		}
+	}
+}`
+
+	Bet01ReportMapping = `{
+	"mappings": {
+		"properties": {
+			"betId": {"type": "long"},
+			"gid": {"type": "integer"},
+			"event": {"type": "keyword"},
+			"eventChild": {"type": "integer"},
+			"userId": {"type": "long"},
+			"bet07": {"type": "date", "format": "yyyy-MM-dd||strict_date_optional_time||epoch_millis"},
+			"betTime": {"type": "date", "format": "strict_date_optional_time||epoch_millis"},
+			"betResult": {"type": "keyword"},
+			"bet": {"type": "scaled_float", "scaling_factor": 10000},
+			"aid": {"type": "long"},
+			"tableId": {"type": "integer"},
+			"commission": {"type": "integer"},
+			"bet30": {"type": "keyword"},
+			"is_settled": {"type": "boolean"},
+			"updatetime": {"type": "date", "format": "strict_date_optional_time||epoch_millis"},
+			"username": {"type": "keyword"},
+			"gameName": {"type": "keyword"}
+		}
+	}
+}`
+
+	InOutMMapping = `{
+	"mappings": {
+		"properties": {
+			"iom001": {"type": "long"},
+			"iom002": {"type": "date", "format": "yyyy-MM-dd HH:mm:ss||strict_date_optional_time||epoch_millis"},
+			"iom003": {"type": "long"},
+			"iom004": {"type": "scaled_float", "scaling_factor": 10000},
+			"iom005": {"type": "keyword"},
+			"iom006": {"type": "long"},
+			"iom007": {"type": "long"},
+			"iom008": {"type": "keyword"},
+			"iom009": {"type": "long"},
+			"iom010": {"type": "scaled_float", "scaling_factor": 10000}
+		}
+	}
+}`
+)
@@ -53,19 +53,38 @@ func (c *Client) Index(ctx context.Context, index string, id string, doc interfa
 	return nil
 }
 
+// Document is a doc that keeps its own id, indexing it again overwrites it instead of adding a copy
+type Document interface {
+	DocID() string
+}
+
 func (c *Client) BulkIndex(ctx context.Context, index string, docs []interface{}) error {
+	if len(docs) == 0 {
+		return nil
+	}
 	bulk := c.client.Bulk()
 	for _, doc := range docs {
 		req := elastic.NewBulkIndexRequest().
 			Index(index).
 			Doc(doc)
+		if d, ok := doc.(Document); ok {
+			req.Id(d.DocID())
+		}
 		bulk.Add(req)
 	}
 
-	_, err := bulk.Do(ctx)
+	resp, err := bulk.Do(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to bulk index documents: %v", err)
 	}
+	if failed := resp.Failed(); len(failed) > 0 {
+		item := failed[0]
+		reason := ""
+		if item.Error != nil {
+			reason = item.Error.Reason
+		}
+		return fmt.Errorf("failed to bulk index %d of %d documents, first id %s: %s", len(failed), len(docs), item.Id, reason)
+	}
 
 	return nil
 }
@@ -0,0 +1,77 @@
+package es
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// IndexExists reports whether name is an index or an alias
+func (c *Client) IndexExists(ctx context.Context, name string) (bool, error) {
+	exists, err := c.client.IndexExists(name).Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check index %s: %v", name, err)
+	}
+	return exists, nil
+}
+
+// CreateIndex creates index with the settings and mappings in body
+func (c *Client) CreateIndex(ctx context.Context, index, body string) error {
+	_, err := c.client.CreateIndex(index).BodyString(body).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create index %s: %v", index, err)
+	}
+	return nil
+}
+
+// DeleteIndex drops the indices
+func (c *Client) DeleteIndex(ctx context.Context, indices ...string) error {
+	if len(indices) == 0 {
+		return nil
+	}
+	_, err := c.client.DeleteIndex(indices...).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete indices %v: %v", indices, err)
+	}
+	return nil
+}
+
+// SwapAlias points alias at index alone in one atomic step and returns the indices it left.
+// A plain index that still goes by the alias name is dropped in the same step.
+func (c *Client) SwapAlias(ctx context.Context, alias, index string) ([]string, error) {
+	var (
+		old     []string
+		aliased bool
+	)
+	res, err := c.client.Aliases().Alias(alias).Do(ctx)
+	if err != nil && !elastic.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get alias %s: %v", alias, err)
+	}
+	if err == nil {
+		for _, name := range res.IndicesByAlias(alias) {
+			aliased = true
+			if name != index {
+				old = append(old, name)
+			}
+		}
+	}
+
+	actions := []elastic.AliasAction{elastic.NewAliasAddAction(alias).Index(index)}
+	if len(old) > 0 {
+		actions = append(actions, elastic.NewAliasRemoveAction(alias).Index(old...))
+	}
+	if !aliased {
+		exists, err := c.IndexExists(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			actions = append(actions, elastic.NewAliasRemoveIndexAction(alias))
+		}
+	}
+	if _, err := c.client.Alias().Action(actions...).Do(ctx); err != nil {
+		return nil, fmt.Errorf("failed to swap alias %s to %s: %v", alias, index, err)
+	}
+	return old, nil
+}
@@ -8,11 +8,11 @@ import (
 	"time"
 
 	"github.com/olivere/elastic/v7"
-	"github.com/shopspring/decimal"
 )
 
-// GetBet02ListForDateTimeReportEs queries bet02 data from Elasticsearch
-func (c *Client) GetBet02ListForDateTimeReportEs(ctx context.Context, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string) ([]map[string]interface{}, error) {
+// GetBet02ListForDateTimeReportEs queries one page of bet02 data from Elasticsearch, ordered by the report time then bet01.
+// The page holds at most size hits and starts right after the after cursor, nil for the first page.
+func (c *Client) GetBet02ListForDateTimeReportEs(ctx context.Context, memberID int64, agentID int64, startTime, endTime int64, dataType, timeType int, gameNo1, gameNo2 string, after *db.ReportCursor, size int) ([]*db.Bet02Report, error) {
 	// Create bool query
 	boolQuery := elastic.NewBoolQuery()
 
@@ -38,44 +38,46 @@ func (c *Client) GetBet02ListForDateTimeReportEs(ctx context.Context, memberID i
 		}
 	}
 
-	// Add time range filters using proper date format
-	startTimeStr := time.Unix(startTime, 0).Format("2006-01-02T15:04:05Z")
-	endTimeStr := time.Unix(endTime, 0).Format("2006-01-02T15:04:05Z")
+	timeField := "bet08"
 	if timeType == 1 {
-		boolQuery.Must(elastic.NewRangeQuery("updatetime").Gte(startTimeStr).Lte(endTimeStr))
-	} else {
-		boolQuery.Must(elastic.NewRangeQuery("bet08").Gte(startTimeStr).Lte(endTimeStr))
+		timeField = "updatetime"
 	}
+	boolQuery.Must(reportTimeRange(timeField, startTime, endTime))
 
-	// Execute search with reasonable size limit and timeout
-	searchResult, err := c.client.Search().
-		Index("bet02_report_index").
+	// bet01 breaks ties of the time so search_after never skips or repeats a hit
+	search := c.client.Search().
+		Index(Bet02ReportIndex).
 		Query(boolQuery).
-		Size(10000).    // Increased size limit for debugging
-		Timeout("60s"). // Increased timeout
-		Do(ctx)
+		Sort(timeField, true).
+		Sort("bet01", true).
+		Size(size).
+		Timeout("60s")
+	if after != nil {
+		search = search.SearchAfter(after.Time, after.Bet01)
+	}
+	searchResult, err := search.Do(ctx)
 	if err != nil {
 		xlog.Errorf("Elasticsearch query failed: %v, query: %+v", err, boolQuery)
 		return nil, err
 	}
 
-	// Process results
-	var results []map[string]interface{}
+	// Process results, a page missing a bet would skip it for good so a bad hit fails the page
+	results := make([]*db.Bet02Report, 0, len(searchResult.Hits.Hits))
 	for _, hit := range searchResult.Hits.Hits {
-		xlog.Infof("hit.Source: %+v", hit.Source)
-		var bet02 map[string]interface{}
+		xlog.Debugf("hit.Source: %s", hit.Source)
+		var bet02 db.Bet02Report
 		if err := json.Unmarshal(hit.Source, &bet02); err != nil {
-			xlog.Errorf("Failed to unmarshal hit: %v", err)
-			continue
+			xlog.Errorf("Failed to unmarshal hit %s: %v", hit.Id, err)
+			return nil, err
 		}
-		results = append(results, bet02)
+		results = append(results, &bet02)
 	}
 
 	return results, nil
 }
 
 // GetInOutMsEs queries in_out_m data from Elasticsearch
-func (c *Client) GetInOutMsEs(ctx context.Context, mIDs []int64, orderID, order string, startTime, endTime int64) ([]map[string]interface{}, error) {
+func (c *Client) GetInOutMsEs(ctx context.Context, mIDs []int64, orderID, order string, startTime, endTime int64) ([]*db.InOutMReport, error) {
 	// Create bool query
 	boolQuery := elastic.NewBoolQuery()
 
@@ -123,7 +125,7 @@ func (c *Client) GetInOutMsEs(ctx context.Context, mIDs []int64, orderID, order
 
 	// Execute search
 	searchResult, err := c.client.Search().
-		Index("in_out_m_index").
+		Index(InOutMIndex).
 		Query(boolQuery).
 		Do(ctx)
 	if err != nil {
@@ -132,26 +134,27 @@ func (c *Client) GetInOutMsEs(ctx context.Context, mIDs []int64, orderID, order
 	}
 
 	// Process results
-	var results []map[string]interface{}
+	results := make([]*db.InOutMReport, 0, len(searchResult.Hits.Hits))
 	for _, hit := range searchResult.Hits.Hits {
-		xlog.Infof("hit: %v", hit)
-		var inOutM map[string]interface{}
+		xlog.Infof("hit: %s", hit.Source)
+		var inOutM db.InOutMReport
 		if err := json.Unmarshal(hit.Source, &inOutM); err != nil {
-			continue
+			xlog.Errorf("Failed to unmarshal hit %s: %v", hit.Id, err)
+			return nil, err
 		}
-		results = append(results, inOutM)
+		results = append(results, &inOutM)
 	}
 
 	return results, nil
 }
 
 // GetBet02ListForReportDetailEs queries bet02 detail from Elasticsearch by betID
-func (c *Client) GetBet02ListForReportDetailEs(ctx context.Context, betID int64) (map[string]interface{}, error) {
+func (c *Client) GetBet02ListForReportDetailEs(ctx context.Context, betID int64) (*db.Bet02Report, error) {
 	boolQuery := elastic.NewBoolQuery()
 	boolQuery.Must(elastic.NewTermQuery("bet01", float64(betID)))
 
 	searchResult, err := c.client.Search().
-		Index("bet02_report_index").
+		Index(Bet02ReportIndex).
 		Query(boolQuery).
 		Size(1).
 		Do(ctx)
@@ -165,15 +168,28 @@ func (c *Client) GetBet02ListForReportDetailEs(ctx context.Context, betID int64)
 	}
 
 	hit := searchResult.Hits.Hits[0]
-	var bet02 map[string]interface{}
+	var bet02 db.Bet02Report
 	if err := json.Unmarshal(hit.Source, &bet02); err != nil {
 		xlog.Errorf("Failed to unmarshal hit: %v", err)
 		return nil, err
 	}
 
-	return bet02, nil
+	return &bet02, nil
+}
+
+// reportTimeRange matches the report times from startTime to endTime as the MySQL BETWEEN on the second columns does,
+// in epoch millis so the window does not depend on the zone of the host
+func reportTimeRange(timeField string, startTime, endTime int64) *elastic.RangeQuery {
+	return elastic.NewRangeQuery(timeField).Gte(startTime * 1000).Lt((endTime + 1) * 1000).Format("epoch_millis")
 }
 
+const (
+	// scanPageSize is how many hits one search_after page of a scan reads
+	scanPageSize = 5000
+	// scanKeepAlive keeps the point in time open between two pages
+	scanKeepAlive = "1m"
+)
+
 // GetBet01ListForUnsettledReportEs queries unsettled bet01 data from Elasticsearch
 func (c *Client) GetBet01ListForUnsettledReportEs(ctx context.Context, date time.Time) ([]*db.Bet01Summary, error) {
 	boolQuery := elastic.NewBoolQuery()
@@ -183,96 +199,78 @@ func (c *Client) GetBet01ListForUnsettledReportEs(ctx context.Context, date time
 	boolQuery.Must(elastic.NewTermQuery("bet07", dateStr))
 
 	// Exclude bet02 == 301
-	boolQuery.MustNot(elastic.NewTermQuery("bet02", 301))
+	boolQuery.MustNot(elastic.NewTermQuery("gid", 301))
 
 	// Exclude bet30 == "Y" (cancelled)
-	boolQuery.Must(elastic.NewTermQuery("bet30", 2))
+	boolQuery.Must(elastic.NewTermQuery("bet30", "N"))
 
-	boolQuery.Must(elastic.NewTermQuery("settle", false))
+	// Exclude bet01 that exist in bet02, the indexer sets is_settled once the bet02 is written
+	boolQuery.Must(elastic.NewTermQuery("is_settled", false))
 
-	// Exclude bet01 that exist in bet02 (simulate: bet01 NOT IN (SELECT bet01 FROM bet02))
-	// This is tricky in ES; assume a field like 'is_settled' or similar, or skip this filter if not available.
-	// If ES has a field 'is_settled' or similar, use it. Otherwise, this filter may need to be handled at index time.
-	// boolQuery.Must(elastic.NewTermQuery("is_settled", false)) // Uncomment if such a field exists
-
-	searchResult, err := c.client.Search().
-		Index("bet01_report_index").
-		Query(boolQuery).
-		Size(10000).
-		Do(ctx)
+	var results []*db.Bet01Summary
+	// a row missing from the report would go unnoticed, so a bad hit fails the report
+	err := c.scan(ctx, Bet01ReportIndex, boolQuery, nil, func(src json.RawMessage) error {
+		item, err := db.DecodeBet01Summary(src)
+		if err != nil {
+			xlog.Errorf("Failed to decode bet01 hit: %v", err)
+			return err
+		}
+		results = append(results, item)
+		return nil
+	})
 	if err != nil {
-		xlog.Errorf("Elasticsearch query failed: %v, query: %+v", err, boolQuery)
 		return nil, err
 	}
+	xlog.Debugf("debug to get bet01 list from ES, results: %v", results)
+	return results, nil
+}
 
-	xlog.Debugf("debug to get bet01 list from ES, searchResult: %v", searchResult)
-
-	var results []*db.Bet01Summary
-	for _, hit := range searchResult.Hits.Hits {
-		var src map[string]interface{}
-		if err := json.Unmarshal(hit.Source, &src); err != nil {
-			xlog.Errorf("Failed to unmarshal hit: %v", err)
-			continue
-		}
-		item := &db.Bet01Summary{}
-		if v, ok := src["betId"].(float64); ok {
-			item.BetID = int64(v)
+// scan reads every hit of query in index through a point in time, which keeps the pages consistent while they
+// are read. fields limits the source to them, nil for all of it. An error of fn stops the scan.
+func (c *Client) scan(ctx context.Context, index string, query elastic.Query, fields []string, fn func(src json.RawMessage) error) error {
+	pit, err := c.client.OpenPointInTime(index).KeepAlive(scanKeepAlive).Do(ctx)
+	if err != nil {
+		xlog.Errorf("error to open point in time of %s, err:%+v", index, err)
+		return err
+	}
+	pitID := pit.Id
+	defer func() {
+		if _, err := c.client.ClosePointInTime(pitID).Do(context.Background()); err != nil {
+			xlog.Errorf("error to close point in time, err:%+v", err)
 		}
-		if v, ok := src["gid"].(float64); ok {
-			item.GID = int(v)
+	}()
+
+	var after []interface{}
+	for {
+		search := c.client.Search().
+			PointInTime(elastic.NewPointInTimeWithKeepAlive(pitID, scanKeepAlive)).
+			Query(query).
+			Sort("_shard_doc", true).
+			Size(scanPageSize)
+		if fields != nil {
+			search = search.FetchSourceContext(elastic.NewFetchSourceContext(true).Include(fields...))
 		}
-		if v, ok := src["event"].(string); ok {
-			item.Event, _ = decimal.NewFromString(v)
-		} else if v, ok := src["event"].(float64); ok {
-			item.Event = decimal.NewFromFloat(v)
+		if after != nil {
+			search = search.SearchAfter(after...)
 		}
-		if v, ok := src["eventChild"].(float64); ok {
-			item.EventChild = int(v)
+		searchResult, err := search.Do(ctx)
+		if err != nil {
+			xlog.Errorf("Elasticsearch query failed: %v, query: %+v", err, query)
+			return err
 		}
-		if v, ok := src["userId"].(float64); ok {
-			item.ID = int(v)
+		if searchResult.PitId != "" {
+			pitID = searchResult.PitId
 		}
-		if v, ok := src["betTime"].(string); ok {
-			if t, err := time.Parse(time.RFC3339, v); err == nil {
-				item.BetTime = t
+
+		hits := searchResult.Hits.Hits
+		for _, hit := range hits {
+			if err := fn(hit.Source); err != nil {
+				return err
 			}
-		} else if v, ok := src["betTime"].(float64); ok {
-			item.BetTime = time.Unix(int64(v)/1000, 0)
-		}
-		if v, ok := src["betResult"].(string); ok {
-			item.BetResult = v
-		}
-		if v, ok := src["bet"].(string); ok {
-			item.Bet, _ = decimal.NewFromString(v)
-		} else if v, ok := src["bet"].(float64); ok {
-			item.Bet = decimal.NewFromFloat(v)
 		}
-		if v, ok := src["aid"].(float64); ok {
-			item.AID = int(v)
+		if len(hits) < scanPageSize {
+			return nil
 		}
-		if v, ok := src["tableId"].(float64); ok {
-			item.TableID = int(v)
-		}
-		if v, ok := src["commission"].(float64); ok {
-			item.Commission = int(v)
-		}
-		if v, ok := src["event"].(string); ok {
-			item.Round, _ = decimal.NewFromString(v)
-		} else if v, ok := src["round"].(float64); ok {
-			item.Round = decimal.NewFromFloat(v)
-		}
-		if v, ok := src["eventChild"].(float64); ok {
-			item.SubRound = int(v)
-		}
-		// gname and user may be indexed in ES, or need enrichment
-		if v, ok := src["gameName"].(string); ok {
-			item.GName = v
-		}
-		if v, ok := src["username"].(string); ok {
-			item.User = v
-		}
-		results = append(results, item)
+		after = hits[len(hits)-1].Sort
 	}
-	xlog.Debugf("debug to get bet01 list from ES, results: %v", results)
-	return results, nil
 }
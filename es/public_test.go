@@ -0,0 +1,38 @@
+package es
+
+import (
+	"go-zrbc/db"
+	"testing"
+	"time"
+)
+
+func TestReportTimeRange(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("CST", 8*3600)
+	t.Cleanup(func() { time.Local = local })
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local).Unix()
+	end := start + 86400 - 1
+	src, err := reportTimeRange("bet08", start, end).Source()
+	if err != nil {
+		t.Fatalf("source err:(%+v)", err)
+	}
+	r := src.(map[string]interface{})["range"].(map[string]interface{})["bet08"].(map[string]interface{})
+	if r["format"] != "epoch_millis" || r["from"] != start*1000 || r["to"] != (end+1)*1000 || r["include_upper"] != false {
+		t.Fatalf("range:(%+v)", r)
+	}
+
+	// The first and last second of the local day are in, as MySQL BETWEEN has them, the next day is out
+	from, to := r["from"].(int64), r["to"].(int64)
+	for at, in := range map[time.Time]bool{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local):      true,
+		time.Date(2024, 1, 1, 23, 59, 59, 0, time.Local):   true,
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local):      false,
+		time.Date(2023, 12, 31, 23, 59, 59, 0, time.Local): false,
+	} {
+		doc := NewBet02Doc(&db.Bet02Extra{Bet02: db.Bet02{Bet01: 1, Bet08: at}})
+		if got := doc.Bet08 >= from && doc.Bet08 < to; got != in {
+			t.Fatalf("bet at %s in range:%v, want %v", at, got, in)
+		}
+	}
+}
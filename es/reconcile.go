@@ -0,0 +1,189 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-zrbc/db"
+	"go-zrbc/pkg/xlog"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/olivere/elastic/v7"
+	"github.com/shopspring/decimal"
+)
+
+// DriftKey hash of DriftField to the json reconcile report that found the report indices of an agent drifted from
+// MySQL on a day. The reports of an agent in it are read from MySQL until a clean check or an operator clears it.
+const DriftKey = "es_report_drift"
+
+// DriftAgentKey hash of agent id to the number of its days in DriftKey, kept by SaveDrift and ClearDrift so
+// a report checks its agent with one HEXISTS
+const DriftAgentKey = "es_report_drift_agent"
+
+// DriftField is the DriftKey field of agentID on day, formatted 2006-01-02
+func DriftField(agentID int64, day string) string {
+	return strconv.FormatInt(agentID, 10) + ":" + day
+}
+
+var (
+	saveDriftScript = redis.NewScript(`
+if redis.call("HSET", KEYS[1], ARGV[2], ARGV[3]) == 1 then
+	redis.call("HINCRBY", KEYS[2], ARGV[1], 1)
+end
+return 0
+`)
+	clearDriftScript = redis.NewScript(`
+local n = redis.call("HDEL", KEYS[1], unpack(ARGV, 2))
+if n > 0 and redis.call("HINCRBY", KEYS[2], ARGV[1], -n) <= 0 then
+	redis.call("HDEL", KEYS[2], ARGV[1])
+end
+return n
+`)
+)
+
+// SaveDrift records the json report that found agentID drifted on day
+func SaveDrift(ctx context.Context, redisCli *redis.Client, agentID int64, day string, report []byte) error {
+	return saveDriftScript.Run(ctx, redisCli, []string{DriftKey, DriftAgentKey},
+		agentID, DriftField(agentID, day), report).Err()
+}
+
+// ClearDrift drops the drift of agentID on days, the agent reads ES again once none is left
+func ClearDrift(ctx context.Context, redisCli *redis.Client, agentID int64, days ...string) error {
+	if len(days) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, len(days)+1)
+	args = append(args, agentID)
+	for _, day := range days {
+		args = append(args, DriftField(agentID, day))
+	}
+	return clearDriftScript.Run(ctx, redisCli, []string{DriftKey, DriftAgentKey}, args...).Err()
+}
+
+// bet02ReconcileQuery matches the bets of an agent placed in [start, end), as SumBet02ForReconcile does
+func bet02ReconcileQuery(agentID int64, start, end time.Time) elastic.Query {
+	return elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery("bet22", agentID)).
+		Filter(elastic.NewRangeQuery("bet08").Gte(start.UnixMilli()).Lt(end.UnixMilli()).Format("epoch_millis"))
+}
+
+// inOutMReconcileQuery matches the records of an agent written in [start, end), as SumInOutMForReconcile does
+func inOutMReconcileQuery(agentID int64, start, end time.Time) elastic.Query {
+	return elastic.NewBoolQuery().
+		Filter(elastic.NewTermQuery("iom007", agentID)).
+		Filter(elastic.NewRangeQuery("iom002").Gte(start.UnixMilli()).Lt(end.UnixMilli()).Format("epoch_millis"))
+}
+
+// SumBet02 sums what SumBet02ForReconcile sums in MySQL from bet02_report_index
+func (c *Client) SumBet02(ctx context.Context, agentID int64, start, end time.Time) (*db.ReconcileTotals, error) {
+	sums := map[string]string{"bet": "bet13", "result": "bet17", "water": "bet16"}
+	vals, count, err := c.sum(ctx, Bet02ReportIndex, bet02ReconcileQuery(agentID, start, end), sums)
+	if err != nil {
+		return nil, err
+	}
+	return &db.ReconcileTotals{Count: count, Bet: vals["bet"], Result: vals["result"], Water: vals["water"]}, nil
+}
+
+// SumInOutM sums what SumInOutMForReconcile sums in MySQL from in_out_m_index
+func (c *Client) SumInOutM(ctx context.Context, agentID int64, start, end time.Time) (*db.ReconcileTotals, error) {
+	vals, count, err := c.sum(ctx, InOutMIndex, inOutMReconcileQuery(agentID, start, end), map[string]string{"money": "iom004"})
+	if err != nil {
+		return nil, err
+	}
+	return &db.ReconcileTotals{Count: count, Money: vals["money"]}, nil
+}
+
+// sum counts the hits of query and sums each field into its name. The scaled_float sums come back as floats,
+// they are rounded to the 4 decimals of their scaling factor.
+func (c *Client) sum(ctx context.Context, index string, query elastic.Query, fields map[string]string) (map[string]decimal.Decimal, int64, error) {
+	search := c.client.Search().Index(index).Query(query).Size(0).TrackTotalHits(true)
+	for name, field := range fields {
+		search = search.Aggregation(name, elastic.NewSumAggregation().Field(field))
+	}
+	searchResult, err := search.Do(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to sum %s: %v", index, err)
+	}
+
+	vals := make(map[string]decimal.Decimal, len(fields))
+	for name := range fields {
+		agg, ok := searchResult.Aggregations.Sum(name)
+		if !ok || agg.Value == nil {
+			vals[name] = decimal.Zero
+			continue
+		}
+		vals[name] = decimal.NewFromFloat(*agg.Value).Round(4)
+	}
+	return vals, searchResult.TotalHits(), nil
+}
+
+// ListBet02Versions returns the updatetime in unix millis of every bet SumBet02 sums by bet01
+func (c *Client) ListBet02Versions(ctx context.Context, agentID int64, start, end time.Time) (map[int64]int64, error) {
+	return c.versions(ctx, Bet02ReportIndex, bet02ReconcileQuery(agentID, start, end), "bet01", "updatetime")
+}
+
+// ListInOutMVersions returns the iom002 in unix millis of every record SumInOutM sums by iom001
+func (c *Client) ListInOutMVersions(ctx context.Context, agentID int64, start, end time.Time) (map[int64]int64, error) {
+	return c.versions(ctx, InOutMIndex, inOutMReconcileQuery(agentID, start, end), "iom001", "iom002")
+}
+
+func (c *Client) versions(ctx context.Context, index string, query elastic.Query, idField, timeField string) (map[int64]int64, error) {
+	ret := map[int64]int64{}
+	err := c.scan(ctx, index, query, []string{idField, timeField}, func(raw json.RawMessage) error {
+		var src map[string]interface{}
+		if err := json.Unmarshal(raw, &src); err != nil {
+			xlog.Errorf("Failed to unmarshal hit: %v", err)
+			return nil
+		}
+		id, ok := src[idField].(float64)
+		if !ok {
+			return nil
+		}
+		switch v := src[timeField].(type) {
+		case float64:
+			ret[int64(id)] = int64(v)
+		case string:
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				ret[int64(id)] = t.UnixMilli()
+			} else {
+				ret[int64(id)] = 0
+			}
+		default:
+			ret[int64(id)] = 0
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// BulkDelete removes the documents of ids from index, ids already gone are not an error
+func (c *Client) BulkDelete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	bulk := c.client.Bulk()
+	for _, id := range ids {
+		bulk.Add(elastic.NewBulkDeleteRequest().Index(index).Id(id))
+	}
+
+	resp, err := bulk.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bulk delete documents: %v", err)
+	}
+	for _, item := range resp.Failed() {
+		if item.Status == 404 {
+			continue
+		}
+		reason := ""
+		if item.Error != nil {
+			reason = item.Error.Reason
+		}
+		return fmt.Errorf("failed to bulk delete %d documents, id %s: %s", len(ids), item.Id, reason)
+	}
+	return nil
+}
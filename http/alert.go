@@ -0,0 +1,195 @@
+package http
+
+import (
+	"context"
+	"go-zrbc/pkg/http/middleware"
+	"go-zrbc/pkg/http/response"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/view"
+	"strconv"
+
+	aService "go-zrbc/service/alert"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AlertHandler struct {
+	srv aService.AlertService
+}
+
+func NewAlertHandler(srv aService.AlertService) *AlertHandler {
+	return &AlertHandler{
+		srv: srv,
+	}
+}
+
+func (handler *AlertHandler) SetRouter(r *gin.Engine) {
+	admin := r.Group("/v1/admin", middleware.AdminAuth)
+	// 未处理告警列表
+	admin.GET("/alerts", handler.ListUnhandledAlerts)
+	// 确认告警
+	admin.POST("/alert/ack", handler.AckAlert)
+	// 处理告警
+	admin.POST("/alert/resolve", handler.ResolveAlert)
+	// 交易锁定的会员
+	admin.GET("/locked_members", handler.ListLockedMembers)
+	// 会员近期加扣点纪录
+	admin.GET("/member/transfers", handler.GetMemberTransfers)
+	// 复核会员锁定
+	admin.POST("/member/review_lock", handler.ReviewMemberLock)
+	// 会员锁定复核纪录
+	admin.GET("/member/lock_audits", handler.GetMemberLockAudits)
+}
+
+// swagger:route GET /v1/admin/alerts 后台接口 ListUnhandledAlerts
+// 未处理及已确认的告警
+// responses:
+//
+//	200: ListUnhandledAlertsResp
+//	500: CommonError
+func (handler *AlertHandler) ListUnhandledAlerts(c *gin.Context) {
+	cursor, _ := strconv.ParseInt(c.Query("cursor"), 10, 64)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	resp, err := handler.srv.ListUnhandledAlerts(context.TODO(), &view.ListUnhandledAlertsReq{Cursor: cursor, Limit: limit})
+	if err != nil {
+		response.ErrResp(c, err)
+		return
+	}
+	response.JsonResp(c, resp)
+}
+
+// swagger:route POST /v1/admin/alert/ack 后台接口 AckAlert
+// 确认告警, 由当前操作员跟进
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: ok
+//	500: CommonError
+func (handler *AlertHandler) AckAlert(c *gin.Context) {
+	id, err := strconv.ParseInt(c.PostForm("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.ErrResp(c, utils.ErrParamError)
+		return
+	}
+	err = handler.srv.AckAlert(context.TODO(), &view.AckAlertReq{ID: id}, middleware.GetOperator(c))
+	if err != nil {
+		response.ErrResp(c, err)
+		return
+	}
+	response.JsonResp(c, "ok")
+}
+
+// swagger:route POST /v1/admin/alert/resolve 后台接口 ResolveAlert
+// 处理告警并结案
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: ok
+//	500: CommonError
+func (handler *AlertHandler) ResolveAlert(c *gin.Context) {
+	id, err := strconv.ParseInt(c.PostForm("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.ErrResp(c, utils.ErrParamError)
+		return
+	}
+	req := &view.ResolveAlertReq{
+		ID:     id,
+		Remark: c.PostForm("remark"),
+	}
+	err = handler.srv.ResolveAlert(context.TODO(), req, middleware.GetOperator(c))
+	if err != nil {
+		response.ErrResp(c, err)
+		return
+	}
+	response.JsonResp(c, "ok")
+}
+
+// swagger:route GET /v1/admin/locked_members 后台接口 ListLockedMembers
+// 交易锁定的会员及其未结案告警
+// responses:
+//
+//	200: ListLockedMembersResp
+//	500: CommonError
+func (handler *AlertHandler) ListLockedMembers(c *gin.Context) {
+	cursor, _ := strconv.ParseInt(c.Query("cursor"), 10, 64)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	resp, err := handler.srv.ListLockedMembers(context.TODO(), &view.ListLockedMembersReq{Cursor: cursor, Limit: limit})
+	if err != nil {
+		response.ErrResp(c, err)
+		return
+	}
+	response.JsonResp(c, resp)
+}
+
+// swagger:route GET /v1/admin/member/transfers 后台接口 GetMemberTransfers
+// 会员近期加扣点纪录
+// responses:
+//
+//	200: GetMemberTransfersResp
+//	500: CommonError
+func (handler *AlertHandler) GetMemberTransfers(c *gin.Context) {
+	mid, err := strconv.ParseInt(c.Query("mid"), 10, 64)
+	if err != nil || mid <= 0 {
+		response.ErrResp(c, utils.ErrParamError)
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	resp, err := handler.srv.GetMemberTransfers(context.TODO(), &view.GetMemberTransfersReq{Mid: mid, Limit: limit})
+	if err != nil {
+		response.ErrResp(c, err)
+		return
+	}
+	response.JsonResp(c, resp)
+}
+
+// swagger:route POST /v1/admin/member/review_lock 后台接口 ReviewMemberLock
+// 复核会员锁定, 解除或维持锁定并结案其告警
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: ok
+//	500: CommonError
+func (handler *AlertHandler) ReviewMemberLock(c *gin.Context) {
+	mid, err := strconv.ParseInt(c.PostForm("mid"), 10, 64)
+	if err != nil || mid <= 0 {
+		response.ErrResp(c, utils.ErrParamError)
+		return
+	}
+	req := &view.ReviewMemberLockReq{
+		Mid:      mid,
+		Decision: c.PostForm("decision"),
+		Reason:   c.PostForm("reason"),
+	}
+	err = handler.srv.ReviewMemberLock(context.TODO(), req, middleware.GetOperator(c))
+	if err != nil {
+		response.ErrResp(c, err)
+		return
+	}
+	response.JsonResp(c, "ok")
+}
+
+// swagger:route GET /v1/admin/member/lock_audits 后台接口 GetMemberLockAudits
+// 会员锁定复核纪录
+// responses:
+//
+//	200: GetMemberLockAuditsResp
+//	500: CommonError
+func (handler *AlertHandler) GetMemberLockAudits(c *gin.Context) {
+	mid, err := strconv.ParseInt(c.Query("mid"), 10, 64)
+	if err != nil || mid <= 0 {
+		response.ErrResp(c, utils.ErrParamError)
+		return
+	}
+	resp, err := handler.srv.GetMemberLockAudits(context.TODO(), &view.GetMemberLockAuditsReq{Mid: mid})
+	if err != nil {
+		response.ErrResp(c, err)
+		return
+	}
+	response.JsonResp(c, resp)
+}
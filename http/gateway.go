@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"go-zrbc/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitClass groups gateway commands that share a throttle policy
+type RateLimitClass = ratelimit.Class
+
+const (
+	RateLimitQuery    = ratelimit.ClassQuery    // 余额、订单查询
+	RateLimitAccount  = ratelimit.ClassAccount  // 会员帐号操作
+	RateLimitTransfer = ratelimit.ClassTransfer // 加扣点
+	RateLimitReport   = ratelimit.ClassReport   // 报表
+)
+
+// GatewayCommand is one cmd of /api/public/Gateway.php
+type GatewayCommand struct {
+	// Name is the value of the cmd parameter
+	Name string
+	// Path is the /v1 route serving the same command, empty for gateway only commands
+	Path string
+	// NeedAgentVerify makes the gateway check vendorId/signature before Handle runs
+	NeedAgentVerify bool
+	// RateLimit is the throttle class the command is counted against
+	RateLimit RateLimitClass
+	// ThrottledByHandle leaves the rate limit to Handle, which answers idempotent replays before it
+	ThrottledByHandle bool
+	// Bind reads the command parameters from the request
+	Bind func(c *gin.Context) (interface{}, error)
+	// Handle runs the command with the value returned by Bind
+	Handle func(ctx context.Context, req interface{}) (interface{}, error)
+}
+
+// NewGatewayCommand adapts a typed binder and service method to a GatewayCommand
+func NewGatewayCommand[Req any, Resp any](
+	name, path string,
+	needAgentVerify bool,
+	rateLimit RateLimitClass,
+	bind func(c *gin.Context) (*Req, error),
+	handle func(ctx context.Context, req *Req) (*Resp, error),
+) *GatewayCommand {
+	return &GatewayCommand{
+		Name:            name,
+		Path:            path,
+		NeedAgentVerify: needAgentVerify,
+		RateLimit:       rateLimit,
+		Bind: func(c *gin.Context) (interface{}, error) {
+			req, err := bind(c)
+			if err != nil {
+				return nil, err
+			}
+			return req, nil
+		},
+		Handle: func(ctx context.Context, req interface{}) (interface{}, error) {
+			resp, err := handle(ctx, req.(*Req))
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		},
+	}
+}
+
+// ThrottleInHandle marks a command whose service checks the rate limit itself
+func (cmd *GatewayCommand) ThrottleInHandle() *GatewayCommand {
+	cmd.ThrottledByHandle = true
+	return cmd
+}
+
+// CommandRegistry keeps the gateway commands in registration order
+type CommandRegistry struct {
+	commands map[string]*GatewayCommand
+	ordered  []*GatewayCommand
+}
+
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{
+		commands: make(map[string]*GatewayCommand),
+	}
+}
+
+// Register adds cmd, registering the same name twice is a programming error
+func (r *CommandRegistry) Register(cmd *GatewayCommand) {
+	if _, ok := r.commands[cmd.Name]; ok {
+		panic(fmt.Sprintf("gateway command %s registered twice", cmd.Name))
+	}
+	r.commands[cmd.Name] = cmd
+	r.ordered = append(r.ordered, cmd)
+}
+
+func (r *CommandRegistry) Lookup(name string) (*GatewayCommand, bool) {
+	cmd, ok := r.commands[name]
+	return cmd, ok
+}
+
+func (r *CommandRegistry) Commands() []*GatewayCommand {
+	return r.ordered
+}
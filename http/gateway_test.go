@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type echoReq struct {
+	Name string
+}
+
+type echoResp struct {
+	Result string
+}
+
+func bindEcho(c *gin.Context) (*echoReq, error) {
+	name := c.PostForm("name")
+	if name == "" {
+		return nil, errors.New("name is empty")
+	}
+	return &echoReq{Name: name}, nil
+}
+
+func handleEcho(ctx context.Context, req *echoReq) (*echoResp, error) {
+	return &echoResp{Result: "hello " + req.Name}, nil
+}
+
+func TestGateway_RegistryOrderAndLookup(t *testing.T) {
+	r := NewCommandRegistry()
+	r.Register(NewGatewayCommand("B", "/v1/b", true, RateLimitQuery, bindEcho, handleEcho))
+	r.Register(NewGatewayCommand("A", "", false, RateLimitReport, bindEcho, handleEcho))
+
+	cmds := r.Commands()
+	if len(cmds) != 2 || cmds[0].Name != "B" || cmds[1].Name != "A" {
+		t.Fatalf("commands not kept in registration order: %+v", cmds)
+	}
+	if _, ok := r.Lookup("C"); ok {
+		t.Fatalf("lookup of unknown command succeeded")
+	}
+	cmd, ok := r.Lookup("A")
+	if !ok || cmd.NeedAgentVerify || cmd.RateLimit != RateLimitReport {
+		t.Fatalf("lookup A:(%+v), ok:(%v)", cmd, ok)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("registering a command twice did not panic")
+		}
+	}()
+	r.Register(NewGatewayCommand("A", "", false, RateLimitReport, bindEcho, handleEcho))
+}
+
+func TestGateway_CommandBindAndHandle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cmd := NewGatewayCommand("Echo", "", false, RateLimitQuery, bindEcho, handleEcho)
+
+	newCtx := func(form url.Values) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", "/api/public/Gateway.php", strings.NewReader(form.Encode()))
+		c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return c
+	}
+
+	if _, err := cmd.Bind(newCtx(url.Values{})); err == nil {
+		t.Fatalf("bind without name succeeded")
+	}
+
+	c := newCtx(url.Values{"name": {"zr"}})
+	req, err := cmd.Bind(c)
+	if err != nil {
+		t.Fatalf("bind err:(%+v)", err)
+	}
+	resp, err := cmd.Handle(c, req)
+	if err != nil {
+		t.Fatalf("handle err:(%+v)", err)
+	}
+	if got := resp.(*echoResp).Result; got != "hello zr" {
+		t.Fatalf("resp:(%s), want hello zr", got)
+	}
+}
+
+func TestGateway_SignedParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newCtx := func(target string, form url.Values) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", target, strings.NewReader(form.Encode()))
+		c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		c.Request.ParseForm()
+		return c
+	}
+
+	// A cmd sent in the query string is signed like a form field
+	c := newCtx("/api/public/Gateway.php?cmd=ChangeBalance", url.Values{"vendorId": {"demo"}, "nonce": {"n1"}})
+	if got := signedParams(c).Encode(); got != "cmd=ChangeBalance&nonce=n1&vendorId=demo" {
+		t.Fatalf("signed params:(%s)", got)
+	}
+	if c.Request.PostForm.Get("cmd") != "" {
+		t.Fatalf("signedParams modified the form")
+	}
+
+	// The form cmd is the one that runs, the query one is left out
+	c = newCtx("/api/public/Gateway.php?cmd=ChangeBalance", url.Values{"cmd": {"GetBalance"}, "vendorId": {"demo"}})
+	if got := signedParams(c).Encode(); got != "cmd=GetBalance&vendorId=demo" {
+		t.Fatalf("signed params:(%s)", got)
+	}
+}
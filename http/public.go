@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"go-zrbc/pkg/gameUtil"
 	"go-zrbc/pkg/http/middleware"
@@ -10,6 +11,7 @@ import (
 	"go-zrbc/pkg/xlog"
 	service "go-zrbc/service/public"
 	"go-zrbc/view"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -17,105 +19,202 @@ import (
 )
 
 type PublicApiHandler struct {
-	srv service.PublicApiService
+	srv      service.PublicApiService
+	commands *CommandRegistry
 }
 
 func NewPublicApiHandler(srv service.PublicApiService) *PublicApiHandler {
-	return &PublicApiHandler{
-		srv: srv,
+	h := &PublicApiHandler{
+		srv:      srv,
+		commands: NewCommandRegistry(),
 	}
+	h.registerCommands()
+	return h
+}
+
+// registerCommands declares every command served by Gateway.php, the /v1 routes are derived from it
+func (h *PublicApiHandler) registerCommands() {
+	h.commands.Register(NewGatewayCommand("SigninGame", "/v1/signin_game", true, RateLimitAccount, bindSigninGame, h.srv.SigninGame))
+	h.commands.Register(NewGatewayCommand("MemberLogin", "/v1/member_login", true, RateLimitAccount, bindMemberLogin, h.srv.MemberLogin))
+	h.commands.Register(NewGatewayCommand("MemberRegister", "/v1/member_register", true, RateLimitAccount, bindMemberRegister, h.srv.MemberRegister))
+	h.commands.Register(NewGatewayCommand("EditLimit", "/v1/edit_limit", true, RateLimitAccount, bindEditLimit, h.srv.EditLimit))
+	h.commands.Register(NewGatewayCommand("LogoutGame", "/v1/logout_game", true, RateLimitAccount, bindLogoutGame, h.srv.LogoutGame))
+	h.commands.Register(NewGatewayCommand("ChangePassword", "/v1/change_password", true, RateLimitAccount, bindChangePassword, h.srv.ChangePassword))
+	h.commands.Register(NewGatewayCommand("EnableorDisablemem", "/v1/enable_or_disable_mem", true, RateLimitAccount, bindEnableOrDisableMem, h.srv.EnableOrDisableMem))
+	h.commands.Register(NewGatewayCommand("GetAgentBalance", "/v1/get_agent_balance", true, RateLimitQuery, bindGetAgentBalance, h.srv.GetAgentBalance))
+	h.commands.Register(NewGatewayCommand("GetBalance", "/v1/get_balance", true, RateLimitQuery, bindGetBalance, h.srv.GetBalance))
+	h.commands.Register(NewGatewayCommand("CheckTransfer", "/v1/check_transfer", true, RateLimitQuery, bindCheckTransfer, h.srv.CheckTransfer))
+	h.commands.Register(NewGatewayCommand("ChangeBalance", "/v1/change_balance", true, RateLimitTransfer, bindChangeBalance, h.srv.ChangeBalance).ThrottleInHandle())
+	h.commands.Register(NewGatewayCommand("BatchChangeBalance", "/v1/batch_change_balance", true, RateLimitTransfer, bindBatchChangeBalance, h.srv.BatchChangeBalance))
+	h.commands.Register(NewGatewayCommand("GetMemberTradeReport", "/v1/get_member_trade_report", true, RateLimitReport, bindGetMemberTradeReport, h.srv.GetMemberTradeReport))
+	h.commands.Register(NewGatewayCommand("GetDateTimeReport", "/v1/get_date_time_report", true, RateLimitReport, bindGetDateTimeReport, h.srv.GetDateTimeReport))
+	h.commands.Register(NewGatewayCommand("GetDateTimeCountReport", "/v1/get_date_time_count_report", true, RateLimitReport, bindGetDateTimeCountReport, h.srv.GetDateTimeCountReport))
+	h.commands.Register(NewGatewayCommand("GetTipReport", "/v1/get_tip_report", true, RateLimitReport, bindGetTipReport, h.srv.GetTipReport))
+	h.commands.Register(NewGatewayCommand("GetUnsettleReport", "/v1/get_unsettle_report", true, RateLimitReport, bindGetUnsettleReport, h.srv.GetUnsettleReport))
+	h.commands.Register(NewGatewayCommand("GetReportDetail", "/v1/get_report_detail", true, RateLimitReport, bindGetReportDetail, h.srv.GetReportDetail))
+	h.commands.Register(NewGatewayCommand("CreateReportExport", "/v1/create_report_export", true, RateLimitReport, bindCreateReportExport, h.srv.CreateReportExport))
+	h.commands.Register(NewGatewayCommand("GetReportExport", "/v1/get_report_export", true, RateLimitQuery, bindGetReportExport, h.srv.GetReportExport))
+	h.commands.Register(NewGatewayCommand("Hello", "/v1/hello", true, RateLimitQuery, bindHello, h.srv.Hello))
+	h.commands.Register(NewGatewayCommand("ListCommands", "/v1/list_commands", false, RateLimitQuery, bindListCommands, h.ListCommands))
 }
 
 func (h *PublicApiHandler) SetRouter(r *gin.Engine) {
 	r.POST("/api/public/Gateway.php", h.handlePublicApi)
 
 	r.GET("/v1/user_info", h.GetUserInfo)
-	r.POST("/v1/signin_game", h.SigninGame)
-	r.POST("/v1/member_register", h.MemberRegister)
-	r.POST("/v1/edit_limit", h.EditLimit)
-	r.POST("/v1/logout_game", h.LogoutGame)
-	r.POST("/v1/change_password", h.ChangePassword)
-	r.POST("/v1/get_agent_balance", h.GetAgentBalance)
-	r.POST("/v1/get_balance", h.GetBalance)
-	r.POST("/v1/change_balance", h.ChangeBalance)
-	r.POST("/v1/get_member_trade_report", h.GetMemberTradeReport)
-	r.POST("/v1/enable_or_disable_mem", h.EnableOrDisableMem)
-	r.POST("/v1/get_date_time_report", h.GetDateTimeReport)
-	r.POST("/v1/get_tip_report", h.GetTipReport)
-	r.POST("/v1/get_unsettle_report", h.GetUnsettleReport)
-	r.POST("/v1/get_report_detail", h.GetReportDetail)
+	for _, cmd := range h.commands.Commands() {
+		if cmd.Path != "" {
+			r.POST(cmd.Path, h.serve(cmd))
+		}
+	}
+
+	admin := r.Group("/v1/admin", middleware.AdminAuth)
+	// 报表索引偏差的代理
+	admin.GET("/es_drift", h.ListEsDrift)
+	// 解除代理的报表索引偏差
+	admin.POST("/es_drift/clear", h.ClearEsDrift)
+}
+
+// swagger:route GET /v1/admin/es_drift 后台接口 ListEsDrift
+// 报表索引与 MySQL 有偏差的代理, 其报表改查 MySQL
+// responses:
+//
+//	200: ListEsDriftResp
+//	500: CommonError
+func (h *PublicApiHandler) ListEsDrift(c *gin.Context) {
+	resp, err := h.srv.ListEsDrift(context.TODO())
+	if err != nil {
+		commonresp.ErrResp(c, err)
+		return
+	}
+	commonresp.JsonResp(c, resp)
+}
+
+// swagger:route POST /v1/admin/es_drift/clear 后台接口 ClearEsDrift
+// 解除代理的报表索引偏差, 其报表恢复查 ES
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: ok
+//	500: CommonError
+func (h *PublicApiHandler) ClearEsDrift(c *gin.Context) {
+	agentID, err := strconv.ParseInt(c.PostForm("agentId"), 10, 64)
+	if err != nil || agentID <= 0 {
+		commonresp.ErrResp(c, utils.ErrParamError)
+		return
+	}
+	day := c.PostForm("day")
+	if day != "" {
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			commonresp.ErrResp(c, utils.ErrParamError)
+			return
+		}
+	}
+	err = h.srv.ClearEsDrift(context.TODO(), &view.ClearEsDriftReq{AgentID: agentID, Day: day})
+	if err != nil {
+		commonresp.ErrResp(c, err)
+		return
+	}
+	xlog.Infof("operator %s cleared es drift of agent %d %s", middleware.GetOperator(c), agentID, day)
+	commonresp.JsonResp(c, "ok")
 }
 
 func (h *PublicApiHandler) handlePublicApi(c *gin.Context) {
 	// Get command from request
-	cmd := c.PostForm("cmd")
-	if cmd == "" {
-		cmd = c.Query("cmd")
-	}
-
-	xlog.Debugf("EnableOrDisableMem cmd: %s", cmd)
-	// Check if command is in allowed list
-	passCommands := map[string]bool{
-		"GetAgentBalance":        true,
-		"MemberRegister":         true,
-		"EditLimit":              true,
-		"Hello":                  true,
-		"MemberLogin":            true,
-		"GetDateTimeReport":      true,
-		"GetTipReport":           true,
-		"EnableorDisablemem":     true,
-		"GetMemberTradeReport":   true,
-		"LogoutGame":             true,
-		"GetUnsettleReport":      true,
-		"GetDateTimeCountReport": true,
-		"GetBalance":             true,
-		"ChangePassword":         true,
-		"SigninGame":             true,
-		"ChangeBalance":          true,
-	}
-
-	// Handle command
-	switch cmd {
-	case "MemberRegister":
-		h.MemberRegister(c)
-	case "LogoutGame":
-		h.LogoutGame(c)
-	case "EditLimit":
-		h.EditLimit(c)
-	case "ChangePassword":
-		h.ChangePassword(c)
-	case "GetAgentBalance":
-		h.GetAgentBalance(c)
-	case "GetBalance":
-		h.GetBalance(c)
-	case "SigninGame":
-		h.SigninGame(c)
-	case "ChangeBalance":
-		h.ChangeBalance(c)
-	case "GetMemberTradeReport":
-		h.GetMemberTradeReport(c)
-	case "EnableorDisablemem":
-		xlog.Debugf("EnableOrDisableMem req: %+v", c.Request.PostForm)
-		h.EnableOrDisableMem(c)
-	case "GetDateTimeReport":
-		h.GetDateTimeReport(c)
-	case "GetTipReport":
-		h.GetTipReport(c)
-	case "GetUnsettleReport":
-		h.GetUnsettleReport(c)
-	case "GetReportDetail":
-		h.GetReportDetail(c)
-	// Add other command handlers as needed
-	default:
-		if !passCommands[cmd] {
-			xlog.Errorf("Invalid command: %s", cmd)
-			commonresp.ErrResp(c, errors.New("invalid command"))
+	name := c.PostForm("cmd")
+	if name == "" {
+		name = c.Query("cmd")
+	}
+
+	cmd, ok := h.commands.Lookup(name)
+	if !ok {
+		xlog.Errorf("Invalid command: %s", name)
+		commonresp.ErrResp(c, utils.ErrNoCommand)
+		return
+	}
+	h.serve(cmd)(c)
+}
+
+// serve binds the request of cmd, verifies and rate limits the agent when the command asks for it and runs it
+func (h *PublicApiHandler) serve(cmd *GatewayCommand) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := cmd.Bind(c)
+		if err != nil {
+			commonresp.ErrResp(c, err)
 			return
-		} else {
-			commonresp.ErrResp(c, errors.New("this command is not supported"))
+		}
+		xlog.Debugf("%s req: %+v", cmd.Name, req)
+
+		if cmd.NeedAgentVerify {
+			avResp, err := h.srv.AgentVerify(c, &view.AgentVerifyReq{
+				VendorID:  c.PostForm("vendorId"),
+				Signature: c.PostForm("signature"),
+				Params:    signedParams(c),
+			})
+			if err != nil {
+				commonresp.ErrResp(c, err)
+				return
+			}
+			// The service reuses it instead of querying the agent again
+			c.Set(service.AgentVerifyCtxKey, avResp)
+
+			if !cmd.ThrottledByHandle {
+				if err := h.srv.CheckRateLimit(c, avResp.Agent.VendorID, cmd.Name, cmd.RateLimit, c.PostForm("user")); err != nil {
+					commonresp.ErrResp(c, err)
+					return
+				}
+			}
+		}
+
+		resp, err := cmd.Handle(c, req)
+		if err != nil {
+			commonresp.ErrResp(c, err)
 			return
 		}
+		commonresp.JsonResp(c, resp)
+	}
+}
+
+// signedParams returns the params an HMAC signature covers: the form, plus the cmd when it was
+// sent in the query string, so a signed request cannot be replayed under another command
+func signedParams(c *gin.Context) url.Values {
+	params := c.Request.PostForm
+	if name := c.Query("cmd"); name != "" && params.Get("cmd") == "" {
+		params = url.Values{}
+		for k, v := range c.Request.PostForm {
+			params[k] = v
+		}
+		params.Set("cmd", name)
 	}
+	return params
+}
+
+func bindListCommands(c *gin.Context) (*view.ListCommandsReq, error) {
+	return &view.ListCommandsReq{}, nil
+}
+
+// swagger:route POST /v1/list_commands api渠道接口 ListCommands
+// 列出此部署支持的指令
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: ListCommandsResp
+//	500: CommonError
+func (h *PublicApiHandler) ListCommands(ctx context.Context, req *view.ListCommandsReq) (*view.ListCommandsResp, error) {
+	resp := &view.ListCommandsResp{}
+	for _, cmd := range h.commands.Commands() {
+		resp.Result = append(resp.Result, &view.GatewayCommandInfo{
+			Cmd:             cmd.Name,
+			Path:            cmd.Path,
+			NeedAgentVerify: cmd.NeedAgentVerify,
+			RateLimit:       string(cmd.RateLimit),
+		})
+	}
+	return resp, nil
 }
 
 // swagger:route GET /v1/user_info 用户接口 GetUserInfo
@@ -143,7 +242,7 @@ func (h *PublicApiHandler) GetUserInfo(c *gin.Context) {
 //
 //	200: SigninGameResp
 //	500: CommonError
-func (h *PublicApiHandler) SigninGame(c *gin.Context) {
+func bindSigninGame(c *gin.Context) (*view.SigninGameReq, error) {
 	var req view.SigninGameReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -184,13 +283,43 @@ func (h *PublicApiHandler) SigninGame(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("SigninGame req: %+v", &req)
-	resp, err := h.srv.SigninGame(c, &req)
+	return &req, nil
+}
+
+// swagger:route POST /v1/member_login api渠道接口 MemberLogin
+// 会员登入取得sid
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: MemberLoginResp
+//	500: CommonError
+func bindMemberLogin(c *gin.Context) (*view.MemberLoginReq, error) {
+	var req view.MemberLoginReq
+	req.VendorID = c.PostForm("vendorId")
+	req.Signature = c.PostForm("signature")
+	req.User = c.PostForm("user")
+	req.Password = c.PostForm("password")
+	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
 	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
+		xlog.Warnf("timestamp is not a number, use default value 0")
+		// 方便测试自动时间戳
+		timestamp = time.Now().Unix()
 	}
-	commonresp.JsonResp(c, resp)
+	req.Timestamp = timestamp
+	syslang, err := strconv.Atoi(c.PostForm("syslang"))
+	if err != nil {
+		xlog.Warnf("syslang is not a number, use default value 0")
+		syslang = 0
+	}
+	if tmpLang, ok := gameUtil.LanguageMap[syslang]; ok {
+		req.Syslang = tmpLang
+	} else {
+		req.Syslang = "cn"
+	}
+
+	return &req, nil
 }
 
 // swagger:route POST /v1/member_register api渠道接口 MemberRegister
@@ -202,7 +331,7 @@ func (h *PublicApiHandler) SigninGame(c *gin.Context) {
 //
 //	200: MemberRegisterResp
 //	500: CommonError
-func (h *PublicApiHandler) MemberRegister(c *gin.Context) {
+func bindMemberRegister(c *gin.Context) (*view.MemberRegisterReq, error) {
 	var req view.MemberRegisterReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -255,13 +384,7 @@ func (h *PublicApiHandler) MemberRegister(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("MemberRegister req: %+v", &req)
-	resp, err := h.srv.MemberRegister(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/edit_limit api渠道接口 EditLimit
@@ -273,7 +396,7 @@ func (h *PublicApiHandler) MemberRegister(c *gin.Context) {
 //
 //	200: EditLimitResp
 //	500: CommonError
-func (h *PublicApiHandler) EditLimit(c *gin.Context) {
+func bindEditLimit(c *gin.Context) (*view.EditLimitReq, error) {
 	var req view.EditLimitReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -319,13 +442,7 @@ func (h *PublicApiHandler) EditLimit(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("EditLimit req: %+v", &req)
-	resp, err := h.srv.EditLimit(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/logout_game api渠道接口 LogoutGame
@@ -337,7 +454,7 @@ func (h *PublicApiHandler) EditLimit(c *gin.Context) {
 //
 //	200: LogoutGameResp
 //	500: CommonError
-func (h *PublicApiHandler) LogoutGame(c *gin.Context) {
+func bindLogoutGame(c *gin.Context) (*view.LogoutGameReq, error) {
 	var req view.LogoutGameReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -360,13 +477,7 @@ func (h *PublicApiHandler) LogoutGame(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("LogoutGame req: %+v", &req)
-	resp, err := h.srv.LogoutGame(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/change_password api渠道接口 ChangePassword
@@ -378,7 +489,7 @@ func (h *PublicApiHandler) LogoutGame(c *gin.Context) {
 //
 //	200: ChangePasswordResp
 //	500: CommonError
-func (h *PublicApiHandler) ChangePassword(c *gin.Context) {
+func bindChangePassword(c *gin.Context) (*view.ChangePasswordReq, error) {
 	var req view.ChangePasswordReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -402,13 +513,7 @@ func (h *PublicApiHandler) ChangePassword(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("ChangePassword req: %+v", &req)
-	resp, err := h.srv.ChangePassword(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/get_agent_balance api渠道接口 GetAgentBalance
@@ -420,7 +525,7 @@ func (h *PublicApiHandler) ChangePassword(c *gin.Context) {
 //
 //	200: GetAgentBalanceResp
 //	500: CommonError
-func (h *PublicApiHandler) GetAgentBalance(c *gin.Context) {
+func bindGetAgentBalance(c *gin.Context) (*view.GetAgentBalanceReq, error) {
 	var req view.GetAgentBalanceReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -442,13 +547,7 @@ func (h *PublicApiHandler) GetAgentBalance(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("GetAgentBalance req: %+v", &req)
-	resp, err := h.srv.GetAgentBalance(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/get_balance api渠道接口 GetBalance
@@ -460,7 +559,7 @@ func (h *PublicApiHandler) GetAgentBalance(c *gin.Context) {
 //
 //	200: GetBalanceResp
 //	500: CommonError
-func (h *PublicApiHandler) GetBalance(c *gin.Context) {
+func bindGetBalance(c *gin.Context) (*view.GetBalanceReq, error) {
 	var req view.GetBalanceReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -483,17 +582,12 @@ func (h *PublicApiHandler) GetBalance(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("GetBalance req: %+v", &req)
-	resp, err := h.srv.GetBalance(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/change_balance api渠道接口 ChangeBalance
-// 修改会员余额
+// 修改会员余额. 同一order重送不会重复加扣点, 未带order时由系统产生单号并于回应带回,
+// 可用CheckTransfer查询, 但重送会视为新订单
 // consumes:
 //   - multipart/form-data
 //
@@ -501,7 +595,7 @@ func (h *PublicApiHandler) GetBalance(c *gin.Context) {
 //
 //	200: ChangeBalanceResp
 //	500: CommonError
-func (h *PublicApiHandler) ChangeBalance(c *gin.Context) {
+func bindChangeBalance(c *gin.Context) (*view.ChangeBalanceReq, error) {
 	var req view.ChangeBalanceReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -526,13 +620,81 @@ func (h *PublicApiHandler) ChangeBalance(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("ChangeBalance req: %+v", &req)
-	resp, err := h.srv.ChangeBalance(c, &req)
+	return &req, nil
+}
+
+// swagger:route POST /v1/batch_change_balance api渠道接口 BatchChangeBalance
+// 批次加扣点
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: BatchChangeBalanceResp
+//	500: CommonError
+func bindBatchChangeBalance(c *gin.Context) (*view.BatchChangeBalanceReq, error) {
+	var req view.BatchChangeBalanceReq
+	req.VendorID = c.PostForm("vendorId")
+	req.Signature = c.PostForm("signature")
+	req.Data = c.PostForm("data")
+	if err := json.Unmarshal([]byte(req.Data), &req.Items); err != nil {
+		xlog.Errorf("error to unmarshal batch change balance data, err:%+v", err)
+		return nil, utils.ErrWalletParamFormatError
+	}
+	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
 	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
+		xlog.Warnf("timestamp is not a number, use default value 0")
+		// 方便测试自动时间戳
+		timestamp = time.Now().Unix()
 	}
-	commonresp.JsonResp(c, resp)
+	req.Timestamp = timestamp
+	syslang, err := strconv.Atoi(c.PostForm("syslang"))
+	if err != nil {
+		xlog.Warnf("syslang is not a number, use default value 0")
+		syslang = 0
+	}
+	if tmpLang, ok := gameUtil.LanguageMap[syslang]; ok {
+		req.Syslang = tmpLang
+	} else {
+		req.Syslang = "cn"
+	}
+
+	return &req, nil
+}
+
+// swagger:route POST /v1/check_transfer api渠道接口 CheckTransfer
+// 查询加扣点订单状态
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: CheckTransferResp
+//	500: CommonError
+func bindCheckTransfer(c *gin.Context) (*view.CheckTransferReq, error) {
+	var req view.CheckTransferReq
+	req.VendorID = c.PostForm("vendorId")
+	req.Signature = c.PostForm("signature")
+	req.Order = c.PostForm("order")
+	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
+	if err != nil {
+		xlog.Warnf("timestamp is not a number, use default value 0")
+		// 方便测试自动时间戳
+		timestamp = time.Now().Unix()
+	}
+	req.Timestamp = timestamp
+	syslang, err := strconv.Atoi(c.PostForm("syslang"))
+	if err != nil {
+		xlog.Warnf("syslang is not a number, use default value 0")
+		syslang = 0
+	}
+	if tmpLang, ok := gameUtil.LanguageMap[syslang]; ok {
+		req.Syslang = tmpLang
+	} else {
+		req.Syslang = "cn"
+	}
+
+	return &req, nil
 }
 
 // swagger:route POST /v1/get_member_trade_report api渠道接口 GetMemberTradeReport
@@ -544,7 +706,7 @@ func (h *PublicApiHandler) ChangeBalance(c *gin.Context) {
 //
 //	200: GetMemberTradeReportResp
 //	500: CommonError
-func (h *PublicApiHandler) GetMemberTradeReport(c *gin.Context) {
+func bindGetMemberTradeReport(c *gin.Context) (*view.GetMemberTradeReportReq, error) {
 	var req view.GetMemberTradeReportReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -557,8 +719,7 @@ func (h *PublicApiHandler) GetMemberTradeReport(c *gin.Context) {
 		if err != nil {
 			err := errors.New("startTime format error")
 			xlog.Errorf("startTime format error, err:%+v", err)
-			commonresp.ErrResp(c, err)
-			return
+			return nil, err
 		}
 		req.StartTime = startTime
 	} else {
@@ -570,8 +731,7 @@ func (h *PublicApiHandler) GetMemberTradeReport(c *gin.Context) {
 		if err != nil {
 			err := errors.New("endTime format error")
 			xlog.Errorf("endTime format error, err:%+v", err)
-			commonresp.ErrResp(c, err)
-			return
+			return nil, err
 		}
 		req.EndTime = endTime
 	} else {
@@ -596,13 +756,7 @@ func (h *PublicApiHandler) GetMemberTradeReport(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("GetMemberTradeReport req: %+v", &req)
-	resp, err := h.srv.GetMemberTradeReport(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/enable_or_disable_mem api渠道接口 EnableOrDisableMem
@@ -614,7 +768,7 @@ func (h *PublicApiHandler) GetMemberTradeReport(c *gin.Context) {
 //
 //	200: EnableOrDisableMemResp
 //	500: CommonError
-func (h *PublicApiHandler) EnableOrDisableMem(c *gin.Context) {
+func bindEnableOrDisableMem(c *gin.Context) (*view.EnableOrDisableMemReq, error) {
 	var req view.EnableOrDisableMemReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -639,13 +793,7 @@ func (h *PublicApiHandler) EnableOrDisableMem(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("EnableOrDisableMem req: %+v", &req)
-	resp, err := h.srv.EnableOrDisableMem(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/get_date_time_report api渠道接口 GetDateTimeReport
@@ -657,7 +805,7 @@ func (h *PublicApiHandler) EnableOrDisableMem(c *gin.Context) {
 //
 //	200: GetDateTimeReportResp
 //	500: CommonError
-func (h *PublicApiHandler) GetDateTimeReport(c *gin.Context) {
+func bindGetDateTimeReport(c *gin.Context) (*view.GetDateTimeReportReq, error) {
 	var req view.GetDateTimeReportReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -668,8 +816,7 @@ func (h *PublicApiHandler) GetDateTimeReport(c *gin.Context) {
 		if err != nil {
 			err := errors.New("startTime format error")
 			xlog.Errorf("startTime format error, err:%+v", err)
-			commonresp.ErrResp(c, err)
-			return
+			return nil, err
 		}
 		req.StartTime = startTime
 	} else {
@@ -681,8 +828,7 @@ func (h *PublicApiHandler) GetDateTimeReport(c *gin.Context) {
 		if err != nil {
 			err := errors.New("endTime format error")
 			xlog.Errorf("endTime format error, err:%+v", err)
-			commonresp.ErrResp(c, err)
-			return
+			return nil, err
 		}
 		req.EndTime = endTime
 	} else {
@@ -702,6 +848,7 @@ func (h *PublicApiHandler) GetDateTimeReport(c *gin.Context) {
 	req.DataType = dataType
 	req.GameNo1 = c.PostForm("gameno1")
 	req.GameNo2 = c.PostForm("gameno2")
+	req.PageToken = c.PostForm("pageToken")
 	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
 	if err != nil {
 		xlog.Warnf("timestamp is not a number, use default value 0")
@@ -720,13 +867,20 @@ func (h *PublicApiHandler) GetDateTimeReport(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("GetDateTimeReport req: %+v", &req)
-	resp, err := h.srv.GetDateTimeReport(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
+}
+
+// swagger:route POST /v1/get_date_time_count_report api渠道接口 GetDateTimeCountReport
+// 获取会员时间报表的笔数及合计, 参数同GetDateTimeReport
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: GetDateTimeCountReportResp
+//	500: CommonError
+func bindGetDateTimeCountReport(c *gin.Context) (*view.GetDateTimeReportReq, error) {
+	return bindGetDateTimeReport(c)
 }
 
 // swagger:route POST /v1/get_tip_report api渠道接口 GetTipReport
@@ -738,7 +892,7 @@ func (h *PublicApiHandler) GetDateTimeReport(c *gin.Context) {
 //
 //	200: GetTipReportResp
 //	500: CommonError
-func (h *PublicApiHandler) GetTipReport(c *gin.Context) {
+func bindGetTipReport(c *gin.Context) (*view.GetTipReportReq, error) {
 	var req view.GetTipReportReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -749,8 +903,7 @@ func (h *PublicApiHandler) GetTipReport(c *gin.Context) {
 		if err != nil {
 			err := errors.New("startTime format error")
 			xlog.Errorf("startTime format error, err:%+v", err)
-			commonresp.ErrResp(c, err)
-			return
+			return nil, err
 		}
 		req.StartTime = startTime
 	} else {
@@ -762,13 +915,13 @@ func (h *PublicApiHandler) GetTipReport(c *gin.Context) {
 		if err != nil {
 			err := errors.New("endTime format error")
 			xlog.Errorf("endTime format error, err:%+v", err)
-			commonresp.ErrResp(c, err)
-			return
+			return nil, err
 		}
 		req.EndTime = endTime
 	} else {
 		req.EndTime = 0
 	}
+	req.PageToken = c.PostForm("pageToken")
 	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
 	if err != nil {
 		xlog.Warnf("timestamp is not a number, use default value 0")
@@ -788,13 +941,7 @@ func (h *PublicApiHandler) GetTipReport(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("GetTipReport req: %+v", &req)
-	resp, err := h.srv.GetTipReport(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/get_unsettle_report api渠道接口 GetUnsettleReport
@@ -806,7 +953,7 @@ func (h *PublicApiHandler) GetTipReport(c *gin.Context) {
 //
 //	200: GetUnsettleReportResp
 //	500: CommonError
-func (h *PublicApiHandler) GetUnsettleReport(c *gin.Context) {
+func bindGetUnsettleReport(c *gin.Context) (*view.GetUnsettleReportReq, error) {
 	var req view.GetUnsettleReportReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -830,13 +977,7 @@ func (h *PublicApiHandler) GetUnsettleReport(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("GetUnsettleReport req: %+v", &req)
-	resp, err := h.srv.GetUnsettleReport(c, &req)
-	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
-	}
-	commonresp.JsonResp(c, resp)
+	return &req, nil
 }
 
 // swagger:route POST /v1/get_report_detail api渠道接口 GetReportDetail
@@ -848,7 +989,7 @@ func (h *PublicApiHandler) GetUnsettleReport(c *gin.Context) {
 //
 //	200: GetReportDetailResp
 //	500: CommonError
-func (h *PublicApiHandler) GetReportDetail(c *gin.Context) {
+func bindGetReportDetail(c *gin.Context) (*view.GetReportDetailReq, error) {
 	var req view.GetReportDetailReq
 	req.VendorID = c.PostForm("vendorId")
 	req.Signature = c.PostForm("signature")
@@ -885,11 +1026,127 @@ func (h *PublicApiHandler) GetReportDetail(c *gin.Context) {
 		req.Syslang = "cn"
 	}
 
-	xlog.Debugf("GetReportDetail req: %+v", &req)
-	resp, err := h.srv.GetReportDetail(c, &req)
+	return &req, nil
+}
+
+// swagger:route POST /v1/create_report_export api渠道接口 CreateReportExport
+// 建立报表导出任务, 以GetReportExport查询进度及下载链接
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: CreateReportExportResp
+//	500: CommonError
+func bindCreateReportExport(c *gin.Context) (*view.CreateReportExportReq, error) {
+	var req view.CreateReportExportReq
+	req.VendorID = c.PostForm("vendorId")
+	req.Signature = c.PostForm("signature")
+	req.Report = c.PostForm("report")
+	req.Format = c.PostForm("format")
+	req.User = c.PostForm("user")
+	startTime, err := utils.Strtotime(c.PostForm("startTime"))
 	if err != nil {
-		commonresp.ErrResp(c, err)
-		return
+		err := errors.New("startTime format error")
+		xlog.Errorf("startTime format error, err:%+v", err)
+		return nil, err
 	}
-	commonresp.JsonResp(c, resp)
+	req.StartTime = startTime
+	endTime, err := utils.Strtotime(c.PostForm("endTime"))
+	if err != nil {
+		err := errors.New("endTime format error")
+		xlog.Errorf("endTime format error, err:%+v", err)
+		return nil, err
+	}
+	req.EndTime = endTime
+	timeType, err := strconv.Atoi(c.PostForm("timetype"))
+	if err != nil {
+		xlog.Warnf("timetype is not a number, use default value 0")
+		timeType = 0
+	}
+	req.TimeType = timeType
+	dataType, err := strconv.Atoi(c.PostForm("datatype"))
+	if err != nil {
+		xlog.Warnf("datatype is not a number, use default value 0")
+		dataType = 0
+	}
+	req.DataType = dataType
+	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
+	if err != nil {
+		xlog.Warnf("timestamp is not a number, use default value 0")
+		// 方便测试自动时间戳
+		timestamp = time.Now().Unix()
+	}
+	req.Timestamp = timestamp
+	syslang, err := strconv.Atoi(c.PostForm("syslang"))
+	if err != nil {
+		xlog.Warnf("syslang is not a number, use default value 0")
+		syslang = 0
+	}
+	if tmpLang, ok := gameUtil.LanguageMap[syslang]; ok {
+		req.Syslang = tmpLang
+	} else {
+		req.Syslang = "cn"
+	}
+
+	return &req, nil
+}
+
+// swagger:route POST /v1/get_report_export api渠道接口 GetReportExport
+// 查询报表导出任务, 完成后返回下载链接
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: GetReportExportResp
+//	500: CommonError
+func bindGetReportExport(c *gin.Context) (*view.GetReportExportReq, error) {
+	var req view.GetReportExportReq
+	req.VendorID = c.PostForm("vendorId")
+	req.Signature = c.PostForm("signature")
+	req.JobID = c.PostForm("jobId")
+	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
+	if err != nil {
+		xlog.Warnf("timestamp is not a number, use default value 0")
+		// 方便测试自动时间戳
+		timestamp = time.Now().Unix()
+	}
+	req.Timestamp = timestamp
+
+	return &req, nil
+}
+
+// swagger:route POST /v1/hello api渠道接口 Hello
+// 连线及签名测试
+// consumes:
+//   - multipart/form-data
+//
+// responses:
+//
+//	200: HelloResp
+//	500: CommonError
+func bindHello(c *gin.Context) (*view.HelloReq, error) {
+	var req view.HelloReq
+	req.VendorID = c.PostForm("vendorId")
+	req.Signature = c.PostForm("signature")
+	timestamp, err := strconv.ParseInt(c.PostForm("timestamp"), 10, 64)
+	if err != nil {
+		xlog.Warnf("timestamp is not a number, use default value 0")
+		// 方便测试自动时间戳
+		timestamp = time.Now().Unix()
+	}
+	req.Timestamp = timestamp
+	syslang, err := strconv.Atoi(c.PostForm("syslang"))
+	if err != nil {
+		xlog.Warnf("syslang is not a number, use default value 0")
+		syslang = 0
+	}
+	if tmpLang, ok := gameUtil.LanguageMap[syslang]; ok {
+		req.Syslang = tmpLang
+	} else {
+		req.Syslang = "cn"
+	}
+
+	return &req, nil
 }
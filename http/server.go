@@ -5,6 +5,7 @@ import (
 	"go-zrbc/config"
 	. "go-zrbc/pkg/http/handler"
 
+	aService "go-zrbc/service/alert"
 	pService "go-zrbc/service/public"
 	s3Service "go-zrbc/service/s3"
 	wService "go-zrbc/service/web"
@@ -20,17 +21,20 @@ type Server struct {
 	webService    wService.WebService
 	pubApiService pService.PublicApiService
 	s3Service     s3Service.S3Service
+	alertService  aService.AlertService
 }
 
 func NewServer(
 	webService wService.WebService,
 	userService pService.PublicApiService,
 	s3Service s3Service.S3Service,
+	alertService aService.AlertService,
 ) *Server {
 	return &Server{
 		webService:    webService,
 		pubApiService: userService,
 		s3Service:     s3Service,
+		alertService:  alertService,
 	}
 }
 
@@ -39,6 +43,11 @@ var (
 )
 
 func (s *Server) RunMetric() {
+	RunMetric()
+}
+
+// RunMetric serves /metrics on the metric port, for commands that run without the api server
+func RunMetric() {
 	r := gin.New()
 
 	r.GET("/metrics", WrapH(promhttp.Handler()))
@@ -76,5 +85,8 @@ func (s *Server) Run() {
 	S3Handler := NewOssHandler(s.s3Service)
 	S3Handler.SetRouter(r)
 
+	AlertHandler := NewAlertHandler(s.alertService)
+	AlertHandler.SetRouter(r)
+
 	r.Run(fmt.Sprintf(":%d", config.Global.HttpServerPort))
 }
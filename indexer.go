@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"go-zrbc/config"
+	"go-zrbc/db"
+	"go-zrbc/es"
+	"go-zrbc/http"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/service"
+	iService "go-zrbc/service/indexer"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/cobra"
+)
+
+var indexerCmd = &cobra.Command{
+	Use:   "indexer",
+	Short: "Tail bet01, bet02 and in_out_m into their elasticsearch report indices",
+	Long: `Polls bet01 and bet02 by updatetime and in_out_m by iom001 and bulk indexes denormalized
+documents into bet01_report_index, bet02_report_index and in_out_m_index. The rows changed
+within a minute of the last one read are read again for transactions that committed late.
+--reindex rebuilds the listed tables into new indices and swaps the aliases over before tailing.
+It can run next to a tailing indexer, the tail leaves a table alone while it is rebuilt. The bet02
+tail also settles bet01 documents, it waits for a bet01 rebuild as well.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+		StartIndexer()
+	},
+}
+
+var (
+	reindexTables []string
+	reindexOnly   bool
+)
+
+func init() {
+	indexerCmd.Flags().StringSliceVar(&reindexTables, "reindex", nil, "rebuild these tables first: bet01, bet02, in_out_m")
+	indexerCmd.Flags().BoolVar(&reindexOnly, "reindex-only", false, "exit after the rebuild instead of tailing")
+	rootCmd.AddCommand(indexerCmd)
+}
+
+// newIndexer builds the indexer on the configured MySQL, Redis and ES, the caller closes the es client
+func newIndexer() (iService.Indexer, *es.Client, error) {
+	dbh := db.NewDBHandler()
+	sess := service.NewSession(dbh)
+
+	redisCli := redis.NewClient(&redis.Options{
+		Addr:     config.Global.Redis.Addr,
+		Password: config.Global.Redis.Password,
+		DB:       config.Global.Redis.DB,
+	})
+	esClient, err := es.NewClient(config.Global.ES.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return iService.NewIndexer(sess, db.NewBet01Dao(), db.NewBet02Dao(), db.NewInOutMDao(), esClient, redisCli), esClient, nil
+}
+
+func StartIndexer() {
+	indexer, esClient, err := newIndexer()
+	if err != nil {
+		xlog.Errorf("error to create es client: %v", err)
+		return
+	}
+	defer esClient.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		s := <-c
+		xlog.Info("receive interrupt signal", s)
+		cancel()
+	}()
+
+	for _, table := range reindexTables {
+		if err := indexer.Reindex(ctx, table); err != nil {
+			xlog.Errorf("error to reindex %s, err:%+v", table, err)
+			return
+		}
+	}
+	if reindexOnly {
+		return
+	}
+
+	go http.RunMetric()
+	xlog.Info("indexer start success!")
+	indexer.Run(ctx)
+}
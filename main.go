@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"go-zrbc/config"
 	"go-zrbc/db"
@@ -9,6 +10,7 @@ import (
 	awsS3 "go-zrbc/pkg/oss"
 	"go-zrbc/pkg/xlog"
 	"go-zrbc/service"
+	aService "go-zrbc/service/alert"
 	pService "go-zrbc/service/public"
 	sService "go-zrbc/service/s3"
 	wService "go-zrbc/service/web"
@@ -16,6 +18,8 @@ import (
 
 	"os"
 	"os/signal"
+	"sync"
+	"syscall"
 
 	_ "go-zrbc/docs"
 
@@ -39,26 +43,35 @@ var rootCmd = &cobra.Command{
                 love by spf13 and friends in Go.
                 Complete documentation is available at http://hugo.spf13.com`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if configPath == "config.json" {
-			fmt.Println("config path:", configPath)
-			config.Init(configPath)
-		} else {
-			// config.InitConfigWithJson(DATAID, GROUP, config.Global)
-			config.GetConfigFromApollo(config.Global)
+		initConfig()
+		if kafkaReplayFrom > 0 {
+			config.Global.Kafka.ReplayFrom = kafkaReplayFrom
 		}
-		xlog.LogLevel = config.Global.LogLevel
-		xlog.LogFile = config.Global.LogFile
-		xlog.Init()
 		Start()
 	},
 }
 
+func initConfig() {
+	if configPath == "config.json" {
+		fmt.Println("config path:", configPath)
+		config.Init(configPath)
+	} else {
+		// config.InitConfigWithJson(DATAID, GROUP, config.Global)
+		config.GetConfigFromApollo(config.Global)
+	}
+	xlog.LogLevel = config.Global.LogLevel
+	xlog.LogFile = config.Global.LogFile
+	xlog.Init()
+}
+
 var (
-	configPath string
+	configPath      string
+	kafkaReplayFrom int64
 )
 
 func init() {
-	rootCmd.Flags().StringVarP(&configPath, "config", "c", "", "path to config file")
+	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "path to config file")
+	rootCmd.Flags().Int64Var(&kafkaReplayFrom, "kafka-replay-from", 0, "replay game events from this unix time instead of the committed offsets")
 	//rootCmd.MarkFlagRequired("config")
 }
 
@@ -101,25 +114,52 @@ func Start() {
 	alertMessageDao := db.NewAlertMessageDao()
 	gameInfoDao := db.NewGameInfoDao()
 	bet01Dao := db.NewBet01Dao()
+	transferRecordDao := db.NewTransferRecordDao()
+	rateLimitRuleDao := db.NewRateLimitRuleDao()
+	launderRuleDao := db.NewLaunderRuleDao()
+	memberLockAuditDao := db.NewMemberLockAuditDao()
+	betSerialDao := db.NewBetSerialDao()
 
-	userSrv := pService.NewPublicApiService(sess, userDao, apiurlDao, wechatURLDao, agentsLoginPassDao, agentDao, memLoginDao, bet02Dao, agentDtlDao, betLimitDao, memberDtlDao, gameTypeDao, inOutMDao, logAgeCashChangeDao, alertMessageDao, gameInfoDao, bet01Dao, s3Client, redisCli, esClient)
+	userSrv := pService.NewPublicApiService(sess, userDao, apiurlDao, wechatURLDao, agentsLoginPassDao, agentDao, memLoginDao, bet02Dao, agentDtlDao, betLimitDao, memberDtlDao, gameTypeDao, inOutMDao, logAgeCashChangeDao, alertMessageDao, gameInfoDao, bet01Dao, transferRecordDao, rateLimitRuleDao, launderRuleDao, betSerialDao, memberLockAuditDao, s3Client, redisCli, esClient)
 	s3Srv := sService.NewS3Service()
 	webSrv := wService.NewWebService(sess, barrageDao, s3Client, redisCli)
+	alertSrv := aService.NewAlertService(sess, alertMessageDao, userDao, inOutMDao, memberLockAuditDao)
 
-	httpSrv := http.NewServer(webSrv, userSrv, s3Srv)
+	httpSrv := http.NewServer(webSrv, userSrv, s3Srv, alertSrv)
 
 	go httpSrv.RunMetric()
 	go httpSrv.Run()
 
+	// 背景任务共用, 退出时取消并等待收尾
+	ctx, cancel := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for _, run := range []func(context.Context){alertSrv.RunDispatcher, userSrv.RunReportExporter, userSrv.RunTransferReaper} {
+		workers.Add(1)
+		go func(run func(context.Context)) {
+			defer workers.Done()
+			run(ctx)
+		}(run)
+	}
+
 	wsSrv := wschannel.NewWsServer("0.0.0.0:8082", webSrv, userSrv)
 	go wsSrv.Run()
 
 	xlog.Info("server start success!")
 
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	select {
 	case s := <-c:
 		xlog.Info("receive interrupt signal", s)
+		// 背景任务不再接新工作, 与排空同时收尾
+		cancel()
+		// 部署下线: 排空大厅长连接后再退出
+		if s == syscall.SIGTERM {
+			wsSrv.Drain(wschannel.DrainTimeout())
+		}
+	case <-wsSrv.Drained():
+		xlog.Info("ws-channel drained")
 	}
+	cancel()
+	workers.Wait()
 }
@@ -0,0 +1,71 @@
+// Package export streams report rows into a csv or xlsx file
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Writer writes the rows of a sheet one after another, Close finishes the file but leaves the underlying writer open
+type Writer interface {
+	WriteRow(row []string) error
+	Close() error
+}
+
+// NewWriter returns a writer of format with header as its first row
+func NewWriter(format string, w io.Writer, header []string) (Writer, error) {
+	var ret Writer
+	switch format {
+	case FormatCSV:
+		// Excel reads the chinese headers of a csv wrong without the utf-8 bom
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return nil, err
+		}
+		ret = &csvWriter{w: csv.NewWriter(w)}
+	case FormatXLSX:
+		x, err := newXLSXWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		ret = x
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+	if len(header) > 0 {
+		if err := ret.WriteRow(header); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// Valid reports whether format is one NewWriter writes
+func Valid(format string) bool {
+	return format == FormatCSV || format == FormatXLSX
+}
+
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteRow(row []string) error {
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
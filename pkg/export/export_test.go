@@ -0,0 +1,80 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf, []string{"会员", "金额"})
+	if err != nil {
+		t.Fatalf("new writer err:(%+v)", err)
+	}
+	if err := w.WriteRow([]string{"tom", "1,000.5"}); err != nil {
+		t.Fatalf("write err:(%+v)", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close err:(%+v)", err)
+	}
+
+	body := buf.String()
+	if !strings.HasPrefix(body, "\ufeff") {
+		t.Fatalf("csv without bom:(%q)", body)
+	}
+	rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(body, "\ufeff"))).ReadAll()
+	if err != nil || len(rows) != 2 || rows[0][0] != "会员" || rows[1][1] != "1,000.5" {
+		t.Fatalf("csv rows:(%q), err:(%+v)", rows, err)
+	}
+}
+
+func TestNewWriter_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &buf, []string{"会员", "下注内容"})
+	if err != nil {
+		t.Fatalf("new writer err:(%+v)", err)
+	}
+	if err := w.WriteRow([]string{"tom", "Banker <& Player>"}); err != nil {
+		t.Fatalf("write err:(%+v)", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close err:(%+v)", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open xlsx err:(%+v)", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s err:(%+v)", f.Name, err)
+		}
+		b, _ := io.ReadAll(r)
+		r.Close()
+		parts[f.Name] = string(b)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if parts[name] == "" {
+			t.Fatalf("xlsx without %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	want := `<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">tom</t></is></c><c r="B2" t="inlineStr"><is><t xml:space="preserve">Banker &lt;&amp; Player&gt;</t></is></c></row></sheetData></worksheet>`
+	if !strings.Contains(sheet, `<t xml:space="preserve">会员</t>`) || !strings.HasSuffix(sheet, want) {
+		t.Fatalf("sheet:(%s)", sheet)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Fatalf("column %d:(%s), want:(%s)", i, got, want)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package gameUtil
 
 import (
+	"go-zrbc/db"
 	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
 	"strconv"
 	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
 const (
@@ -61,33 +65,35 @@ var ChipsCheck = []int64{
 	5000000, 10000000, 20000000, 50000000, 10000000, 20000000, 50000000, 100000000,
 }
 
-// ReportFormat formats a row of game data into a map
-func ReportFormat(row map[string]interface{}, lang string) map[string]interface{} {
-	tmp := make(map[string]interface{})
-	tmp["user"] = row["username"]
-	tmp["betId"] = row["bet01"]
-	tmp["betTime"] = row["bet08"]
-	tmp["beforeCash"] = row["bet12"]
-	tmp["bet"] = row["bet13"]
-	tmp["validbet"] = row["bet41"]
-	tmp["water"] = row["bet16"]
-	tmp["result"] = row["bet17"]
-	tmp["betResult"] = GetBetContent(row["bet02"].(string), row["bet09"].(string), lang)
-	tmp["waterbet"] = row["bet41"]
-	tmp["winLoss"] = row["bet14"].(float64) - row["bet13"].(float64)
-	tmp["ip"] = row["ip"]
-	tmp["gid"] = row["bet02"]
-	tmp["event"] = row["bet03"]
-	tmp["eventChild"] = row["bet04"]
-	tmp["round"] = row["bet03"]
-	tmp["subround"] = row["bet04"]
-	tmp["tableId"] = row["bet39"]
-	tmp["commission"] = row["commission"]
-	tmp["settime"] = row["updatetime"]
-	tmp["reset"] = row["bet38"]
-	tmp["gameResult"] = row["gameResult"]
-	tmp["gname"] = GetLangText(row["cnname"].(string), lang)
-	return tmp
+// ReportFormat formats a settled bet into a row of the date time report
+func ReportFormat(row *db.Bet02Report, lang string) *view.DateTimeReportItem {
+	gid := strconv.Itoa(row.Bet02)
+	return &view.DateTimeReportItem{
+		User:       row.Username,
+		BetID:      strconv.FormatInt(row.Bet01, 10),
+		BetTime:    row.Bet08.Format("2006-01-02 15:04:05"),
+		BeforeCash: row.Bet12,
+		Bet:        row.Bet13,
+		ValidBet:   row.Bet41,
+		Water:      row.Bet16,
+		Result:     row.Bet17,
+		BetCode:    row.Bet09,
+		BetResult:  GetBetContent(gid, row.Bet09, lang),
+		WaterBet:   row.Bet41,
+		WinLoss:    row.Bet14.Sub(row.Bet13),
+		IP:         row.IP,
+		GID:        gid,
+		Event:      row.Bet03.String(),
+		EventChild: strconv.Itoa(row.Bet04),
+		Round:      row.Bet03.String(),
+		Subround:   strconv.Itoa(row.Bet04),
+		TableID:    strconv.Itoa(row.Bet39),
+		Commission: decimal.NewFromInt(int64(row.Commission)),
+		Settime:    row.Updatetime.Format("2006-01-02 15:04:05"),
+		Reset:      row.Bet38,
+		GameResult: GetGameResultString(gid, row.Result, lang),
+		GName:      GetLangText(row.GameName, lang),
+	}
 }
 
 // GetBetContent converts bet content based on game type
@@ -0,0 +1,65 @@
+package gameUtil
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"go-zrbc/db"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of testdata/report")
+
+// TestReportFormat_Golden formats one report document per game type, testdata/report/<gid>.json, and compares
+// the row with <gid>.golden. The documents mix what the indexer writes with what older writers left in ES.
+func TestReportFormat_Golden(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("CST", 8*3600)
+	t.Cleanup(func() { time.Local = local })
+
+	inputs, err := filepath.Glob(filepath.Join("testdata", "report", "*.json"))
+	if err != nil || len(inputs) == 0 {
+		t.Fatalf("no report documents, err:(%+v)", err)
+	}
+	for _, input := range inputs {
+		gid := strings.TrimSuffix(filepath.Base(input), ".json")
+		t.Run(gid, func(t *testing.T) {
+			b, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatalf("read %s err:(%+v)", input, err)
+			}
+			var row db.Bet02Report
+			if err := json.Unmarshal(b, &row); err != nil {
+				t.Fatalf("decode %s err:(%+v)", input, err)
+			}
+			if strconv.Itoa(row.Bet02) != gid {
+				t.Fatalf("%s holds game %d", input, row.Bet02)
+			}
+			got, err := json.MarshalIndent(ReportFormat(&row, LangEnglish), "", "  ")
+			if err != nil {
+				t.Fatalf("encode err:(%+v)", err)
+			}
+			got = append(got, '\n')
+
+			golden := strings.TrimSuffix(input, ".json") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatalf("write %s err:(%+v)", golden, err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read %s err:(%+v), run with -update to create it", golden, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("report row of %s:\n%s\nwant:\n%s", gid, got, want)
+			}
+		})
+	}
+}
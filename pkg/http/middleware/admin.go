@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"go-zrbc/config"
+
+	commonresp "go-zrbc/pkg/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth lets through the operators of config.Global.Admins and keeps their name as "operator"
+func AdminAuth(c *gin.Context) {
+	key := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if key != "" {
+		for _, admin := range config.Global.Admins {
+			if admin.Key != "" && subtle.ConstantTimeCompare([]byte(admin.Key), []byte(key)) == 1 {
+				c.Set("operator", admin.Name)
+				c.Next()
+				return
+			}
+		}
+	}
+	commonresp.AbortResp(c, http.StatusUnauthorized)
+}
+
+// GetOperator returns the operator AdminAuth let through
+func GetOperator(c *gin.Context) string {
+	return c.GetString("operator")
+}
@@ -0,0 +1,159 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-zrbc/pkg/xlog"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Handler processes one message. A failed message is logged and still committed, so one bad
+// event cannot stall its partition.
+type Handler func(ctx context.Context, msg kafka.Message) error
+
+// Consumer reads topics as a member of a consumer group, committing what it handled per partition
+type Consumer struct {
+	addrs   []string
+	topics  []string
+	groupID string
+	// replayFrom, when set, starts every partition at its first message at or after that time the
+	// first time this process reads it, rather than at the committed offset
+	replayFrom time.Time
+	replayed   sync.Map
+	// how often a partition commits what it handled, it commits once more when it stops
+	commitInterval time.Duration
+}
+
+// partitionReader is the part of *kafka.Reader a partition routine uses
+type partitionReader interface {
+	SetOffset(offset int64) error
+	SetOffsetAt(ctx context.Context, t time.Time) error
+	ReadMessage(ctx context.Context) (kafka.Message, error)
+}
+
+// offsetCommitter is the part of *kafka.Generation a partition routine uses
+type offsetCommitter interface {
+	CommitOffsets(offsets map[string]map[int]int64) error
+}
+
+func NewConsumer(addrs []string, topics []string, groupID string, replayFrom time.Time) *Consumer {
+	return &Consumer{
+		addrs:          addrs,
+		topics:         topics,
+		groupID:        groupID,
+		replayFrom:     replayFrom,
+		commitInterval: commitInterval,
+	}
+}
+
+// Run joins the group and consumes until ctx is done
+func (c *Consumer) Run(ctx context.Context, handle Handler) error {
+	group, err := kafka.NewConsumerGroup(kafka.ConsumerGroupConfig{
+		ID:      c.groupID,
+		Brokers: c.addrs,
+		Topics:  c.topics,
+		// a new group only cares about live tables
+		StartOffset: kafka.LastOffset,
+	})
+	if err != nil {
+		xlog.Error(err)
+		return err
+	}
+	defer group.Close()
+
+	for {
+		gen, err := group.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, kafka.ErrGroupClosed) {
+				return nil
+			}
+			xlog.Errorf("error to join consumer group, group:%s, err:%+v", c.groupID, err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		for topic, assignments := range gen.Assignments {
+			for _, assignment := range assignments {
+				topic, partition, offset := topic, assignment.ID, assignment.Offset
+				gen.Start(func(ctx context.Context) {
+					c.consumePartition(ctx, gen, topic, partition, offset, handle)
+				})
+			}
+		}
+	}
+}
+
+// startOffset seeks reader to where this partition should resume
+func (c *Consumer) startOffset(ctx context.Context, reader partitionReader, topic string, partition int, committed int64) error {
+	if !c.replayFrom.IsZero() {
+		if _, done := c.replayed.LoadOrStore(fmt.Sprintf("%s/%d", topic, partition), struct{}{}); !done {
+			return reader.SetOffsetAt(ctx, c.replayFrom)
+		}
+	}
+	return reader.SetOffset(committed)
+}
+
+func (c *Consumer) consumePartition(ctx context.Context, gen *kafka.Generation, topic string, partition int, committed int64, handle Handler) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   c.addrs,
+		Topic:     topic,
+		Partition: partition,
+		MinBytes:  1,
+		MaxWait:   time.Millisecond * 500,
+		MaxBytes:  10e6, // 10MB
+	})
+	defer reader.Close()
+	c.consume(ctx, reader, gen, topic, partition, committed, handle)
+}
+
+// consume hands the messages of one partition to handle from where startOffset puts it, committing
+// the last one handled every commitInterval and when reading stops
+func (c *Consumer) consume(ctx context.Context, reader partitionReader, gen offsetCommitter, topic string, partition int, committed int64, handle Handler) {
+	if err := c.startOffset(ctx, reader, topic, partition, committed); err != nil {
+		xlog.Errorf("error to seek partition, topic:%s, partition:%d, err:%+v", topic, partition, err)
+		return
+	}
+
+	pending := int64(-1)
+	lastCommit := time.Now()
+	commit := func() {
+		if pending < 0 {
+			return
+		}
+		// the committed offset is the next one to read
+		if err := gen.CommitOffsets(map[string]map[int]int64{topic: {partition: pending + 1}}); err != nil {
+			xlog.Errorf("commit offset err, topic:%s, partition:%d, offset:%d, err:(%+v)", topic, partition, pending, err)
+			return
+		}
+		pending = -1
+		lastCommit = time.Now()
+	}
+	// the generation stays valid until every partition routine returned
+	defer commit()
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				xlog.Errorf("error to read message, topic:%s, partition:%d, err:%+v", topic, partition, err)
+			}
+			return
+		}
+		if err := handle(ctx, msg); err != nil {
+			xlog.Errorf("error to handle message, topic:%s, partition:%d, offset:%d, value:%s, err:%+v",
+				topic, partition, msg.Offset, msg.Value, err)
+		}
+		pending = msg.Offset
+		if time.Since(lastCommit) >= c.commitInterval {
+			commit()
+		}
+	}
+}
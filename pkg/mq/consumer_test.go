@@ -0,0 +1,110 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// fakeReader serves msgs then io.EOF, recording where it was seeked
+type fakeReader struct {
+	msgs     []kafka.Message
+	offset   int64
+	offsetAt time.Time
+}
+
+func (r *fakeReader) SetOffset(offset int64) error {
+	r.offset = offset
+	return nil
+}
+
+func (r *fakeReader) SetOffsetAt(ctx context.Context, t time.Time) error {
+	r.offsetAt = t
+	return nil
+}
+
+func (r *fakeReader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.msgs) == 0 {
+		return kafka.Message{}, io.EOF
+	}
+	msg := r.msgs[0]
+	r.msgs = r.msgs[1:]
+	return msg, nil
+}
+
+type fakeCommitter struct {
+	commits []int64
+}
+
+func (g *fakeCommitter) CommitOffsets(offsets map[string]map[int]int64) error {
+	g.commits = append(g.commits, offsets["game_event"][1])
+	return nil
+}
+
+func TestConsumer_CommitOffsets(t *testing.T) {
+	ctx := context.Background()
+	reader := &fakeReader{msgs: []kafka.Message{{Offset: 7}, {Offset: 8}, {Offset: 9}}}
+	gen := &fakeCommitter{}
+	c := NewConsumer(nil, []string{"game_event"}, "ws_channel", time.Time{})
+
+	var handled []int64
+	c.consume(ctx, reader, gen, "game_event", 1, 7, func(ctx context.Context, msg kafka.Message) error {
+		handled = append(handled, msg.Offset)
+		// a failed message is committed all the same
+		return errors.New("bad event")
+	})
+	if reader.offset != 7 || !reflect.DeepEqual(handled, []int64{7, 8, 9}) {
+		t.Fatalf("seeked to:(%d), handled:(%v)", reader.offset, handled)
+	}
+	// within the interval only the final commit runs, at the next offset to read
+	if !reflect.DeepEqual(gen.commits, []int64{10}) {
+		t.Fatalf("commits:(%v), want [10]", gen.commits)
+	}
+
+	reader = &fakeReader{msgs: []kafka.Message{{Offset: 10}, {Offset: 11}}}
+	gen = &fakeCommitter{}
+	c.commitInterval = 0
+	c.consume(ctx, reader, gen, "game_event", 1, 10, func(ctx context.Context, msg kafka.Message) error { return nil })
+	if !reflect.DeepEqual(gen.commits, []int64{11, 12}) {
+		t.Fatalf("commits:(%v), want [11 12]", gen.commits)
+	}
+
+	// nothing handled, nothing committed
+	gen = &fakeCommitter{}
+	c.consume(ctx, &fakeReader{}, gen, "game_event", 1, 12, func(ctx context.Context, msg kafka.Message) error { return nil })
+	if len(gen.commits) != 0 {
+		t.Fatalf("commits:(%v), want none", gen.commits)
+	}
+}
+
+func TestConsumer_ReplayFromOnce(t *testing.T) {
+	ctx := context.Background()
+	from := time.Unix(1700000000, 0)
+	c := NewConsumer(nil, []string{"game_event"}, "ws_channel", from)
+
+	first := &fakeReader{offset: -1}
+	if err := c.startOffset(ctx, first, "game_event", 1, 42); err != nil || !first.offsetAt.Equal(from) || first.offset != -1 {
+		t.Fatalf("first seek at:(%s) offset:(%d), err:(%+v)", first.offsetAt, first.offset, err)
+	}
+	// a rebalance handing the partition back resumes from the committed offset
+	again := &fakeReader{}
+	if err := c.startOffset(ctx, again, "game_event", 1, 42); err != nil || !again.offsetAt.IsZero() || again.offset != 42 {
+		t.Fatalf("second seek at:(%s) offset:(%d), err:(%+v)", again.offsetAt, again.offset, err)
+	}
+	other := &fakeReader{}
+	if err := c.startOffset(ctx, other, "game_event", 2, 5); err != nil || !other.offsetAt.Equal(from) {
+		t.Fatalf("partition 2 seek at:(%s), err:(%+v)", other.offsetAt, err)
+	}
+
+	// without replayFrom every partition resumes from its committed offset
+	c = NewConsumer(nil, []string{"game_event"}, "ws_channel", time.Time{})
+	plain := &fakeReader{}
+	if err := c.startOffset(ctx, plain, "game_event", 1, 42); err != nil || !plain.offsetAt.IsZero() || plain.offset != 42 {
+		t.Fatalf("plain seek at:(%s) offset:(%d), err:(%+v)", plain.offsetAt, plain.offset, err)
+	}
+}
@@ -23,6 +23,8 @@ const (
 	TopicClientKline = "BIB_PUSH_GO_KLINE"
 	TopicStatsReport = "bib_push_go_stats_report"
 	TopicBibApi      = "bib_push_go_api"
+	// 荷官端/游戏服务推送的桌况、下注倒数、开奖与派彩, 未设定 kafka.game_event_topics 时使用
+	TopicGameEvent = "game_event"
 
 	UniqueGroupID = "ws_channel_1"
 	SharedGroupID = "ws_channel"
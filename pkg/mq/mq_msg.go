@@ -19,7 +19,17 @@ type MQMsg struct {
 	Payload   interface{} `json:"payload"`
 }
 
+// ChannelWsKick is the redis channel every ws-channel node listens to for MQMsgTypeBatchKickUser
+const ChannelWsKick = "wschannel:kick"
+
+// 踢线原因
+const (
+	KickReasonLogout   = 1 // LogoutGame
+	KickReasonDisabled = 2 // 会员停用登入
+)
+
 type BatchKickUser struct {
 	UserIDs   []int64 `json:"u_ids"`
+	Reason    int     `json:"reason"`
 	Timestamp int64   `json:"ts"`
 }
@@ -0,0 +1,11824 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ws.proto
+
+package proto
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// WsFrame 每个二进制帧一个, Data为Protocol对应的讯息, Json为true时Data是该协议的JSON资料
+// Notice不为空时为连线通知(错误, 踢线), 此时其余栏位无意义
+type WsFrame struct {
+	Protocol int32          `protobuf:"varint,1,opt,name=Protocol,proto3" json:"protocol"`
+	Seq      int64          `protobuf:"varint,2,opt,name=Seq,proto3" json:"seq,omitempty"`
+	Data     []byte         `protobuf:"bytes,3,opt,name=Data,proto3" json:"data,omitempty"`
+	Json     bool           `protobuf:"varint,4,opt,name=Json,proto3" json:"json,omitempty"`
+	Notice   *WsConnMessage `protobuf:"bytes,5,opt,name=Notice,proto3" json:"notice,omitempty"`
+}
+
+func (m *WsFrame) Reset()         { *m = WsFrame{} }
+func (m *WsFrame) String() string { return proto.CompactTextString(m) }
+func (*WsFrame) ProtoMessage()    {}
+func (*WsFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{0}
+}
+func (m *WsFrame) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsFrame.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsFrame.Merge(m, src)
+}
+func (m *WsFrame) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsFrame proto.InternalMessageInfo
+
+func (m *WsFrame) GetProtocol() int32 {
+	if m != nil {
+		return m.Protocol
+	}
+	return 0
+}
+
+func (m *WsFrame) GetSeq() int64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *WsFrame) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *WsFrame) GetJson() bool {
+	if m != nil {
+		return m.Json
+	}
+	return false
+}
+
+func (m *WsFrame) GetNotice() *WsConnMessage {
+	if m != nil {
+		return m.Notice
+	}
+	return nil
+}
+
+type WsConnMessage struct {
+	Code   int32  `protobuf:"varint,1,opt,name=Code,proto3" json:"code"`
+	ErrMsg string `protobuf:"bytes,2,opt,name=ErrMsg,proto3" json:"err_msg"`
+}
+
+func (m *WsConnMessage) Reset()         { *m = WsConnMessage{} }
+func (m *WsConnMessage) String() string { return proto.CompactTextString(m) }
+func (*WsConnMessage) ProtoMessage()    {}
+func (*WsConnMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{1}
+}
+func (m *WsConnMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsConnMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsConnMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsConnMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsConnMessage.Merge(m, src)
+}
+func (m *WsConnMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsConnMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsConnMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsConnMessage proto.InternalMessageInfo
+
+func (m *WsConnMessage) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *WsConnMessage) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+// 0 登录验证
+type WsAuthData struct {
+	Sid      string `protobuf:"bytes,1,opt,name=Sid,proto3" json:"sid"`
+	Account  string `protobuf:"bytes,2,opt,name=Account,proto3" json:"account"`
+	Password string `protobuf:"bytes,3,opt,name=Password,proto3" json:"password"`
+}
+
+func (m *WsAuthData) Reset()         { *m = WsAuthData{} }
+func (m *WsAuthData) String() string { return proto.CompactTextString(m) }
+func (*WsAuthData) ProtoMessage()    {}
+func (*WsAuthData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{2}
+}
+func (m *WsAuthData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsAuthData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsAuthData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsAuthData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsAuthData.Merge(m, src)
+}
+func (m *WsAuthData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsAuthData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsAuthData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsAuthData proto.InternalMessageInfo
+
+func (m *WsAuthData) GetSid() string {
+	if m != nil {
+		return m.Sid
+	}
+	return ""
+}
+
+func (m *WsAuthData) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *WsAuthData) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type WsAuthResp struct {
+	MemberID       int64  `protobuf:"varint,1,opt,name=MemberID,proto3" json:"memberID"`
+	Account        string `protobuf:"bytes,2,opt,name=Account,proto3" json:"account"`
+	UserName       string `protobuf:"bytes,3,opt,name=UserName,proto3" json:"userName"`
+	Sid            string `protobuf:"bytes,4,opt,name=Sid,proto3" json:"sid"`
+	BOk            bool   `protobuf:"varint,5,opt,name=BOk,proto3" json:"bOk"`
+	BValidPassword bool   `protobuf:"varint,6,opt,name=BValidPassword,proto3" json:"bValidPassword"`
+	ResumeID       string `protobuf:"bytes,7,opt,name=ResumeID,proto3" json:"resumeID"`
+}
+
+func (m *WsAuthResp) Reset()         { *m = WsAuthResp{} }
+func (m *WsAuthResp) String() string { return proto.CompactTextString(m) }
+func (*WsAuthResp) ProtoMessage()    {}
+func (*WsAuthResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{3}
+}
+func (m *WsAuthResp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsAuthResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsAuthResp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsAuthResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsAuthResp.Merge(m, src)
+}
+func (m *WsAuthResp) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsAuthResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsAuthResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsAuthResp proto.InternalMessageInfo
+
+func (m *WsAuthResp) GetMemberID() int64 {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
+}
+
+func (m *WsAuthResp) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *WsAuthResp) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *WsAuthResp) GetSid() string {
+	if m != nil {
+		return m.Sid
+	}
+	return ""
+}
+
+func (m *WsAuthResp) GetBOk() bool {
+	if m != nil {
+		return m.BOk
+	}
+	return false
+}
+
+func (m *WsAuthResp) GetBValidPassword() bool {
+	if m != nil {
+		return m.BValidPassword
+	}
+	return false
+}
+
+func (m *WsAuthResp) GetResumeID() string {
+	if m != nil {
+		return m.ResumeID
+	}
+	return ""
+}
+
+// 10 进入桌台
+type WsJoinTableData struct {
+	DtBetLimitSelectID map[string]int32 `protobuf:"bytes,1,rep,name=DtBetLimitSelectID,proto3" json:"dtBetLimitSelectID" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	GroupID            int32            `protobuf:"varint,2,opt,name=GroupID,proto3" json:"groupID"`
+	GameID             int32            `protobuf:"varint,3,opt,name=GameID,proto3" json:"gameID"`
+}
+
+func (m *WsJoinTableData) Reset()         { *m = WsJoinTableData{} }
+func (m *WsJoinTableData) String() string { return proto.CompactTextString(m) }
+func (*WsJoinTableData) ProtoMessage()    {}
+func (*WsJoinTableData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{4}
+}
+func (m *WsJoinTableData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsJoinTableData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsJoinTableData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsJoinTableData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsJoinTableData.Merge(m, src)
+}
+func (m *WsJoinTableData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsJoinTableData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsJoinTableData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsJoinTableData proto.InternalMessageInfo
+
+func (m *WsJoinTableData) GetDtBetLimitSelectID() map[string]int32 {
+	if m != nil {
+		return m.DtBetLimitSelectID
+	}
+	return nil
+}
+
+func (m *WsJoinTableData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsJoinTableData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+type WsTableEntryData struct {
+	BOk            bool               `protobuf:"varint,1,opt,name=BOk,proto3" json:"bOk"`
+	GameID         int32              `protobuf:"varint,2,opt,name=GameID,proto3" json:"gameID"`
+	GroupID        int32              `protobuf:"varint,3,opt,name=GroupID,proto3" json:"groupID"`
+	GroupType      int32              `protobuf:"varint,4,opt,name=GroupType,proto3" json:"groupType"`
+	AreaID         int32              `protobuf:"varint,5,opt,name=AreaID,proto3" json:"areaID"`
+	AreaType       int32              `protobuf:"varint,6,opt,name=AreaType,proto3" json:"areaType"`
+	MemberID       int64              `protobuf:"varint,7,opt,name=MemberID,proto3" json:"memberID"`
+	Account        string             `protobuf:"bytes,8,opt,name=Account,proto3" json:"account"`
+	UserName       string             `protobuf:"bytes,9,opt,name=UserName,proto3" json:"userName"`
+	HeadID         int32              `protobuf:"varint,10,opt,name=HeadID,proto3" json:"headID"`
+	SeatIDArr      []int32            `protobuf:"varint,11,rep,packed,name=SeatIDArr,proto3" json:"seatIDArr"`
+	CurrencyCode   string             `protobuf:"bytes,12,opt,name=CurrencyCode,proto3" json:"currencyCode"`
+	CurrencyName   string             `protobuf:"bytes,13,opt,name=CurrencyName,proto3" json:"currencyName"`
+	CurrencyRate   float64            `protobuf:"fixed64,14,opt,name=CurrencyRate,proto3" json:"currencyRate"`
+	Balance        float64            `protobuf:"fixed64,15,opt,name=Balance,proto3" json:"balance"`
+	Chips          float64            `protobuf:"fixed64,16,opt,name=Chips,proto3" json:"chips"`
+	BetMilliSecond int32              `protobuf:"varint,17,opt,name=BetMilliSecond,proto3" json:"betMilliSecond"`
+	DtOdds         map[string]*WsOdds `protobuf:"bytes,18,rep,name=DtOdds,proto3" json:"dtOdds" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	UserCount      int32              `protobuf:"varint,19,opt,name=UserCount,proto3" json:"userCount"`
+	// JSON
+	DtCard   []byte `protobuf:"bytes,20,opt,name=DtCard,proto3" json:"dtCard"`
+	MinBet01 int32  `protobuf:"varint,21,opt,name=MinBet01,proto3" json:"minBet01"`
+	MaxBet01 int32  `protobuf:"varint,22,opt,name=MaxBet01,proto3" json:"maxBet01"`
+	MinBet02 int32  `protobuf:"varint,23,opt,name=MinBet02,proto3" json:"minBet02"`
+	MaxBet02 int32  `protobuf:"varint,24,opt,name=MaxBet02,proto3" json:"maxBet02"`
+	MinBet03 int32  `protobuf:"varint,25,opt,name=MinBet03,proto3" json:"minBet03"`
+	MaxBet03 int32  `protobuf:"varint,26,opt,name=MaxBet03,proto3" json:"maxBet03"`
+	MinBet04 int32  `protobuf:"varint,27,opt,name=MinBet04,proto3" json:"minBet04"`
+	MaxBet04 int32  `protobuf:"varint,28,opt,name=MaxBet04,proto3" json:"maxBet04"`
+	MinBet05 int32  `protobuf:"varint,29,opt,name=MinBet05,proto3" json:"minBet05"`
+	MaxBet05 int32  `protobuf:"varint,30,opt,name=MaxBet05,proto3" json:"maxBet05"`
+	MinBet06 int32  `protobuf:"varint,31,opt,name=MinBet06,proto3" json:"minBet06"`
+	MaxBet06 int32  `protobuf:"varint,32,opt,name=MaxBet06,proto3" json:"maxBet06"`
+	MinBet07 int32  `protobuf:"varint,33,opt,name=MinBet07,proto3" json:"minBet07"`
+	MaxBet07 int32  `protobuf:"varint,34,opt,name=MaxBet07,proto3" json:"maxBet07"`
+	MinBet08 int32  `protobuf:"varint,35,opt,name=MinBet08,proto3" json:"minBet08"`
+	MaxBet08 int32  `protobuf:"varint,36,opt,name=MaxBet08,proto3" json:"maxBet08"`
+	MinBet09 int32  `protobuf:"varint,37,opt,name=MinBet09,proto3" json:"minBet09"`
+	MaxBet09 int32  `protobuf:"varint,38,opt,name=MaxBet09,proto3" json:"maxBet09"`
+	MinBet10 int32  `protobuf:"varint,39,opt,name=MinBet10,proto3" json:"minBet10"`
+	MaxBet10 int32  `protobuf:"varint,40,opt,name=MaxBet10,proto3" json:"maxBet10"`
+	MinBet11 int32  `protobuf:"varint,41,opt,name=MinBet11,proto3" json:"minBet11"`
+	MaxBet11 int32  `protobuf:"varint,42,opt,name=MaxBet11,proto3" json:"maxBet11"`
+	MinBet12 int32  `protobuf:"varint,43,opt,name=MinBet12,proto3" json:"minBet12"`
+	MaxBet12 int32  `protobuf:"varint,44,opt,name=MaxBet12,proto3" json:"maxBet12"`
+	MinBet13 int32  `protobuf:"varint,45,opt,name=MinBet13,proto3" json:"minBet13"`
+	MaxBet13 int32  `protobuf:"varint,46,opt,name=MaxBet13,proto3" json:"maxBet13"`
+	MinBet14 int32  `protobuf:"varint,47,opt,name=MinBet14,proto3" json:"minBet14"`
+	MaxBet14 int32  `protobuf:"varint,48,opt,name=MaxBet14,proto3" json:"maxBet14"`
+}
+
+func (m *WsTableEntryData) Reset()         { *m = WsTableEntryData{} }
+func (m *WsTableEntryData) String() string { return proto.CompactTextString(m) }
+func (*WsTableEntryData) ProtoMessage()    {}
+func (*WsTableEntryData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{5}
+}
+func (m *WsTableEntryData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsTableEntryData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsTableEntryData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsTableEntryData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsTableEntryData.Merge(m, src)
+}
+func (m *WsTableEntryData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsTableEntryData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsTableEntryData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsTableEntryData proto.InternalMessageInfo
+
+func (m *WsTableEntryData) GetBOk() bool {
+	if m != nil {
+		return m.BOk
+	}
+	return false
+}
+
+func (m *WsTableEntryData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetGroupType() int32 {
+	if m != nil {
+		return m.GroupType
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetAreaID() int32 {
+	if m != nil {
+		return m.AreaID
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetAreaType() int32 {
+	if m != nil {
+		return m.AreaType
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMemberID() int64 {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *WsTableEntryData) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *WsTableEntryData) GetHeadID() int32 {
+	if m != nil {
+		return m.HeadID
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetSeatIDArr() []int32 {
+	if m != nil {
+		return m.SeatIDArr
+	}
+	return nil
+}
+
+func (m *WsTableEntryData) GetCurrencyCode() string {
+	if m != nil {
+		return m.CurrencyCode
+	}
+	return ""
+}
+
+func (m *WsTableEntryData) GetCurrencyName() string {
+	if m != nil {
+		return m.CurrencyName
+	}
+	return ""
+}
+
+func (m *WsTableEntryData) GetCurrencyRate() float64 {
+	if m != nil {
+		return m.CurrencyRate
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetBalance() float64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetChips() float64 {
+	if m != nil {
+		return m.Chips
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetBetMilliSecond() int32 {
+	if m != nil {
+		return m.BetMilliSecond
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetDtOdds() map[string]*WsOdds {
+	if m != nil {
+		return m.DtOdds
+	}
+	return nil
+}
+
+func (m *WsTableEntryData) GetUserCount() int32 {
+	if m != nil {
+		return m.UserCount
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetDtCard() []byte {
+	if m != nil {
+		return m.DtCard
+	}
+	return nil
+}
+
+func (m *WsTableEntryData) GetMinBet01() int32 {
+	if m != nil {
+		return m.MinBet01
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet01() int32 {
+	if m != nil {
+		return m.MaxBet01
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet02() int32 {
+	if m != nil {
+		return m.MinBet02
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet02() int32 {
+	if m != nil {
+		return m.MaxBet02
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet03() int32 {
+	if m != nil {
+		return m.MinBet03
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet03() int32 {
+	if m != nil {
+		return m.MaxBet03
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet04() int32 {
+	if m != nil {
+		return m.MinBet04
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet04() int32 {
+	if m != nil {
+		return m.MaxBet04
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet05() int32 {
+	if m != nil {
+		return m.MinBet05
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet05() int32 {
+	if m != nil {
+		return m.MaxBet05
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet06() int32 {
+	if m != nil {
+		return m.MinBet06
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet06() int32 {
+	if m != nil {
+		return m.MaxBet06
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet07() int32 {
+	if m != nil {
+		return m.MinBet07
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet07() int32 {
+	if m != nil {
+		return m.MaxBet07
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet08() int32 {
+	if m != nil {
+		return m.MinBet08
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet08() int32 {
+	if m != nil {
+		return m.MaxBet08
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet09() int32 {
+	if m != nil {
+		return m.MinBet09
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet09() int32 {
+	if m != nil {
+		return m.MaxBet09
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet10() int32 {
+	if m != nil {
+		return m.MinBet10
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet10() int32 {
+	if m != nil {
+		return m.MaxBet10
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet11() int32 {
+	if m != nil {
+		return m.MinBet11
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet11() int32 {
+	if m != nil {
+		return m.MaxBet11
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet12() int32 {
+	if m != nil {
+		return m.MinBet12
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet12() int32 {
+	if m != nil {
+		return m.MaxBet12
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet13() int32 {
+	if m != nil {
+		return m.MinBet13
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet13() int32 {
+	if m != nil {
+		return m.MaxBet13
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMinBet14() int32 {
+	if m != nil {
+		return m.MinBet14
+	}
+	return 0
+}
+
+func (m *WsTableEntryData) GetMaxBet14() int32 {
+	if m != nil {
+		return m.MaxBet14
+	}
+	return 0
+}
+
+// 赔率以字串表示, 与JSON相同
+type WsOdds struct {
+	Odds []string `protobuf:"bytes,1,rep,name=Odds,proto3" json:"odds"`
+}
+
+func (m *WsOdds) Reset()         { *m = WsOdds{} }
+func (m *WsOdds) String() string { return proto.CompactTextString(m) }
+func (*WsOdds) ProtoMessage()    {}
+func (*WsOdds) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{6}
+}
+func (m *WsOdds) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsOdds) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsOdds.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsOdds) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsOdds.Merge(m, src)
+}
+func (m *WsOdds) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsOdds) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsOdds.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsOdds proto.InternalMessageInfo
+
+func (m *WsOdds) GetOdds() []string {
+	if m != nil {
+		return m.Odds
+	}
+	return nil
+}
+
+// 11 离开桌台
+type WsLeaveTableData struct {
+	GroupID int32 `protobuf:"varint,1,opt,name=GroupID,proto3" json:"groupID"`
+	BOk     bool  `protobuf:"varint,2,opt,name=BOk,proto3" json:"bOk"`
+}
+
+func (m *WsLeaveTableData) Reset()         { *m = WsLeaveTableData{} }
+func (m *WsLeaveTableData) String() string { return proto.CompactTextString(m) }
+func (*WsLeaveTableData) ProtoMessage()    {}
+func (*WsLeaveTableData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{7}
+}
+func (m *WsLeaveTableData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsLeaveTableData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsLeaveTableData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsLeaveTableData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsLeaveTableData.Merge(m, src)
+}
+func (m *WsLeaveTableData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsLeaveTableData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsLeaveTableData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsLeaveTableData proto.InternalMessageInfo
+
+func (m *WsLeaveTableData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsLeaveTableData) GetBOk() bool {
+	if m != nil {
+		return m.BOk
+	}
+	return false
+}
+
+// 12 断线续传
+type WsResumeData struct {
+	ResumeID string `protobuf:"bytes,1,opt,name=ResumeID,proto3" json:"resumeID"`
+	LastSeq  int64  `protobuf:"varint,2,opt,name=LastSeq,proto3" json:"lastSeq"`
+}
+
+func (m *WsResumeData) Reset()         { *m = WsResumeData{} }
+func (m *WsResumeData) String() string { return proto.CompactTextString(m) }
+func (*WsResumeData) ProtoMessage()    {}
+func (*WsResumeData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{8}
+}
+func (m *WsResumeData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsResumeData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsResumeData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsResumeData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsResumeData.Merge(m, src)
+}
+func (m *WsResumeData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsResumeData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsResumeData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsResumeData proto.InternalMessageInfo
+
+func (m *WsResumeData) GetResumeID() string {
+	if m != nil {
+		return m.ResumeID
+	}
+	return ""
+}
+
+func (m *WsResumeData) GetLastSeq() int64 {
+	if m != nil {
+		return m.LastSeq
+	}
+	return 0
+}
+
+type WsResumeRespData struct {
+	BOk      bool   `protobuf:"varint,1,opt,name=BOk,proto3" json:"bOk"`
+	Resync   bool   `protobuf:"varint,2,opt,name=Resync,proto3" json:"resync"`
+	ResumeID string `protobuf:"bytes,3,opt,name=ResumeID,proto3" json:"resumeID"`
+	Seq      int64  `protobuf:"varint,4,opt,name=Seq,proto3" json:"seq"`
+}
+
+func (m *WsResumeRespData) Reset()         { *m = WsResumeRespData{} }
+func (m *WsResumeRespData) String() string { return proto.CompactTextString(m) }
+func (*WsResumeRespData) ProtoMessage()    {}
+func (*WsResumeRespData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{9}
+}
+func (m *WsResumeRespData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsResumeRespData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsResumeRespData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsResumeRespData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsResumeRespData.Merge(m, src)
+}
+func (m *WsResumeRespData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsResumeRespData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsResumeRespData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsResumeRespData proto.InternalMessageInfo
+
+func (m *WsResumeRespData) GetBOk() bool {
+	if m != nil {
+		return m.BOk
+	}
+	return false
+}
+
+func (m *WsResumeRespData) GetResync() bool {
+	if m != nil {
+		return m.Resync
+	}
+	return false
+}
+
+func (m *WsResumeRespData) GetResumeID() string {
+	if m != nil {
+		return m.ResumeID
+	}
+	return ""
+}
+
+func (m *WsResumeRespData) GetSeq() int64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+// 13 节点下线, 等待DelayMs后断开并重连
+type WsReconnectData struct {
+	DelayMs int32 `protobuf:"varint,1,opt,name=DelayMs,proto3" json:"delayMs"`
+}
+
+func (m *WsReconnectData) Reset()         { *m = WsReconnectData{} }
+func (m *WsReconnectData) String() string { return proto.CompactTextString(m) }
+func (*WsReconnectData) ProtoMessage()    {}
+func (*WsReconnectData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{10}
+}
+func (m *WsReconnectData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsReconnectData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsReconnectData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsReconnectData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsReconnectData.Merge(m, src)
+}
+func (m *WsReconnectData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsReconnectData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsReconnectData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsReconnectData proto.InternalMessageInfo
+
+func (m *WsReconnectData) GetDelayMs() int32 {
+	if m != nil {
+		return m.DelayMs
+	}
+	return 0
+}
+
+// 21 桌况
+type WsTableData struct {
+	GameID                   int32          `protobuf:"varint,1,opt,name=GameID,proto3" json:"gameID"`
+	GroupID                  int32          `protobuf:"varint,2,opt,name=GroupID,proto3" json:"groupID"`
+	GroupType                int32          `protobuf:"varint,3,opt,name=GroupType,proto3" json:"groupType"`
+	GameNo                   int32          `protobuf:"varint,4,opt,name=GameNo,proto3" json:"gameNo"`
+	GameNoRound              int32          `protobuf:"varint,5,opt,name=GameNoRound,proto3" json:"gameNoRound"`
+	DealerID                 int32          `protobuf:"varint,6,opt,name=DealerID,proto3" json:"dealerID"`
+	DealerName               string         `protobuf:"bytes,7,opt,name=DealerName,proto3" json:"dealerName"`
+	DealerImage              string         `protobuf:"bytes,8,opt,name=DealerImage,proto3" json:"dealerImage"`
+	DealerImage2             string         `protobuf:"bytes,9,opt,name=DealerImage2,proto3" json:"dealerImage2"`
+	Dealer2ID                int32          `protobuf:"varint,10,opt,name=Dealer2ID,proto3" json:"dealer2ID"`
+	Dealer2Name              string         `protobuf:"bytes,11,opt,name=Dealer2Name,proto3" json:"dealer2Name"`
+	Dealer2Image             string         `protobuf:"bytes,12,opt,name=Dealer2Image,proto3" json:"dealer2Image"`
+	Dealer2Image2            string         `protobuf:"bytes,13,opt,name=Dealer2Image2,proto3" json:"dealer2Image2"`
+	BetMilliSecond           int32          `protobuf:"varint,14,opt,name=BetMilliSecond,proto3" json:"betMilliSecond"`
+	BWantToShuffle           bool           `protobuf:"varint,15,opt,name=BWantToShuffle,proto3" json:"bWantToShuffle"`
+	BWantToEnd               bool           `protobuf:"varint,16,opt,name=BWantToEnd,proto3" json:"bWantToEnd"`
+	KeyStatus                int32          `protobuf:"varint,17,opt,name=KeyStatus,proto3" json:"keyStatus"`
+	GameMode                 int32          `protobuf:"varint,18,opt,name=GameMode,proto3" json:"gameMode"`
+	SingleLimit              int32          `protobuf:"varint,19,opt,name=SingleLimit,proto3" json:"singleLimit"`
+	TableMinBet              int32          `protobuf:"varint,20,opt,name=TableMinBet,proto3" json:"tableMinBet"`
+	TableMaxBet              int32          `protobuf:"varint,21,opt,name=TableMaxBet,proto3" json:"tableMaxBet"`
+	TableTieMinBet           int32          `protobuf:"varint,22,opt,name=TableTieMinBet,proto3" json:"tableTieMinBet"`
+	TableTieMaxBet           int32          `protobuf:"varint,23,opt,name=TableTieMaxBet,proto3" json:"tableTieMaxBet"`
+	TablePairMinBet          int32          `protobuf:"varint,24,opt,name=TablePairMinBet,proto3" json:"tablePairMinBet"`
+	TablePairMaxBet          int32          `protobuf:"varint,25,opt,name=TablePairMaxBet,proto3" json:"tablePairMaxBet"`
+	TableType                int32          `protobuf:"varint,26,opt,name=TableType,proto3" json:"tableType"`
+	TableStatus              int32          `protobuf:"varint,27,opt,name=TableStatus,proto3" json:"tableStatus"`
+	TableSort                int32          `protobuf:"varint,28,opt,name=TableSort,proto3" json:"tableSort"`
+	TableSort2               int32          `protobuf:"varint,29,opt,name=TableSort2,proto3" json:"tableSort2"`
+	ReservedTable            int32          `protobuf:"varint,30,opt,name=ReservedTable,proto3" json:"reservedTable"`
+	ReservedTableParentIDArr []int32        `protobuf:"varint,31,rep,packed,name=ReservedTableParentIDArr,proto3" json:"reservedTableParentIDArr"`
+	ReservedTableMemberIDArr []int32        `protobuf:"varint,32,rep,packed,name=ReservedTableMemberIDArr,proto3" json:"reservedTableMemberIDArr"`
+	TableDtExtend            *TableDtExtend `protobuf:"bytes,33,opt,name=TableDtExtend,proto3" json:"tableDtExtend"`
+}
+
+func (m *WsTableData) Reset()         { *m = WsTableData{} }
+func (m *WsTableData) String() string { return proto.CompactTextString(m) }
+func (*WsTableData) ProtoMessage()    {}
+func (*WsTableData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{11}
+}
+func (m *WsTableData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsTableData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsTableData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsTableData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsTableData.Merge(m, src)
+}
+func (m *WsTableData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsTableData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsTableData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsTableData proto.InternalMessageInfo
+
+func (m *WsTableData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *WsTableData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsTableData) GetGroupType() int32 {
+	if m != nil {
+		return m.GroupType
+	}
+	return 0
+}
+
+func (m *WsTableData) GetGameNo() int32 {
+	if m != nil {
+		return m.GameNo
+	}
+	return 0
+}
+
+func (m *WsTableData) GetGameNoRound() int32 {
+	if m != nil {
+		return m.GameNoRound
+	}
+	return 0
+}
+
+func (m *WsTableData) GetDealerID() int32 {
+	if m != nil {
+		return m.DealerID
+	}
+	return 0
+}
+
+func (m *WsTableData) GetDealerName() string {
+	if m != nil {
+		return m.DealerName
+	}
+	return ""
+}
+
+func (m *WsTableData) GetDealerImage() string {
+	if m != nil {
+		return m.DealerImage
+	}
+	return ""
+}
+
+func (m *WsTableData) GetDealerImage2() string {
+	if m != nil {
+		return m.DealerImage2
+	}
+	return ""
+}
+
+func (m *WsTableData) GetDealer2ID() int32 {
+	if m != nil {
+		return m.Dealer2ID
+	}
+	return 0
+}
+
+func (m *WsTableData) GetDealer2Name() string {
+	if m != nil {
+		return m.Dealer2Name
+	}
+	return ""
+}
+
+func (m *WsTableData) GetDealer2Image() string {
+	if m != nil {
+		return m.Dealer2Image
+	}
+	return ""
+}
+
+func (m *WsTableData) GetDealer2Image2() string {
+	if m != nil {
+		return m.Dealer2Image2
+	}
+	return ""
+}
+
+func (m *WsTableData) GetBetMilliSecond() int32 {
+	if m != nil {
+		return m.BetMilliSecond
+	}
+	return 0
+}
+
+func (m *WsTableData) GetBWantToShuffle() bool {
+	if m != nil {
+		return m.BWantToShuffle
+	}
+	return false
+}
+
+func (m *WsTableData) GetBWantToEnd() bool {
+	if m != nil {
+		return m.BWantToEnd
+	}
+	return false
+}
+
+func (m *WsTableData) GetKeyStatus() int32 {
+	if m != nil {
+		return m.KeyStatus
+	}
+	return 0
+}
+
+func (m *WsTableData) GetGameMode() int32 {
+	if m != nil {
+		return m.GameMode
+	}
+	return 0
+}
+
+func (m *WsTableData) GetSingleLimit() int32 {
+	if m != nil {
+		return m.SingleLimit
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableMinBet() int32 {
+	if m != nil {
+		return m.TableMinBet
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableMaxBet() int32 {
+	if m != nil {
+		return m.TableMaxBet
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableTieMinBet() int32 {
+	if m != nil {
+		return m.TableTieMinBet
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableTieMaxBet() int32 {
+	if m != nil {
+		return m.TableTieMaxBet
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTablePairMinBet() int32 {
+	if m != nil {
+		return m.TablePairMinBet
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTablePairMaxBet() int32 {
+	if m != nil {
+		return m.TablePairMaxBet
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableType() int32 {
+	if m != nil {
+		return m.TableType
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableStatus() int32 {
+	if m != nil {
+		return m.TableStatus
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableSort() int32 {
+	if m != nil {
+		return m.TableSort
+	}
+	return 0
+}
+
+func (m *WsTableData) GetTableSort2() int32 {
+	if m != nil {
+		return m.TableSort2
+	}
+	return 0
+}
+
+func (m *WsTableData) GetReservedTable() int32 {
+	if m != nil {
+		return m.ReservedTable
+	}
+	return 0
+}
+
+func (m *WsTableData) GetReservedTableParentIDArr() []int32 {
+	if m != nil {
+		return m.ReservedTableParentIDArr
+	}
+	return nil
+}
+
+func (m *WsTableData) GetReservedTableMemberIDArr() []int32 {
+	if m != nil {
+		return m.ReservedTableMemberIDArr
+	}
+	return nil
+}
+
+func (m *WsTableData) GetTableDtExtend() *TableDtExtend {
+	if m != nil {
+		return m.TableDtExtend
+	}
+	return nil
+}
+
+type TableDtExtend struct {
+	NetGroupName   string `protobuf:"bytes,1,opt,name=NetGroupName,proto3" json:"netGroupName"`
+	PhoneGroupName string `protobuf:"bytes,2,opt,name=PhoneGroupName,proto3" json:"phoneGroupName"`
+	TableName      string `protobuf:"bytes,3,opt,name=TableName,proto3" json:"tableName"`
+	NetType        string `protobuf:"bytes,4,opt,name=NetType,proto3" json:"netType"`
+	PhoneType      string `protobuf:"bytes,5,opt,name=PhoneType,proto3" json:"phoneType"`
+}
+
+func (m *TableDtExtend) Reset()         { *m = TableDtExtend{} }
+func (m *TableDtExtend) String() string { return proto.CompactTextString(m) }
+func (*TableDtExtend) ProtoMessage()    {}
+func (*TableDtExtend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{12}
+}
+func (m *TableDtExtend) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TableDtExtend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TableDtExtend.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TableDtExtend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableDtExtend.Merge(m, src)
+}
+func (m *TableDtExtend) XXX_Size() int {
+	return m.Size()
+}
+func (m *TableDtExtend) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableDtExtend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TableDtExtend proto.InternalMessageInfo
+
+func (m *TableDtExtend) GetNetGroupName() string {
+	if m != nil {
+		return m.NetGroupName
+	}
+	return ""
+}
+
+func (m *TableDtExtend) GetPhoneGroupName() string {
+	if m != nil {
+		return m.PhoneGroupName
+	}
+	return ""
+}
+
+func (m *TableDtExtend) GetTableName() string {
+	if m != nil {
+		return m.TableName
+	}
+	return ""
+}
+
+func (m *TableDtExtend) GetNetType() string {
+	if m != nil {
+		return m.NetType
+	}
+	return ""
+}
+
+func (m *TableDtExtend) GetPhoneType() string {
+	if m != nil {
+		return m.PhoneType
+	}
+	return ""
+}
+
+// 22 下注
+type WsBettingData struct {
+	BetSerialNumber int32                `protobuf:"varint,1,opt,name=BetSerialNumber,proto3" json:"betSerialNumber"`
+	GameNo          int32                `protobuf:"varint,2,opt,name=GameNo,proto3" json:"gameNo"`
+	GameNoRound     int32                `protobuf:"varint,3,opt,name=GameNoRound,proto3" json:"gameNoRound"`
+	BetArr          []*WsBettingInfoItem `protobuf:"bytes,4,rep,name=BetArr,proto3" json:"betArr"`
+	Commission      int32                `protobuf:"varint,5,opt,name=Commission,proto3" json:"commission"`
+}
+
+func (m *WsBettingData) Reset()         { *m = WsBettingData{} }
+func (m *WsBettingData) String() string { return proto.CompactTextString(m) }
+func (*WsBettingData) ProtoMessage()    {}
+func (*WsBettingData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{13}
+}
+func (m *WsBettingData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsBettingData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsBettingData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsBettingData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsBettingData.Merge(m, src)
+}
+func (m *WsBettingData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsBettingData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsBettingData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsBettingData proto.InternalMessageInfo
+
+func (m *WsBettingData) GetBetSerialNumber() int32 {
+	if m != nil {
+		return m.BetSerialNumber
+	}
+	return 0
+}
+
+func (m *WsBettingData) GetGameNo() int32 {
+	if m != nil {
+		return m.GameNo
+	}
+	return 0
+}
+
+func (m *WsBettingData) GetGameNoRound() int32 {
+	if m != nil {
+		return m.GameNoRound
+	}
+	return 0
+}
+
+func (m *WsBettingData) GetBetArr() []*WsBettingInfoItem {
+	if m != nil {
+		return m.BetArr
+	}
+	return nil
+}
+
+func (m *WsBettingData) GetCommission() int32 {
+	if m != nil {
+		return m.Commission
+	}
+	return 0
+}
+
+type WsBettingInfoItem struct {
+	BetArea     int32 `protobuf:"varint,1,opt,name=BetArea,proto3" json:"betArea"`
+	AddBetMoney int32 `protobuf:"varint,2,opt,name=AddBetMoney,proto3" json:"addBetMoney"`
+}
+
+func (m *WsBettingInfoItem) Reset()         { *m = WsBettingInfoItem{} }
+func (m *WsBettingInfoItem) String() string { return proto.CompactTextString(m) }
+func (*WsBettingInfoItem) ProtoMessage()    {}
+func (*WsBettingInfoItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{14}
+}
+func (m *WsBettingInfoItem) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsBettingInfoItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsBettingInfoItem.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsBettingInfoItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsBettingInfoItem.Merge(m, src)
+}
+func (m *WsBettingInfoItem) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsBettingInfoItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsBettingInfoItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsBettingInfoItem proto.InternalMessageInfo
+
+func (m *WsBettingInfoItem) GetBetArea() int32 {
+	if m != nil {
+		return m.BetArea
+	}
+	return 0
+}
+
+func (m *WsBettingInfoItem) GetAddBetMoney() int32 {
+	if m != nil {
+		return m.AddBetMoney
+	}
+	return 0
+}
+
+type WsBettingRespData struct {
+	GameID          int32                  `protobuf:"varint,1,opt,name=GameID,proto3" json:"gameID"`
+	GroupID         int32                  `protobuf:"varint,2,opt,name=GroupID,proto3" json:"groupID"`
+	AreaID          int32                  `protobuf:"varint,3,opt,name=AreaID,proto3" json:"areaID"`
+	AreaType        int32                  `protobuf:"varint,4,opt,name=AreaType,proto3" json:"areaType"`
+	BOk             bool                   `protobuf:"varint,5,opt,name=BOk,proto3" json:"bOk"`
+	BetSerialNumber int32                  `protobuf:"varint,6,opt,name=BetSerialNumber,proto3" json:"betSerialNumber"`
+	Balance         float64                `protobuf:"fixed64,7,opt,name=Balance,proto3" json:"balance"`
+	BetArr          []*WsBettingResultItem `protobuf:"bytes,8,rep,name=BetArr,proto3" json:"betArr"`
+}
+
+func (m *WsBettingRespData) Reset()         { *m = WsBettingRespData{} }
+func (m *WsBettingRespData) String() string { return proto.CompactTextString(m) }
+func (*WsBettingRespData) ProtoMessage()    {}
+func (*WsBettingRespData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{15}
+}
+func (m *WsBettingRespData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsBettingRespData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsBettingRespData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsBettingRespData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsBettingRespData.Merge(m, src)
+}
+func (m *WsBettingRespData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsBettingRespData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsBettingRespData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsBettingRespData proto.InternalMessageInfo
+
+func (m *WsBettingRespData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *WsBettingRespData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsBettingRespData) GetAreaID() int32 {
+	if m != nil {
+		return m.AreaID
+	}
+	return 0
+}
+
+func (m *WsBettingRespData) GetAreaType() int32 {
+	if m != nil {
+		return m.AreaType
+	}
+	return 0
+}
+
+func (m *WsBettingRespData) GetBOk() bool {
+	if m != nil {
+		return m.BOk
+	}
+	return false
+}
+
+func (m *WsBettingRespData) GetBetSerialNumber() int32 {
+	if m != nil {
+		return m.BetSerialNumber
+	}
+	return 0
+}
+
+func (m *WsBettingRespData) GetBalance() float64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *WsBettingRespData) GetBetArr() []*WsBettingResultItem {
+	if m != nil {
+		return m.BetArr
+	}
+	return nil
+}
+
+type WsBettingResultItem struct {
+	BetArea     int32  `protobuf:"varint,1,opt,name=BetArea,proto3" json:"betArea"`
+	AddBetMoney int32  `protobuf:"varint,2,opt,name=AddBetMoney,proto3" json:"addBetMoney"`
+	BOk         bool   `protobuf:"varint,3,opt,name=BOk,proto3" json:"bOk"`
+	Code        int32  `protobuf:"varint,4,opt,name=Code,proto3" json:"code"`
+	Msg         string `protobuf:"bytes,5,opt,name=Msg,proto3" json:"msg"`
+}
+
+func (m *WsBettingResultItem) Reset()         { *m = WsBettingResultItem{} }
+func (m *WsBettingResultItem) String() string { return proto.CompactTextString(m) }
+func (*WsBettingResultItem) ProtoMessage()    {}
+func (*WsBettingResultItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{16}
+}
+func (m *WsBettingResultItem) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsBettingResultItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsBettingResultItem.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsBettingResultItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsBettingResultItem.Merge(m, src)
+}
+func (m *WsBettingResultItem) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsBettingResultItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsBettingResultItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsBettingResultItem proto.InternalMessageInfo
+
+func (m *WsBettingResultItem) GetBetArea() int32 {
+	if m != nil {
+		return m.BetArea
+	}
+	return 0
+}
+
+func (m *WsBettingResultItem) GetAddBetMoney() int32 {
+	if m != nil {
+		return m.AddBetMoney
+	}
+	return 0
+}
+
+func (m *WsBettingResultItem) GetBOk() bool {
+	if m != nil {
+		return m.BOk
+	}
+	return false
+}
+
+func (m *WsBettingResultItem) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *WsBettingResultItem) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+// 25 开奖结果
+type WsGameResultRespData struct {
+	GameID        int32            `protobuf:"varint,1,opt,name=GameID,proto3" json:"gameID"`
+	GroupID       int32            `protobuf:"varint,2,opt,name=GroupID,proto3" json:"groupID"`
+	Result        int32            `protobuf:"varint,3,opt,name=Result,proto3" json:"result"`
+	PlayerScore   int32            `protobuf:"varint,4,opt,name=PlayerScore,proto3" json:"playerScore"`
+	BankerScore   int32            `protobuf:"varint,5,opt,name=BankerScore,proto3" json:"bankerScore"`
+	DtCard        map[string]int32 `protobuf:"bytes,6,rep,name=DtCard,proto3" json:"dtCard" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	WinBetAreaArr []int32          `protobuf:"varint,7,rep,packed,name=WinBetAreaArr,proto3" json:"winBetAreaArr"`
+}
+
+func (m *WsGameResultRespData) Reset()         { *m = WsGameResultRespData{} }
+func (m *WsGameResultRespData) String() string { return proto.CompactTextString(m) }
+func (*WsGameResultRespData) ProtoMessage()    {}
+func (*WsGameResultRespData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{17}
+}
+func (m *WsGameResultRespData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsGameResultRespData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsGameResultRespData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsGameResultRespData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsGameResultRespData.Merge(m, src)
+}
+func (m *WsGameResultRespData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsGameResultRespData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsGameResultRespData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsGameResultRespData proto.InternalMessageInfo
+
+func (m *WsGameResultRespData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *WsGameResultRespData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsGameResultRespData) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *WsGameResultRespData) GetPlayerScore() int32 {
+	if m != nil {
+		return m.PlayerScore
+	}
+	return 0
+}
+
+func (m *WsGameResultRespData) GetBankerScore() int32 {
+	if m != nil {
+		return m.BankerScore
+	}
+	return 0
+}
+
+func (m *WsGameResultRespData) GetDtCard() map[string]int32 {
+	if m != nil {
+		return m.DtCard
+	}
+	return nil
+}
+
+func (m *WsGameResultRespData) GetWinBetAreaArr() []int32 {
+	if m != nil {
+		return m.WinBetAreaArr
+	}
+	return nil
+}
+
+// 31 派彩, 金额以字串表示, 与JSON相同
+type WsPayoutResultData struct {
+	GameID       int32             `protobuf:"varint,1,opt,name=GameID,proto3" json:"gameID"`
+	GroupID      int32             `protobuf:"varint,2,opt,name=GroupID,proto3" json:"groupID"`
+	AreaID       int32             `protobuf:"varint,3,opt,name=AreaID,proto3" json:"areaID"`
+	AreaType     int32             `protobuf:"varint,4,opt,name=AreaType,proto3" json:"areaType"`
+	MemberID     int64             `protobuf:"varint,5,opt,name=MemberID,proto3" json:"memberID"`
+	MoneyWin     string            `protobuf:"bytes,6,opt,name=MoneyWin,proto3" json:"moneyWin"`
+	MoneyWinLoss string            `protobuf:"bytes,7,opt,name=MoneyWinLoss,proto3" json:"moneyWinLoss"`
+	DtMoneyWin   map[string]string `protobuf:"bytes,8,rep,name=DtMoneyWin,proto3" json:"dtMoneyWin" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *WsPayoutResultData) Reset()         { *m = WsPayoutResultData{} }
+func (m *WsPayoutResultData) String() string { return proto.CompactTextString(m) }
+func (*WsPayoutResultData) ProtoMessage()    {}
+func (*WsPayoutResultData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{18}
+}
+func (m *WsPayoutResultData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsPayoutResultData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsPayoutResultData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsPayoutResultData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsPayoutResultData.Merge(m, src)
+}
+func (m *WsPayoutResultData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsPayoutResultData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsPayoutResultData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsPayoutResultData proto.InternalMessageInfo
+
+func (m *WsPayoutResultData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *WsPayoutResultData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsPayoutResultData) GetAreaID() int32 {
+	if m != nil {
+		return m.AreaID
+	}
+	return 0
+}
+
+func (m *WsPayoutResultData) GetAreaType() int32 {
+	if m != nil {
+		return m.AreaType
+	}
+	return 0
+}
+
+func (m *WsPayoutResultData) GetMemberID() int64 {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
+}
+
+func (m *WsPayoutResultData) GetMoneyWin() string {
+	if m != nil {
+		return m.MoneyWin
+	}
+	return ""
+}
+
+func (m *WsPayoutResultData) GetMoneyWinLoss() string {
+	if m != nil {
+		return m.MoneyWinLoss
+	}
+	return ""
+}
+
+func (m *WsPayoutResultData) GetDtMoneyWin() map[string]string {
+	if m != nil {
+		return m.DtMoneyWin
+	}
+	return nil
+}
+
+// 38 下注倒数
+type WsBetTimeData struct {
+	GameID       int32 `protobuf:"varint,1,opt,name=GameID,proto3" json:"gameID"`
+	GroupID      int32 `protobuf:"varint,2,opt,name=GroupID,proto3" json:"groupID"`
+	GroupType    int32 `protobuf:"varint,3,opt,name=GroupType,proto3" json:"groupType"`
+	GameNo       int32 `protobuf:"varint,4,opt,name=GameNo,proto3" json:"gameNo"`
+	GameNoRound  int32 `protobuf:"varint,5,opt,name=GameNoRound,proto3" json:"gameNoRound"`
+	BetTimeCount int32 `protobuf:"varint,6,opt,name=BetTimeCount,proto3" json:"betTimeCount"`
+	// JSON
+	BetTimeContent  []byte `protobuf:"bytes,7,opt,name=BetTimeContent,proto3" json:"betTimeContent"`
+	TimeMillisecond int32  `protobuf:"varint,8,opt,name=TimeMillisecond,proto3" json:"timeMillisecond"`
+}
+
+func (m *WsBetTimeData) Reset()         { *m = WsBetTimeData{} }
+func (m *WsBetTimeData) String() string { return proto.CompactTextString(m) }
+func (*WsBetTimeData) ProtoMessage()    {}
+func (*WsBetTimeData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{19}
+}
+func (m *WsBetTimeData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsBetTimeData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsBetTimeData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsBetTimeData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsBetTimeData.Merge(m, src)
+}
+func (m *WsBetTimeData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsBetTimeData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsBetTimeData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsBetTimeData proto.InternalMessageInfo
+
+func (m *WsBetTimeData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *WsBetTimeData) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsBetTimeData) GetGroupType() int32 {
+	if m != nil {
+		return m.GroupType
+	}
+	return 0
+}
+
+func (m *WsBetTimeData) GetGameNo() int32 {
+	if m != nil {
+		return m.GameNo
+	}
+	return 0
+}
+
+func (m *WsBetTimeData) GetGameNoRound() int32 {
+	if m != nil {
+		return m.GameNoRound
+	}
+	return 0
+}
+
+func (m *WsBetTimeData) GetBetTimeCount() int32 {
+	if m != nil {
+		return m.BetTimeCount
+	}
+	return 0
+}
+
+func (m *WsBetTimeData) GetBetTimeContent() []byte {
+	if m != nil {
+		return m.BetTimeContent
+	}
+	return nil
+}
+
+func (m *WsBetTimeData) GetTimeMillisecond() int32 {
+	if m != nil {
+		return m.TimeMillisecond
+	}
+	return 0
+}
+
+// 限红修改
+type WsBetLimitModifyData struct {
+	GameID             int32            `protobuf:"varint,1,opt,name=GameID,proto3" json:"gameID"`
+	MemberID           int64            `protobuf:"varint,2,opt,name=MemberID,proto3" json:"memberID"`
+	DtBetLimitSelectID map[string]int32 `protobuf:"bytes,3,rep,name=DtBetLimitSelectID,proto3" json:"dtBetLimitSelectID" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	MinBet01           int32            `protobuf:"varint,4,opt,name=MinBet01,proto3" json:"minBet01"`
+	MaxBet01           int32            `protobuf:"varint,5,opt,name=MaxBet01,proto3" json:"maxBet01"`
+	MinBet02           int32            `protobuf:"varint,6,opt,name=MinBet02,proto3" json:"minBet02"`
+	MaxBet02           int32            `protobuf:"varint,7,opt,name=MaxBet02,proto3" json:"maxBet02"`
+	MinBet03           int32            `protobuf:"varint,8,opt,name=MinBet03,proto3" json:"minBet03"`
+	MaxBet03           int32            `protobuf:"varint,9,opt,name=MaxBet03,proto3" json:"maxBet03"`
+	MinBet04           int32            `protobuf:"varint,10,opt,name=MinBet04,proto3" json:"minBet04"`
+	MaxBet04           int32            `protobuf:"varint,11,opt,name=MaxBet04,proto3" json:"maxBet04"`
+	MinBet05           int32            `protobuf:"varint,12,opt,name=MinBet05,proto3" json:"minBet05"`
+	MaxBet05           int32            `protobuf:"varint,13,opt,name=MaxBet05,proto3" json:"maxBet05"`
+	MinBet06           int32            `protobuf:"varint,14,opt,name=MinBet06,proto3" json:"minBet06"`
+	MaxBet06           int32            `protobuf:"varint,15,opt,name=MaxBet06,proto3" json:"maxBet06"`
+	MinBet07           int32            `protobuf:"varint,16,opt,name=MinBet07,proto3" json:"minBet07"`
+	MaxBet07           int32            `protobuf:"varint,17,opt,name=MaxBet07,proto3" json:"maxBet07"`
+	MinBet08           int32            `protobuf:"varint,18,opt,name=MinBet08,proto3" json:"minBet08"`
+	MaxBet08           int32            `protobuf:"varint,19,opt,name=MaxBet08,proto3" json:"maxBet08"`
+	MinBet09           int32            `protobuf:"varint,20,opt,name=MinBet09,proto3" json:"minBet09"`
+	MaxBet09           int32            `protobuf:"varint,21,opt,name=MaxBet09,proto3" json:"maxBet09"`
+	MinBet10           int32            `protobuf:"varint,22,opt,name=MinBet10,proto3" json:"minBet10"`
+	MaxBet10           int32            `protobuf:"varint,23,opt,name=MaxBet10,proto3" json:"maxBet10"`
+	MinBet11           int32            `protobuf:"varint,24,opt,name=MinBet11,proto3" json:"minBet11"`
+	MaxBet11           int32            `protobuf:"varint,25,opt,name=MaxBet11,proto3" json:"maxBet11"`
+	MinBet12           int32            `protobuf:"varint,26,opt,name=MinBet12,proto3" json:"minBet12"`
+	MaxBet12           int32            `protobuf:"varint,27,opt,name=MaxBet12,proto3" json:"maxBet12"`
+	MinBet13           int32            `protobuf:"varint,28,opt,name=MinBet13,proto3" json:"minBet13"`
+	MaxBet13           int32            `protobuf:"varint,29,opt,name=MaxBet13,proto3" json:"maxBet13"`
+	MinBet14           int32            `protobuf:"varint,30,opt,name=MinBet14,proto3" json:"minBet14"`
+	MaxBet14           int32            `protobuf:"varint,31,opt,name=MaxBet14,proto3" json:"maxBet14"`
+}
+
+func (m *WsBetLimitModifyData) Reset()         { *m = WsBetLimitModifyData{} }
+func (m *WsBetLimitModifyData) String() string { return proto.CompactTextString(m) }
+func (*WsBetLimitModifyData) ProtoMessage()    {}
+func (*WsBetLimitModifyData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{20}
+}
+func (m *WsBetLimitModifyData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsBetLimitModifyData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsBetLimitModifyData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsBetLimitModifyData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsBetLimitModifyData.Merge(m, src)
+}
+func (m *WsBetLimitModifyData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsBetLimitModifyData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsBetLimitModifyData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsBetLimitModifyData proto.InternalMessageInfo
+
+func (m *WsBetLimitModifyData) GetGameID() int32 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMemberID() int64 {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetDtBetLimitSelectID() map[string]int32 {
+	if m != nil {
+		return m.DtBetLimitSelectID
+	}
+	return nil
+}
+
+func (m *WsBetLimitModifyData) GetMinBet01() int32 {
+	if m != nil {
+		return m.MinBet01
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet01() int32 {
+	if m != nil {
+		return m.MaxBet01
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet02() int32 {
+	if m != nil {
+		return m.MinBet02
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet02() int32 {
+	if m != nil {
+		return m.MaxBet02
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet03() int32 {
+	if m != nil {
+		return m.MinBet03
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet03() int32 {
+	if m != nil {
+		return m.MaxBet03
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet04() int32 {
+	if m != nil {
+		return m.MinBet04
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet04() int32 {
+	if m != nil {
+		return m.MaxBet04
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet05() int32 {
+	if m != nil {
+		return m.MinBet05
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet05() int32 {
+	if m != nil {
+		return m.MaxBet05
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet06() int32 {
+	if m != nil {
+		return m.MinBet06
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet06() int32 {
+	if m != nil {
+		return m.MaxBet06
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet07() int32 {
+	if m != nil {
+		return m.MinBet07
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet07() int32 {
+	if m != nil {
+		return m.MaxBet07
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet08() int32 {
+	if m != nil {
+		return m.MinBet08
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet08() int32 {
+	if m != nil {
+		return m.MaxBet08
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet09() int32 {
+	if m != nil {
+		return m.MinBet09
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet09() int32 {
+	if m != nil {
+		return m.MaxBet09
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet10() int32 {
+	if m != nil {
+		return m.MinBet10
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet10() int32 {
+	if m != nil {
+		return m.MaxBet10
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet11() int32 {
+	if m != nil {
+		return m.MinBet11
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet11() int32 {
+	if m != nil {
+		return m.MaxBet11
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet12() int32 {
+	if m != nil {
+		return m.MinBet12
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet12() int32 {
+	if m != nil {
+		return m.MaxBet12
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet13() int32 {
+	if m != nil {
+		return m.MinBet13
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet13() int32 {
+	if m != nil {
+		return m.MaxBet13
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMinBet14() int32 {
+	if m != nil {
+		return m.MinBet14
+	}
+	return 0
+}
+
+func (m *WsBetLimitModifyData) GetMaxBet14() int32 {
+	if m != nil {
+		return m.MaxBet14
+	}
+	return 0
+}
+
+// 35 桌台列表
+type WsGroupListData struct {
+	GroupArr []*WsGroupInfo `protobuf:"bytes,1,rep,name=GroupArr,proto3" json:"groupArr"`
+}
+
+func (m *WsGroupListData) Reset()         { *m = WsGroupListData{} }
+func (m *WsGroupListData) String() string { return proto.CompactTextString(m) }
+func (*WsGroupListData) ProtoMessage()    {}
+func (*WsGroupListData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{21}
+}
+func (m *WsGroupListData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsGroupListData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsGroupListData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsGroupListData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsGroupListData.Merge(m, src)
+}
+func (m *WsGroupListData) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsGroupListData) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsGroupListData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsGroupListData proto.InternalMessageInfo
+
+func (m *WsGroupListData) GetGroupArr() []*WsGroupInfo {
+	if m != nil {
+		return m.GroupArr
+	}
+	return nil
+}
+
+type WsGroupInfo struct {
+	GroupID         int32 `protobuf:"varint,1,opt,name=GroupID,proto3" json:"groupID"`
+	GroupType       int32 `protobuf:"varint,2,opt,name=GroupType,proto3" json:"groupType"`
+	SingleLimit     int32 `protobuf:"varint,3,opt,name=SingleLimit,proto3" json:"singleLimit"`
+	TableMinBet     int32 `protobuf:"varint,4,opt,name=TableMinBet,proto3" json:"tableMinBet"`
+	TableMaxBet     int32 `protobuf:"varint,5,opt,name=TableMaxBet,proto3" json:"tableMaxBet"`
+	TableTieMinBet  int32 `protobuf:"varint,6,opt,name=TableTieMinBet,proto3" json:"tableTieMinBet"`
+	TableTieMaxBet  int32 `protobuf:"varint,7,opt,name=TableTieMaxBet,proto3" json:"tableTieMaxBet"`
+	TablePairMinBet int32 `protobuf:"varint,8,opt,name=TablePairMinBet,proto3" json:"tablePairMinBet"`
+	TablePairMaxBet int32 `protobuf:"varint,9,opt,name=TablePairMaxBet,proto3" json:"tablePairMaxBet"`
+	TableStatus     int32 `protobuf:"varint,10,opt,name=TableStatus,proto3" json:"tableStatus"`
+}
+
+func (m *WsGroupInfo) Reset()         { *m = WsGroupInfo{} }
+func (m *WsGroupInfo) String() string { return proto.CompactTextString(m) }
+func (*WsGroupInfo) ProtoMessage()    {}
+func (*WsGroupInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ff87931dac4ca82, []int{22}
+}
+func (m *WsGroupInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WsGroupInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WsGroupInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WsGroupInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WsGroupInfo.Merge(m, src)
+}
+func (m *WsGroupInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *WsGroupInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_WsGroupInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WsGroupInfo proto.InternalMessageInfo
+
+func (m *WsGroupInfo) GetGroupID() int32 {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetGroupType() int32 {
+	if m != nil {
+		return m.GroupType
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetSingleLimit() int32 {
+	if m != nil {
+		return m.SingleLimit
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetTableMinBet() int32 {
+	if m != nil {
+		return m.TableMinBet
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetTableMaxBet() int32 {
+	if m != nil {
+		return m.TableMaxBet
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetTableTieMinBet() int32 {
+	if m != nil {
+		return m.TableTieMinBet
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetTableTieMaxBet() int32 {
+	if m != nil {
+		return m.TableTieMaxBet
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetTablePairMinBet() int32 {
+	if m != nil {
+		return m.TablePairMinBet
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetTablePairMaxBet() int32 {
+	if m != nil {
+		return m.TablePairMaxBet
+	}
+	return 0
+}
+
+func (m *WsGroupInfo) GetTableStatus() int32 {
+	if m != nil {
+		return m.TableStatus
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*WsFrame)(nil), "WsFrame")
+	proto.RegisterType((*WsConnMessage)(nil), "WsConnMessage")
+	proto.RegisterType((*WsAuthData)(nil), "WsAuthData")
+	proto.RegisterType((*WsAuthResp)(nil), "WsAuthResp")
+	proto.RegisterType((*WsJoinTableData)(nil), "WsJoinTableData")
+	proto.RegisterMapType((map[string]int32)(nil), "WsJoinTableData.DtBetLimitSelectIDEntry")
+	proto.RegisterType((*WsTableEntryData)(nil), "WsTableEntryData")
+	proto.RegisterMapType((map[string]*WsOdds)(nil), "WsTableEntryData.DtOddsEntry")
+	proto.RegisterType((*WsOdds)(nil), "WsOdds")
+	proto.RegisterType((*WsLeaveTableData)(nil), "WsLeaveTableData")
+	proto.RegisterType((*WsResumeData)(nil), "WsResumeData")
+	proto.RegisterType((*WsResumeRespData)(nil), "WsResumeRespData")
+	proto.RegisterType((*WsReconnectData)(nil), "WsReconnectData")
+	proto.RegisterType((*WsTableData)(nil), "WsTableData")
+	proto.RegisterType((*TableDtExtend)(nil), "TableDtExtend")
+	proto.RegisterType((*WsBettingData)(nil), "WsBettingData")
+	proto.RegisterType((*WsBettingInfoItem)(nil), "WsBettingInfoItem")
+	proto.RegisterType((*WsBettingRespData)(nil), "WsBettingRespData")
+	proto.RegisterType((*WsBettingResultItem)(nil), "WsBettingResultItem")
+	proto.RegisterType((*WsGameResultRespData)(nil), "WsGameResultRespData")
+	proto.RegisterMapType((map[string]int32)(nil), "WsGameResultRespData.DtCardEntry")
+	proto.RegisterType((*WsPayoutResultData)(nil), "WsPayoutResultData")
+	proto.RegisterMapType((map[string]string)(nil), "WsPayoutResultData.DtMoneyWinEntry")
+	proto.RegisterType((*WsBetTimeData)(nil), "WsBetTimeData")
+	proto.RegisterType((*WsBetLimitModifyData)(nil), "WsBetLimitModifyData")
+	proto.RegisterMapType((map[string]int32)(nil), "WsBetLimitModifyData.DtBetLimitSelectIDEntry")
+	proto.RegisterType((*WsGroupListData)(nil), "WsGroupListData")
+	proto.RegisterType((*WsGroupInfo)(nil), "WsGroupInfo")
+}
+
+func init() { proto.RegisterFile("ws.proto", fileDescriptor_7ff87931dac4ca82) }
+
+var fileDescriptor_7ff87931dac4ca82 = []byte{
+	// 2685 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0xcb, 0x6e, 0x23, 0xc7,
+	0xd5, 0x36, 0xef, 0x54, 0x51, 0xb7, 0xe9, 0x91, 0x67, 0x4a, 0xf2, 0x8c, 0x28, 0x73, 0x7e, 0xfb,
+	0x57, 0x62, 0x9b, 0x1e, 0x52, 0x1a, 0x5d, 0x9c, 0x78, 0x31, 0x14, 0x15, 0x47, 0xce, 0x48, 0x16,
+	0x8a, 0x4a, 0x18, 0x64, 0x63, 0x34, 0xd9, 0x35, 0x9c, 0xce, 0x90, 0xdd, 0x72, 0x77, 0xd3, 0x1e,
+	0x6e, 0xb3, 0x0f, 0x92, 0x4d, 0xf2, 0x02, 0x06, 0xf2, 0x10, 0xd9, 0x66, 0x13, 0x20, 0x1b, 0x6f,
+	0x02, 0x64, 0x45, 0x04, 0x36, 0x90, 0x00, 0xdc, 0xe4, 0x15, 0x82, 0x3a, 0x55, 0x5d, 0x55, 0xdd,
+	0x4d, 0x69, 0x5a, 0xf1, 0x18, 0x08, 0xb2, 0x12, 0xfb, 0x3b, 0xdf, 0xf9, 0xea, 0xde, 0x75, 0xfa,
+	0x1c, 0xa1, 0xf2, 0x17, 0x7e, 0xfd, 0xd2, 0x73, 0x03, 0x77, 0x63, 0x6d, 0xe0, 0x0e, 0x5c, 0xf8,
+	0xf9, 0x3e, 0xfb, 0xc5, 0xd1, 0xda, 0x3f, 0x32, 0xa8, 0xd4, 0xf5, 0x7f, 0xe4, 0x99, 0x23, 0x6a,
+	0x6c, 0xa3, 0xf2, 0x39, 0x03, 0xfb, 0xee, 0x10, 0x67, 0xb6, 0x32, 0xdb, 0x85, 0xd6, 0xe2, 0x6c,
+	0x5a, 0x2d, 0x5f, 0x0a, 0x8c, 0x48, 0xab, 0xf1, 0x00, 0xe5, 0x3a, 0xf4, 0x33, 0x9c, 0xdd, 0xca,
+	0x6c, 0xe7, 0x5a, 0xb7, 0x66, 0xd3, 0xea, 0x92, 0x4f, 0x3f, 0x7b, 0xd7, 0x1d, 0xd9, 0x01, 0x1d,
+	0x5d, 0x06, 0x13, 0xc2, 0xac, 0xc6, 0xdb, 0x28, 0xdf, 0x36, 0x03, 0x13, 0xe7, 0xb6, 0x32, 0xdb,
+	0x8b, 0x2d, 0x63, 0x36, 0xad, 0x2e, 0x5b, 0x66, 0x60, 0x6a, 0x34, 0xb0, 0x33, 0xde, 0xc7, 0xbe,
+	0xeb, 0xe0, 0xfc, 0x56, 0x66, 0xbb, 0xcc, 0x79, 0xbf, 0xf4, 0x5d, 0x47, 0xe7, 0x31, 0xbb, 0xf1,
+	0x43, 0x54, 0x3c, 0x73, 0x03, 0xbb, 0x4f, 0x71, 0x61, 0x2b, 0xb3, 0x5d, 0x69, 0x2e, 0xd7, 0xbb,
+	0xfe, 0x91, 0xeb, 0x38, 0xa7, 0xd4, 0xf7, 0xcd, 0x01, 0x6d, 0xad, 0xcd, 0xa6, 0xd5, 0x55, 0x07,
+	0x18, 0x9a, 0xaf, 0xf0, 0xa9, 0x11, 0xb4, 0x14, 0xa1, 0x1b, 0xf7, 0x50, 0xfe, 0xc8, 0xb5, 0xa8,
+	0x18, 0x69, 0x79, 0x36, 0xad, 0xe6, 0xfb, 0xae, 0x45, 0x09, 0xa0, 0xc6, 0x03, 0x54, 0x3c, 0xf6,
+	0xbc, 0x53, 0x7f, 0x00, 0x83, 0x5c, 0x68, 0x55, 0x66, 0xd3, 0x6a, 0x89, 0x7a, 0xde, 0xa7, 0x23,
+	0x7f, 0x40, 0x84, 0xa9, 0xf6, 0x02, 0xa1, 0xae, 0xff, 0x78, 0x1c, 0x3c, 0x83, 0x71, 0xac, 0xa3,
+	0x5c, 0xc7, 0xb6, 0x40, 0x6f, 0xa1, 0x55, 0x9a, 0x4d, 0xab, 0x39, 0xdf, 0xb6, 0x08, 0xc3, 0x8c,
+	0xb7, 0x50, 0xe9, 0x71, 0xbf, 0xef, 0x8e, 0x9d, 0x40, 0x97, 0x33, 0x39, 0x44, 0x42, 0x1b, 0x2c,
+	0x80, 0xe9, 0xfb, 0x5f, 0xb8, 0x9e, 0x05, 0xb3, 0xb6, 0x20, 0x16, 0x40, 0x60, 0x44, 0x5a, 0x6b,
+	0x5f, 0x66, 0xc3, 0xa6, 0x09, 0xf5, 0x2f, 0x99, 0xe3, 0x29, 0x1d, 0xf5, 0xa8, 0x77, 0xd2, 0x86,
+	0xf6, 0x73, 0xdc, 0x71, 0x24, 0x30, 0x22, 0xad, 0x37, 0xe8, 0xc9, 0x4f, 0x7d, 0xea, 0x9d, 0x99,
+	0x23, 0xaa, 0xf7, 0x64, 0x2c, 0x30, 0x22, 0xad, 0xe1, 0xa8, 0xf3, 0x73, 0x46, 0xbd, 0x8e, 0x72,
+	0xad, 0x4f, 0x9e, 0xc3, 0x6a, 0x95, 0xb9, 0xa9, 0xf7, 0xc9, 0x73, 0xc2, 0x30, 0xe3, 0x03, 0xb4,
+	0xdc, 0xfa, 0x99, 0x39, 0xb4, 0x2d, 0x39, 0xde, 0xa2, 0x5a, 0xfd, 0x5e, 0xc4, 0x42, 0x62, 0x4c,
+	0xd6, 0x37, 0x42, 0xfd, 0xf1, 0x88, 0x9e, 0xb4, 0x71, 0x49, 0xf5, 0xcd, 0x13, 0x18, 0x91, 0xd6,
+	0xda, 0xef, 0xb2, 0x68, 0xa5, 0xeb, 0x7f, 0xec, 0xda, 0xce, 0x85, 0xd9, 0x1b, 0x52, 0x58, 0xa5,
+	0x4b, 0x64, 0xb4, 0x83, 0x16, 0x0d, 0x9e, 0xd8, 0x23, 0x3b, 0xe8, 0xd0, 0x21, 0xed, 0x07, 0x30,
+	0x69, 0xb9, 0xed, 0x4a, 0x73, 0xbb, 0x1e, 0x63, 0xd7, 0x93, 0xd4, 0x63, 0x27, 0xf0, 0x26, 0xad,
+	0x3b, 0xb3, 0x69, 0xd5, 0xb0, 0x12, 0x46, 0x32, 0x47, 0x9b, 0x4d, 0xf9, 0x47, 0x9e, 0x3b, 0xbe,
+	0x3c, 0x69, 0xc3, 0x94, 0x17, 0xf8, 0x94, 0x0f, 0x38, 0x44, 0x42, 0x9b, 0x51, 0x43, 0xc5, 0x8f,
+	0x4c, 0x18, 0x54, 0x0e, 0x58, 0x68, 0x36, 0xad, 0x16, 0x07, 0x80, 0x10, 0x61, 0xd9, 0x38, 0x46,
+	0x77, 0xaf, 0xe8, 0x91, 0xb1, 0x8a, 0x72, 0xcf, 0xe9, 0x84, 0xef, 0x3e, 0xc2, 0x7e, 0x1a, 0x6b,
+	0xa8, 0xf0, 0xb9, 0x39, 0x1c, 0x53, 0xde, 0x2a, 0xe1, 0x0f, 0x1f, 0x64, 0x0f, 0x32, 0xb5, 0xbf,
+	0xae, 0xa2, 0xd5, 0xae, 0x0f, 0xa3, 0x04, 0xe7, 0x70, 0xfb, 0xb2, 0xd5, 0xca, 0xcc, 0x59, 0x2d,
+	0xd5, 0xb5, 0xec, 0x55, 0x5d, 0xd3, 0x47, 0x99, 0xbb, 0x66, 0x94, 0xef, 0xa0, 0x05, 0xf8, 0x79,
+	0x31, 0xb9, 0xa4, 0xb0, 0x69, 0x0a, 0xad, 0xa5, 0xd9, 0xb4, 0xba, 0x30, 0x08, 0x41, 0xa2, 0xec,
+	0xac, 0xdd, 0xc7, 0x1e, 0x35, 0x4f, 0xda, 0xb8, 0xa0, 0xda, 0x35, 0x01, 0x21, 0xc2, 0xc2, 0x76,
+	0x03, 0xfb, 0x05, 0x7a, 0x45, 0xf5, 0xd2, 0x32, 0x05, 0x46, 0xa4, 0x35, 0x72, 0x48, 0x4a, 0x69,
+	0x0f, 0x49, 0x39, 0xe5, 0x21, 0x59, 0xb8, 0xf6, 0x90, 0xd4, 0x50, 0xf1, 0xc7, 0xd4, 0xb4, 0x4e,
+	0xda, 0x18, 0xa9, 0x81, 0x3c, 0x03, 0x84, 0x08, 0x0b, 0x9b, 0x99, 0x0e, 0x35, 0x83, 0x93, 0xf6,
+	0x63, 0xcf, 0xc3, 0x95, 0xad, 0x5c, 0x38, 0x33, 0x7e, 0x08, 0x12, 0x65, 0x37, 0x76, 0xd1, 0xe2,
+	0xd1, 0xd8, 0xf3, 0xa8, 0xd3, 0x9f, 0xc0, 0x4b, 0x6c, 0x11, 0x9a, 0x5f, 0x9d, 0x4d, 0xab, 0x8b,
+	0x7d, 0x0d, 0x27, 0x11, 0x96, 0xee, 0x05, 0x9d, 0x5e, 0x4a, 0x7a, 0x41, 0xc7, 0x23, 0x2c, 0xdd,
+	0x8b, 0x98, 0x01, 0xc5, 0xcb, 0x5b, 0x99, 0xed, 0x4c, 0xd4, 0x8b, 0xe1, 0x24, 0xc2, 0x62, 0x73,
+	0xd8, 0x32, 0x87, 0xa6, 0xd3, 0xa7, 0x78, 0x05, 0x1c, 0x60, 0x0e, 0x7b, 0x1c, 0x22, 0xa1, 0xcd,
+	0xa8, 0xa2, 0xc2, 0xd1, 0x33, 0xfb, 0xd2, 0xc7, 0xab, 0x40, 0x5a, 0x98, 0x4d, 0xab, 0x85, 0x3e,
+	0x03, 0x08, 0xc7, 0xe1, 0x4d, 0x41, 0x83, 0x53, 0x7b, 0x38, 0xb4, 0x3b, 0xb4, 0xef, 0x3a, 0x16,
+	0xbe, 0x05, 0x53, 0xc8, 0xdf, 0x14, 0x11, 0x0b, 0x89, 0x31, 0x8d, 0xc7, 0xa8, 0xd8, 0x0e, 0x3e,
+	0xb1, 0x2c, 0x1f, 0x1b, 0x70, 0xbe, 0xef, 0xd7, 0xe3, 0xbb, 0xbe, 0xce, 0xed, 0xf0, 0xcc, 0x57,
+	0xc5, 0x02, 0x80, 0x08, 0x47, 0xb6, 0x2a, 0x6c, 0x15, 0x8f, 0x60, 0x33, 0xdc, 0x56, 0xfb, 0x75,
+	0x1c, 0x82, 0x44, 0xd9, 0xd9, 0x32, 0xb7, 0x83, 0x23, 0xd3, 0xb3, 0xf0, 0x1a, 0xdc, 0x79, 0x42,
+	0x90, 0x21, 0x44, 0x58, 0x60, 0x17, 0xda, 0x4e, 0x8b, 0x06, 0x0f, 0x1b, 0xf8, 0x75, 0xb5, 0x5f,
+	0x47, 0x02, 0x23, 0xd2, 0x0a, 0x4c, 0xf3, 0x05, 0x67, 0xde, 0xd1, 0x98, 0x02, 0x23, 0xd2, 0xaa,
+	0x69, 0x36, 0xf1, 0xdd, 0x84, 0x66, 0x53, 0x6a, 0x36, 0x35, 0xcd, 0x26, 0xc6, 0x09, 0xcd, 0xa6,
+	0xd4, 0x6c, 0x6a, 0x9a, 0x3b, 0x78, 0x3d, 0xa1, 0xb9, 0x23, 0x35, 0x77, 0x34, 0xcd, 0x1d, 0xbc,
+	0x91, 0xd0, 0xdc, 0x91, 0x9a, 0x3b, 0x9a, 0xe6, 0x2e, 0x7e, 0x23, 0xa1, 0xb9, 0x2b, 0x35, 0x77,
+	0x35, 0xcd, 0x5d, 0x7c, 0x2f, 0xa1, 0xb9, 0x2b, 0x35, 0x77, 0x35, 0xcd, 0x47, 0xf8, 0x7e, 0x42,
+	0xf3, 0x91, 0xd4, 0x7c, 0xa4, 0x69, 0x3e, 0xc2, 0x9b, 0x09, 0xcd, 0x47, 0x52, 0xf3, 0x91, 0xa6,
+	0xb9, 0x87, 0xab, 0x09, 0xcd, 0x3d, 0xa9, 0xb9, 0xa7, 0x69, 0xee, 0xe1, 0xad, 0x84, 0xe6, 0x9e,
+	0xd4, 0xdc, 0xd3, 0x34, 0xf7, 0xf1, 0x9b, 0x09, 0xcd, 0x7d, 0xa9, 0xb9, 0xaf, 0x69, 0xee, 0xe3,
+	0x5a, 0x42, 0x73, 0x5f, 0x6a, 0xee, 0x6b, 0x9a, 0x07, 0xf8, 0x41, 0x42, 0xf3, 0x40, 0x6a, 0x1e,
+	0x68, 0x9a, 0x07, 0xf8, 0xff, 0x12, 0x9a, 0x07, 0x52, 0xf3, 0x40, 0xd3, 0x3c, 0xc4, 0x6f, 0x25,
+	0x34, 0x0f, 0xa5, 0xe6, 0xa1, 0xa6, 0x79, 0x88, 0xdf, 0x4e, 0x68, 0x1e, 0x4a, 0xcd, 0x43, 0xa5,
+	0xd9, 0x78, 0x88, 0xff, 0x3f, 0xae, 0xd9, 0x78, 0x48, 0xa4, 0x55, 0x69, 0x36, 0x1e, 0xe2, 0xed,
+	0xb8, 0x26, 0x30, 0xcd, 0x17, 0x8a, 0xc9, 0xbd, 0x1a, 0xf8, 0x7b, 0x09, 0x4d, 0x79, 0x8e, 0x1a,
+	0xda, 0x39, 0x6a, 0x34, 0xf0, 0xf7, 0x13, 0x9a, 0xf2, 0x1c, 0x35, 0xb4, 0x73, 0xd4, 0x68, 0xe2,
+	0x77, 0x12, 0x9a, 0xf2, 0x1c, 0x35, 0xb4, 0x73, 0xd4, 0x68, 0xe2, 0x77, 0x13, 0x9a, 0xf2, 0x1c,
+	0x35, 0xb4, 0x73, 0xd4, 0xd8, 0xc1, 0xef, 0x25, 0x34, 0xe5, 0x39, 0x6a, 0x68, 0xe7, 0xa8, 0xb1,
+	0x83, 0xeb, 0x09, 0x4d, 0x79, 0x8e, 0x1a, 0xda, 0x39, 0x6a, 0xec, 0xe2, 0xf7, 0x13, 0x9a, 0xf2,
+	0x1c, 0x35, 0xb4, 0x73, 0xd4, 0xd8, 0xc5, 0x0f, 0x13, 0x9a, 0xf2, 0x1c, 0x35, 0x76, 0x37, 0x5a,
+	0xa8, 0xa2, 0xbd, 0x0b, 0xe7, 0x84, 0x13, 0xf7, 0xf5, 0x70, 0xa2, 0xd2, 0x2c, 0xd5, 0xbb, 0x3e,
+	0xa3, 0xeb, 0x71, 0xc5, 0xdb, 0xa8, 0xc8, 0x41, 0x16, 0x5c, 0xb3, 0xbf, 0x10, 0x57, 0x2d, 0xf0,
+	0xe0, 0xda, 0x65, 0x64, 0x40, 0x6b, 0x17, 0x2c, 0xfc, 0x78, 0x42, 0xcd, 0xcf, 0xa9, 0x8a, 0xcb,
+	0xb4, 0xf8, 0x21, 0x73, 0x4d, 0xfc, 0x20, 0xa2, 0x94, 0x6c, 0x32, 0x4a, 0xa9, 0x7d, 0x8a, 0x16,
+	0xbb, 0x3e, 0x8f, 0xfd, 0x40, 0x51, 0x8f, 0x13, 0x33, 0xd7, 0xc5, 0x89, 0xac, 0xed, 0x27, 0xa6,
+	0x1f, 0xa8, 0x4f, 0x1a, 0x68, 0x7b, 0xc8, 0x21, 0x12, 0xda, 0x6a, 0xbf, 0xcf, 0xb0, 0x7e, 0x73,
+	0x2f, 0x16, 0x76, 0xa7, 0x08, 0x9b, 0x08, 0xf5, 0x27, 0x4e, 0x5f, 0x74, 0x17, 0xae, 0x03, 0x0f,
+	0x10, 0x22, 0x2c, 0x91, 0x4e, 0xe6, 0xae, 0xed, 0xe4, 0x3a, 0xff, 0xe6, 0xca, 0x43, 0x07, 0x79,
+	0xa0, 0x4d, 0x3f, 0x83, 0x2f, 0xad, 0xda, 0x01, 0x0b, 0x73, 0x09, 0xbb, 0xf3, 0x1c, 0xda, 0x0f,
+	0xc2, 0xe9, 0x6c, 0xd3, 0xa1, 0x39, 0x39, 0xf5, 0xf5, 0xe9, 0xb4, 0x38, 0x44, 0x42, 0x5b, 0xed,
+	0x5f, 0x4b, 0xa8, 0x22, 0xee, 0x44, 0x70, 0x53, 0x91, 0x5e, 0x26, 0x4d, 0xa4, 0x97, 0x4d, 0x1b,
+	0xe9, 0xe5, 0x5e, 0x1e, 0xe9, 0x31, 0xf5, 0x33, 0x17, 0xe7, 0xa3, 0xed, 0x9e, 0xb9, 0x44, 0x58,
+	0x8c, 0x06, 0xaa, 0xf0, 0x5f, 0xc4, 0x1d, 0x3b, 0x96, 0x08, 0x09, 0x57, 0x66, 0xd3, 0x6a, 0x65,
+	0xa0, 0x60, 0xa2, 0x73, 0xd8, 0xec, 0xb6, 0xa9, 0x39, 0x84, 0x90, 0x4f, 0x0b, 0x0e, 0x2d, 0x81,
+	0x11, 0x69, 0x35, 0xea, 0x08, 0xf1, 0xdf, 0x10, 0x18, 0xf1, 0xcf, 0x8a, 0xe5, 0xd9, 0xb4, 0x8a,
+	0x2c, 0x89, 0x12, 0x8d, 0xc1, 0x3a, 0x23, 0x7c, 0x47, 0xe6, 0x80, 0x8a, 0x30, 0x11, 0x3a, 0x63,
+	0x29, 0x98, 0xe8, 0x1c, 0x16, 0x47, 0x69, 0x8f, 0x4d, 0x11, 0x32, 0x42, 0x1c, 0xa5, 0xf9, 0x34,
+	0x49, 0x84, 0xc5, 0xa6, 0x91, 0x3f, 0x37, 0x65, 0xf4, 0x08, 0xd3, 0x68, 0x85, 0x20, 0x51, 0x76,
+	0xd5, 0xab, 0x26, 0x0c, 0xa3, 0x12, 0xef, 0x15, 0xc0, 0x44, 0xe7, 0xa8, 0x5e, 0x35, 0xf9, 0x48,
+	0x16, 0xe3, 0xbd, 0xe2, 0x38, 0x89, 0xb0, 0x8c, 0x7d, 0xb4, 0xa4, 0x3f, 0x37, 0x45, 0x28, 0x09,
+	0xa9, 0x00, 0xdd, 0xad, 0x49, 0xa2, 0xbc, 0x39, 0xe1, 0xdc, 0x72, 0xea, 0x70, 0x8e, 0xf9, 0x76,
+	0x4d, 0x27, 0xb8, 0x70, 0x3b, 0xcf, 0xc6, 0x4f, 0x9f, 0x0e, 0x79, 0x64, 0x19, 0x7e, 0x34, 0x46,
+	0x2c, 0x24, 0xc6, 0x64, 0xeb, 0x2b, 0x90, 0x63, 0xc7, 0x82, 0x60, 0xb3, 0xcc, 0xd7, 0xb7, 0x27,
+	0x51, 0xa2, 0x31, 0xd8, 0xb4, 0xff, 0x84, 0x4e, 0x3a, 0x81, 0x19, 0x8c, 0x7d, 0x7c, 0x4b, 0x4d,
+	0xfb, 0xf3, 0x10, 0x24, 0xca, 0xce, 0xb6, 0x19, 0xdb, 0x75, 0xa7, 0x2c, 0x12, 0x37, 0xd4, 0x36,
+	0x1b, 0x08, 0x8c, 0x48, 0x2b, 0x5b, 0xa0, 0x8e, 0xed, 0x0c, 0x86, 0x14, 0xbe, 0xe0, 0x44, 0x40,
+	0x09, 0x0b, 0xe4, 0x2b, 0x98, 0xe8, 0x1c, 0xe6, 0x02, 0xe7, 0x93, 0xbf, 0xd3, 0xf1, 0x9a, 0x72,
+	0x09, 0x14, 0x4c, 0x74, 0x8e, 0x72, 0x81, 0x97, 0x3b, 0x7e, 0x3d, 0xee, 0x62, 0xbe, 0xd0, 0x5c,
+	0xe0, 0x81, 0xcd, 0x2d, 0x3c, 0x5e, 0xd8, 0x61, 0x43, 0x77, 0xd4, 0xba, 0x04, 0x11, 0x0b, 0x89,
+	0x31, 0x23, 0xbe, 0xbc, 0xc5, 0xbb, 0x73, 0x7c, 0x79, 0xa3, 0x31, 0xa6, 0xf1, 0x21, 0x5a, 0x01,
+	0xe4, 0xdc, 0xb4, 0x3d, 0xd1, 0x30, 0x8f, 0x4b, 0x6f, 0xcf, 0xa6, 0xd5, 0x95, 0x20, 0x6a, 0x22,
+	0x71, 0x6e, 0xd4, 0x9d, 0xb7, 0xbd, 0x3e, 0xcf, 0x9d, 0x37, 0x1e, 0xe7, 0xb2, 0x55, 0xe6, 0xfd,
+	0x61, 0xef, 0xa8, 0x0d, 0xb5, 0xca, 0x41, 0x08, 0x12, 0x65, 0x97, 0xb3, 0x2a, 0x36, 0xc5, 0x1b,
+	0xb1, 0x59, 0xe5, 0x30, 0xd1, 0x39, 0x52, 0xbf, 0xe3, 0x7a, 0x01, 0xbe, 0x17, 0xd3, 0x67, 0x20,
+	0x51, 0x76, 0xb6, 0x45, 0xe5, 0x43, 0x53, 0xc4, 0xb2, 0xb0, 0x45, 0x25, 0xbb, 0x49, 0x34, 0x06,
+	0x3b, 0x83, 0x84, 0xfa, 0xd4, 0xfb, 0x9c, 0x5a, 0x80, 0x8a, 0xa0, 0x16, 0xce, 0xa0, 0xa7, 0x1b,
+	0x48, 0x94, 0x67, 0xfc, 0x1c, 0xe1, 0x08, 0x70, 0x6e, 0x7a, 0xd4, 0x11, 0x1f, 0x9e, 0x55, 0xf8,
+	0xf0, 0xbc, 0x37, 0x9b, 0x56, 0xb1, 0x77, 0x05, 0x87, 0x5c, 0xe9, 0x9d, 0x50, 0x0e, 0xbf, 0xa8,
+	0x99, 0xf2, 0xd6, 0x15, 0xca, 0x1a, 0x87, 0x5c, 0xe9, 0x6d, 0x7c, 0x84, 0x96, 0x00, 0x6b, 0x07,
+	0xc7, 0x2f, 0x02, 0xea, 0x58, 0xf8, 0x4d, 0x91, 0x03, 0x8c, 0xa0, 0x7c, 0xf0, 0x81, 0x0e, 0x91,
+	0xa8, 0x5f, 0xed, 0x57, 0xd9, 0x98, 0x12, 0x7b, 0x03, 0x9e, 0xd1, 0x00, 0xee, 0x22, 0x78, 0x6b,
+	0x66, 0xd4, 0x1b, 0xd0, 0xd1, 0x70, 0x12, 0x61, 0xb1, 0x4d, 0x7f, 0xfe, 0xcc, 0x75, 0xa8, 0xf2,
+	0xe3, 0xf9, 0x34, 0xd8, 0xf4, 0x97, 0x11, 0x0b, 0x89, 0x31, 0xe5, 0xb6, 0xd0, 0xd2, 0x6b, 0x6a,
+	0x5b, 0x80, 0x87, 0xb2, 0xb3, 0xeb, 0xf6, 0x8c, 0x06, 0x32, 0x5f, 0x22, 0x92, 0x11, 0x0e, 0x87,
+	0x48, 0x68, 0x63, 0x9a, 0xd0, 0x0a, 0x10, 0x0b, 0x4a, 0xf3, 0x32, 0x04, 0x89, 0xb2, 0xd7, 0x7e,
+	0x9d, 0x65, 0xd9, 0xd0, 0x16, 0x0d, 0x02, 0xdb, 0x19, 0xc0, 0xc5, 0xff, 0x21, 0x5a, 0x69, 0xd1,
+	0xa0, 0x43, 0x3d, 0xdb, 0x1c, 0x9e, 0x8d, 0xd9, 0xbc, 0xe3, 0x8c, 0x3a, 0x48, 0xbd, 0xa8, 0x89,
+	0xc4, 0xb9, 0xda, 0xfd, 0x9d, 0x4d, 0x7b, 0x7f, 0xe7, 0x52, 0xdc, 0xdf, 0x7b, 0xa8, 0xd8, 0xa2,
+	0x01, 0xdb, 0x3d, 0x79, 0xf8, 0x80, 0x37, 0xea, 0xb2, 0xd7, 0x27, 0xce, 0x53, 0xf7, 0x24, 0xa0,
+	0x23, 0xde, 0x54, 0x0f, 0x58, 0x44, 0xb0, 0xd9, 0x51, 0x3a, 0x72, 0x47, 0x23, 0xdb, 0xf7, 0x6d,
+	0xd7, 0xc1, 0x05, 0x75, 0x94, 0xfa, 0x12, 0x25, 0x1a, 0xa3, 0x36, 0x42, 0xb7, 0x12, 0xc2, 0x6c,
+	0xe2, 0x41, 0x8e, 0x9a, 0x7a, 0x08, 0xd5, 0xe3, 0x10, 0x09, 0x6d, 0x6c, 0x58, 0x8f, 0x2d, 0x8b,
+	0x5d, 0x55, 0xae, 0x43, 0x27, 0x38, 0xab, 0x86, 0x65, 0x2a, 0x98, 0xe8, 0x9c, 0xda, 0x3f, 0xb3,
+	0x5a, 0x7b, 0x32, 0x92, 0x7c, 0x85, 0xb1, 0x97, 0x4a, 0x9c, 0xe5, 0x52, 0x25, 0xce, 0xf2, 0xd7,
+	0x26, 0xce, 0xae, 0xc9, 0xe3, 0xce, 0xd9, 0x36, 0xc5, 0x1b, 0x6c, 0x1b, 0x2d, 0x49, 0x54, 0xba,
+	0x26, 0x49, 0x74, 0x20, 0xb7, 0x41, 0x19, 0xb6, 0xc1, 0x5a, 0x5d, 0x9f, 0xbd, 0xf1, 0x30, 0xb8,
+	0x6a, 0x23, 0xd4, 0xfe, 0x94, 0x41, 0xb7, 0xe7, 0x70, 0xbf, 0xbb, 0xb5, 0x0d, 0x27, 0x2b, 0x37,
+	0x67, 0xb2, 0xc2, 0x8a, 0x43, 0x7e, 0x6e, 0xc5, 0x61, 0x1d, 0xe5, 0x58, 0xb9, 0xa1, 0xa0, 0x12,
+	0xe9, 0xac, 0xd4, 0xc0, 0xb0, 0xda, 0x1f, 0x72, 0x68, 0xad, 0xeb, 0xb3, 0x2d, 0xc0, 0x87, 0xf0,
+	0x1d, 0x6d, 0x19, 0x2e, 0xae, 0x6f, 0x19, 0x8f, 0x37, 0x27, 0x2c, 0x6c, 0x3a, 0xce, 0x87, 0xe6,
+	0x84, 0x7a, 0x9d, 0xbe, 0xeb, 0x85, 0xe3, 0x80, 0xe9, 0xb8, 0x54, 0x30, 0xd1, 0x39, 0xcc, 0xa5,
+	0x65, 0x3a, 0xcf, 0x43, 0x17, 0x2d, 0x68, 0xef, 0x29, 0x98, 0xe8, 0x1c, 0xe3, 0x58, 0x66, 0xd1,
+	0x8a, 0xb0, 0xda, 0x6f, 0xd6, 0xe7, 0x8d, 0xbd, 0xce, 0x39, 0x91, 0xcc, 0x5d, 0x24, 0xd1, 0xb6,
+	0x8f, 0x96, 0xba, 0xb6, 0x23, 0x56, 0x92, 0xed, 0x9d, 0x12, 0x5c, 0x40, 0x70, 0x43, 0x7c, 0xa1,
+	0x1b, 0x48, 0x94, 0xb7, 0x71, 0x88, 0x2a, 0x5c, 0xe2, 0xe6, 0x89, 0xf5, 0xbf, 0xe4, 0x90, 0xd1,
+	0xf5, 0xcf, 0xcd, 0x89, 0x3b, 0x0e, 0x78, 0x77, 0xff, 0xbb, 0x4f, 0xb6, 0x9e, 0x12, 0x2f, 0x5c,
+	0x9b, 0x12, 0x67, 0x4c, 0xb6, 0xbf, 0xbb, 0xb6, 0x83, 0x8b, 0xea, 0x3b, 0x75, 0x24, 0x30, 0x22,
+	0xad, 0xec, 0x3a, 0x0d, 0x7f, 0x3f, 0x71, 0x7d, 0x1f, 0x97, 0xd4, 0x75, 0x3a, 0xd2, 0x70, 0x12,
+	0x61, 0x19, 0x1d, 0x84, 0xda, 0x81, 0x6c, 0x81, 0x1f, 0xf3, 0x07, 0xf5, 0xe4, 0x5c, 0xd6, 0x15,
+	0x8b, 0x2f, 0x3d, 0xff, 0x48, 0x93, 0x20, 0xd1, 0x64, 0x36, 0x3e, 0x44, 0x2b, 0x31, 0xfa, 0xcb,
+	0x56, 0x73, 0x41, 0x5f, 0xcd, 0xdf, 0xe4, 0xc4, 0x2d, 0x79, 0x61, 0x8f, 0xfe, 0x27, 0x3f, 0x8f,
+	0x77, 0xd1, 0xa2, 0x18, 0x1d, 0xcf, 0x6f, 0xf3, 0x57, 0x37, 0x2c, 0x55, 0x4f, 0xc3, 0x49, 0x84,
+	0x25, 0x3e, 0xe1, 0xf8, 0xb3, 0x13, 0x50, 0x27, 0xc0, 0x25, 0x55, 0xe1, 0xed, 0x45, 0x2c, 0x24,
+	0xc6, 0x84, 0x78, 0xdd, 0x1e, 0x51, 0xf8, 0xaa, 0xf3, 0xf9, 0xf7, 0x5f, 0x59, 0x8b, 0xd7, 0xa3,
+	0x26, 0x12, 0xe7, 0xd6, 0xfe, 0x58, 0x61, 0x2f, 0xc2, 0xb0, 0x00, 0x76, 0xea, 0x5a, 0xf6, 0xd3,
+	0x49, 0xea, 0x85, 0xd1, 0x37, 0x7b, 0xf6, 0xda, 0xcd, 0x3e, 0x9e, 0x5b, 0x23, 0xcc, 0xc1, 0xa6,
+	0x7c, 0xaf, 0x3e, 0xaf, 0x03, 0xaf, 0xa4, 0x50, 0xa8, 0x97, 0x06, 0xf2, 0xa9, 0x4b, 0x03, 0x85,
+	0xd4, 0xa5, 0x81, 0x62, 0xea, 0xd2, 0x40, 0x29, 0x75, 0x69, 0xa0, 0x9c, 0xba, 0x34, 0xb0, 0x90,
+	0xba, 0x34, 0x80, 0x52, 0x97, 0x06, 0x2a, 0xa9, 0x4b, 0x03, 0x8b, 0xa9, 0x4b, 0x03, 0x4b, 0xa9,
+	0x4b, 0x03, 0xcb, 0xa9, 0x4b, 0x03, 0x2b, 0xa9, 0x4b, 0x03, 0xab, 0xa9, 0x4b, 0x03, 0xb7, 0x52,
+	0x97, 0x06, 0x8c, 0xd4, 0xa5, 0x81, 0xdb, 0xa9, 0x4b, 0x03, 0x6b, 0xa9, 0x4b, 0x03, 0xaf, 0xa7,
+	0x2e, 0x0d, 0xdc, 0x49, 0x5d, 0x1a, 0xb8, 0x9b, 0xba, 0x34, 0x80, 0x53, 0x97, 0x06, 0xd6, 0x53,
+	0x97, 0x06, 0x36, 0x52, 0x97, 0x06, 0xde, 0x48, 0x5d, 0x1a, 0xb8, 0x97, 0xba, 0x34, 0x70, 0x3f,
+	0x75, 0x69, 0x60, 0x33, 0x75, 0x69, 0xa0, 0x7a, 0x6d, 0x69, 0xe0, 0x15, 0xfd, 0xd7, 0xc1, 0x09,
+	0xcb, 0x52, 0xc3, 0x9d, 0xf6, 0xc4, 0xf6, 0x79, 0x60, 0xb4, 0x87, 0xca, 0x00, 0xb0, 0xf0, 0x8c,
+	0xff, 0x0b, 0xc6, 0x62, 0x5d, 0x70, 0xd8, 0x67, 0x98, 0x48, 0xa3, 0x09, 0x06, 0x91, 0xdc, 0xda,
+	0x97, 0x79, 0x96, 0xb6, 0x96, 0xbc, 0xb4, 0xc5, 0x83, 0xc8, 0x9d, 0x9b, 0x7d, 0xc9, 0x9d, 0x1b,
+	0x4b, 0xd5, 0xe5, 0x6e, 0x9e, 0xaa, 0xcb, 0xdf, 0x3c, 0x55, 0x57, 0xf8, 0x8f, 0x52, 0x75, 0xc5,
+	0x6f, 0x91, 0xaa, 0x2b, 0x7d, 0x9b, 0x54, 0x5d, 0xf9, 0xdb, 0xa5, 0xea, 0x16, 0x6e, 0x90, 0xaa,
+	0x8b, 0x65, 0xdf, 0xd0, 0xcb, 0xb3, 0x6f, 0xad, 0xda, 0x9f, 0xbf, 0xde, 0xcc, 0x7c, 0xf5, 0xf5,
+	0x66, 0xe6, 0xef, 0x5f, 0x6f, 0x66, 0x7e, 0xfb, 0xcd, 0xe6, 0x6b, 0x5f, 0x7d, 0xb3, 0xf9, 0xda,
+	0xdf, 0xbe, 0xd9, 0x7c, 0xed, 0x17, 0xe5, 0xfa, 0xfb, 0x3f, 0x80, 0x7f, 0x6b, 0xeb, 0x15, 0xe1,
+	0xcf, 0xce, 0xbf, 0x07, 0x00, 0x94, 0x94, 0x00, 0xf1, 0x28, 0x27, 0x00, 0x00,
+}
+
+func (m *WsFrame) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsFrame) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsFrame) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Notice != nil {
+		{
+			size, err := m.Notice.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Json {
+		i--
+		if m.Json {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Seq != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Seq))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Protocol != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Protocol))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsConnMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsConnMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsConnMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ErrMsg) > 0 {
+		i -= len(m.ErrMsg)
+		copy(dAtA[i:], m.ErrMsg)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.ErrMsg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Code != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsAuthData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsAuthData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsAuthData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Password) > 0 {
+		i -= len(m.Password)
+		copy(dAtA[i:], m.Password)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Password)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Account) > 0 {
+		i -= len(m.Account)
+		copy(dAtA[i:], m.Account)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Account)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Sid) > 0 {
+		i -= len(m.Sid)
+		copy(dAtA[i:], m.Sid)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Sid)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsAuthResp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsAuthResp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsAuthResp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ResumeID) > 0 {
+		i -= len(m.ResumeID)
+		copy(dAtA[i:], m.ResumeID)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.ResumeID)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.BValidPassword {
+		i--
+		if m.BValidPassword {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BOk {
+		i--
+		if m.BOk {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Sid) > 0 {
+		i -= len(m.Sid)
+		copy(dAtA[i:], m.Sid)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Sid)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.UserName) > 0 {
+		i -= len(m.UserName)
+		copy(dAtA[i:], m.UserName)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.UserName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Account) > 0 {
+		i -= len(m.Account)
+		copy(dAtA[i:], m.Account)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Account)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsJoinTableData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsJoinTableData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsJoinTableData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.DtBetLimitSelectID) > 0 {
+		for k := range m.DtBetLimitSelectID {
+			v := m.DtBetLimitSelectID[k]
+			baseI := i
+			i = encodeVarintWs(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintWs(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintWs(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsTableEntryData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsTableEntryData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsTableEntryData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBet14 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet14))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.MinBet14 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet14))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.MaxBet13 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet13))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.MinBet13 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet13))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.MaxBet12 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet12))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.MinBet12 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet12))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.MaxBet11 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet11))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.MinBet11 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet11))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.MaxBet10 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet10))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.MinBet10 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet10))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.MaxBet09 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet09))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.MinBet09 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet09))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.MaxBet08 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet08))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.MinBet08 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet08))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.MaxBet07 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet07))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.MinBet07 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet07))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.MaxBet06 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet06))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.MinBet06 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet06))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.MaxBet05 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet05))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.MinBet05 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet05))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.MaxBet04 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet04))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.MinBet04 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet04))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.MaxBet03 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet03))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.MinBet03 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet03))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.MaxBet02 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet02))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.MinBet02 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet02))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.MaxBet01 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet01))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.MinBet01 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet01))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.DtCard) > 0 {
+		i -= len(m.DtCard)
+		copy(dAtA[i:], m.DtCard)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.DtCard)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.UserCount != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.UserCount))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if len(m.DtOdds) > 0 {
+		for k := range m.DtOdds {
+			v := m.DtOdds[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintWs(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintWs(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintWs(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if m.BetMilliSecond != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BetMilliSecond))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.Chips != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Chips))))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x81
+	}
+	if m.Balance != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Balance))))
+		i--
+		dAtA[i] = 0x79
+	}
+	if m.CurrencyRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CurrencyRate))))
+		i--
+		dAtA[i] = 0x71
+	}
+	if len(m.CurrencyName) > 0 {
+		i -= len(m.CurrencyName)
+		copy(dAtA[i:], m.CurrencyName)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.CurrencyName)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.CurrencyCode) > 0 {
+		i -= len(m.CurrencyCode)
+		copy(dAtA[i:], m.CurrencyCode)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.CurrencyCode)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.SeatIDArr) > 0 {
+		dAtA4 := make([]byte, len(m.SeatIDArr)*10)
+		var j3 int
+		for _, num1 := range m.SeatIDArr {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintWs(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.HeadID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.HeadID))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.UserName) > 0 {
+		i -= len(m.UserName)
+		copy(dAtA[i:], m.UserName)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.UserName)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Account) > 0 {
+		i -= len(m.Account)
+		copy(dAtA[i:], m.Account)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Account)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.AreaType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AreaType))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.AreaID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AreaID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.GroupType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupType))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BOk {
+		i--
+		if m.BOk {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsOdds) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsOdds) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsOdds) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Odds) > 0 {
+		for iNdEx := len(m.Odds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Odds[iNdEx])
+			copy(dAtA[i:], m.Odds[iNdEx])
+			i = encodeVarintWs(dAtA, i, uint64(len(m.Odds[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsLeaveTableData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsLeaveTableData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsLeaveTableData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BOk {
+		i--
+		if m.BOk {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsResumeData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsResumeData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsResumeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastSeq != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.LastSeq))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ResumeID) > 0 {
+		i -= len(m.ResumeID)
+		copy(dAtA[i:], m.ResumeID)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.ResumeID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsResumeRespData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsResumeRespData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsResumeRespData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Seq != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Seq))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ResumeID) > 0 {
+		i -= len(m.ResumeID)
+		copy(dAtA[i:], m.ResumeID)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.ResumeID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Resync {
+		i--
+		if m.Resync {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BOk {
+		i--
+		if m.BOk {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsReconnectData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsReconnectData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsReconnectData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DelayMs != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.DelayMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsTableData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsTableData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsTableData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TableDtExtend != nil {
+		{
+			size, err := m.TableDtExtend.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x8a
+	}
+	if len(m.ReservedTableMemberIDArr) > 0 {
+		dAtA7 := make([]byte, len(m.ReservedTableMemberIDArr)*10)
+		var j6 int
+		for _, num1 := range m.ReservedTableMemberIDArr {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		i -= j6
+		copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintWs(dAtA, i, uint64(j6))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x82
+	}
+	if len(m.ReservedTableParentIDArr) > 0 {
+		dAtA9 := make([]byte, len(m.ReservedTableParentIDArr)*10)
+		var j8 int
+		for _, num1 := range m.ReservedTableParentIDArr {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j8++
+			}
+			dAtA9[j8] = uint8(num)
+			j8++
+		}
+		i -= j8
+		copy(dAtA[i:], dAtA9[:j8])
+		i = encodeVarintWs(dAtA, i, uint64(j8))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xfa
+	}
+	if m.ReservedTable != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.ReservedTable))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.TableSort2 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableSort2))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.TableSort != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableSort))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.TableStatus != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableStatus))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.TableType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableType))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.TablePairMaxBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TablePairMaxBet))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.TablePairMinBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TablePairMinBet))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.TableTieMaxBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableTieMaxBet))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.TableTieMinBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableTieMinBet))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.TableMaxBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableMaxBet))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.TableMinBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableMinBet))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.SingleLimit != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.SingleLimit))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.GameMode != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameMode))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.KeyStatus != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.KeyStatus))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.BWantToEnd {
+		i--
+		if m.BWantToEnd {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.BWantToShuffle {
+		i--
+		if m.BWantToShuffle {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.BetMilliSecond != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BetMilliSecond))
+		i--
+		dAtA[i] = 0x70
+	}
+	if len(m.Dealer2Image2) > 0 {
+		i -= len(m.Dealer2Image2)
+		copy(dAtA[i:], m.Dealer2Image2)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Dealer2Image2)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.Dealer2Image) > 0 {
+		i -= len(m.Dealer2Image)
+		copy(dAtA[i:], m.Dealer2Image)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Dealer2Image)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.Dealer2Name) > 0 {
+		i -= len(m.Dealer2Name)
+		copy(dAtA[i:], m.Dealer2Name)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Dealer2Name)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.Dealer2ID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Dealer2ID))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.DealerImage2) > 0 {
+		i -= len(m.DealerImage2)
+		copy(dAtA[i:], m.DealerImage2)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.DealerImage2)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.DealerImage) > 0 {
+		i -= len(m.DealerImage)
+		copy(dAtA[i:], m.DealerImage)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.DealerImage)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.DealerName) > 0 {
+		i -= len(m.DealerName)
+		copy(dAtA[i:], m.DealerName)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.DealerName)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.DealerID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.DealerID))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.GameNoRound != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameNoRound))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.GameNo != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameNo))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.GroupType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupType))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TableDtExtend) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableDtExtend) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableDtExtend) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PhoneType) > 0 {
+		i -= len(m.PhoneType)
+		copy(dAtA[i:], m.PhoneType)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.PhoneType)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.NetType) > 0 {
+		i -= len(m.NetType)
+		copy(dAtA[i:], m.NetType)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.NetType)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TableName) > 0 {
+		i -= len(m.TableName)
+		copy(dAtA[i:], m.TableName)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.TableName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PhoneGroupName) > 0 {
+		i -= len(m.PhoneGroupName)
+		copy(dAtA[i:], m.PhoneGroupName)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.PhoneGroupName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NetGroupName) > 0 {
+		i -= len(m.NetGroupName)
+		copy(dAtA[i:], m.NetGroupName)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.NetGroupName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsBettingData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsBettingData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsBettingData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Commission != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Commission))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.BetArr) > 0 {
+		for iNdEx := len(m.BetArr) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BetArr[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.GameNoRound != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameNoRound))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GameNo != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameNo))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BetSerialNumber != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BetSerialNumber))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsBettingInfoItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsBettingInfoItem) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsBettingInfoItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AddBetMoney != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AddBetMoney))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BetArea != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BetArea))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsBettingRespData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsBettingRespData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsBettingRespData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BetArr) > 0 {
+		for iNdEx := len(m.BetArr) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BetArr[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.Balance != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Balance))))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.BetSerialNumber != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BetSerialNumber))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BOk {
+		i--
+		if m.BOk {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.AreaType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AreaType))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.AreaID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AreaID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsBettingResultItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsBettingResultItem) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsBettingResultItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Code != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.BOk {
+		i--
+		if m.BOk {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.AddBetMoney != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AddBetMoney))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BetArea != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BetArea))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsGameResultRespData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsGameResultRespData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsGameResultRespData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.WinBetAreaArr) > 0 {
+		dAtA11 := make([]byte, len(m.WinBetAreaArr)*10)
+		var j10 int
+		for _, num1 := range m.WinBetAreaArr {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		i -= j10
+		copy(dAtA[i:], dAtA11[:j10])
+		i = encodeVarintWs(dAtA, i, uint64(j10))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.DtCard) > 0 {
+		for k := range m.DtCard {
+			v := m.DtCard[k]
+			baseI := i
+			i = encodeVarintWs(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintWs(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintWs(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.BankerScore != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BankerScore))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.PlayerScore != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.PlayerScore))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Result != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.Result))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsPayoutResultData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsPayoutResultData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsPayoutResultData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DtMoneyWin) > 0 {
+		for k := range m.DtMoneyWin {
+			v := m.DtMoneyWin[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintWs(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintWs(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintWs(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.MoneyWinLoss) > 0 {
+		i -= len(m.MoneyWinLoss)
+		copy(dAtA[i:], m.MoneyWinLoss)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.MoneyWinLoss)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.MoneyWin) > 0 {
+		i -= len(m.MoneyWin)
+		copy(dAtA[i:], m.MoneyWin)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.MoneyWin)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.AreaType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AreaType))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.AreaID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.AreaID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsBetTimeData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsBetTimeData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsBetTimeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimeMillisecond != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TimeMillisecond))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.BetTimeContent) > 0 {
+		i -= len(m.BetTimeContent)
+		copy(dAtA[i:], m.BetTimeContent)
+		i = encodeVarintWs(dAtA, i, uint64(len(m.BetTimeContent)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.BetTimeCount != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.BetTimeCount))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.GameNoRound != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameNoRound))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.GameNo != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameNo))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.GroupType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupType))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsBetLimitModifyData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsBetLimitModifyData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsBetLimitModifyData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBet14 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet14))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.MinBet14 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet14))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.MaxBet13 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet13))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.MinBet13 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet13))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.MaxBet12 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet12))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.MinBet12 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet12))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.MaxBet11 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet11))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.MinBet11 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet11))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.MaxBet10 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet10))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.MinBet10 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet10))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.MaxBet09 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet09))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.MinBet09 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet09))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.MaxBet08 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet08))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.MinBet08 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet08))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.MaxBet07 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet07))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.MinBet07 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet07))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.MaxBet06 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet06))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.MinBet06 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet06))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.MaxBet05 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet05))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.MinBet05 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet05))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.MaxBet04 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet04))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.MinBet04 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet04))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.MaxBet03 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet03))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.MinBet03 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet03))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.MaxBet02 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet02))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.MinBet02 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet02))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.MaxBet01 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MaxBet01))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MinBet01 != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MinBet01))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.DtBetLimitSelectID) > 0 {
+		for k := range m.DtBetLimitSelectID {
+			v := m.DtBetLimitSelectID[k]
+			baseI := i
+			i = encodeVarintWs(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintWs(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintWs(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GameID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GameID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsGroupListData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsGroupListData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsGroupListData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.GroupArr) > 0 {
+		for iNdEx := len(m.GroupArr) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.GroupArr[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WsGroupInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WsGroupInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WsGroupInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TableStatus != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableStatus))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.TablePairMaxBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TablePairMaxBet))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.TablePairMinBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TablePairMinBet))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.TableTieMaxBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableTieMaxBet))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.TableTieMinBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableTieMinBet))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.TableMaxBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableMaxBet))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.TableMinBet != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.TableMinBet))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SingleLimit != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.SingleLimit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.GroupType != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupType))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintWs(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWs(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWs(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *WsFrame) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Protocol != 0 {
+		n += 1 + sovWs(uint64(m.Protocol))
+	}
+	if m.Seq != 0 {
+		n += 1 + sovWs(uint64(m.Seq))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.Json {
+		n += 2
+	}
+	if m.Notice != nil {
+		l = m.Notice.Size()
+		n += 1 + l + sovWs(uint64(l))
+	}
+	return n
+}
+
+func (m *WsConnMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovWs(uint64(m.Code))
+	}
+	l = len(m.ErrMsg)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	return n
+}
+
+func (m *WsAuthData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Sid)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.Account)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.Password)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	return n
+}
+
+func (m *WsAuthResp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		n += 1 + sovWs(uint64(m.MemberID))
+	}
+	l = len(m.Account)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.UserName)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.Sid)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.BOk {
+		n += 2
+	}
+	if m.BValidPassword {
+		n += 2
+	}
+	l = len(m.ResumeID)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	return n
+}
+
+func (m *WsJoinTableData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DtBetLimitSelectID) > 0 {
+		for k, v := range m.DtBetLimitSelectID {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovWs(uint64(len(k))) + 1 + sovWs(uint64(v))
+			n += mapEntrySize + 1 + sovWs(uint64(mapEntrySize))
+		}
+	}
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	return n
+}
+
+func (m *WsTableEntryData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BOk {
+		n += 2
+	}
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.GroupType != 0 {
+		n += 1 + sovWs(uint64(m.GroupType))
+	}
+	if m.AreaID != 0 {
+		n += 1 + sovWs(uint64(m.AreaID))
+	}
+	if m.AreaType != 0 {
+		n += 1 + sovWs(uint64(m.AreaType))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovWs(uint64(m.MemberID))
+	}
+	l = len(m.Account)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.UserName)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.HeadID != 0 {
+		n += 1 + sovWs(uint64(m.HeadID))
+	}
+	if len(m.SeatIDArr) > 0 {
+		l = 0
+		for _, e := range m.SeatIDArr {
+			l += sovWs(uint64(e))
+		}
+		n += 1 + sovWs(uint64(l)) + l
+	}
+	l = len(m.CurrencyCode)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.CurrencyName)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.CurrencyRate != 0 {
+		n += 9
+	}
+	if m.Balance != 0 {
+		n += 9
+	}
+	if m.Chips != 0 {
+		n += 10
+	}
+	if m.BetMilliSecond != 0 {
+		n += 2 + sovWs(uint64(m.BetMilliSecond))
+	}
+	if len(m.DtOdds) > 0 {
+		for k, v := range m.DtOdds {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovWs(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovWs(uint64(len(k))) + l
+			n += mapEntrySize + 2 + sovWs(uint64(mapEntrySize))
+		}
+	}
+	if m.UserCount != 0 {
+		n += 2 + sovWs(uint64(m.UserCount))
+	}
+	l = len(m.DtCard)
+	if l > 0 {
+		n += 2 + l + sovWs(uint64(l))
+	}
+	if m.MinBet01 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet01))
+	}
+	if m.MaxBet01 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet01))
+	}
+	if m.MinBet02 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet02))
+	}
+	if m.MaxBet02 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet02))
+	}
+	if m.MinBet03 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet03))
+	}
+	if m.MaxBet03 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet03))
+	}
+	if m.MinBet04 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet04))
+	}
+	if m.MaxBet04 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet04))
+	}
+	if m.MinBet05 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet05))
+	}
+	if m.MaxBet05 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet05))
+	}
+	if m.MinBet06 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet06))
+	}
+	if m.MaxBet06 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet06))
+	}
+	if m.MinBet07 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet07))
+	}
+	if m.MaxBet07 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet07))
+	}
+	if m.MinBet08 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet08))
+	}
+	if m.MaxBet08 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet08))
+	}
+	if m.MinBet09 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet09))
+	}
+	if m.MaxBet09 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet09))
+	}
+	if m.MinBet10 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet10))
+	}
+	if m.MaxBet10 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet10))
+	}
+	if m.MinBet11 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet11))
+	}
+	if m.MaxBet11 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet11))
+	}
+	if m.MinBet12 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet12))
+	}
+	if m.MaxBet12 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet12))
+	}
+	if m.MinBet13 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet13))
+	}
+	if m.MaxBet13 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet13))
+	}
+	if m.MinBet14 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet14))
+	}
+	if m.MaxBet14 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet14))
+	}
+	return n
+}
+
+func (m *WsOdds) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Odds) > 0 {
+		for _, s := range m.Odds {
+			l = len(s)
+			n += 1 + l + sovWs(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WsLeaveTableData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.BOk {
+		n += 2
+	}
+	return n
+}
+
+func (m *WsResumeData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ResumeID)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.LastSeq != 0 {
+		n += 1 + sovWs(uint64(m.LastSeq))
+	}
+	return n
+}
+
+func (m *WsResumeRespData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BOk {
+		n += 2
+	}
+	if m.Resync {
+		n += 2
+	}
+	l = len(m.ResumeID)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.Seq != 0 {
+		n += 1 + sovWs(uint64(m.Seq))
+	}
+	return n
+}
+
+func (m *WsReconnectData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DelayMs != 0 {
+		n += 1 + sovWs(uint64(m.DelayMs))
+	}
+	return n
+}
+
+func (m *WsTableData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.GroupType != 0 {
+		n += 1 + sovWs(uint64(m.GroupType))
+	}
+	if m.GameNo != 0 {
+		n += 1 + sovWs(uint64(m.GameNo))
+	}
+	if m.GameNoRound != 0 {
+		n += 1 + sovWs(uint64(m.GameNoRound))
+	}
+	if m.DealerID != 0 {
+		n += 1 + sovWs(uint64(m.DealerID))
+	}
+	l = len(m.DealerName)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.DealerImage)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.DealerImage2)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.Dealer2ID != 0 {
+		n += 1 + sovWs(uint64(m.Dealer2ID))
+	}
+	l = len(m.Dealer2Name)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.Dealer2Image)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.Dealer2Image2)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.BetMilliSecond != 0 {
+		n += 1 + sovWs(uint64(m.BetMilliSecond))
+	}
+	if m.BWantToShuffle {
+		n += 2
+	}
+	if m.BWantToEnd {
+		n += 3
+	}
+	if m.KeyStatus != 0 {
+		n += 2 + sovWs(uint64(m.KeyStatus))
+	}
+	if m.GameMode != 0 {
+		n += 2 + sovWs(uint64(m.GameMode))
+	}
+	if m.SingleLimit != 0 {
+		n += 2 + sovWs(uint64(m.SingleLimit))
+	}
+	if m.TableMinBet != 0 {
+		n += 2 + sovWs(uint64(m.TableMinBet))
+	}
+	if m.TableMaxBet != 0 {
+		n += 2 + sovWs(uint64(m.TableMaxBet))
+	}
+	if m.TableTieMinBet != 0 {
+		n += 2 + sovWs(uint64(m.TableTieMinBet))
+	}
+	if m.TableTieMaxBet != 0 {
+		n += 2 + sovWs(uint64(m.TableTieMaxBet))
+	}
+	if m.TablePairMinBet != 0 {
+		n += 2 + sovWs(uint64(m.TablePairMinBet))
+	}
+	if m.TablePairMaxBet != 0 {
+		n += 2 + sovWs(uint64(m.TablePairMaxBet))
+	}
+	if m.TableType != 0 {
+		n += 2 + sovWs(uint64(m.TableType))
+	}
+	if m.TableStatus != 0 {
+		n += 2 + sovWs(uint64(m.TableStatus))
+	}
+	if m.TableSort != 0 {
+		n += 2 + sovWs(uint64(m.TableSort))
+	}
+	if m.TableSort2 != 0 {
+		n += 2 + sovWs(uint64(m.TableSort2))
+	}
+	if m.ReservedTable != 0 {
+		n += 2 + sovWs(uint64(m.ReservedTable))
+	}
+	if len(m.ReservedTableParentIDArr) > 0 {
+		l = 0
+		for _, e := range m.ReservedTableParentIDArr {
+			l += sovWs(uint64(e))
+		}
+		n += 2 + sovWs(uint64(l)) + l
+	}
+	if len(m.ReservedTableMemberIDArr) > 0 {
+		l = 0
+		for _, e := range m.ReservedTableMemberIDArr {
+			l += sovWs(uint64(e))
+		}
+		n += 2 + sovWs(uint64(l)) + l
+	}
+	if m.TableDtExtend != nil {
+		l = m.TableDtExtend.Size()
+		n += 2 + l + sovWs(uint64(l))
+	}
+	return n
+}
+
+func (m *TableDtExtend) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NetGroupName)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.PhoneGroupName)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.TableName)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.NetType)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.PhoneType)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	return n
+}
+
+func (m *WsBettingData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BetSerialNumber != 0 {
+		n += 1 + sovWs(uint64(m.BetSerialNumber))
+	}
+	if m.GameNo != 0 {
+		n += 1 + sovWs(uint64(m.GameNo))
+	}
+	if m.GameNoRound != 0 {
+		n += 1 + sovWs(uint64(m.GameNoRound))
+	}
+	if len(m.BetArr) > 0 {
+		for _, e := range m.BetArr {
+			l = e.Size()
+			n += 1 + l + sovWs(uint64(l))
+		}
+	}
+	if m.Commission != 0 {
+		n += 1 + sovWs(uint64(m.Commission))
+	}
+	return n
+}
+
+func (m *WsBettingInfoItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BetArea != 0 {
+		n += 1 + sovWs(uint64(m.BetArea))
+	}
+	if m.AddBetMoney != 0 {
+		n += 1 + sovWs(uint64(m.AddBetMoney))
+	}
+	return n
+}
+
+func (m *WsBettingRespData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.AreaID != 0 {
+		n += 1 + sovWs(uint64(m.AreaID))
+	}
+	if m.AreaType != 0 {
+		n += 1 + sovWs(uint64(m.AreaType))
+	}
+	if m.BOk {
+		n += 2
+	}
+	if m.BetSerialNumber != 0 {
+		n += 1 + sovWs(uint64(m.BetSerialNumber))
+	}
+	if m.Balance != 0 {
+		n += 9
+	}
+	if len(m.BetArr) > 0 {
+		for _, e := range m.BetArr {
+			l = e.Size()
+			n += 1 + l + sovWs(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WsBettingResultItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BetArea != 0 {
+		n += 1 + sovWs(uint64(m.BetArea))
+	}
+	if m.AddBetMoney != 0 {
+		n += 1 + sovWs(uint64(m.AddBetMoney))
+	}
+	if m.BOk {
+		n += 2
+	}
+	if m.Code != 0 {
+		n += 1 + sovWs(uint64(m.Code))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	return n
+}
+
+func (m *WsGameResultRespData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.Result != 0 {
+		n += 1 + sovWs(uint64(m.Result))
+	}
+	if m.PlayerScore != 0 {
+		n += 1 + sovWs(uint64(m.PlayerScore))
+	}
+	if m.BankerScore != 0 {
+		n += 1 + sovWs(uint64(m.BankerScore))
+	}
+	if len(m.DtCard) > 0 {
+		for k, v := range m.DtCard {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovWs(uint64(len(k))) + 1 + sovWs(uint64(v))
+			n += mapEntrySize + 1 + sovWs(uint64(mapEntrySize))
+		}
+	}
+	if len(m.WinBetAreaArr) > 0 {
+		l = 0
+		for _, e := range m.WinBetAreaArr {
+			l += sovWs(uint64(e))
+		}
+		n += 1 + sovWs(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *WsPayoutResultData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.AreaID != 0 {
+		n += 1 + sovWs(uint64(m.AreaID))
+	}
+	if m.AreaType != 0 {
+		n += 1 + sovWs(uint64(m.AreaType))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovWs(uint64(m.MemberID))
+	}
+	l = len(m.MoneyWin)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	l = len(m.MoneyWinLoss)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if len(m.DtMoneyWin) > 0 {
+		for k, v := range m.DtMoneyWin {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovWs(uint64(len(k))) + 1 + len(v) + sovWs(uint64(len(v)))
+			n += mapEntrySize + 1 + sovWs(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *WsBetTimeData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.GroupType != 0 {
+		n += 1 + sovWs(uint64(m.GroupType))
+	}
+	if m.GameNo != 0 {
+		n += 1 + sovWs(uint64(m.GameNo))
+	}
+	if m.GameNoRound != 0 {
+		n += 1 + sovWs(uint64(m.GameNoRound))
+	}
+	if m.BetTimeCount != 0 {
+		n += 1 + sovWs(uint64(m.BetTimeCount))
+	}
+	l = len(m.BetTimeContent)
+	if l > 0 {
+		n += 1 + l + sovWs(uint64(l))
+	}
+	if m.TimeMillisecond != 0 {
+		n += 1 + sovWs(uint64(m.TimeMillisecond))
+	}
+	return n
+}
+
+func (m *WsBetLimitModifyData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GameID != 0 {
+		n += 1 + sovWs(uint64(m.GameID))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovWs(uint64(m.MemberID))
+	}
+	if len(m.DtBetLimitSelectID) > 0 {
+		for k, v := range m.DtBetLimitSelectID {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovWs(uint64(len(k))) + 1 + sovWs(uint64(v))
+			n += mapEntrySize + 1 + sovWs(uint64(mapEntrySize))
+		}
+	}
+	if m.MinBet01 != 0 {
+		n += 1 + sovWs(uint64(m.MinBet01))
+	}
+	if m.MaxBet01 != 0 {
+		n += 1 + sovWs(uint64(m.MaxBet01))
+	}
+	if m.MinBet02 != 0 {
+		n += 1 + sovWs(uint64(m.MinBet02))
+	}
+	if m.MaxBet02 != 0 {
+		n += 1 + sovWs(uint64(m.MaxBet02))
+	}
+	if m.MinBet03 != 0 {
+		n += 1 + sovWs(uint64(m.MinBet03))
+	}
+	if m.MaxBet03 != 0 {
+		n += 1 + sovWs(uint64(m.MaxBet03))
+	}
+	if m.MinBet04 != 0 {
+		n += 1 + sovWs(uint64(m.MinBet04))
+	}
+	if m.MaxBet04 != 0 {
+		n += 1 + sovWs(uint64(m.MaxBet04))
+	}
+	if m.MinBet05 != 0 {
+		n += 1 + sovWs(uint64(m.MinBet05))
+	}
+	if m.MaxBet05 != 0 {
+		n += 1 + sovWs(uint64(m.MaxBet05))
+	}
+	if m.MinBet06 != 0 {
+		n += 1 + sovWs(uint64(m.MinBet06))
+	}
+	if m.MaxBet06 != 0 {
+		n += 1 + sovWs(uint64(m.MaxBet06))
+	}
+	if m.MinBet07 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet07))
+	}
+	if m.MaxBet07 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet07))
+	}
+	if m.MinBet08 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet08))
+	}
+	if m.MaxBet08 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet08))
+	}
+	if m.MinBet09 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet09))
+	}
+	if m.MaxBet09 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet09))
+	}
+	if m.MinBet10 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet10))
+	}
+	if m.MaxBet10 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet10))
+	}
+	if m.MinBet11 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet11))
+	}
+	if m.MaxBet11 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet11))
+	}
+	if m.MinBet12 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet12))
+	}
+	if m.MaxBet12 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet12))
+	}
+	if m.MinBet13 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet13))
+	}
+	if m.MaxBet13 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet13))
+	}
+	if m.MinBet14 != 0 {
+		n += 2 + sovWs(uint64(m.MinBet14))
+	}
+	if m.MaxBet14 != 0 {
+		n += 2 + sovWs(uint64(m.MaxBet14))
+	}
+	return n
+}
+
+func (m *WsGroupListData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.GroupArr) > 0 {
+		for _, e := range m.GroupArr {
+			l = e.Size()
+			n += 1 + l + sovWs(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WsGroupInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovWs(uint64(m.GroupID))
+	}
+	if m.GroupType != 0 {
+		n += 1 + sovWs(uint64(m.GroupType))
+	}
+	if m.SingleLimit != 0 {
+		n += 1 + sovWs(uint64(m.SingleLimit))
+	}
+	if m.TableMinBet != 0 {
+		n += 1 + sovWs(uint64(m.TableMinBet))
+	}
+	if m.TableMaxBet != 0 {
+		n += 1 + sovWs(uint64(m.TableMaxBet))
+	}
+	if m.TableTieMinBet != 0 {
+		n += 1 + sovWs(uint64(m.TableTieMinBet))
+	}
+	if m.TableTieMaxBet != 0 {
+		n += 1 + sovWs(uint64(m.TableTieMaxBet))
+	}
+	if m.TablePairMinBet != 0 {
+		n += 1 + sovWs(uint64(m.TablePairMinBet))
+	}
+	if m.TablePairMaxBet != 0 {
+		n += 1 + sovWs(uint64(m.TablePairMaxBet))
+	}
+	if m.TableStatus != 0 {
+		n += 1 + sovWs(uint64(m.TableStatus))
+	}
+	return n
+}
+
+func sovWs(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWs(x uint64) (n int) {
+	return sovWs(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WsFrame) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsFrame: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsFrame: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+			}
+			m.Protocol = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Protocol |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Json", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Json = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Notice == nil {
+				m.Notice = &WsConnMessage{}
+			}
+			if err := m.Notice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsConnMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsConnMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsConnMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrMsg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrMsg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsAuthData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsAuthData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsAuthData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Account = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsAuthResp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsAuthResp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsAuthResp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Account = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BOk", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BOk = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BValidPassword", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BValidPassword = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsJoinTableData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsJoinTableData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsJoinTableData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DtBetLimitSelectID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DtBetLimitSelectID == nil {
+				m.DtBetLimitSelectID = make(map[string]int32)
+			}
+			var mapkey string
+			var mapvalue int32
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipWs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthWs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DtBetLimitSelectID[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsTableEntryData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsTableEntryData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsTableEntryData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BOk", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BOk = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupType", wireType)
+			}
+			m.GroupType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AreaID", wireType)
+			}
+			m.AreaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AreaID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AreaType", wireType)
+			}
+			m.AreaType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AreaType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Account = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadID", wireType)
+			}
+			m.HeadID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HeadID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.SeatIDArr = append(m.SeatIDArr, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthWs
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthWs
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.SeatIDArr) == 0 {
+					m.SeatIDArr = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.SeatIDArr = append(m.SeatIDArr, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeatIDArr", wireType)
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrencyCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CurrencyCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrencyName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CurrencyName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrencyRate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CurrencyRate = float64(math.Float64frombits(v))
+		case 15:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Balance", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Balance = float64(math.Float64frombits(v))
+		case 16:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chips", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Chips = float64(math.Float64frombits(v))
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetMilliSecond", wireType)
+			}
+			m.BetMilliSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BetMilliSecond |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DtOdds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DtOdds == nil {
+				m.DtOdds = make(map[string]*WsOdds)
+			}
+			var mapkey string
+			var mapvalue *WsOdds
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthWs
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthWs
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &WsOdds{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipWs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthWs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DtOdds[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserCount", wireType)
+			}
+			m.UserCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DtCard", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DtCard = append(m.DtCard[:0], dAtA[iNdEx:postIndex]...)
+			if m.DtCard == nil {
+				m.DtCard = []byte{}
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet01", wireType)
+			}
+			m.MinBet01 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet01 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet01", wireType)
+			}
+			m.MaxBet01 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet01 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet02", wireType)
+			}
+			m.MinBet02 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet02 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet02", wireType)
+			}
+			m.MaxBet02 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet02 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet03", wireType)
+			}
+			m.MinBet03 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet03 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet03", wireType)
+			}
+			m.MaxBet03 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet03 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet04", wireType)
+			}
+			m.MinBet04 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet04 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet04", wireType)
+			}
+			m.MaxBet04 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet04 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet05", wireType)
+			}
+			m.MinBet05 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet05 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet05", wireType)
+			}
+			m.MaxBet05 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet05 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 31:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet06", wireType)
+			}
+			m.MinBet06 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet06 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 32:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet06", wireType)
+			}
+			m.MaxBet06 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet06 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 33:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet07", wireType)
+			}
+			m.MinBet07 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet07 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 34:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet07", wireType)
+			}
+			m.MaxBet07 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet07 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 35:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet08", wireType)
+			}
+			m.MinBet08 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet08 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 36:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet08", wireType)
+			}
+			m.MaxBet08 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet08 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 37:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet09", wireType)
+			}
+			m.MinBet09 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet09 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 38:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet09", wireType)
+			}
+			m.MaxBet09 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet09 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 39:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet10", wireType)
+			}
+			m.MinBet10 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet10 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 40:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet10", wireType)
+			}
+			m.MaxBet10 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet10 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 41:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet11", wireType)
+			}
+			m.MinBet11 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet11 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 42:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet11", wireType)
+			}
+			m.MaxBet11 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet11 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 43:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet12", wireType)
+			}
+			m.MinBet12 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet12 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet12", wireType)
+			}
+			m.MaxBet12 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet12 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 45:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet13", wireType)
+			}
+			m.MinBet13 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet13 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 46:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet13", wireType)
+			}
+			m.MaxBet13 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet13 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 47:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet14", wireType)
+			}
+			m.MinBet14 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet14 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 48:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet14", wireType)
+			}
+			m.MaxBet14 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet14 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsOdds) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsOdds: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsOdds: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Odds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Odds = append(m.Odds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsLeaveTableData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsLeaveTableData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsLeaveTableData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BOk", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BOk = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsResumeData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsResumeData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsResumeData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeq", wireType)
+			}
+			m.LastSeq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSeq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsResumeRespData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsResumeRespData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsResumeRespData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BOk", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BOk = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resync", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Resync = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsReconnectData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsReconnectData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsReconnectData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DelayMs", wireType)
+			}
+			m.DelayMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DelayMs |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsTableData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsTableData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsTableData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupType", wireType)
+			}
+			m.GroupType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameNo", wireType)
+			}
+			m.GameNo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameNo |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameNoRound", wireType)
+			}
+			m.GameNoRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameNoRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DealerID", wireType)
+			}
+			m.DealerID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DealerID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DealerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DealerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DealerImage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DealerImage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DealerImage2", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DealerImage2 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dealer2ID", wireType)
+			}
+			m.Dealer2ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Dealer2ID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dealer2Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dealer2Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dealer2Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dealer2Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dealer2Image2", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dealer2Image2 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetMilliSecond", wireType)
+			}
+			m.BetMilliSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BetMilliSecond |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BWantToShuffle", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BWantToShuffle = bool(v != 0)
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BWantToEnd", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BWantToEnd = bool(v != 0)
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyStatus", wireType)
+			}
+			m.KeyStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeyStatus |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameMode", wireType)
+			}
+			m.GameMode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameMode |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SingleLimit", wireType)
+			}
+			m.SingleLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SingleLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableMinBet", wireType)
+			}
+			m.TableMinBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableMinBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableMaxBet", wireType)
+			}
+			m.TableMaxBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableMaxBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTieMinBet", wireType)
+			}
+			m.TableTieMinBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableTieMinBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTieMaxBet", wireType)
+			}
+			m.TableTieMaxBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableTieMaxBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TablePairMinBet", wireType)
+			}
+			m.TablePairMinBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TablePairMinBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TablePairMaxBet", wireType)
+			}
+			m.TablePairMaxBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TablePairMaxBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableType", wireType)
+			}
+			m.TableType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableStatus", wireType)
+			}
+			m.TableStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableStatus |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableSort", wireType)
+			}
+			m.TableSort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableSort |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableSort2", wireType)
+			}
+			m.TableSort2 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableSort2 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservedTable", wireType)
+			}
+			m.ReservedTable = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReservedTable |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 31:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ReservedTableParentIDArr = append(m.ReservedTableParentIDArr, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthWs
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthWs
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ReservedTableParentIDArr) == 0 {
+					m.ReservedTableParentIDArr = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ReservedTableParentIDArr = append(m.ReservedTableParentIDArr, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservedTableParentIDArr", wireType)
+			}
+		case 32:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ReservedTableMemberIDArr = append(m.ReservedTableMemberIDArr, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthWs
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthWs
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ReservedTableMemberIDArr) == 0 {
+					m.ReservedTableMemberIDArr = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ReservedTableMemberIDArr = append(m.ReservedTableMemberIDArr, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservedTableMemberIDArr", wireType)
+			}
+		case 33:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableDtExtend", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TableDtExtend == nil {
+				m.TableDtExtend = &TableDtExtend{}
+			}
+			if err := m.TableDtExtend.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableDtExtend) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TableDtExtend: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TableDtExtend: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NetGroupName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NetGroupName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PhoneGroupName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PhoneGroupName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TableName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NetType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NetType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PhoneType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PhoneType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsBettingData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsBettingData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsBettingData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetSerialNumber", wireType)
+			}
+			m.BetSerialNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BetSerialNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameNo", wireType)
+			}
+			m.GameNo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameNo |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameNoRound", wireType)
+			}
+			m.GameNoRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameNoRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetArr", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BetArr = append(m.BetArr, &WsBettingInfoItem{})
+			if err := m.BetArr[len(m.BetArr)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commission", wireType)
+			}
+			m.Commission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Commission |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsBettingInfoItem) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsBettingInfoItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsBettingInfoItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetArea", wireType)
+			}
+			m.BetArea = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BetArea |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddBetMoney", wireType)
+			}
+			m.AddBetMoney = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AddBetMoney |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsBettingRespData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsBettingRespData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsBettingRespData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AreaID", wireType)
+			}
+			m.AreaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AreaID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AreaType", wireType)
+			}
+			m.AreaType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AreaType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BOk", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BOk = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetSerialNumber", wireType)
+			}
+			m.BetSerialNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BetSerialNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Balance", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Balance = float64(math.Float64frombits(v))
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetArr", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BetArr = append(m.BetArr, &WsBettingResultItem{})
+			if err := m.BetArr[len(m.BetArr)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsBettingResultItem) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsBettingResultItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsBettingResultItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetArea", wireType)
+			}
+			m.BetArea = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BetArea |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddBetMoney", wireType)
+			}
+			m.AddBetMoney = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AddBetMoney |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BOk", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BOk = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsGameResultRespData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsGameResultRespData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsGameResultRespData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlayerScore", wireType)
+			}
+			m.PlayerScore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PlayerScore |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BankerScore", wireType)
+			}
+			m.BankerScore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BankerScore |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DtCard", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DtCard == nil {
+				m.DtCard = make(map[string]int32)
+			}
+			var mapkey string
+			var mapvalue int32
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipWs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthWs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DtCard[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.WinBetAreaArr = append(m.WinBetAreaArr, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthWs
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthWs
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.WinBetAreaArr) == 0 {
+					m.WinBetAreaArr = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.WinBetAreaArr = append(m.WinBetAreaArr, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field WinBetAreaArr", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsPayoutResultData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsPayoutResultData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsPayoutResultData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AreaID", wireType)
+			}
+			m.AreaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AreaID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AreaType", wireType)
+			}
+			m.AreaType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AreaType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MoneyWin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MoneyWin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MoneyWinLoss", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MoneyWinLoss = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DtMoneyWin", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DtMoneyWin == nil {
+				m.DtMoneyWin = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthWs
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthWs
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipWs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthWs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DtMoneyWin[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsBetTimeData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsBetTimeData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsBetTimeData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupType", wireType)
+			}
+			m.GroupType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameNo", wireType)
+			}
+			m.GameNo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameNo |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameNoRound", wireType)
+			}
+			m.GameNoRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameNoRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetTimeCount", wireType)
+			}
+			m.BetTimeCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BetTimeCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BetTimeContent", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BetTimeContent = append(m.BetTimeContent[:0], dAtA[iNdEx:postIndex]...)
+			if m.BetTimeContent == nil {
+				m.BetTimeContent = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeMillisecond", wireType)
+			}
+			m.TimeMillisecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeMillisecond |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsBetLimitModifyData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsBetLimitModifyData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsBetLimitModifyData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			m.GameID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GameID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DtBetLimitSelectID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DtBetLimitSelectID == nil {
+				m.DtBetLimitSelectID = make(map[string]int32)
+			}
+			var mapkey string
+			var mapvalue int32
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowWs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthWs
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowWs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipWs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthWs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DtBetLimitSelectID[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet01", wireType)
+			}
+			m.MinBet01 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet01 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet01", wireType)
+			}
+			m.MaxBet01 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet01 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet02", wireType)
+			}
+			m.MinBet02 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet02 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet02", wireType)
+			}
+			m.MaxBet02 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet02 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet03", wireType)
+			}
+			m.MinBet03 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet03 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet03", wireType)
+			}
+			m.MaxBet03 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet03 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet04", wireType)
+			}
+			m.MinBet04 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet04 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet04", wireType)
+			}
+			m.MaxBet04 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet04 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet05", wireType)
+			}
+			m.MinBet05 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet05 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet05", wireType)
+			}
+			m.MaxBet05 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet05 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet06", wireType)
+			}
+			m.MinBet06 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet06 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet06", wireType)
+			}
+			m.MaxBet06 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet06 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet07", wireType)
+			}
+			m.MinBet07 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet07 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet07", wireType)
+			}
+			m.MaxBet07 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet07 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet08", wireType)
+			}
+			m.MinBet08 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet08 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet08", wireType)
+			}
+			m.MaxBet08 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet08 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet09", wireType)
+			}
+			m.MinBet09 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet09 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet09", wireType)
+			}
+			m.MaxBet09 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet09 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet10", wireType)
+			}
+			m.MinBet10 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet10 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet10", wireType)
+			}
+			m.MaxBet10 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet10 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet11", wireType)
+			}
+			m.MinBet11 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet11 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet11", wireType)
+			}
+			m.MaxBet11 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet11 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet12", wireType)
+			}
+			m.MinBet12 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet12 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet12", wireType)
+			}
+			m.MaxBet12 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet12 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet13", wireType)
+			}
+			m.MinBet13 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet13 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet13", wireType)
+			}
+			m.MaxBet13 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet13 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBet14", wireType)
+			}
+			m.MinBet14 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinBet14 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 31:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBet14", wireType)
+			}
+			m.MaxBet14 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBet14 |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsGroupListData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsGroupListData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsGroupListData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupArr", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupArr = append(m.GroupArr, &WsGroupInfo{})
+			if err := m.GroupArr[len(m.GroupArr)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WsGroupInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WsGroupInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WsGroupInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupType", wireType)
+			}
+			m.GroupType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupType |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SingleLimit", wireType)
+			}
+			m.SingleLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SingleLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableMinBet", wireType)
+			}
+			m.TableMinBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableMinBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableMaxBet", wireType)
+			}
+			m.TableMaxBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableMaxBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTieMinBet", wireType)
+			}
+			m.TableTieMinBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableTieMinBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTieMaxBet", wireType)
+			}
+			m.TableTieMaxBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableTieMaxBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TablePairMinBet", wireType)
+			}
+			m.TablePairMinBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TablePairMinBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TablePairMaxBet", wireType)
+			}
+			m.TablePairMaxBet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TablePairMaxBet |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableStatus", wireType)
+			}
+			m.TableStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableStatus |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWs(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWs
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWs
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWs
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWs
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWs
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWs        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWs          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWs = fmt.Errorf("proto: unexpected end of group")
+)
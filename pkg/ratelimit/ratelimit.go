@@ -0,0 +1,139 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Algorithm 限流算法
+type Algorithm string
+
+const (
+	SlidingWindow Algorithm = "window" // 滑动窗口, window内最多limit次
+	TokenBucket   Algorithm = "bucket" // 令牌桶, 容量limit, 每window补满
+)
+
+// Scope 限流对象
+type Scope string
+
+const (
+	ScopeVendor Scope = "vendor" // 每个代理商每个指令
+	ScopeMember Scope = "member" // 每个代理商每个指令每个会员
+)
+
+// Class groups gateway commands by how they are throttled and which error a rejected call gets
+type Class string
+
+const (
+	ClassQuery    Class = "query"
+	ClassAccount  Class = "account"
+	ClassTransfer Class = "transfer"
+	ClassReport   Class = "report"
+)
+
+// Rule limits one command, VendorID empty is the default of every vendor
+type Rule struct {
+	VendorID  string
+	Command   string
+	Scope     Scope
+	Algorithm Algorithm
+	// Limit 0 turns the limit of this scope off
+	Limit int
+	// Window in seconds
+	Window int
+}
+
+// Resolve returns the rules that apply to command for vendorID, one per scope.
+// A vendor rule beats a default one, and among equals the later rule wins.
+func Resolve(rules []Rule, vendorID, command string) []Rule {
+	var picked []Rule
+	for _, rule := range rules {
+		if rule.Command != command || (rule.VendorID != "" && rule.VendorID != vendorID) {
+			continue
+		}
+		i := 0
+		for ; i < len(picked); i++ {
+			if picked[i].Scope == rule.Scope {
+				break
+			}
+		}
+		if i == len(picked) {
+			picked = append(picked, rule)
+			continue
+		}
+		if rule.VendorID != "" || picked[i].VendorID == "" {
+			picked[i] = rule
+		}
+	}
+	return picked
+}
+
+// KEYS[1] zset of request times, ARGV now(ms), window(ms), limit, member
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], now, ARGV[4])
+redis.call('PEXPIRE', KEYS[1], window)
+return 1
+`)
+
+// KEYS[1] hash of tokens and last refill, ARGV now(ms), window(ms), capacity
+var tokenBucketScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local capacity = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + (now - ts) * capacity / window)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], window)
+return allowed
+`)
+
+type Limiter struct {
+	redisCli *redis.Client
+	now      func() time.Time
+}
+
+func NewLimiter(redisCli *redis.Client) *Limiter {
+	return &Limiter{
+		redisCli: redisCli,
+		now:      time.Now,
+	}
+}
+
+// Allow takes one request from key and reports whether it fits in limit per window
+func (l *Limiter) Allow(ctx context.Context, key string, algorithm Algorithm, limit int, window time.Duration) (bool, error) {
+	now := l.now().UnixMilli()
+	var (
+		ret int64
+		err error
+	)
+	switch algorithm {
+	case TokenBucket:
+		ret, err = tokenBucketScript.Run(ctx, l.redisCli, []string{key}, now, window.Milliseconds(), limit).Int64()
+	case SlidingWindow, "":
+		member := fmt.Sprintf("%d-%d", now, rand.Int63())
+		ret, err = slidingWindowScript.Run(ctx, l.redisCli, []string{key}, now, window.Milliseconds(), limit, member).Int64()
+	default:
+		return false, fmt.Errorf("unknown rate limit algorithm %q", algorithm)
+	}
+	if err != nil {
+		return false, err
+	}
+	return ret == 1, nil
+}
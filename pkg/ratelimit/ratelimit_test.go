@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestLimiter(t *testing.T) (*Limiter, *time.Time) {
+	mr := miniredis.RunT(t)
+	l := NewLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter_SlidingWindow(t *testing.T) {
+	l, now := newTestLimiter(t)
+	ctx := context.Background()
+
+	allow := func() bool {
+		ok, err := l.Allow(ctx, "k", SlidingWindow, 2, 5*time.Second)
+		if err != nil {
+			t.Fatalf("allow err:(%+v)", err)
+		}
+		return ok
+	}
+	if !allow() || !allow() {
+		t.Fatalf("first two requests rejected")
+	}
+	if allow() {
+		t.Fatalf("third request within the window allowed")
+	}
+	*now = now.Add(4999 * time.Millisecond)
+	if allow() {
+		t.Fatalf("request before the window slid allowed")
+	}
+	*now = now.Add(time.Millisecond)
+	if !allow() {
+		t.Fatalf("request after the window slid rejected")
+	}
+}
+
+func TestLimiter_TokenBucket(t *testing.T) {
+	l, now := newTestLimiter(t)
+	ctx := context.Background()
+
+	allow := func() bool {
+		ok, err := l.Allow(ctx, "k", TokenBucket, 4, 2*time.Second)
+		if err != nil {
+			t.Fatalf("allow err:(%+v)", err)
+		}
+		return ok
+	}
+	for i := 0; i < 4; i++ {
+		if !allow() {
+			t.Fatalf("burst request %d rejected", i)
+		}
+	}
+	if allow() {
+		t.Fatalf("request over the burst allowed")
+	}
+	// 4 tokens per 2s refill one every 500ms
+	*now = now.Add(500 * time.Millisecond)
+	if !allow() {
+		t.Fatalf("request after a refill rejected")
+	}
+	if allow() {
+		t.Fatalf("second request after a single refill allowed")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	rules := []Rule{
+		{Command: "ChangeBalance", Scope: ScopeMember, Limit: 1, Window: 5},
+		{Command: "GetTipReport", Scope: ScopeVendor, Limit: 1, Window: 30},
+		{VendorID: "fastapi", Command: "ChangeBalance", Scope: ScopeMember, Limit: 1, Window: 2},
+		{Command: "ChangeBalance", Scope: ScopeMember, Limit: 1, Window: 3},
+		{VendorID: "fastapi", Command: "ChangeBalance", Scope: ScopeVendor, Algorithm: TokenBucket, Limit: 50, Window: 1},
+	}
+
+	got := Resolve(rules, "demoapi", "ChangeBalance")
+	if len(got) != 1 || got[0].Window != 3 {
+		t.Fatalf("demoapi rules:(%+v), want the later default", got)
+	}
+	got = Resolve(rules, "fastapi", "ChangeBalance")
+	if len(got) != 2 || got[0].Window != 2 || got[1].Limit != 50 {
+		t.Fatalf("fastapi rules:(%+v), want the vendor member and vendor rules", got)
+	}
+	if got := Resolve(rules, "demoapi", "GetBalance"); len(got) != 0 {
+		t.Fatalf("GetBalance rules:(%+v), want none", got)
+	}
+}
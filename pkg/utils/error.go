@@ -28,6 +28,8 @@ const (
 	CodeNotFound ErrorCode = 40400
 	// 请求超时
 	CodeTimeout ErrorCode = 40800
+	// 状态冲突
+	CodeConflict ErrorCode = 40900
 
 	// 操作失敗
 	CodeError ErrorCode = 1
@@ -47,6 +49,10 @@ const (
 	CodeFunctionOnlyQueryOneDayReport ErrorCode = 10201
 	// 时间戳异常,超过30秒
 	CodeTimestampError ErrorCode = 10202
+	// 报表导出任务不存在或已过期
+	CodeReportExportNotExist ErrorCode = 10203
+	// 报表导出任务进行中过多
+	CodeReportExportTooMany ErrorCode = 10204
 	// 代理商ID与识别码格式错误
 	CodeAgentIDAndSignatureFormatError ErrorCode = 103
 	// 代理商ID为空,请检查(vendorid)
@@ -59,6 +65,10 @@ const (
 	CodeAgentSignatureEmpty ErrorCode = 10304
 	// 代理商已被停用登入或下注
 	CodeAgentDisabled ErrorCode = 10305
+	// nonce不得为空
+	CodeAgentNonceEmpty ErrorCode = 10306
+	// 请求已被使用
+	CodeAgentNonceReplay ErrorCode = 10307
 
 	// 账号已存在
 	CodeAccountExists ErrorCode = 104
@@ -115,6 +125,8 @@ const (
 	CodeInvalidChipsType ErrorCode = 10421
 	// 帐号只接受英文、数字、下划线与@
 	CodeInvalidAccountFormat ErrorCode = 10422
+	// 请求过于频繁
+	CodeInvalidRequestTooFrequent ErrorCode = 10423
 
 	// 代入参数error
 	// 帐号密码格式错误
@@ -190,6 +202,12 @@ const (
 	CodeWalletTransferRepeatIn2Error ErrorCode = 10809
 	// 连线异常，交易未成功
 	CodeWalletTransferLineError ErrorCode = 10810
+	// 该笔单号交易处理中
+	CodeWalletTransferPending ErrorCode = 10811
+	// 批次转帐笔数超过上限
+	CodeWalletBatchTooLarge ErrorCode = 10812
+	// 转帐触发风控规则被拒绝
+	CodeWalletTransferRiskRejected ErrorCode = 10813
 
 	// 注单编号不可为空
 	CodeWalletBetNumberEmpty ErrorCode = 10910
@@ -197,6 +215,18 @@ const (
 	CodeWalletBetNumberNotExist ErrorCode = 10911
 	// 参数格式错误
 	CodeWalletParamFormatError ErrorCode = 10912
+	// 会员已停止下注
+	CodeBetDisabled ErrorCode = 10920
+	// 下注金额不符限额
+	CodeBetOutOfLimit ErrorCode = 10921
+	// 无此桌台
+	CodeTableNotExist ErrorCode = 10922
+	// 桌台游戏不符
+	CodeTableGameMismatch ErrorCode = 10923
+	// 局号不符
+	CodeBetRoundMismatch ErrorCode = 10924
+	// 本局已停止下注
+	CodeBetRoundClosed ErrorCode = 10925
 
 	// 识别码验证失败
 	CodeWalletCodeVerifyError ErrorCode = 201
@@ -252,6 +282,8 @@ var (
 	ErrAgentIDExistButSignatureError               = NewError(CodeAgentIDExistButSignatureError, "有此代理商ID,但代理商代码(signature)错误")
 	ErrAgentSignatureEmpty                         = NewError(CodeAgentSignatureEmpty, "代理商代码(signature)为空")
 	ErrAgentDisabled                               = NewError(CodeAgentDisabled, "代理商已被停用登入或下注")
+	ErrAgentNonceEmpty                             = NewError(CodeAgentNonceEmpty, "nonce不得为空")
+	ErrAgentNonceReplay                            = NewError(CodeAgentNonceReplay, "此请求已被使用,请勿重复送出")
 	ErrAccountExists                               = NewError(CodeAccountExists, "账号已存在")
 	ErrInvalidLimitType                            = NewError(CodeInvalidLimitType, "新增资料错误")
 	ErrInvalidAccountEmpty                         = NewError(CodeInvalidAccountEmpty, "新增会员资料错误 帐号(user)不能为空值")
@@ -275,6 +307,7 @@ var (
 	ErrInvalidChipsCount                           = NewError(CodeInvalidChipsCount, "筹码个数错误(介于5-10个)")
 	ErrInvalidChipsType                            = NewError(CodeInvalidChipsType, "筹码种类错误")
 	ErrInvalidAccountFormat                        = NewError(CodeInvalidAccountFormat, "帐号只接受英文、数字、下划线与@")
+	ErrInvalidRequestTooFrequent                   = NewError(CodeInvalidRequestTooFrequent, "请求过于频繁,请稍后再试")
 	ErrParamInvalidAccountPasswordFormat           = NewError(CodeParamInvalidAccountPasswordFormat, "帐号密码格式错误")
 	ErrParamInvalidAccountNotExist                 = NewError(CodeParamInvalidAccountNotExist, "查无此帐号,请检查")
 	ErrParamInvalidAccountNameEmpty                = NewError(CodeParamInvalidAccountNameEmpty, "帐号名不得为空")
@@ -309,9 +342,19 @@ var (
 	ErrWalletTransferLockError                     = NewError(CodeWalletTransferLockError, "转帐失败,一分钟内转帐次数超过10次,帐号已锁定")
 	ErrWalletTransferRepeatIn2Error                = NewError(CodeWalletTransferRepeatIn2Error, "不得2秒內重复转帐")
 	ErrWalletTransferLineError                     = NewError(CodeWalletTransferLineError, "连线异常，交易未成功")
+	ErrWalletTransferPending                       = NewError(CodeWalletTransferPending, "该笔单号交易处理中,请稍后查询")
+	ErrWalletBatchTooLarge                         = NewError(CodeWalletBatchTooLarge, "批次转帐笔数超过上限")
+	ErrWalletTransferRiskLocked                    = NewError(CodeWalletTransferLockError, "转帐失败,触发风控规则,帐号已锁定")
+	ErrWalletTransferRiskRejected                  = NewError(CodeWalletTransferRiskRejected, "转帐失败,触发风控规则,请联系客服")
 	ErrWalletBetNumberEmpty                        = NewError(CodeWalletBetNumberEmpty, "注单编号不可为空")
 	ErrWalletBetNumberNotExist                     = NewError(CodeWalletBetNumberNotExist, "無此注单资料")
 	ErrWalletParamFormatError                      = NewError(CodeWalletParamFormatError, "参数格式错误")
+	ErrBetDisabled                                 = NewError(CodeBetDisabled, "会员已停止下注")
+	ErrBetOutOfLimit                               = NewError(CodeBetOutOfLimit, "下注金额不符限额")
+	ErrTableNotExist                               = NewError(CodeTableNotExist, "无此桌台")
+	ErrTableGameMismatch                           = NewError(CodeTableGameMismatch, "桌台游戏不符")
+	ErrBetRoundMismatch                            = NewError(CodeBetRoundMismatch, "局号不符")
+	ErrBetRoundClosed                              = NewError(CodeBetRoundClosed, "本局已停止下注")
 	ErrWalletCodeVerifyError                       = NewError(CodeWalletCodeVerifyError, "识别码验证失败")
 	ErrWalletCodeEmpty                             = NewError(CodeWalletCodeEmpty, "识别码不得为空")
 	ErrSystemFunctionNotExist                      = NewError(CodeSystemFunctionNotExist, "查无此函数")
@@ -322,6 +365,7 @@ var (
 	ErrForbidden                                   = NewError(CodeForbidden, "禁止访问")
 	ErrNotFound                                    = NewError(CodeNotFound, "资源不存在")
 	ErrTimeout                                     = NewError(CodeTimeout, "请求超时")
+	ErrConflict                                    = NewError(CodeConflict, "状态已变更,请重新整理")
 	ErrSystemError                                 = NewError(CodeSystemError, "系统错误")
 	ErrInternalServerError                         = NewError(CodeInternalServerError, "内部服务器错误")
 	ErrInvalidUsernameLengthLong                   = NewError(CodeInvalidUsernameLengthLong, "姓名长度过长")
@@ -329,6 +373,10 @@ var (
 	ErrInvalidPeriodEmpty                          = NewError(CodeInvalidPeriodEmpty, "期数不得为空")
 	ErrInvalidPeriodNotExist                       = NewError(CodeInvalidPeriodNotExist, "期数资料不存在")
 	ErrFunctionOnlyQueryOneDayReport               = NewError(CodeFunctionOnlyQueryOneDayReport, "此功能仅能查询一天内的报表，您已超过上限")
+	ErrFunctionOnlyQueryOneMonthReport             = NewError(CodeFunctionOnlyQueryOneDayReport, "此功能仅能查询31天内的报表，您已超过上限")
+	ErrParamInvalidPageToken                       = NewError(CodeParamError, "分页标记(pageToken)无效")
+	ErrReportExportNotExist                        = NewError(CodeReportExportNotExist, "报表导出任务不存在或已过期")
+	ErrReportExportTooMany                         = NewError(CodeReportExportTooMany, "报表导出任务进行中过多,请稍后再试")
 	ErrRedisError                                  = NewError(CodeRedisError, "Redis错误")
 	ErrEsError                                     = NewError(CodeEsError, "ES错误")
 )
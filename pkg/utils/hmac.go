@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"net/url"
 )
 
 func HmacSha256(data string, secret string) string {
@@ -18,3 +19,16 @@ func HmacSha256Hex(data string, secret string) string {
 	h.Write([]byte(data))
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// CanonicalParams returns params without the skipped keys, url-encoded and sorted by key,
+// which is the string a vendor signs in HMAC mode
+func CanonicalParams(params url.Values, skip ...string) string {
+	canonical := url.Values{}
+	for k, v := range params {
+		canonical[k] = v
+	}
+	for _, k := range skip {
+		canonical.Del(k)
+	}
+	return canonical.Encode()
+}
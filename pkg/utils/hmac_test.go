@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCanonicalParams(t *testing.T) {
+	params := url.Values{
+		"vendorId":  {"demoapi"},
+		"cmd":       {"GetBalance"},
+		"user":      {"tom jr"},
+		"timestamp": {"1700000000"},
+		"nonce":     {"a1b2"},
+		"signature": {"deadbeef"},
+	}
+
+	got := CanonicalParams(params, "signature")
+	want := "cmd=GetBalance&nonce=a1b2&timestamp=1700000000&user=tom+jr&vendorId=demoapi"
+	if got != want {
+		t.Fatalf("canonical:(%s), want:(%s)", got, want)
+	}
+	if params.Get("signature") == "" {
+		t.Fatalf("CanonicalParams modified its input")
+	}
+
+	// The digest a vendor sends must change with any signed parameter
+	sign := HmacSha256Hex(got, "secret")
+	params.Set("user", "tom")
+	if HmacSha256Hex(CanonicalParams(params, "signature"), "secret") == sign {
+		t.Fatalf("signature did not change with the params")
+	}
+}
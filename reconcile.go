@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-zrbc/pkg/xlog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Check the elasticsearch report indices of a day against MySQL",
+	Long: `Compares the count, bet13, bet17 and bet16 sums of bet02 with bet02_report_index and the count and
+iom004 sum of in_out_m with in_out_m_index for each agent on --day, listing the differing ids when they disagree.
+Agents found drifted read their reports from MySQL until a later clean check. --fix indexes the differing
+documents again. Prints one json report per agent and exits 1 when any drifted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+		if !StartReconcile() {
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	reconcileDay   string
+	reconcileAgent int64
+	reconcileFix   bool
+)
+
+func init() {
+	reconcileCmd.Flags().StringVar(&reconcileDay, "day", "", "day to check as 2006-01-02, yesterday by default")
+	reconcileCmd.Flags().Int64Var(&reconcileAgent, "agent", 0, "agent to check, every agent active on the day by default")
+	reconcileCmd.Flags().BoolVar(&reconcileFix, "fix", false, "index the differing documents again")
+	rootCmd.AddCommand(reconcileCmd)
+}
+
+// StartReconcile runs the check and reports whether every agent was clean
+func StartReconcile() bool {
+	day := time.Now().AddDate(0, 0, -1)
+	if reconcileDay != "" {
+		var err error
+		day, err = time.ParseInLocation("2006-01-02", reconcileDay, time.Local)
+		if err != nil {
+			xlog.Errorf("error to parse day %q: %v", reconcileDay, err)
+			return false
+		}
+	}
+
+	indexer, esClient, err := newIndexer()
+	if err != nil {
+		xlog.Errorf("error to create es client: %v", err)
+		return false
+	}
+	defer esClient.Close()
+
+	ctx := context.Background()
+	agents := []int64{reconcileAgent}
+	if reconcileAgent == 0 {
+		if agents, err = indexer.ReconcileAgents(ctx, day); err != nil {
+			xlog.Errorf("error to get agents of %s: %v", day.Format("2006-01-02"), err)
+			return false
+		}
+	}
+
+	clean := true
+	for _, agentID := range agents {
+		report, err := indexer.Reconcile(ctx, agentID, day, reconcileFix)
+		if err != nil {
+			clean = false
+			continue
+		}
+		if report.Drift {
+			clean = false
+		}
+		b, _ := json.Marshal(report)
+		fmt.Println(string(b))
+	}
+	return clean
+}
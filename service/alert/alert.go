@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/config"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/service"
+	"go-zrbc/view"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AlertService interface {
+	ListUnhandledAlerts(ctx context.Context, req *view.ListUnhandledAlertsReq) (*view.ListUnhandledAlertsResp, error)
+	AckAlert(ctx context.Context, req *view.AckAlertReq, operator string) error
+	ResolveAlert(ctx context.Context, req *view.ResolveAlertReq, operator string) error
+
+	ListLockedMembers(ctx context.Context, req *view.ListLockedMembersReq) (*view.ListLockedMembersResp, error)
+	GetMemberTransfers(ctx context.Context, req *view.GetMemberTransfersReq) (*view.GetMemberTransfersResp, error)
+	ReviewMemberLock(ctx context.Context, req *view.ReviewMemberLockReq, operator string) error
+	GetMemberLockAudits(ctx context.Context, req *view.GetMemberLockAuditsReq) (*view.GetMemberLockAuditsResp, error)
+
+	// RunDispatcher pushes unpushed alerts to the configured webhooks until ctx is done
+	RunDispatcher(ctx context.Context)
+}
+
+type alertService struct {
+	alertMessageDao    db.AlertMessageDao
+	userDao            db.UserDao
+	inOutMDao          db.InOutMDao
+	memberLockAuditDao db.MemberLockAuditDao
+
+	hooks  []config.AlertWebhook
+	client *http.Client
+	now    func() time.Time
+	*service.Session
+}
+
+func NewAlertService(
+	sess *service.Session,
+	alertMessageDao db.AlertMessageDao,
+	userDao db.UserDao,
+	inOutMDao db.InOutMDao,
+	memberLockAuditDao db.MemberLockAuditDao,
+) AlertService {
+	srv := &alertService{
+		alertMessageDao:    alertMessageDao,
+		userDao:            userDao,
+		inOutMDao:          inOutMDao,
+		memberLockAuditDao: memberLockAuditDao,
+		hooks:              alertWebhooks(config.Global),
+		client:             &http.Client{Timeout: webhookTimeout},
+		now:                time.Now,
+	}
+	srv.Session = sess
+	return srv
+}
+
+const (
+	unhandledAlertsDefault = 50
+	unhandledAlertsMax     = 200
+)
+
+// ListUnhandledAlerts pages the open alerts oldest first, Cursor is the NextCursor of the previous page
+func (srv *alertService) ListUnhandledAlerts(ctx context.Context, req *view.ListUnhandledAlertsReq) (*view.ListUnhandledAlertsResp, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = unhandledAlertsDefault
+	}
+	if limit > unhandledAlertsMax {
+		limit = unhandledAlertsMax
+	}
+	messages, err := srv.alertMessageDao.QueryUnhandledMessages(srv.DB(), req.Cursor, limit)
+	if err != nil {
+		xlog.Errorf("error to query unhandled alerts, err:%+v", err)
+		return nil, err
+	}
+	ret := &view.ListUnhandledAlertsResp{List: make([]*view.AlertMessage, 0, len(messages))}
+	for _, msg := range messages {
+		ret.List = append(ret.List, alertToView(msg))
+	}
+	if len(messages) == limit {
+		ret.NextCursor = messages[len(messages)-1].ID
+	}
+	return ret, nil
+}
+
+// AckAlert marks an unhandled alert as being looked at by operator
+func (srv *alertService) AckAlert(ctx context.Context, req *view.AckAlertReq, operator string) error {
+	return srv.moveAlert(req.ID, []int{db.AlertStatusUnhandled}, db.AlertStatusAcknowledged, map[string]interface{}{
+		"operator": operator,
+		"ack_time": srv.now().Unix(),
+	})
+}
+
+// ResolveAlert closes an unhandled or acknowledged alert on behalf of operator
+func (srv *alertService) ResolveAlert(ctx context.Context, req *view.ResolveAlertReq, operator string) error {
+	return srv.moveAlert(req.ID, []int{db.AlertStatusUnhandled, db.AlertStatusAcknowledged}, db.AlertStatusHandled, map[string]interface{}{
+		"operator":     operator,
+		"resolve_time": srv.now().Unix(),
+		"remark":       req.Remark,
+	})
+}
+
+func (srv *alertService) moveAlert(id int64, from []int, to int, data map[string]interface{}) error {
+	return srv.Tx(func(tx *gorm.DB) error {
+		rows, err := srv.alertMessageDao.UpdateStatus(tx, id, from, to, data)
+		if err != nil {
+			xlog.Errorf("error to update alert status, id:%d, err:%+v", id, err)
+			return err
+		}
+		if rows == 1 {
+			return nil
+		}
+		// Nothing moved, tell a missing alert from one another operator already moved
+		if _, err := srv.alertMessageDao.QueryByID(tx, id); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			xlog.Errorf("error to query alert, id:%d, err:%+v", id, err)
+			return err
+		}
+		return utils.ErrConflict
+	})
+}
+
+func alertToView(msg *db.AlertMessage) *view.AlertMessage {
+	return &view.AlertMessage{
+		ID:          msg.ID,
+		Mid:         msg.Mid,
+		Message:     msg.Message,
+		RuleCode:    msg.RuleCode,
+		Action:      msg.Action,
+		ErrorTime:   msg.UnierrorTime,
+		Status:      msg.Status,
+		PushStatus:  msg.PushStatus,
+		PushError:   msg.PushError,
+		Operator:    msg.Operator,
+		AckTime:     msg.AckTime,
+		ResolveTime: msg.ResolveTime,
+		Remark:      msg.Remark,
+	}
+}
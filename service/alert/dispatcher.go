@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-zrbc/config"
+	"go-zrbc/db"
+	"go-zrbc/pkg/xlog"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	dispatchInterval = 5 * time.Second
+	dispatchBatch    = 100
+	webhookTimeout   = 5 * time.Second
+	// pushLease keeps other nodes off an alert while one node is sending it
+	pushLease       = 60 * time.Second
+	pushMaxRetries  = 6
+	pushBackoffBase = 10 * time.Second
+	pushBackoffMax  = 10 * time.Minute
+)
+
+const (
+	WebhookFormatJSON     = "json"
+	WebhookFormatTelegram = "telegram"
+)
+
+// alertWebhooks returns the legacy alert urls as json webhooks followed by the configured ones
+func alertWebhooks(cfg *config.Config) []config.AlertWebhook {
+	var hooks []config.AlertWebhook
+	if cfg.AlertUrl != "" {
+		hooks = append(hooks, config.AlertWebhook{Name: "alert_url", URL: cfg.AlertUrl, Format: WebhookFormatJSON})
+	}
+	if cfg.WMAlertUrl != "" {
+		hooks = append(hooks, config.AlertWebhook{Name: "wm_alert_url", URL: cfg.WMAlertUrl, Format: WebhookFormatJSON})
+	}
+	for i, hook := range cfg.AlertWebhooks {
+		if hook.URL == "" {
+			continue
+		}
+		// The name records which hooks an alert reached, it must be set and unique
+		if hook.Name == "" {
+			hook.Name = fmt.Sprintf("webhook_%d", i)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks
+}
+
+// pushBackoff is the wait before attempt retries+1, doubling from pushBackoffBase
+func pushBackoff(retries int) time.Duration {
+	d := pushBackoffBase
+	for i := 1; i < retries && d < pushBackoffMax; i++ {
+		d *= 2
+	}
+	if d > pushBackoffMax {
+		d = pushBackoffMax
+	}
+	return d
+}
+
+func (srv *alertService) RunDispatcher(ctx context.Context) {
+	if len(srv.hooks) == 0 {
+		xlog.Warnf("no alert webhook configured, alert dispatcher not started")
+		return
+	}
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+	for {
+		srv.dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch sends every due alert once and schedules the failed ones for a retry
+func (srv *alertService) dispatch(ctx context.Context) {
+	now := srv.now().Unix()
+	messages, err := srv.alertMessageDao.QueryPendingPush(srv.DB(), now, dispatchBatch)
+	if err != nil {
+		xlog.Errorf("error to query pending alerts, err:%+v", err)
+		return
+	}
+	for _, msg := range messages {
+		claimed, err := srv.alertMessageDao.ClaimPush(srv.DB(), msg.ID, now, now+int64(pushLease/time.Second))
+		if err != nil {
+			xlog.Errorf("error to claim alert push, id:%d, err:%+v", msg.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		pushed, err := srv.push(ctx, msg)
+		data := map[string]interface{}{
+			"push_status":  db.AlertPushSent,
+			"push_error":   "",
+			"pushed_hooks": strings.Join(pushed, ","),
+		}
+		if err != nil {
+			xlog.Errorf("error to push alert, id:%d, retries:%d, err:%+v", msg.ID, msg.PushRetries, err)
+			retries := msg.PushRetries + 1
+			pushErr := err.Error()
+			if len(pushErr) > 255 {
+				pushErr = pushErr[:255]
+			}
+			data = map[string]interface{}{
+				"push_status":    db.AlertPushPending,
+				"push_retries":   retries,
+				"next_push_time": now + int64(pushBackoff(retries)/time.Second),
+				"push_error":     pushErr,
+				"pushed_hooks":   strings.Join(pushed, ","),
+			}
+			if retries >= pushMaxRetries {
+				data["push_status"] = db.AlertPushFailed
+			}
+		}
+		if err := srv.alertMessageDao.Updates(srv.DB(), msg.ID, data); err != nil {
+			xlog.Errorf("error to update alert push status, id:%d, err:%+v", msg.ID, err)
+		}
+	}
+}
+
+// push posts msg to every webhook it has not reached yet and returns all the hooks it reached so far,
+// a retry only goes to the hooks that failed
+func (srv *alertService) push(ctx context.Context, msg *db.AlertMessage) ([]string, error) {
+	var pushed []string
+	if msg.PushedHooks != "" {
+		pushed = strings.Split(msg.PushedHooks, ",")
+	}
+	var errs []error
+	for _, hook := range srv.hooks {
+		if slices.Contains(pushed, hook.Name) {
+			continue
+		}
+		if err := srv.pushHook(ctx, hook, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", hook.Name, err))
+			continue
+		}
+		pushed = append(pushed, hook.Name)
+	}
+	return pushed, errors.Join(errs...)
+}
+
+func (srv *alertService) pushHook(ctx context.Context, hook config.AlertWebhook, msg *db.AlertMessage) error {
+	var payload interface{}
+	switch hook.Format {
+	case WebhookFormatTelegram:
+		payload = map[string]string{"chat_id": hook.ChatID, "text": msg.Message}
+	default:
+		payload = alertToView(msg)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := srv.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
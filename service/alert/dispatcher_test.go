@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-zrbc/config"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/service"
+	"go-zrbc/view"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestAlertService(t *testing.T, hooks []config.AlertWebhook) (*alertService, *gorm.DB, *time.Time) {
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "alert.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	ddl := "CREATE TABLE alertMessage (id INTEGER PRIMARY KEY, mid INTEGER, message TEXT, errorTime DATETIME, unierrorTime INTEGER, " +
+		"operator TEXT DEFAULT '', status INTEGER DEFAULT 0, rule_code TEXT DEFAULT '', action TEXT DEFAULT '', " +
+		"push_status INTEGER DEFAULT 0, push_retries INTEGER DEFAULT 0, next_push_time INTEGER DEFAULT 0, push_error TEXT DEFAULT '', pushed_hooks TEXT DEFAULT '', " +
+		"ack_time INTEGER DEFAULT 0, resolve_time INTEGER DEFAULT 0, remark TEXT DEFAULT '')"
+	if err := tx.Exec(ddl).Error; err != nil {
+		t.Fatalf("create table err:(%+v)", err)
+	}
+	now := time.Unix(1700000000, 0)
+	srv := &alertService{
+		alertMessageDao: db.NewAlertMessageDao(),
+		hooks:           hooks,
+		client:          &http.Client{Timeout: time.Second},
+		now:             func() time.Time { return now },
+		Session:         service.NewSession(tx),
+	}
+	return srv, tx, &now
+}
+
+func createAlert(t *testing.T, tx *gorm.DB, msg string) *db.AlertMessage {
+	alert := &db.AlertMessage{Mid: 1, Message: msg, ErrorTime: time.Unix(1700000000, 0), UnierrorTime: 1700000000}
+	if _, err := db.NewAlertMessageDao().Create(tx, alert); err != nil {
+		t.Fatalf("create alert err:(%+v)", err)
+	}
+	return alert
+}
+
+func TestAlertService_DispatchRetry(t *testing.T) {
+	fail := true
+	var got []map[string]interface{}
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, body)
+	}))
+	defer hook.Close()
+
+	srv, tx, now := newTestAlertService(t, []config.AlertWebhook{{Name: "tg", URL: hook.URL, Format: WebhookFormatTelegram, ChatID: "-100"}})
+	alert := createAlert(t, tx, "member locked")
+	ctx := context.Background()
+
+	srv.dispatch(ctx)
+	msg, _ := srv.alertMessageDao.QueryByID(tx, alert.ID)
+	if msg.PushStatus != db.AlertPushPending || msg.PushRetries != 1 || msg.NextPushTime != now.Unix()+10 || msg.PushError == "" {
+		t.Fatalf("after a failed push:(%+v)", msg)
+	}
+
+	// Not due yet, the webhook is left alone
+	fail = false
+	srv.dispatch(ctx)
+	if len(got) != 0 {
+		t.Fatalf("pushed before the backoff elapsed:(%+v)", got)
+	}
+
+	*now = now.Add(10 * time.Second)
+	srv.dispatch(ctx)
+	msg, _ = srv.alertMessageDao.QueryByID(tx, alert.ID)
+	if msg.PushStatus != db.AlertPushSent || msg.PushError != "" {
+		t.Fatalf("after a good push:(%+v)", msg)
+	}
+	if len(got) != 1 || got[0]["chat_id"] != "-100" || got[0]["text"] != "member locked" {
+		t.Fatalf("telegram payload:(%+v)", got)
+	}
+
+	// Retries run out
+	fail = true
+	alert = createAlert(t, tx, "another")
+	for i := 0; i < pushMaxRetries; i++ {
+		srv.dispatch(ctx)
+		*now = now.Add(pushBackoffMax)
+	}
+	msg, _ = srv.alertMessageDao.QueryByID(tx, alert.ID)
+	if msg.PushStatus != db.AlertPushFailed || msg.PushRetries != pushMaxRetries {
+		t.Fatalf("after the last retry:(%+v)", msg)
+	}
+}
+
+func TestAlertService_DispatchPerHook(t *testing.T) {
+	hits := map[string]int{}
+	failB := true
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.Path]++
+		if r.URL.Path == "/b" && failB {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer hook.Close()
+
+	srv, tx, now := newTestAlertService(t, []config.AlertWebhook{
+		{Name: "a", URL: hook.URL + "/a", Format: WebhookFormatJSON},
+		{Name: "b", URL: hook.URL + "/b", Format: WebhookFormatJSON},
+	})
+	alert := createAlert(t, tx, "member locked")
+	ctx := context.Background()
+
+	srv.dispatch(ctx)
+	msg, _ := srv.alertMessageDao.QueryByID(tx, alert.ID)
+	if msg.PushStatus != db.AlertPushPending || msg.PushedHooks != "a" || msg.PushError != "b: status 502" {
+		t.Fatalf("after b failed:(%+v)", msg)
+	}
+
+	// The retry only goes to the hook that failed
+	failB = false
+	*now = now.Add(pushBackoffBase)
+	srv.dispatch(ctx)
+	msg, _ = srv.alertMessageDao.QueryByID(tx, alert.ID)
+	if msg.PushStatus != db.AlertPushSent || msg.PushedHooks != "a,b" {
+		t.Fatalf("after the retry:(%+v)", msg)
+	}
+	if hits["/a"] != 1 || hits["/b"] != 2 {
+		t.Fatalf("webhook hits:(%+v), want a once and b twice", hits)
+	}
+}
+
+func TestAlertService_ListUnhandledAlertsPages(t *testing.T) {
+	srv, tx, _ := newTestAlertService(t, nil)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		createAlert(t, tx, "member locked")
+	}
+	srv.ResolveAlert(ctx, &view.ResolveAlertReq{ID: 2}, "amy")
+
+	var ids []int64
+	cursor := int64(0)
+	for page := 0; ; page++ {
+		resp, err := srv.ListUnhandledAlerts(ctx, &view.ListUnhandledAlertsReq{Cursor: cursor, Limit: 2})
+		if err != nil || len(resp.List) > 2 || page > 2 {
+			t.Fatalf("page %d:(%+v), err:(%+v)", page, resp, err)
+		}
+		for _, alert := range resp.List {
+			ids = append(ids, alert.ID)
+		}
+		if resp.NextCursor == 0 {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	if len(ids) != 4 || ids[0] != 1 || ids[1] != 3 || ids[3] != 5 {
+		t.Fatalf("alert ids:(%v), want 1 3 4 5", ids)
+	}
+}
+
+func TestAlertService_AckResolve(t *testing.T) {
+	srv, tx, _ := newTestAlertService(t, nil)
+	alert := createAlert(t, tx, "member locked")
+	ctx := context.Background()
+
+	if err := srv.AckAlert(ctx, &view.AckAlertReq{ID: alert.ID}, "amy"); err != nil {
+		t.Fatalf("ack err:(%+v)", err)
+	}
+	if err := srv.AckAlert(ctx, &view.AckAlertReq{ID: alert.ID}, "bob"); err != utils.ErrConflict {
+		t.Fatalf("second ack err:(%+v), want conflict", err)
+	}
+	resp, err := srv.ListUnhandledAlerts(ctx, &view.ListUnhandledAlertsReq{})
+	if err != nil || len(resp.List) != 1 || resp.List[0].Operator != "amy" || resp.List[0].Status != db.AlertStatusAcknowledged {
+		t.Fatalf("unhandled alerts:(%+v), err:(%+v)", resp, err)
+	}
+
+	if err := srv.ResolveAlert(ctx, &view.ResolveAlertReq{ID: alert.ID, Remark: "vendor confirmed"}, "bob"); err != nil {
+		t.Fatalf("resolve err:(%+v)", err)
+	}
+	msg, _ := srv.alertMessageDao.QueryByID(tx, alert.ID)
+	if msg.Status != db.AlertStatusHandled || msg.Operator != "bob" || msg.Remark != "vendor confirmed" || msg.ResolveTime == 0 {
+		t.Fatalf("resolved alert:(%+v)", msg)
+	}
+	if err := srv.ResolveAlert(ctx, &view.ResolveAlertReq{ID: 999}, "bob"); err != utils.ErrNotFound {
+		t.Fatalf("resolve missing err:(%+v), want not found", err)
+	}
+	resp, _ = srv.ListUnhandledAlerts(ctx, &view.ListUnhandledAlertsReq{})
+	if len(resp.List) != 0 {
+		t.Fatalf("unhandled alerts after resolve:(%+v)", resp.List)
+	}
+}
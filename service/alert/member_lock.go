@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	memberTransfersDefault = 50
+	memberTransfersMax     = 200
+	lockedMembersDefault   = 50
+	lockedMembersMax       = 200
+)
+
+// ListLockedMembers pages the trade locked members with the open alerts that explain the lock,
+// Cursor is the NextCursor of the previous page
+func (srv *alertService) ListLockedMembers(ctx context.Context, req *view.ListLockedMembersReq) (*view.ListLockedMembersResp, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = lockedMembersDefault
+	}
+	if limit > lockedMembersMax {
+		limit = lockedMembersMax
+	}
+	members, err := srv.userDao.QueryTradeLocked(srv.DB(), req.Cursor, limit)
+	if err != nil {
+		xlog.Errorf("error to query locked members, err:%+v", err)
+		return nil, err
+	}
+	ret := &view.ListLockedMembersResp{List: make([]*view.LockedMember, 0, len(members))}
+	if len(members) == 0 {
+		return ret, nil
+	}
+
+	mids := make([]int64, 0, len(members))
+	for _, member := range members {
+		mids = append(mids, member.ID)
+	}
+	alerts, err := srv.alertMessageDao.QueryUnhandledByMids(srv.DB(), mids)
+	if err != nil {
+		xlog.Errorf("error to query alerts of locked members, err:%+v", err)
+		return nil, err
+	}
+	alertsByMid := map[int64][]*view.AlertMessage{}
+	for _, alert := range alerts {
+		alertsByMid[alert.Mid] = append(alertsByMid[alert.Mid], alertToView(alert))
+	}
+
+	for _, member := range members {
+		ret.List = append(ret.List, &view.LockedMember{
+			Mid:      member.ID,
+			User:     member.User,
+			UserName: member.UserName,
+			AgentID:  member.Mem011,
+			Cash:     member.Cash,
+			Alerts:   alertsByMid[member.ID],
+		})
+	}
+	if len(members) == limit {
+		ret.NextCursor = members[len(members)-1].ID
+	}
+	return ret, nil
+}
+
+// GetMemberTransfers returns the latest wallet records of a member
+func (srv *alertService) GetMemberTransfers(ctx context.Context, req *view.GetMemberTransfersReq) (*view.GetMemberTransfersResp, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = memberTransfersDefault
+	}
+	if limit > memberTransfersMax {
+		limit = memberTransfersMax
+	}
+	records, err := srv.inOutMDao.QueryRecentByMember(srv.DB(), req.Mid, limit)
+	if err != nil {
+		xlog.Errorf("error to query member transfers, mid:%d, err:%+v", req.Mid, err)
+		return nil, err
+	}
+	ret := &view.GetMemberTransfersResp{List: make([]*view.MemberTransfer, 0, len(records))}
+	for _, record := range records {
+		ret.List = append(ret.List, &view.MemberTransfer{
+			ID:      record.Iom001,
+			Time:    record.Iom002.Unix(),
+			Money:   record.Iom004,
+			Code:    record.Iom005,
+			AgentID: record.Iom007,
+			Memo:    record.Iom008,
+			Balance: record.Iom010,
+		})
+	}
+	return ret, nil
+}
+
+// ReviewMemberLock unlocks a locked member or keeps the lock, closes its open alerts with the reason
+// and records the decision in member_lock_audit
+func (srv *alertService) ReviewMemberLock(ctx context.Context, req *view.ReviewMemberLockReq, operator string) error {
+	if req.Decision != db.LockDecisionUnlock && req.Decision != db.LockDecisionKeep {
+		return utils.ErrParamError
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return utils.ErrParamError
+	}
+
+	return srv.Tx(func(tx *gorm.DB) error {
+		member, err := srv.userDao.QueryByIDForUpdate(tx, req.Mid)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			xlog.Errorf("error to query member, mid:%d, err:%+v", req.Mid, err)
+			return err
+		}
+		// Another operator may have decided while this one was reading
+		if member.Mem020 != "Y" {
+			return utils.ErrConflict
+		}
+
+		if req.Decision == db.LockDecisionUnlock {
+			err = srv.userDao.UpdatesMember(tx, member.ID, map[string]interface{}{
+				"mem020": "N",
+			})
+			if err != nil {
+				xlog.Errorf("error to unlock member, mid:%d, err:%+v", member.ID, err)
+				return err
+			}
+		}
+
+		alerts, err := srv.alertMessageDao.QueryUnhandledByMids(tx, []int64{member.ID})
+		if err != nil {
+			xlog.Errorf("error to query alerts of member, mid:%d, err:%+v", member.ID, err)
+			return err
+		}
+		now := srv.now()
+		alertIDs := make([]string, 0, len(alerts))
+		for _, alert := range alerts {
+			_, err := srv.alertMessageDao.UpdateStatus(tx, alert.ID, []int{db.AlertStatusUnhandled, db.AlertStatusAcknowledged}, db.AlertStatusHandled, map[string]interface{}{
+				"operator":     operator,
+				"resolve_time": now.Unix(),
+				"remark":       req.Decision + ": " + req.Reason,
+			})
+			if err != nil {
+				xlog.Errorf("error to resolve alert, id:%d, err:%+v", alert.ID, err)
+				return err
+			}
+			alertIDs = append(alertIDs, strconv.FormatInt(alert.ID, 10))
+		}
+
+		_, err = srv.memberLockAuditDao.Create(tx, &db.MemberLockAudit{
+			Mid:        member.ID,
+			Operator:   operator,
+			Decision:   req.Decision,
+			Reason:     req.Reason,
+			AlertIDs:   strings.Join(alertIDs, ","),
+			CreateTime: now,
+		})
+		if err != nil {
+			xlog.Errorf("error to create member lock audit, mid:%d, err:%+v", member.ID, err)
+			return err
+		}
+		return nil
+	})
+}
+
+// GetMemberLockAudits returns the lock decisions on a member, latest first
+func (srv *alertService) GetMemberLockAudits(ctx context.Context, req *view.GetMemberLockAuditsReq) (*view.GetMemberLockAuditsResp, error) {
+	audits, err := srv.memberLockAuditDao.QueryByMid(srv.DB(), req.Mid)
+	if err != nil {
+		xlog.Errorf("error to query member lock audits, mid:%d, err:%+v", req.Mid, err)
+		return nil, err
+	}
+	ret := &view.GetMemberLockAuditsResp{List: make([]*view.MemberLockAudit, 0, len(audits))}
+	for _, audit := range audits {
+		ret.List = append(ret.List, &view.MemberLockAudit{
+			ID:         audit.ID,
+			Mid:        audit.Mid,
+			Operator:   audit.Operator,
+			Decision:   audit.Decision,
+			Reason:     audit.Reason,
+			AlertIDs:   audit.AlertIDs,
+			CreateTime: audit.CreateTime.Unix(),
+		})
+	}
+	return ret, nil
+}
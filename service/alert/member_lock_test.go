@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/view"
+	"testing"
+)
+
+func TestAlertService_ReviewMemberLock(t *testing.T) {
+	srv, tx, _ := newTestAlertService(t, nil)
+	srv.userDao = db.NewMemberDao()
+	srv.inOutMDao = db.NewInOutMDao()
+	srv.memberLockAuditDao = db.NewMemberLockAuditDao()
+	for _, ddl := range []string{
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT, mem004 TEXT, mem011 INTEGER, mem020 TEXT, cash DECIMAL(15,4))",
+		"CREATE TABLE member_lock_audit (id INTEGER PRIMARY KEY, mid INTEGER, operator TEXT, decision TEXT, reason TEXT, alert_ids TEXT, create_time DATETIME)",
+		"INSERT INTO member VALUES (1, 'tom', 'Tom', 9, 'Y', 100), (2, 'amy', 'Amy', 9, 'Y', 0), (3, 'bob', 'Bob', 9, 'N', 0)",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	first := createAlert(t, tx, "tom locked")
+	second := createAlert(t, tx, "tom again")
+	ctx := context.Background()
+
+	locked, err := srv.ListLockedMembers(ctx, &view.ListLockedMembersReq{})
+	if err != nil || len(locked.List) != 2 || locked.NextCursor != 0 {
+		t.Fatalf("locked members:(%+v), err:(%+v)", locked, err)
+	}
+	if tom := locked.List[0]; tom.User != "tom" || len(tom.Alerts) != 2 || tom.Alerts[0].ID != second.ID {
+		t.Fatalf("tom:(%+v), want both alerts latest first", tom)
+	}
+	// One member a page, the second page holds amy
+	locked, _ = srv.ListLockedMembers(ctx, &view.ListLockedMembersReq{Limit: 1})
+	if len(locked.List) != 1 || locked.List[0].Mid != 1 || locked.NextCursor != 1 {
+		t.Fatalf("first page:(%+v)", locked)
+	}
+	locked, _ = srv.ListLockedMembers(ctx, &view.ListLockedMembersReq{Cursor: locked.NextCursor, Limit: 1})
+	if len(locked.List) != 1 || locked.List[0].Mid != 2 || locked.NextCursor != 2 {
+		t.Fatalf("second page:(%+v)", locked)
+	}
+	locked, _ = srv.ListLockedMembers(ctx, &view.ListLockedMembersReq{Cursor: locked.NextCursor, Limit: 1})
+	if len(locked.List) != 0 || locked.NextCursor != 0 {
+		t.Fatalf("last page:(%+v)", locked)
+	}
+
+	if err := srv.ReviewMemberLock(ctx, &view.ReviewMemberLockReq{Mid: 1, Decision: db.LockDecisionUnlock}, "amy"); err != utils.ErrParamError {
+		t.Fatalf("review without reason err:(%+v)", err)
+	}
+	if err := srv.ReviewMemberLock(ctx, &view.ReviewMemberLockReq{Mid: 3, Decision: db.LockDecisionUnlock, Reason: "ok"}, "amy"); err != utils.ErrConflict {
+		t.Fatalf("review unlocked member err:(%+v)", err)
+	}
+	if err := srv.ReviewMemberLock(ctx, &view.ReviewMemberLockReq{Mid: 1, Decision: db.LockDecisionUnlock, Reason: "vendor confirmed"}, "amy"); err != nil {
+		t.Fatalf("unlock err:(%+v)", err)
+	}
+	if err := srv.ReviewMemberLock(ctx, &view.ReviewMemberLockReq{Mid: 2, Decision: db.LockDecisionKeep, Reason: "under review"}, "amy"); err != nil {
+		t.Fatalf("keep err:(%+v)", err)
+	}
+
+	member, _ := srv.userDao.QueryByID(tx, 1)
+	if member.Mem020 != "N" {
+		t.Fatalf("tom still locked:(%s)", member.Mem020)
+	}
+	member, _ = srv.userDao.QueryByID(tx, 2)
+	if member.Mem020 != "Y" {
+		t.Fatalf("amy unlocked by a keep decision")
+	}
+	alert, _ := srv.alertMessageDao.QueryByID(tx, first.ID)
+	if alert.Status != db.AlertStatusHandled || alert.Operator != "amy" {
+		t.Fatalf("alert of an unlocked member:(%+v)", alert)
+	}
+
+	audits, err := srv.GetMemberLockAudits(ctx, &view.GetMemberLockAuditsReq{Mid: 1})
+	if err != nil || len(audits.List) != 1 {
+		t.Fatalf("audits:(%+v), err:(%+v)", audits, err)
+	}
+	if audit := audits.List[0]; audit.Decision != db.LockDecisionUnlock || audit.Operator != "amy" || audit.Reason != "vendor confirmed" || audit.AlertIDs == "" {
+		t.Fatalf("audit:(%+v)", audit)
+	}
+
+	locked, _ = srv.ListLockedMembers(ctx, &view.ListLockedMembersReq{})
+	if len(locked.List) != 1 || locked.List[0].Mid != 2 {
+		t.Fatalf("locked members after review:(%+v)", locked.List)
+	}
+}
@@ -0,0 +1,635 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-zrbc/config"
+	"go-zrbc/db"
+	"go-zrbc/es"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/service"
+	"go-zrbc/view"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Tables the indexer tails into their report index
+const (
+	TableBet01  = "bet01"
+	TableBet02  = "bet02"
+	TableInOutM = "in_out_m"
+)
+
+var Tables = []string{TableBet01, TableBet02, TableInOutM}
+
+const (
+	defaultPollInterval = 5 * time.Second
+	indexBatch          = 1000
+	// settleDelay keeps the tail behind now, a second still being written is read once it is over
+	settleDelay = 3 * time.Second
+	// checkpointKey hash of table to the json checkpoint the tail resumes from
+	checkpointKey = "es_indexer_checkpoint"
+	// lateCommitWindow is how far behind its checkpoint a tail reads again. iom001 and updatetime are taken when
+	// the row is written, a transaction committing later lands behind rows already read. settleDelay only covers
+	// the transactions that commit within it.
+	lateCommitWindow = time.Minute
+
+	// lockKey is held by whoever writes the index of a table, a reindex keeps the tail off it until the swap
+	lockKey   = "es_indexer_lock_%s"
+	lockTTL   = time.Minute
+	lockRetry = time.Second
+)
+
+var (
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+	refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+)
+
+var (
+	indexerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "es_indexer_lag_seconds",
+		Help: "Seconds between now and the last change indexed, 0 once the table is caught up",
+	}, []string{"table"})
+	indexerDocs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "es_indexer_docs_indexed_total",
+		Help: "Documents written to the report indices",
+	}, []string{"index"})
+	indexerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "es_indexer_errors_total",
+		Help: "Failed indexer batches",
+	}, []string{"table"})
+)
+
+// Sink is the part of es.Client the indexer writes through
+type Sink interface {
+	BulkIndex(ctx context.Context, index string, docs []interface{}) error
+	IndexExists(ctx context.Context, name string) (bool, error)
+	CreateIndex(ctx context.Context, index, body string) error
+	SwapAlias(ctx context.Context, alias, index string) ([]string, error)
+	DeleteIndex(ctx context.Context, indices ...string) error
+	BulkDelete(ctx context.Context, index string, ids []string) error
+
+	// What the reconcile check compares with MySQL
+	SumBet02(ctx context.Context, agentID int64, start, end time.Time) (*db.ReconcileTotals, error)
+	SumInOutM(ctx context.Context, agentID int64, start, end time.Time) (*db.ReconcileTotals, error)
+	ListBet02Versions(ctx context.Context, agentID int64, start, end time.Time) (map[int64]int64, error)
+	ListInOutMVersions(ctx context.Context, agentID int64, start, end time.Time) (map[int64]int64, error)
+}
+
+type Indexer interface {
+	// Run tails every table into its index until ctx is done
+	Run(ctx context.Context)
+	// Sync indexes one batch of table after its checkpoint and returns how many rows it read
+	Sync(ctx context.Context, table string) (int, error)
+	// Reindex rebuilds the index of table into a new index and swaps its alias over
+	Reindex(ctx context.Context, table string) error
+	// Reconcile compares the bet02 and in_out_m of agentID on day with their report indices and, with fix,
+	// indexes the documents that differ again
+	Reconcile(ctx context.Context, agentID int64, day time.Time, fix bool) (*view.EsReconcileReport, error)
+	// ReconcileAgents returns the agents with bets or balance changes on day
+	ReconcileAgents(ctx context.Context, day time.Time) ([]int64, error)
+}
+
+// Checkpoint is where the tail of a table stopped, Time is the unix millis of the last change and
+// ID its bet01 or iom001
+type Checkpoint struct {
+	Time int64 `json:"t"`
+	ID   int64 `json:"id"`
+}
+
+type indexer struct {
+	bet01Dao  db.Bet01Dao
+	bet02Dao  db.Bet02Dao
+	inOutMDao db.InOutMDao
+
+	sink     Sink
+	redisCli *redis.Client
+	interval time.Duration
+	now      func() time.Time
+	// table to the id and version in unix millis, iom002 or updatetime, of the rows indexed within
+	// lateCommitWindow, so reading the window again only indexes what was not there before. The map of a
+	// table is used with its lock held.
+	recent map[string]map[int64]int64
+	*service.Session
+}
+
+func NewIndexer(
+	sess *service.Session,
+	bet01Dao db.Bet01Dao,
+	bet02Dao db.Bet02Dao,
+	inOutMDao db.InOutMDao,
+	sink Sink,
+	redisCli *redis.Client,
+) Indexer {
+	interval := defaultPollInterval
+	if config.Global.ES.IndexerInterval > 0 {
+		interval = time.Duration(config.Global.ES.IndexerInterval) * time.Second
+	}
+	srv := &indexer{
+		bet01Dao:  bet01Dao,
+		bet02Dao:  bet02Dao,
+		inOutMDao: inOutMDao,
+		sink:      sink,
+		redisCli:  redisCli,
+		interval:  interval,
+		now:       time.Now,
+
+		recent: map[string]map[int64]int64{},
+	}
+	srv.Session = sess
+	return srv
+}
+
+// indexOf returns the alias and index body of table
+func indexOf(table string) (string, string, error) {
+	switch table {
+	case TableBet01:
+		return es.Bet01ReportIndex, es.Bet01ReportMapping, nil
+	case TableBet02:
+		return es.Bet02ReportIndex, es.Bet02ReportMapping, nil
+	case TableInOutM:
+		return es.InOutMIndex, es.InOutMMapping, nil
+	}
+	return "", "", fmt.Errorf("unknown indexer table %q", table)
+}
+
+func (srv *indexer) Run(ctx context.Context) {
+	// A missing alias would be created by the first write with guessed mappings
+	for _, table := range Tables {
+		alias, _, _ := indexOf(table)
+		exists, err := srv.sink.IndexExists(ctx, alias)
+		if err != nil {
+			xlog.Errorf("error to check index %s, err:%+v", alias, err)
+			continue
+		}
+		if !exists {
+			if err := srv.Reindex(ctx, table); err != nil {
+				xlog.Errorf("error to build index of %s, err:%+v", table, err)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(srv.interval)
+	defer ticker.Stop()
+	for {
+		for _, table := range Tables {
+			// a full batch means more is waiting, keep reading before the next tick
+			for {
+				n, err := srv.Sync(ctx, table)
+				if err != nil || n < indexBatch || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *indexer) Sync(ctx context.Context, table string) (int, error) {
+	alias, _, err := indexOf(table)
+	if err != nil {
+		return 0, err
+	}
+	lock, err := srv.lock(ctx, table)
+	if err != nil {
+		xlog.Errorf("error to lock %s, err:%+v", table, err)
+		indexerErrors.WithLabelValues(table).Inc()
+		return 0, err
+	}
+	if lock == nil {
+		// a reindex is writing the table, the tail goes on from where it ends
+		return 0, nil
+	}
+	defer lock.unlock()
+
+	cp, err := srv.loadCheckpoint(ctx, table)
+	if err != nil {
+		xlog.Errorf("error to load checkpoint of %s, err:%+v", table, err)
+		indexerErrors.WithLabelValues(table).Inc()
+		return 0, err
+	}
+
+	next, n, err := srv.batch(ctx, table, alias, cp, srv.now().Add(-settleDelay))
+	if err != nil {
+		xlog.Errorf("error to index %s after %+v, err:%+v", table, cp, err)
+		indexerErrors.WithLabelValues(table).Inc()
+		if cp != nil {
+			indexerLag.WithLabelValues(table).Set(srv.now().Sub(time.UnixMilli(cp.Time)).Seconds())
+		}
+		return 0, err
+	}
+	if n == 0 {
+		indexerLag.WithLabelValues(table).Set(0)
+		return 0, nil
+	}
+	if err := srv.saveCheckpoint(ctx, table, next); err != nil {
+		xlog.Errorf("error to save checkpoint of %s, err:%+v", table, err)
+		indexerErrors.WithLabelValues(table).Inc()
+		return 0, err
+	}
+	if n < indexBatch {
+		indexerLag.WithLabelValues(table).Set(0)
+	} else {
+		indexerLag.WithLabelValues(table).Set(srv.now().Sub(time.UnixMilli(next.Time)).Seconds())
+	}
+	return n, nil
+}
+
+func (srv *indexer) Reindex(ctx context.Context, table string) error {
+	alias, body, err := indexOf(table)
+	if err != nil {
+		return err
+	}
+	lock, err := srv.waitLock(ctx, table)
+	if err != nil {
+		return err
+	}
+	defer lock.unlock()
+
+	started := srv.now()
+	index := fmt.Sprintf("%s_%d", alias, started.Unix())
+	if err := srv.sink.CreateIndex(ctx, index, body); err != nil {
+		return err
+	}
+
+	var (
+		cp    *Checkpoint
+		total int
+		until = started.Add(-settleDelay)
+	)
+	for {
+		next, n, err := srv.batch(ctx, table, index, cp, until)
+		if err != nil {
+			return err
+		}
+		if err := lock.refresh(ctx); err != nil {
+			return err
+		}
+		total += n
+		if n == 0 {
+			break
+		}
+		cp = next
+		if n < indexBatch {
+			break
+		}
+	}
+
+	old, err := srv.sink.SwapAlias(ctx, alias, index)
+	if err != nil {
+		return err
+	}
+	// The tail goes on from the end of the rebuild, what changed meanwhile went to the old index
+	if cp != nil {
+		if err := srv.saveCheckpoint(ctx, table, cp); err != nil {
+			return err
+		}
+	} else if err := srv.redisCli.HDel(ctx, checkpointKey, table).Err(); err != nil {
+		return err
+	}
+	if err := srv.sink.DeleteIndex(ctx, old...); err != nil {
+		xlog.Errorf("error to delete old indices %v of %s, err:%+v", old, alias, err)
+	}
+	xlog.Infof("reindex %s into %s done, docs:%d, took:%s", table, index, total, srv.now().Sub(started))
+	return nil
+}
+
+// batch indexes the rows of table after cp changed no later than until into index and returns the checkpoint of
+// the last one
+func (srv *indexer) batch(ctx context.Context, table, index string, cp *Checkpoint, until time.Time) (*Checkpoint, int, error) {
+	switch table {
+	case TableBet01:
+		return srv.batchBet01(ctx, index, cp, until)
+	case TableBet02:
+		return srv.batchBet02(ctx, index, cp, until)
+	case TableInOutM:
+		return srv.batchInOutM(ctx, index, cp, until)
+	}
+	return nil, 0, fmt.Errorf("unknown indexer table %q", table)
+}
+
+func (srv *indexer) batchBet01(ctx context.Context, index string, cp *Checkpoint, until time.Time) (*Checkpoint, int, error) {
+	bets, err := srv.bet01Dao.GetBet01ListForIndex(srv.DB(), cp.cursor(), until, indexBatch)
+	if err != nil {
+		return nil, 0, err
+	}
+	late, err := srv.lateBet01s(cp)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(bets) == 0 && len(late) == 0 {
+		return cp, 0, nil
+	}
+	bets = append(late, bets...)
+	if err := srv.index(ctx, index, bet01Docs(bets)); err != nil {
+		return nil, 0, err
+	}
+	for _, bet := range bets {
+		srv.remember(TableBet01, bet.Bet01.Bet01, bet.Updatetime.UnixMilli())
+	}
+	bets = bets[len(late):]
+	if len(bets) == 0 {
+		return cp, 0, nil
+	}
+	last := bets[len(bets)-1]
+	return &Checkpoint{Time: last.Updatetime.UnixMilli(), ID: last.Bet01.Bet01}, len(bets), nil
+}
+
+func (srv *indexer) batchBet02(ctx context.Context, index string, cp *Checkpoint, until time.Time) (*Checkpoint, int, error) {
+	bets, err := srv.bet02Dao.GetBet02ListForIndex(srv.DB(), cp.cursor(), until, indexBatch)
+	if err != nil {
+		return nil, 0, err
+	}
+	late, err := srv.lateBet02s(cp)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(bets) == 0 && len(late) == 0 {
+		return cp, 0, nil
+	}
+	bets = append(late, bets...)
+	if err := srv.indexBet02(ctx, index, bets); err != nil {
+		return nil, 0, err
+	}
+	for _, bet := range bets {
+		srv.remember(TableBet02, bet.Bet01, bet.Updatetime.UnixMilli())
+	}
+	bets = bets[len(late):]
+	if len(bets) == 0 {
+		return cp, 0, nil
+	}
+	last := bets[len(bets)-1]
+	return &Checkpoint{Time: last.Updatetime.UnixMilli(), ID: last.Bet01}, len(bets), nil
+}
+
+// indexBet02 indexes bets into index and their bet01 documents as settled
+func (srv *indexer) indexBet02(ctx context.Context, index string, bets []*db.Bet02Extra) error {
+	docs := make([]interface{}, 0, len(bets))
+	ids := make([]int64, 0, len(bets))
+	for _, bet := range bets {
+		docs = append(docs, es.NewBet02Doc(bet))
+		ids = append(ids, bet.Bet01)
+	}
+	if err := srv.index(ctx, index, docs); err != nil {
+		return err
+	}
+
+	// Writing a bet02 settles its bet01 without touching the bet01 row
+	settled, err := srv.bet01Dao.GetBet01ListForIndexByIDs(srv.DB(), ids)
+	if err != nil {
+		return err
+	}
+	return srv.index(ctx, es.Bet01ReportIndex, bet01Docs(settled))
+}
+
+func (srv *indexer) batchInOutM(ctx context.Context, index string, cp *Checkpoint, until time.Time) (*Checkpoint, int, error) {
+	var afterID int64
+	if cp != nil {
+		afterID = cp.ID
+	}
+	records, err := srv.inOutMDao.GetInOutMListForIndex(srv.DB(), afterID, until, indexBatch)
+	if err != nil {
+		return nil, 0, err
+	}
+	late, err := srv.lateInOutMs(cp)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(records) == 0 && len(late) == 0 {
+		return cp, 0, nil
+	}
+	docs := make([]interface{}, 0, len(late)+len(records))
+	for _, record := range append(late, records...) {
+		docs = append(docs, es.NewInOutMDoc(record))
+	}
+	if err := srv.index(ctx, index, docs); err != nil {
+		return nil, 0, err
+	}
+	for _, record := range late {
+		srv.remember(TableInOutM, record.Iom001, record.Iom002.UnixMilli())
+	}
+	for _, record := range records {
+		srv.remember(TableInOutM, record.Iom001, record.Iom002.UnixMilli())
+	}
+	if len(records) == 0 {
+		return cp, 0, nil
+	}
+	last := records[len(records)-1]
+	return &Checkpoint{Time: last.Iom002.UnixMilli(), ID: last.Iom001}, len(records), nil
+}
+
+// lateInOutMs returns the records up to cp written within lateCommitWindow of it that were not indexed yet
+func (srv *indexer) lateInOutMs(cp *Checkpoint) ([]*db.InOutM, error) {
+	if cp == nil {
+		return nil, nil
+	}
+	records, err := srv.inOutMDao.GetInOutMListForIndexSince(srv.DB(), srv.lateSince(TableInOutM, cp), cp.ID)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*db.InOutM
+	for _, record := range records {
+		if !srv.indexed(TableInOutM, record.Iom001, record.Iom002.UnixMilli()) {
+			ret = append(ret, record)
+		}
+	}
+	return ret, nil
+}
+
+// lateBet01s returns the bets up to cp changed within lateCommitWindow of it whose change was not indexed yet
+func (srv *indexer) lateBet01s(cp *Checkpoint) ([]*db.Bet01Extra, error) {
+	if cp == nil {
+		return nil, nil
+	}
+	bets, err := srv.bet01Dao.GetBet01ListForIndexSince(srv.DB(), srv.lateSince(TableBet01, cp), cp.cursor())
+	if err != nil {
+		return nil, err
+	}
+	var ret []*db.Bet01Extra
+	for _, bet := range bets {
+		if !srv.indexed(TableBet01, bet.Bet01.Bet01, bet.Updatetime.UnixMilli()) {
+			ret = append(ret, bet)
+		}
+	}
+	return ret, nil
+}
+
+// lateBet02s returns the bets up to cp changed within lateCommitWindow of it whose change was not indexed yet
+func (srv *indexer) lateBet02s(cp *Checkpoint) ([]*db.Bet02Extra, error) {
+	if cp == nil {
+		return nil, nil
+	}
+	bets, err := srv.bet02Dao.GetBet02ListForIndexSince(srv.DB(), srv.lateSince(TableBet02, cp), cp.cursor())
+	if err != nil {
+		return nil, err
+	}
+	var ret []*db.Bet02Extra
+	for _, bet := range bets {
+		if !srv.indexed(TableBet02, bet.Bet01, bet.Updatetime.UnixMilli()) {
+			ret = append(ret, bet)
+		}
+	}
+	return ret, nil
+}
+
+// lateSince is where the window of table behind cp starts, the rows indexed before it are forgotten. A restart
+// reads the whole window once more, the documents are kept by id so that only overwrites them.
+func (srv *indexer) lateSince(table string, cp *Checkpoint) time.Time {
+	since := time.UnixMilli(cp.Time).Add(-lateCommitWindow)
+	for id, version := range srv.recent[table] {
+		if version < since.UnixMilli() {
+			delete(srv.recent[table], id)
+		}
+	}
+	return since
+}
+
+// indexed reports whether the row id of table was indexed at version
+func (srv *indexer) indexed(table string, id, version int64) bool {
+	v, ok := srv.recent[table][id]
+	return ok && v == version
+}
+
+func (srv *indexer) remember(table string, id, version int64) {
+	if srv.recent[table] == nil {
+		srv.recent[table] = map[int64]int64{}
+	}
+	srv.recent[table][id] = version
+}
+
+func (srv *indexer) index(ctx context.Context, index string, docs []interface{}) error {
+	if err := srv.sink.BulkIndex(ctx, index, docs); err != nil {
+		return err
+	}
+	indexerDocs.WithLabelValues(index).Add(float64(len(docs)))
+	return nil
+}
+
+func bet01Docs(bets []*db.Bet01Extra) []interface{} {
+	docs := make([]interface{}, 0, len(bets))
+	for _, bet := range bets {
+		docs = append(docs, es.NewBet01Doc(bet))
+	}
+	return docs
+}
+
+// cursor is the bet keyset position of the checkpoint, nil for a tail that has not started
+func (cp *Checkpoint) cursor() *db.ReportCursor {
+	if cp == nil {
+		return nil
+	}
+	return &db.ReportCursor{Time: cp.Time, Bet01: cp.ID}
+}
+
+// lockedTables are the tables whose indices a write of table goes to. Indexing a bet02 settles its bet01
+// document, a bet01 reindex must not run meanwhile or the settle would land in the index it swaps away.
+func lockedTables(table string) []string {
+	if table == TableBet02 {
+		return []string{TableBet01, TableBet02}
+	}
+	return []string{table}
+}
+
+// tableLock is the lock of a table and of the tables it writes to, held with token
+type tableLock struct {
+	redisCli *redis.Client
+	keys     []string
+	token    string
+}
+
+// lock takes the lock of table for the tail, nil when a reindex holds it or a table it writes to
+func (srv *indexer) lock(ctx context.Context, table string) (*tableLock, error) {
+	l := &tableLock{redisCli: srv.redisCli, token: uuid.New().String()}
+	for _, t := range lockedTables(table) {
+		key := fmt.Sprintf(lockKey, t)
+		ok, err := srv.redisCli.SetNX(ctx, key, l.token, lockTTL).Result()
+		if err != nil || !ok {
+			l.unlock()
+			return nil, err
+		}
+		l.keys = append(l.keys, key)
+	}
+	return l, nil
+}
+
+// waitLock takes the lock of table once the tail lets it go
+func (srv *indexer) waitLock(ctx context.Context, table string) (*tableLock, error) {
+	ticker := time.NewTicker(lockRetry)
+	defer ticker.Stop()
+	for {
+		l, err := srv.lock(ctx, table)
+		if err != nil || l != nil {
+			return l, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *tableLock) unlock() {
+	// the ctx may be done already, the lock must go anyway
+	for _, key := range l.keys {
+		if err := unlockScript.Run(context.Background(), l.redisCli, []string{key}, l.token).Err(); err != nil {
+			xlog.Errorf("error to unlock %s, err:%+v", key, err)
+		}
+	}
+}
+
+// refresh keeps the lock for another lockTTL, it fails once the lock expired and went to someone else
+func (l *tableLock) refresh(ctx context.Context) error {
+	for _, key := range l.keys {
+		n, err := refreshScript.Run(ctx, l.redisCli, []string{key}, l.token, lockTTL.Milliseconds()).Int()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("lost the lock %s", key)
+		}
+	}
+	return nil
+}
+
+func (srv *indexer) loadCheckpoint(ctx context.Context, table string) (*Checkpoint, error) {
+	val, err := srv.redisCli.HGet(ctx, checkpointKey, table).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal([]byte(val), &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func (srv *indexer) saveCheckpoint(ctx context.Context, table string, cp *Checkpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return srv.redisCli.HSet(ctx, checkpointKey, table, b).Err()
+}
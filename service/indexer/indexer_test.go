@@ -0,0 +1,442 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/es"
+	"go-zrbc/service"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/go-redis/redis/v8"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeSink keeps the documents of each index by id
+type fakeSink struct {
+	docs    map[string]map[string]interface{}
+	aliases map[string]string
+	deleted []string
+}
+
+func (s *fakeSink) BulkIndex(ctx context.Context, index string, docs []interface{}) error {
+	if target, ok := s.aliases[index]; ok {
+		index = target
+	}
+	if s.docs[index] == nil {
+		s.docs[index] = map[string]interface{}{}
+	}
+	for _, doc := range docs {
+		s.docs[index][doc.(es.Document).DocID()] = doc
+	}
+	return nil
+}
+
+func (s *fakeSink) IndexExists(ctx context.Context, name string) (bool, error) {
+	_, aliased := s.aliases[name]
+	_, indexed := s.docs[name]
+	return aliased || indexed, nil
+}
+
+func (s *fakeSink) CreateIndex(ctx context.Context, index, body string) error {
+	s.docs[index] = map[string]interface{}{}
+	return nil
+}
+
+func (s *fakeSink) SwapAlias(ctx context.Context, alias, index string) ([]string, error) {
+	var old []string
+	if target, ok := s.aliases[alias]; ok && target != index {
+		old = append(old, target)
+	}
+	s.aliases[alias] = index
+	return old, nil
+}
+
+func (s *fakeSink) DeleteIndex(ctx context.Context, indices ...string) error {
+	for _, index := range indices {
+		delete(s.docs, index)
+		s.deleted = append(s.deleted, index)
+	}
+	return nil
+}
+
+func (s *fakeSink) BulkDelete(ctx context.Context, index string, ids []string) error {
+	if target, ok := s.aliases[index]; ok {
+		index = target
+	}
+	for _, id := range ids {
+		delete(s.docs[index], id)
+	}
+	return nil
+}
+
+// bet02s returns the bet02 documents of an agent placed in [start, end)
+func (s *fakeSink) bet02s(agentID int64, start, end time.Time) []*es.Bet02Doc {
+	index := es.Bet02ReportIndex
+	if target, ok := s.aliases[index]; ok {
+		index = target
+	}
+	var ret []*es.Bet02Doc
+	for _, doc := range s.docs[index] {
+		bet := doc.(*es.Bet02Doc)
+		if int64(bet.Bet22) == agentID && bet.Bet08 >= start.UnixMilli() && bet.Bet08 < end.UnixMilli() {
+			ret = append(ret, bet)
+		}
+	}
+	return ret
+}
+
+// inOutMs returns the in_out_m documents of an agent written in [start, end)
+func (s *fakeSink) inOutMs(agentID int64, start, end time.Time) []*es.InOutMDoc {
+	index := es.InOutMIndex
+	if target, ok := s.aliases[index]; ok {
+		index = target
+	}
+	var ret []*es.InOutMDoc
+	for _, doc := range s.docs[index] {
+		record := doc.(*es.InOutMDoc)
+		t, _ := time.Parse(time.RFC3339, record.Iom002)
+		if record.Iom007 == agentID && !t.Before(start) && t.Before(end) {
+			ret = append(ret, record)
+		}
+	}
+	return ret
+}
+
+func (s *fakeSink) SumBet02(ctx context.Context, agentID int64, start, end time.Time) (*db.ReconcileTotals, error) {
+	ret := &db.ReconcileTotals{}
+	for _, bet := range s.bet02s(agentID, start, end) {
+		ret.Count++
+		ret.Bet = ret.Bet.Add(bet.Bet13)
+		ret.Result = ret.Result.Add(bet.Bet17)
+		ret.Water = ret.Water.Add(bet.Bet16)
+	}
+	return ret, nil
+}
+
+func (s *fakeSink) SumInOutM(ctx context.Context, agentID int64, start, end time.Time) (*db.ReconcileTotals, error) {
+	ret := &db.ReconcileTotals{}
+	for _, record := range s.inOutMs(agentID, start, end) {
+		ret.Count++
+		ret.Money = ret.Money.Add(decimal.RequireFromString(record.Iom004.String()))
+	}
+	return ret, nil
+}
+
+func (s *fakeSink) ListBet02Versions(ctx context.Context, agentID int64, start, end time.Time) (map[int64]int64, error) {
+	ret := map[int64]int64{}
+	for _, bet := range s.bet02s(agentID, start, end) {
+		ret[bet.Bet01] = bet.Updatetime
+	}
+	return ret, nil
+}
+
+func (s *fakeSink) ListInOutMVersions(ctx context.Context, agentID int64, start, end time.Time) (map[int64]int64, error) {
+	ret := map[int64]int64{}
+	for _, record := range s.inOutMs(agentID, start, end) {
+		t, _ := time.Parse(time.RFC3339, record.Iom002)
+		ret[record.Iom001] = t.UnixMilli()
+	}
+	return ret, nil
+}
+
+func newTestIndexer(t *testing.T) (*indexer, *gorm.DB, *fakeSink, *time.Time) {
+	// sqlite reads datetimes as UTC where MySQL with loc=Local reads them local
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "indexer.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE game_type (Code INTEGER PRIMARY KEY, cnname TEXT NOT NULL)",
+		"CREATE TABLE game_info (gi001 INTEGER, gi002 TEXT, gi003 INTEGER, gi007 TEXT)",
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT)",
+		"CREATE TABLE bet01 (bet01 INTEGER PRIMARY KEY, bet02 INTEGER, bet03 TEXT, bet04 INTEGER, bet05 INTEGER, bet07 DATETIME, " +
+			"bet08 DATETIME, bet09 TEXT, bet13 DECIMAL(15,4), bet19 INTEGER, bet30 TEXT, bet31 INTEGER, updatetime DATETIME)",
+		"CREATE TABLE bet02 (bet01 INTEGER PRIMARY KEY, bet02 INTEGER, bet03 TEXT, bet04 INTEGER, bet05 INTEGER, bet07 DATETIME, " +
+			"bet08 DATETIME, bet09 TEXT, bet13 DECIMAL(15,4), bet14 DECIMAL(15,4), bet17 DECIMAL(15,4), bet22 INTEGER, updatetime DATETIME)",
+		"CREATE TABLE in_out_m (iom001 INTEGER PRIMARY KEY, iom002 DATETIME, iom003 INTEGER, iom004 DECIMAL(15,4), iom005 TEXT, " +
+			"iom006 INTEGER, iom007 INTEGER, iom008 TEXT, iom009 INTEGER, iom010 DECIMAL(15,4))",
+		"INSERT INTO game_type (Code, cnname) VALUES (101, '百家乐')",
+		"INSERT INTO game_info VALUES (101, '100', 1, '1,2')",
+		"INSERT INTO member (mem001, mem002) VALUES (1, 'tom')",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+
+	mr := miniredis.RunT(t)
+	sink := &fakeSink{docs: map[string]map[string]interface{}{}, aliases: map[string]string{}}
+	now := time.Date(2024, 1, 1, 10, 0, 10, 0, time.UTC)
+	srv := &indexer{
+		bet01Dao:  db.NewBet01Dao(),
+		bet02Dao:  db.NewBet02Dao(),
+		inOutMDao: db.NewInOutMDao(),
+		sink:      sink,
+		redisCli:  redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		interval:  time.Second,
+		now:       func() time.Time { return now },
+		Session:   service.NewSession(tx),
+
+		recent: map[string]map[int64]int64{},
+	}
+	return srv, tx, sink, &now
+}
+
+func mustExec(t *testing.T, tx *gorm.DB, sql string) {
+	if err := tx.Exec(sql).Error; err != nil {
+		t.Fatalf("exec %q err:(%+v)", sql, err)
+	}
+}
+
+func syncTable(t *testing.T, srv *indexer, table string) int {
+	n, err := srv.Sync(context.Background(), table)
+	if err != nil {
+		t.Fatalf("sync %s err:(%+v)", table, err)
+	}
+	return n
+}
+
+func TestIndexer_Sync(t *testing.T) {
+	srv, tx, sink, now := newTestIndexer(t)
+	mustExec(t, tx, "INSERT INTO bet01 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:00', 'Banker', 100, 9, 'N', 3, '2024-01-01 10:00:00')")
+	mustExec(t, tx, "INSERT INTO bet01 VALUES (2, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:01', 'Player', 50, 9, 'N', 3, '2024-01-01 10:00:01')")
+	// still inside the settle delay
+	mustExec(t, tx, "INSERT INTO bet01 VALUES (3, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:09', 'Tie', 10, 9, 'N', 3, '2024-01-01 10:00:09')")
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:00', 'Banker', 100, 195, 95, 9, '2024-01-01 10:00:05')")
+	mustExec(t, tx, "INSERT INTO in_out_m VALUES (7, '2024-01-01 09:59:00', 1, 1000.5, '121', 5, 9, 'order-7', 1, 1000.5)")
+
+	if n := syncTable(t, srv, TableBet01); n != 2 {
+		t.Fatalf("bet01 synced:%d, want 2", n)
+	}
+	bet01s := sink.docs[es.Bet01ReportIndex]
+	if len(bet01s) != 2 || !bet01s["1"].(*es.Bet01Doc).IsSettled || bet01s["2"].(*es.Bet01Doc).IsSettled {
+		t.Fatalf("bet01 docs:(%+v)", bet01s)
+	}
+	if doc := bet01s["1"].(*es.Bet01Doc); doc.Username != "tom" || doc.GameName != "百家乐" || doc.AID != 9 || doc.Bet07 != "2024-01-01" {
+		t.Fatalf("bet01 doc:(%+v)", doc)
+	}
+	// Nothing new, the checkpoint holds
+	if n := syncTable(t, srv, TableBet01); n != 0 {
+		t.Fatalf("bet01 synced again:%d", n)
+	}
+
+	// Settling bet 2 marks its bet01 document too
+	*now = now.Add(10 * time.Second)
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (2, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:01', 'Player', 50, 0, -50, 9, '2024-01-01 10:00:12')")
+	if n := syncTable(t, srv, TableBet02); n != 2 {
+		t.Fatalf("bet02 synced:%d, want 2", n)
+	}
+	bet02 := sink.docs[es.Bet02ReportIndex]["1"].(*es.Bet02Doc)
+	if bet02.Result != "1,2" || bet02.Username != "tom" || bet02.Bet17.String() != "95" || bet02.Bet08 != time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC).UnixMilli() {
+		t.Fatalf("bet02 doc:(%+v)", bet02)
+	}
+	if !bet01s["2"].(*es.Bet01Doc).IsSettled {
+		t.Fatalf("bet01 doc of a settled bet:(%+v)", bet01s["2"])
+	}
+	if n := syncTable(t, srv, TableBet01); n != 1 || bet01s["3"] == nil {
+		t.Fatalf("bet01 synced after the delay:%d, docs:(%+v)", n, bet01s)
+	}
+
+	if n := syncTable(t, srv, TableInOutM); n != 1 {
+		t.Fatalf("in_out_m synced:%d, want 1", n)
+	}
+	iom := sink.docs[es.InOutMIndex]["7"].(*es.InOutMDoc)
+	if iom.Iom004.String() != "1000.5" || iom.Iom008 != "order-7" || iom.Iom002 != "2024-01-01T09:59:00Z" {
+		t.Fatalf("in_out_m doc:(%+v)", iom)
+	}
+	cp, err := srv.loadCheckpoint(context.Background(), TableInOutM)
+	if err != nil || cp.ID != 7 {
+		t.Fatalf("in_out_m checkpoint:(%+v), err:(%+v)", cp, err)
+	}
+}
+
+func TestIndexer_Reindex(t *testing.T) {
+	srv, tx, sink, now := newTestIndexer(t)
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:00', 'Banker', 100, 195, 95, 9, '2024-01-01 10:00:05')")
+	sink.aliases[es.Bet02ReportIndex] = "bet02_report_index_1"
+	sink.docs["bet02_report_index_1"] = map[string]interface{}{"9": &es.Bet02Doc{Bet01: 9}}
+
+	if err := srv.Reindex(context.Background(), TableBet02); err != nil {
+		t.Fatalf("reindex err:(%+v)", err)
+	}
+	index := sink.aliases[es.Bet02ReportIndex]
+	if index == "bet02_report_index_1" || len(sink.docs[index]) != 1 || sink.docs[index]["1"] == nil {
+		t.Fatalf("alias to %s, docs:(%+v)", index, sink.docs[index])
+	}
+	if len(sink.deleted) != 1 || sink.deleted[0] != "bet02_report_index_1" {
+		t.Fatalf("deleted:(%v), want the old index", sink.deleted)
+	}
+	// The tail goes on after the rebuilt rows
+	cp, err := srv.loadCheckpoint(context.Background(), TableBet02)
+	if err != nil || cp.ID != 1 {
+		t.Fatalf("checkpoint:(%+v), err:(%+v)", cp, err)
+	}
+	*now = now.Add(time.Minute)
+	if n := syncTable(t, srv, TableBet02); n != 0 {
+		t.Fatalf("synced after reindex:%d, want 0", n)
+	}
+}
+
+func TestIndexer_InOutMLateCommit(t *testing.T) {
+	srv, tx, sink, now := newTestIndexer(t)
+	mustExec(t, tx, "INSERT INTO in_out_m VALUES (7, '2024-01-01 09:59:00', 1, 100, '121', 5, 9, 'order-7', 1, 100)")
+	if n := syncTable(t, srv, TableInOutM); n != 1 {
+		t.Fatalf("in_out_m synced:%d, want 1", n)
+	}
+
+	// 6 was inserted before 7 but committed after it was read
+	mustExec(t, tx, "INSERT INTO in_out_m VALUES (6, '2024-01-01 09:58:30', 1, 50, '121', 5, 9, 'order-6', 1, 150)")
+	*now = now.Add(10 * time.Second)
+	delete(sink.docs[es.InOutMIndex], "7")
+	if n := syncTable(t, srv, TableInOutM); n != 0 {
+		t.Fatalf("in_out_m synced:%d, want no new record", n)
+	}
+	docs := sink.docs[es.InOutMIndex]
+	if docs["6"] == nil {
+		t.Fatalf("late record not indexed, docs:(%+v)", docs)
+	}
+	// 7 was indexed already, reading the window again does not write it twice
+	if docs["7"] != nil {
+		t.Fatalf("record indexed again")
+	}
+	cp, err := srv.loadCheckpoint(context.Background(), TableInOutM)
+	if err != nil || cp.ID != 7 {
+		t.Fatalf("in_out_m checkpoint:(%+v), err:(%+v)", cp, err)
+	}
+
+	// Older than the window, it is left to the reconcile check
+	mustExec(t, tx, "INSERT INTO in_out_m VALUES (5, '2024-01-01 09:57:00', 1, 10, '121', 5, 9, 'order-5', 1, 160)")
+	syncTable(t, srv, TableInOutM)
+	if docs["5"] != nil {
+		t.Fatalf("record older than the window indexed")
+	}
+	// A restarted tail reads the window once more
+	srv.recent = map[string]map[int64]int64{}
+	syncTable(t, srv, TableInOutM)
+	if docs["7"] == nil {
+		t.Fatalf("window not read again after a restart")
+	}
+}
+
+func TestIndexer_ReindexLock(t *testing.T) {
+	srv, tx, sink, _ := newTestIndexer(t)
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:00', 'Banker', 100, 195, 95, 9, '2024-01-01 10:00:05')")
+	ctx := context.Background()
+
+	// A reindex elsewhere holds the table, the tail leaves it alone
+	other, err := srv.lock(ctx, TableBet02)
+	if err != nil || other == nil {
+		t.Fatalf("lock err:(%+v)", err)
+	}
+	if n := syncTable(t, srv, TableBet02); n != 0 || len(sink.docs[es.Bet02ReportIndex]) != 0 {
+		t.Fatalf("tail synced %d while the table was locked", n)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := srv.Reindex(waitCtx, TableBet02); err != context.DeadlineExceeded {
+		t.Fatalf("reindex of a locked table err:(%+v)", err)
+	}
+	if len(sink.aliases) != 0 {
+		t.Fatalf("alias swapped without the lock:(%+v)", sink.aliases)
+	}
+
+	other.unlock()
+	if err := srv.Reindex(ctx, TableBet02); err != nil {
+		t.Fatalf("reindex err:(%+v)", err)
+	}
+	if _, err := srv.redisCli.Get(ctx, "es_indexer_lock_bet02").Result(); err != redis.Nil {
+		t.Fatalf("lock kept after the reindex, err:(%+v)", err)
+	}
+	// An expired lock taken by someone else is not released by its former owner
+	stale, _ := srv.lock(ctx, TableBet02)
+	srv.redisCli.Set(ctx, "es_indexer_lock_bet02", "other", lockTTL)
+	if err := stale.refresh(ctx); err == nil {
+		t.Fatalf("refreshed a lock held by someone else")
+	}
+	stale.unlock()
+	if v, _ := srv.redisCli.Get(ctx, "es_indexer_lock_bet02").Result(); v != "other" {
+		t.Fatalf("lock of someone else released")
+	}
+}
+
+func TestIndexer_BetLateCommit(t *testing.T) {
+	srv, tx, sink, now := newTestIndexer(t)
+	mustExec(t, tx, "INSERT INTO bet01 VALUES (3, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:05', 'Tie', 10, 9, 'N', 3, '2024-01-01 10:00:05')")
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (3, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:05', 'Tie', 10, 0, -10, 9, '2024-01-01 10:00:05')")
+	if n := syncTable(t, srv, TableBet01); n != 1 {
+		t.Fatalf("bet01 synced:%d, want 1", n)
+	}
+	if n := syncTable(t, srv, TableBet02); n != 1 {
+		t.Fatalf("bet02 synced:%d, want 1", n)
+	}
+
+	// 2 was written before 3 but committed after the tails read past it
+	mustExec(t, tx, "INSERT INTO bet01 VALUES (2, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:01', 'Player', 50, 9, 'N', 3, '2024-01-01 10:00:01')")
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (2, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:01', 'Player', 50, 0, -50, 9, '2024-01-01 10:00:01')")
+	*now = now.Add(10 * time.Second)
+	delete(sink.docs[es.Bet01ReportIndex], "3")
+	delete(sink.docs[es.Bet02ReportIndex], "3")
+	if n := syncTable(t, srv, TableBet01); n != 0 {
+		t.Fatalf("bet01 synced:%d, want no new bet", n)
+	}
+	if n := syncTable(t, srv, TableBet02); n != 0 {
+		t.Fatalf("bet02 synced:%d, want no new bet", n)
+	}
+	bet01s, bet02s := sink.docs[es.Bet01ReportIndex], sink.docs[es.Bet02ReportIndex]
+	if bet01s["2"] == nil || !bet01s["2"].(*es.Bet01Doc).IsSettled || bet02s["2"] == nil {
+		t.Fatalf("late bet not indexed, bet01:(%+v), bet02:(%+v)", bet01s, bet02s)
+	}
+	// 3 was indexed already, reading the window again does not write it twice
+	if bet01s["3"] != nil || bet02s["3"] != nil {
+		t.Fatalf("bet indexed again")
+	}
+
+	// A change of an indexed bet inside the window is a new version
+	mustExec(t, tx, "UPDATE bet01 SET bet30 = 'Y', updatetime = '2024-01-01 10:00:04' WHERE bet01 = 3")
+	syncTable(t, srv, TableBet01)
+	if doc := bet01s["3"]; doc == nil || doc.(*es.Bet01Doc).Bet30 != "Y" {
+		t.Fatalf("changed bet not indexed:(%+v)", doc)
+	}
+
+	// Older than the window, it is left to the reconcile check
+	mustExec(t, tx, "INSERT INTO bet01 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 09:58:00', 'Banker', 100, 9, 'N', 3, '2024-01-01 09:58:00')")
+	syncTable(t, srv, TableBet01)
+	if bet01s["1"] != nil {
+		t.Fatalf("bet older than the window indexed")
+	}
+}
+
+func TestIndexer_Bet02LocksBet01(t *testing.T) {
+	srv, tx, sink, _ := newTestIndexer(t)
+	mustExec(t, tx, "INSERT INTO bet01 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:00', 'Banker', 100, 9, 'N', 3, '2024-01-01 10:00:00')")
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 10:00:00', 'Banker', 100, 195, 95, 9, '2024-01-01 10:00:05')")
+	ctx := context.Background()
+
+	// A bet01 reindex elsewhere, the settles of the bet02 tail would go to the index it swaps away
+	other, err := srv.lock(ctx, TableBet01)
+	if err != nil || other == nil {
+		t.Fatalf("lock err:(%+v)", err)
+	}
+	if n := syncTable(t, srv, TableBet02); n != 0 || len(sink.docs[es.Bet02ReportIndex]) != 0 || len(sink.docs[es.Bet01ReportIndex]) != 0 {
+		t.Fatalf("bet02 tail synced %d while bet01 was locked", n)
+	}
+	// The bet02 lock taken on the way is let go
+	if _, err := srv.redisCli.Get(ctx, "es_indexer_lock_bet02").Result(); err != redis.Nil {
+		t.Fatalf("bet02 lock kept, err:(%+v)", err)
+	}
+
+	other.unlock()
+	if n := syncTable(t, srv, TableBet02); n != 1 || sink.docs[es.Bet01ReportIndex]["1"] == nil {
+		t.Fatalf("bet02 synced:%d, bet01 docs:(%+v)", n, sink.docs[es.Bet01ReportIndex])
+	}
+}
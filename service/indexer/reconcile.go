@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-zrbc/db"
+	"go-zrbc/es"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// driftIDLimit caps the ids a reconcile report lists per kind, a fix goes through all of them
+const driftIDLimit = 1000
+
+// docDiff is where a report index differs from its table by id
+type docDiff struct {
+	// missing are rows without a document, stale documents older than their row
+	missing, stale, extra []int64
+}
+
+func (srv *indexer) ReconcileAgents(ctx context.Context, day time.Time) ([]int64, error) {
+	start, end := dayRange(day)
+	bet02Agents, err := srv.bet02Dao.GetBet02AgentsForReconcile(srv.DB(), start, end)
+	if err != nil {
+		return nil, err
+	}
+	inOutMAgents, err := srv.inOutMDao.GetInOutMAgentsForReconcile(srv.DB(), start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[int64]bool{}
+	var ret []int64
+	for _, agentID := range append(bet02Agents, inOutMAgents...) {
+		if !seen[agentID] {
+			seen[agentID] = true
+			ret = append(ret, agentID)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret, nil
+}
+
+// Reconcile compares the totals of a day first and only lists the ids of a table whose totals differ. A drifted
+// day stays in es.DriftKey even once fixed, the reports of the agent read MySQL until a later check finds it clean.
+func (srv *indexer) Reconcile(ctx context.Context, agentID int64, day time.Time, fix bool) (*view.EsReconcileReport, error) {
+	start, end := dayRange(day)
+	report := &view.EsReconcileReport{
+		AgentID:   agentID,
+		Day:       start.Format("2006-01-02"),
+		CheckTime: srv.now().Unix(),
+	}
+
+	for _, table := range []string{TableBet02, TableInOutM} {
+		ret, diff, err := srv.reconcileTable(ctx, table, agentID, start, end)
+		if err != nil {
+			xlog.Errorf("error to reconcile %s of agent %d on %s, err:%+v", table, agentID, report.Day, err)
+			return nil, err
+		}
+		report.Tables = append(report.Tables, ret)
+		if ret.Match {
+			continue
+		}
+		report.Drift = true
+		if fix {
+			if err := srv.fixDocs(ctx, table, diff); err != nil {
+				xlog.Errorf("error to fix %s of agent %d on %s, err:%+v", table, agentID, report.Day, err)
+				return nil, err
+			}
+		}
+	}
+	report.Fixed = fix && report.Drift
+
+	if err := srv.saveDrift(ctx, report); err != nil {
+		xlog.Errorf("error to save drift of agent %d on %s, err:%+v", agentID, report.Day, err)
+		return nil, err
+	}
+	return report, nil
+}
+
+func (srv *indexer) reconcileTable(ctx context.Context, table string, agentID int64, start, end time.Time) (*view.EsReconcileTable, *docDiff, error) {
+	var (
+		mysqlTotals, esTotals *db.ReconcileTotals
+		err                   error
+	)
+	switch table {
+	case TableBet02:
+		if mysqlTotals, err = srv.bet02Dao.SumBet02ForReconcile(srv.DB(), agentID, start, end); err != nil {
+			return nil, nil, err
+		}
+		esTotals, err = srv.sink.SumBet02(ctx, agentID, start, end)
+	case TableInOutM:
+		if mysqlTotals, err = srv.inOutMDao.SumInOutMForReconcile(srv.DB(), agentID, start, end); err != nil {
+			return nil, nil, err
+		}
+		esTotals, err = srv.sink.SumInOutM(ctx, agentID, start, end)
+	default:
+		return nil, nil, fmt.Errorf("unknown reconcile table %q", table)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ret := &view.EsReconcileTable{
+		Table: table,
+		MySQL: totalsToView(mysqlTotals),
+		ES:    totalsToView(esTotals),
+		Match: mysqlTotals.Equal(esTotals),
+	}
+	if ret.Match {
+		return ret, &docDiff{}, nil
+	}
+
+	diff, err := srv.diffDocs(ctx, table, agentID, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	ret.MissingCount, ret.StaleCount, ret.ExtraCount = len(diff.missing), len(diff.stale), len(diff.extra)
+	ret.Missing, ret.Stale, ret.Extra = capIDs(diff.missing), capIDs(diff.stale), capIDs(diff.extra)
+	return ret, diff, nil
+}
+
+// diffDocs lists the rows of the day against the documents of the day by id and change time
+func (srv *indexer) diffDocs(ctx context.Context, table string, agentID int64, start, end time.Time) (*docDiff, error) {
+	var (
+		rows     []*db.ReconcileRow
+		versions map[int64]int64
+		err      error
+	)
+	switch table {
+	case TableBet02:
+		if rows, err = srv.bet02Dao.GetBet02RowsForReconcile(srv.DB(), agentID, start, end); err != nil {
+			return nil, err
+		}
+		versions, err = srv.sink.ListBet02Versions(ctx, agentID, start, end)
+	case TableInOutM:
+		if rows, err = srv.inOutMDao.GetInOutMRowsForReconcile(srv.DB(), agentID, start, end); err != nil {
+			return nil, err
+		}
+		versions, err = srv.sink.ListInOutMVersions(ctx, agentID, start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &docDiff{}
+	for _, row := range rows {
+		version, ok := versions[row.ID]
+		if !ok {
+			diff.missing = append(diff.missing, row.ID)
+			continue
+		}
+		delete(versions, row.ID)
+		if version != row.Updatetime.UnixMilli() {
+			diff.stale = append(diff.stale, row.ID)
+		}
+	}
+	for id := range versions {
+		diff.extra = append(diff.extra, id)
+	}
+	sort.Slice(diff.extra, func(i, j int) bool { return diff.extra[i] < diff.extra[j] })
+	return diff, nil
+}
+
+// fixDocs indexes the missing and stale rows again and deletes the documents without a row
+func (srv *indexer) fixDocs(ctx context.Context, table string, diff *docDiff) error {
+	alias, _, err := indexOf(table)
+	if err != nil {
+		return err
+	}
+	// a reindex running meanwhile would swap the fixed documents away
+	lock, err := srv.waitLock(ctx, table)
+	if err != nil {
+		return err
+	}
+	defer lock.unlock()
+
+	ids := append(append([]int64{}, diff.missing...), diff.stale...)
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > indexBatch {
+			n = indexBatch
+		}
+		if err := srv.reindexIDs(ctx, table, alias, ids[:n]); err != nil {
+			return err
+		}
+		ids = ids[n:]
+	}
+
+	extra := make([]string, 0, len(diff.extra))
+	for _, id := range diff.extra {
+		extra = append(extra, strconv.FormatInt(id, 10))
+	}
+	return srv.sink.BulkDelete(ctx, alias, extra)
+}
+
+func (srv *indexer) reindexIDs(ctx context.Context, table, index string, ids []int64) error {
+	switch table {
+	case TableBet02:
+		bets, err := srv.bet02Dao.GetBet02ListForIndexByIDs(srv.DB(), ids)
+		if err != nil {
+			return err
+		}
+		return srv.indexBet02(ctx, index, bets)
+	case TableInOutM:
+		records, err := srv.inOutMDao.GetInOutMListByIDs(srv.DB(), ids)
+		if err != nil {
+			return err
+		}
+		docs := make([]interface{}, 0, len(records))
+		for _, record := range records {
+			docs = append(docs, es.NewInOutMDoc(record))
+		}
+		return srv.index(ctx, index, docs)
+	}
+	return fmt.Errorf("unknown reconcile table %q", table)
+}
+
+func (srv *indexer) saveDrift(ctx context.Context, report *view.EsReconcileReport) error {
+	if !report.Drift {
+		return es.ClearDrift(ctx, srv.redisCli, report.AgentID, report.Day)
+	}
+	b, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return es.SaveDrift(ctx, srv.redisCli, report.AgentID, report.Day, b)
+}
+
+// dayRange returns the local midnight of day and of the day after
+func dayRange(day time.Time) (time.Time, time.Time) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 0, 1)
+}
+
+func capIDs(ids []int64) []int64 {
+	if len(ids) > driftIDLimit {
+		return ids[:driftIDLimit]
+	}
+	return ids
+}
+
+func totalsToView(t *db.ReconcileTotals) *view.EsReconcileTotals {
+	return &view.EsReconcileTotals{
+		Count:  t.Count,
+		Bet:    t.Bet,
+		Result: t.Result,
+		Water:  t.Water,
+		Money:  t.Money,
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/es"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIndexer_Reconcile(t *testing.T) {
+	srv, tx, sink, now := newTestIndexer(t)
+	ctx := context.Background()
+	mustExec(t, tx, "ALTER TABLE bet02 ADD COLUMN bet16 DECIMAL(15,4) DEFAULT 0")
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (1, 101, '100', 1, 1, '2024-01-01', '2024-01-01 09:00:00', 'Banker', 100, 195, 95, 9, '2024-01-01 09:00:05', 1)")
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (2, 101, '100', 1, 1, '2024-01-01', '2024-01-01 09:00:01', 'Player', 50, 0, -50, 9, '2024-01-01 09:00:06', 0.5)")
+	mustExec(t, tx, "INSERT INTO bet02 VALUES (3, 101, '100', 1, 1, '2024-01-01', '2024-01-01 09:00:02', 'Tie', 10, 0, -10, 8, '2024-01-01 09:00:06', 0)")
+	mustExec(t, tx, "INSERT INTO in_out_m VALUES (7, '2024-01-01 08:00:00', 1, 1000.5, '121', 5, 9, 'order-7', 1, 1000.5)")
+	syncTable(t, srv, TableBet02)
+	syncTable(t, srv, TableInOutM)
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	field := es.DriftField(9, "2024-01-01")
+
+	report, err := srv.Reconcile(ctx, 9, day, false)
+	if err != nil {
+		t.Fatalf("reconcile err:(%+v)", err)
+	}
+	if report.Drift || len(report.Tables) != 2 || report.Tables[0].MySQL.Count != 2 || report.Tables[0].MySQL.Water.String() != "1.5" {
+		t.Fatalf("clean report:(%+v), bet02:(%+v)", report, report.Tables[0])
+	}
+
+	// A settled bet changed after it was indexed, one never reached the index and one is left over
+	mustExec(t, tx, "UPDATE bet02 SET bet17 = 100, updatetime = '2024-01-01 10:00:00' WHERE bet01 = 1")
+	delete(sink.docs[es.Bet02ReportIndex], "2")
+	sink.docs[es.Bet02ReportIndex]["5"] = &es.Bet02Doc{Bet01: 5, Bet08: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC).UnixMilli(), Bet22: 9}
+
+	report, err = srv.Reconcile(ctx, 9, day, false)
+	if err != nil {
+		t.Fatalf("reconcile err:(%+v)", err)
+	}
+	bet02 := report.Tables[0]
+	if !report.Drift || report.Fixed || bet02.Match || !report.Tables[1].Match {
+		t.Fatalf("drift report:(%+v)", report)
+	}
+	if !reflect.DeepEqual(bet02.Missing, []int64{2}) || !reflect.DeepEqual(bet02.Stale, []int64{1}) || !reflect.DeepEqual(bet02.Extra, []int64{5}) {
+		t.Fatalf("bet02 diff:(%+v)", bet02)
+	}
+	if ok, _ := srv.redisCli.HExists(ctx, es.DriftKey, field).Result(); !ok {
+		t.Fatalf("drift of agent 9 not recorded")
+	}
+
+	// The fix leaves the gate on until a clean check
+	*now = now.Add(time.Hour)
+	report, err = srv.Reconcile(ctx, 9, day, true)
+	if err != nil || !report.Fixed {
+		t.Fatalf("fix report:(%+v), err:(%+v)", report, err)
+	}
+	docs := sink.docs[es.Bet02ReportIndex]
+	if docs["2"] == nil || docs["5"] != nil || docs["1"].(*es.Bet02Doc).Bet17.String() != "100" {
+		t.Fatalf("docs after fix:(%+v)", docs)
+	}
+	if ok, _ := srv.redisCli.HExists(ctx, es.DriftKey, field).Result(); !ok {
+		t.Fatalf("drift of agent 9 cleared by the fix")
+	}
+
+	report, err = srv.Reconcile(ctx, 9, day, false)
+	if err != nil || report.Drift {
+		t.Fatalf("report after fix:(%+v), err:(%+v)", report, err)
+	}
+	if ok, _ := srv.redisCli.HExists(ctx, es.DriftKey, field).Result(); ok {
+		t.Fatalf("drift of agent 9 kept after a clean check")
+	}
+
+	agents, err := srv.ReconcileAgents(ctx, day)
+	if err != nil || !reflect.DeepEqual(agents, []int64{8, 9}) {
+		t.Fatalf("agents:(%v), err:(%+v)", agents, err)
+	}
+}
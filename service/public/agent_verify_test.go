@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/view"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestPublicApiService_VerifyHmacSignature(t *testing.T) {
+	mr := miniredis.RunT(t)
+	srv := &publicApiService{
+		redisCli: redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+	}
+	ctx := context.Background()
+	loginPass := &db.AgentsLoginPass{VendorID: "demo", Signature: "secret", SignMode: db.SignModeHmac}
+	signed := func(nonce string) *view.AgentVerifyReq {
+		params := url.Values{
+			"cmd":       {"GetBalance"},
+			"vendorId":  {"demo"},
+			"user":      {"tom"},
+			"timestamp": {strconv.FormatInt(time.Now().Unix(), 10)},
+			"nonce":     {nonce},
+		}
+		signature := utils.HmacSha256Hex(utils.CanonicalParams(params), "secret")
+		params.Set("signature", signature)
+		return &view.AgentVerifyReq{VendorID: "demo", Signature: signature, Params: params}
+	}
+
+	req := signed("n1")
+	if err := srv.verifyHmacSignature(ctx, loginPass, req); err != nil {
+		t.Fatalf("signed request err:(%+v)", err)
+	}
+	if err := srv.verifyHmacSignature(ctx, loginPass, req); err != utils.ErrAgentNonceReplay {
+		t.Fatalf("replayed request err:(%+v), want ErrAgentNonceReplay", err)
+	}
+	if ttl := mr.TTL("AgentNonce_demo_n1"); ttl != agentNonceTTL {
+		t.Fatalf("nonce ttl:(%s), want:(%s)", ttl, agentNonceTTL)
+	}
+
+	// Any change of a signed param breaks the signature, and a rejected request does not burn its nonce
+	req = signed("n2")
+	req.Params.Set("cmd", "ChangeBalance")
+	if err := srv.verifyHmacSignature(ctx, loginPass, req); err != utils.ErrAgentIDExistButSignatureError {
+		t.Fatalf("tampered request err:(%+v), want ErrAgentIDExistButSignatureError", err)
+	}
+	if mr.Exists("AgentNonce_demo_n2") {
+		t.Fatalf("tampered request burnt its nonce")
+	}
+	if err := srv.verifyHmacSignature(ctx, &db.AgentsLoginPass{VendorID: "demo", Signature: "other"}, signed("n3")); err != utils.ErrAgentIDExistButSignatureError {
+		t.Fatalf("wrong secret err:(%+v), want ErrAgentIDExistButSignatureError", err)
+	}
+
+	req = signed("")
+	if err := srv.verifyHmacSignature(ctx, loginPass, req); err != utils.ErrAgentNonceEmpty {
+		t.Fatalf("request without nonce err:(%+v), want ErrAgentNonceEmpty", err)
+	}
+	req = signed("n4")
+	req.Params.Set("timestamp", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+	if err := srv.verifyHmacSignature(ctx, loginPass, req); err != utils.ErrTimestampError {
+		t.Fatalf("stale request err:(%+v), want ErrTimestampError", err)
+	}
+}
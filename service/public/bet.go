@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// checkBetItems validates every area against limits, counting what the member already staked
+// on it this round (keyed by area number, as bet09 holds it). It returns one result per item
+// and the total of the accepted ones.
+func checkBetItems(limits *TableLimits, placed map[string]decimal.Decimal, items []view.WsBettingInfoItem) ([]view.WsBettingResultItem, decimal.Decimal) {
+	ret := make([]view.WsBettingResultItem, 0, len(items))
+	total := decimal.Zero
+	staked := make(map[string]decimal.Decimal, len(placed))
+	for area, stake := range placed {
+		staked[area] = stake
+	}
+	for _, item := range items {
+		res := view.WsBettingResultItem{BetArea: item.BetArea, AddBetMoney: item.AddBetMoney}
+		if item.BetArea < 1 || item.BetArea > tableLimitAreas || item.AddBetMoney <= 0 {
+			res.Code, res.Msg = int(utils.CodeWalletParamFormatError), utils.ErrWalletParamFormatError.Message
+			ret = append(ret, res)
+			continue
+		}
+		area := strconv.Itoa(item.BetArea)
+		limit := limits[item.BetArea-1]
+		after := staked[area].Add(decimal.NewFromInt(int64(item.AddBetMoney)))
+		// an area with max 0 is not open on this limit
+		if limit[1] == 0 || after.LessThan(decimal.NewFromInt(int64(limit[0]))) || after.GreaterThan(decimal.NewFromInt(int64(limit[1]))) {
+			res.Code, res.Msg = int(utils.CodeBetOutOfLimit), utils.ErrBetOutOfLimit.Message
+			ret = append(ret, res)
+			continue
+		}
+		staked[area] = after
+		total = total.Add(decimal.NewFromInt(int64(item.AddBetMoney)))
+		res.BOk = true
+		ret = append(ret, res)
+	}
+	return ret, total
+}
+
+// PlaceBet validates a websocket bet against the member limits and the round the table is
+// dealing, takes the accepted stakes from the wallet and writes them to bet01 in one transaction.
+// The answer is kept by bet serial number, a resent serial gets it again without a second debit.
+func (srv *publicApiService) PlaceBet(ctx context.Context, req *view.WsPlaceBetReq) (*view.WsBettingRespData, error) {
+	table := req.Table
+	ret := &view.WsBettingRespData{
+		GameID:          table.GameID,
+		GroupID:         table.GroupID,
+		BetSerialNumber: req.Data.BetSerialNumber,
+	}
+	if req.Data.BetSerialNumber <= 0 {
+		return nil, utils.ErrWalletParamFormatError
+	}
+
+	member, err := srv.userDao.QueryByID(srv.DB(), table.MemberID)
+	if err != nil {
+		xlog.Errorf("error to query member, mid:%d, err:%+v", table.MemberID, err)
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrParamInvalidAccountNotExist
+		}
+		return nil, err
+	}
+	if member.Mem016 != "Y" || member.Mem017 != "Y" {
+		return nil, utils.ErrBetDisabled
+	}
+	userDtl, err := srv.GetOneUserDtl(member.ID, 7)
+	if err != nil {
+		xlog.Errorf("error to get member dtl, mid:%d, err:%+v", member.ID, err)
+		return nil, err
+	}
+	limits, err := srv.memberTableLimits(userDtl, table.GameID, table.DtBetLimitSelectID)
+	if err != nil {
+		return nil, err
+	}
+	water, _ := decimal.NewFromString(userDtl[strconv.Itoa(table.GameID)]["bkwater"])
+
+	err = srv.Tx(func(tx *gorm.DB) error {
+		// the row lock serialises bets of one member so the per-round sums and serials stay exact
+		member, err = srv.userDao.QueryByIDForUpdate(tx, member.ID)
+		if err != nil {
+			return err
+		}
+		done, err := srv.betSerialDao.QueryByMemberSerial(tx, member.ID, req.Data.BetSerialNumber)
+		if err == nil {
+			return json.Unmarshal([]byte(done.Result), ret)
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		if member.Mem016 != "Y" || member.Mem017 != "Y" {
+			return utils.ErrBetDisabled
+		}
+
+		// the round comes from the table, the client only names the one it bets on
+		game, err := srv.gameInfoDao.QueryLatestByTable(tx, table.GroupID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrTableNotExist
+			}
+			return err
+		}
+		if game.Gi001 != table.GameID {
+			return utils.ErrTableGameMismatch
+		}
+		if game.Gi002 != int64(req.Data.GameNo) || game.Gi003 != req.Data.GameNoRound {
+			return utils.ErrBetRoundMismatch
+		}
+		// bets close once the round is drawn (gi013 0:未開) or locked by the dealer
+		if game.Gi013 != 0 || game.IsLock == "Y" {
+			return utils.ErrBetRoundClosed
+		}
+
+		round := decimal.NewFromInt(game.Gi002)
+		placed, err := srv.bet01Dao.SumStakeByArea(tx, member.ID, game.Gi001, round, game.Gi003)
+		if err != nil {
+			return err
+		}
+		var total decimal.Decimal
+		ret.BetArr, total = checkBetItems(limits, placed, req.Data.BetArr)
+		if !total.IsZero() {
+			if err := srv.userDao.UpdateCash(tx, member.ID, total.Neg()); err != nil {
+				if err == db.ErrInsufficientCash {
+					return utils.ErrWalletTransferBalanceNotEnough
+				}
+				return err
+			}
+			if err := srv.createBets(tx, member, game, req, ret.BetArr, total, water); err != nil {
+				return err
+			}
+			member.Cash = member.Cash.Sub(total)
+			ret.BOk = true
+		}
+		ret.Balance = member.Cash.InexactFloat64()
+
+		result, err := json.Marshal(ret)
+		if err != nil {
+			return err
+		}
+		_, err = srv.betSerialDao.Create(tx, &db.BetSerial{
+			Mid:        member.ID,
+			Serial:     req.Data.BetSerialNumber,
+			Result:     string(result),
+			CreateTime: time.Now(),
+		})
+		return err
+	})
+	if err != nil {
+		xlog.Errorf("error to place bet, mid:%d, req:%+v, err:%+v", member.ID, req.Data, err)
+		return nil, err
+	}
+	return ret, nil
+}
+
+// createBets writes one bet01 row per accepted area of a bet taking total from member.Cash
+func (srv *publicApiService) createBets(tx *gorm.DB, member *db.Member, game *db.GameInfo, req *view.WsPlaceBetReq, items []view.WsBettingResultItem, total, water decimal.Decimal) error {
+	now := time.Now()
+	for _, res := range items {
+		if !res.BOk {
+			continue
+		}
+		_, err := srv.bet01Dao.Create(tx, &db.Bet01{
+			Bet02:      game.Gi001,
+			Bet03:      decimal.NewFromInt(game.Gi002),
+			Bet04:      game.Gi003,
+			Bet05:      int(member.ID),
+			Bet06:      game.Gi004,
+			Bet07:      time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+			Bet08:      now,
+			Bet09:      strconv.Itoa(res.BetArea),
+			Bet10:      member.Currency,
+			Bet11:      decimal.NewFromInt(1),
+			Bet12:      member.Cash,
+			Bet12a:     member.Cash.Sub(total),
+			Bet13:      decimal.NewFromInt(int64(res.AddBetMoney)),
+			Bet14:      water,
+			Bet15:      int(member.Mem007),
+			Bet16:      int(member.Mem008),
+			Bet17:      int(member.Mem009),
+			Bet18:      int(member.Mem010),
+			Bet19:      int(member.Mem011),
+			Bet30:      "N",
+			Bet31:      game.Gi011,
+			Gametype:   1,
+			Commission: req.Data.Commission,
+			Category:   1,
+			IP:         req.IP,
+			Updatetime: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
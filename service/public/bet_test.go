@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/view"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCheckBetItems(t *testing.T) {
+	limits := &TableLimits{}
+	limits[0] = [2]int{10, 1000}
+	limits[1] = [2]int{10, 500}
+
+	placed := map[string]decimal.Decimal{"2": decimal.NewFromInt(450)}
+	got, total := checkBetItems(limits, placed, []view.WsBettingInfoItem{
+		{BetArea: 1, AddBetMoney: 100},
+		{BetArea: 1, AddBetMoney: 950}, // area 1 would reach 1050
+		{BetArea: 2, AddBetMoney: 50},  // area 2 reaches its max exactly
+		{BetArea: 2, AddBetMoney: 10},
+		{BetArea: 3, AddBetMoney: 100}, // not open on this limit
+		{BetArea: 1, AddBetMoney: 5},
+		{BetArea: 15, AddBetMoney: 100},
+	})
+	wantOk := []bool{true, false, true, false, false, true, false}
+	for i, res := range got {
+		if res.BOk != wantOk[i] {
+			t.Fatalf("item %d:(%+v), want bOk %v", i, res, wantOk[i])
+		}
+	}
+	if got[1].Code != int(utils.CodeBetOutOfLimit) || got[6].Code != int(utils.CodeWalletParamFormatError) {
+		t.Fatalf("reject codes:(%+v)", got)
+	}
+	if !total.Equal(decimal.NewFromInt(155)) {
+		t.Fatalf("total:(%s), want 155", total)
+	}
+	if !placed["2"].Equal(decimal.NewFromInt(450)) {
+		t.Fatalf("checkBetItems modified placed:(%+v)", placed)
+	}
+}
+
+func placeBet(srv *publicApiService, serial, gameNo, gameNoRound int, stakes ...int) (*view.WsBettingRespData, error) {
+	req := &view.WsPlaceBetReq{
+		Table: &view.WsTableEntryReq{MemberID: 5, GameID: 1, GroupID: 101},
+		IP:    "127.0.0.1",
+		Data:  view.WsBettingData{BetSerialNumber: serial, GameNo: gameNo, GameNoRound: gameNoRound},
+	}
+	for _, stake := range stakes {
+		req.Data.BetArr = append(req.Data.BetArr, view.WsBettingInfoItem{BetArea: 1, AddBetMoney: stake})
+	}
+	return srv.PlaceBet(context.Background(), req)
+}
+
+func TestPublicApiService_PlaceBet(t *testing.T) {
+	srv, tx := newTableTestService(t)
+
+	ret, err := placeBet(srv, 1, 2000, 3, 30, 20)
+	if err != nil || !ret.BOk || ret.Balance != 50 || len(ret.BetArr) != 2 {
+		t.Fatalf("bet:(%+v), err:(%+v)", ret, err)
+	}
+	var bets []struct {
+		Bet03  decimal.Decimal
+		Bet04  int
+		Bet12  decimal.Decimal
+		Bet12a decimal.Decimal
+		Bet31  int
+	}
+	tx.Raw("SELECT bet03, bet04, bet12, bet12a, bet31 FROM bet01 ORDER BY bet01").Scan(&bets)
+	if len(bets) != 2 || !bets[0].Bet03.Equal(decimal.NewFromInt(2000)) || bets[0].Bet04 != 3 || bets[0].Bet31 != 101 ||
+		!bets[0].Bet12.Equal(decimal.NewFromInt(100)) || !bets[0].Bet12a.Equal(decimal.NewFromInt(50)) {
+		t.Fatalf("bet01 rows:(%+v)", bets)
+	}
+
+	// the resent serial is answered as before and debits nothing
+	again, err := placeBet(srv, 1, 2000, 3, 30, 20)
+	if err != nil || !again.BOk || again.Balance != 50 || len(again.BetArr) != 2 {
+		t.Fatalf("resent bet:(%+v), err:(%+v)", again, err)
+	}
+	var cash decimal.Decimal
+	var count int64
+	tx.Raw("SELECT cash FROM member WHERE mem001 = 5").Scan(&cash)
+	tx.Raw("SELECT COUNT(*) FROM bet01").Scan(&count)
+	if !cash.Equal(decimal.NewFromInt(50)) || count != 2 {
+		t.Fatalf("after resend cash:(%s) bets:(%d), want 50 and 2", cash, count)
+	}
+
+	// insufficient cash rolls the whole bet back, the serial stays free for a retry
+	if _, err := placeBet(srv, 2, 2000, 3, 60); err != utils.ErrWalletTransferBalanceNotEnough {
+		t.Fatalf("overdraw err:(%+v), want ErrWalletTransferBalanceNotEnough", err)
+	}
+	tx.Raw("SELECT COUNT(*) FROM bet01").Scan(&count)
+	if count != 2 {
+		t.Fatalf("bets after overdraw:(%d), want 2", count)
+	}
+	if ret, err := placeBet(srv, 2, 2000, 3, 40); err != nil || !ret.BOk || ret.Balance != 10 {
+		t.Fatalf("retried bet:(%+v), err:(%+v)", ret, err)
+	}
+}
+
+func TestPublicApiService_PlaceBetRound(t *testing.T) {
+	srv, tx := newTableTestService(t)
+
+	if _, err := placeBet(srv, 1, 1999, 9, 30); err != utils.ErrBetRoundMismatch {
+		t.Fatalf("bet on an old round err:(%+v), want ErrBetRoundMismatch", err)
+	}
+	tx.Exec("UPDATE game_info SET gi013 = 1 WHERE gi011 = 101 AND gi002 = 2000")
+	if _, err := placeBet(srv, 1, 2000, 3, 30); err != utils.ErrBetRoundClosed {
+		t.Fatalf("bet on a drawn round err:(%+v), want ErrBetRoundClosed", err)
+	}
+	// table 101 moves on to game 2
+	tx.Exec("INSERT INTO game_info VALUES (2, 600, 1, '2024-01-01 10:05:00', 101, 0, 'N')")
+	if _, err := placeBet(srv, 1, 600, 1, 30); err != utils.ErrTableGameMismatch {
+		t.Fatalf("bet on another game err:(%+v), want ErrTableGameMismatch", err)
+	}
+	var cash decimal.Decimal
+	tx.Raw("SELECT cash FROM member WHERE mem001 = 5").Scan(&cash)
+	if !cash.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("cash after rejected bets:(%s), want 100", cash)
+	}
+}
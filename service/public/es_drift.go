@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-zrbc/es"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// esReadable reports whether the reports of agentID may be read from ES. The reconcile job records the agents
+// whose report indices drifted from MySQL, their reports read MySQL until the drift is cleared.
+func (srv *publicApiService) esReadable(ctx context.Context, agentID int64) bool {
+	drifted, err := srv.redisCli.HExists(ctx, es.DriftAgentKey, strconv.FormatInt(agentID, 10)).Result()
+	if err != nil {
+		xlog.Errorf("error to get es drift of agent %d, err:%+v", agentID, err)
+		return true
+	}
+	return !drifted
+}
+
+func (srv *publicApiService) ListEsDrift(ctx context.Context) (*view.ListEsDriftResp, error) {
+	vals, err := srv.redisCli.HGetAll(ctx, es.DriftKey).Result()
+	if err != nil {
+		xlog.Errorf("error to get es drift, err:%+v", err)
+		return nil, err
+	}
+	ret := &view.ListEsDriftResp{List: make([]*view.EsReconcileReport, 0, len(vals))}
+	for field, val := range vals {
+		var report view.EsReconcileReport
+		if err := json.Unmarshal([]byte(val), &report); err != nil {
+			xlog.Errorf("error to unmarshal es drift %s, err:%+v", field, err)
+			continue
+		}
+		ret.List = append(ret.List, &report)
+	}
+	sort.Slice(ret.List, func(i, j int) bool {
+		if ret.List[i].AgentID != ret.List[j].AgentID {
+			return ret.List[i].AgentID < ret.List[j].AgentID
+		}
+		return ret.List[i].Day < ret.List[j].Day
+	})
+	return ret, nil
+}
+
+// ClearEsDrift lets the reports of an agent read ES again, for one day or every day without req.Day
+func (srv *publicApiService) ClearEsDrift(ctx context.Context, req *view.ClearEsDriftReq) error {
+	if req.AgentID <= 0 {
+		return utils.ErrParamError
+	}
+	days := []string{req.Day}
+	if req.Day == "" {
+		all, err := srv.redisCli.HKeys(ctx, es.DriftKey).Result()
+		if err != nil {
+			xlog.Errorf("error to get es drift, err:%+v", err)
+			return err
+		}
+		prefix := strconv.FormatInt(req.AgentID, 10) + ":"
+		days = days[:0]
+		for _, field := range all {
+			if strings.HasPrefix(field, prefix) {
+				days = append(days, strings.TrimPrefix(field, prefix))
+			}
+		}
+		if len(days) == 0 {
+			return nil
+		}
+	}
+	if err := es.ClearDrift(ctx, srv.redisCli, req.AgentID, days...); err != nil {
+		xlog.Errorf("error to clear es drift of agent %d, err:%+v", req.AgentID, err)
+		return err
+	}
+	xlog.Infof("es drift of agent %d cleared: %v", req.AgentID, days)
+	return nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/es"
+	"go-zrbc/view"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestPublicApiService_EsDrift(t *testing.T) {
+	mr := miniredis.RunT(t)
+	srv := &publicApiService{redisCli: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	ctx := context.Background()
+	es.SaveDrift(ctx, srv.redisCli, 9, "2024-01-01", []byte(`{"agentId":9,"day":"2024-01-01","drift":true}`))
+	es.SaveDrift(ctx, srv.redisCli, 9, "2024-01-02", []byte(`{"agentId":9,"day":"2024-01-02","drift":true}`))
+	es.SaveDrift(ctx, srv.redisCli, 90, "2024-01-01", []byte(`{"agentId":90,"day":"2024-01-01","drift":true}`))
+	// Saved again by a later check, still one day
+	es.SaveDrift(ctx, srv.redisCli, 9, "2024-01-02", []byte(`{"agentId":9,"day":"2024-01-02","drift":true}`))
+
+	if srv.esReadable(ctx, 9) || srv.esReadable(ctx, 90) || !srv.esReadable(ctx, 1) {
+		t.Fatalf("drifted agents read from es")
+	}
+	resp, err := srv.ListEsDrift(ctx)
+	if err != nil || len(resp.List) != 3 || resp.List[0].Day != "2024-01-01" || resp.List[2].AgentID != 90 {
+		t.Fatalf("drift list:(%+v), err:(%+v)", resp, err)
+	}
+
+	if err := srv.ClearEsDrift(ctx, &view.ClearEsDriftReq{AgentID: 9, Day: "2024-01-01"}); err != nil {
+		t.Fatalf("clear err:(%+v)", err)
+	}
+	if srv.esReadable(ctx, 9) {
+		t.Fatalf("agent 9 readable with a day still drifted")
+	}
+	if err := srv.ClearEsDrift(ctx, &view.ClearEsDriftReq{AgentID: 9}); err != nil {
+		t.Fatalf("clear err:(%+v)", err)
+	}
+	if !srv.esReadable(ctx, 9) || srv.esReadable(ctx, 90) {
+		t.Fatalf("agent 9 not readable after clearing every day")
+	}
+	if mr.Exists(es.DriftAgentKey) && mr.HGet(es.DriftAgentKey, "9") != "" {
+		t.Fatalf("drift count of agent 9 kept")
+	}
+	// Clearing a day that did not drift leaves the count alone
+	es.ClearDrift(ctx, srv.redisCli, 90, "2024-01-05")
+	if srv.esReadable(ctx, 90) {
+		t.Fatalf("agent 90 readable after clearing another day")
+	}
+}
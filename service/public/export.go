@@ -0,0 +1,669 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-zrbc/config"
+	"go-zrbc/db"
+	"go-zrbc/pkg/export"
+	"go-zrbc/pkg/gameUtil"
+	awsS3 "go-zrbc/pkg/oss"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ReportExportDateTime = "datetime"
+	ReportExportTrade    = "trade"
+
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+)
+
+const (
+	exportQueueKey = "report_export:queue"
+	// exportRunningKey lists the jobs taken by a worker, a job stays in it until its result is saved
+	exportRunningKey = "report_export:running"
+	// exportFilesKey sorted set of the uploaded files by the unix time their job expires
+	exportFilesKey = "report_export:files"
+	// exportMaxActive caps the pending and running exports of an agent
+	exportMaxActive     = 3
+	exportDefaultRows   = 1000000
+	exportDefaultExpire = 24 * time.Hour
+	exportTradePageSize = 5000
+	// exportURLExpire is how long a download link works, polling again signs a new one
+	exportURLExpire = time.Hour
+	// exportPollTimeout is how long the worker blocks on an empty queue before checking ctx again
+	exportPollTimeout = 5 * time.Second
+	// a running job keeps a heartbeat, the reaper queues it again once the heartbeat is gone on two passes
+	exportHeartbeatTTL = 30 * time.Second
+	exportReapInterval = time.Minute
+	// exportMaxAttempts is how often a job whose worker died is run before it fails
+	exportMaxAttempts = 3
+)
+
+var (
+	dateTimeExportHeader = []string{"user", "betId", "betTime", "beforeCash", "bet", "validbet", "water", "result", "betCode", "betResult", "waterbet", "winLoss", "ip", "gid", "event", "eventChild", "round", "subround", "tableId", "commission", "settime", "reset", "gameResult", "gname"}
+	tradeExportHeader    = []string{"mid", "orderid", "ordernum", "addtime", "money", "op_code", "subtotal"}
+
+	errExportTooManyRows = errors.New("too many rows")
+
+	// requeueExportScript moves a job from the running list back to the queue, only once when reapers race
+	requeueExportScript = redis.NewScript(`
+if redis.call("LREM", KEYS[1], 1, ARGV[1]) == 1 then
+	redis.call("RPUSH", KEYS[2], ARGV[1])
+	return 1
+end
+return 0
+`)
+	// releaseExportScript gives an export slot back, the count lapses with the jobs and never goes below zero
+	releaseExportScript = redis.NewScript(`
+if tonumber(redis.call("GET", KEYS[1]) or "0") > 0 then
+	return redis.call("DECR", KEYS[1])
+end
+return 0
+`)
+)
+
+// exportJob is an export as kept in redis, the parameters of the report beside what a vendor polls
+type exportJob struct {
+	view.ReportExport
+	VendorID  string `json:"vendorId"`
+	AgentID   int64  `json:"agentId"`
+	MemberID  int64  `json:"memberId"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+	TimeType  int    `json:"timeType"`
+	DataType  int    `json:"dataType"`
+	Syslang   string `json:"syslang"`
+	// Key is the s3 key of the file once uploaded
+	Key string `json:"key"`
+	// Attempts counts the runs, a job is run again when its worker died
+	Attempts int `json:"attempts"`
+}
+
+func exportJobKey(jobID string) string {
+	return "report_export:job:" + jobID
+}
+
+func exportActiveKey(agentID int64) string {
+	return fmt.Sprintf("report_export:active:%d", agentID)
+}
+
+func exportHeartbeatKey(jobID string) string {
+	return "report_export:heartbeat:" + jobID
+}
+
+func exportMaxRows() int64 {
+	if n := config.Global.ReportExport.MaxRows; n > 0 {
+		return int64(n)
+	}
+	return exportDefaultRows
+}
+
+// exportExpire is how long a job and its file are kept. The reaper deletes the file once its job expired, a
+// lifecycle rule expiring report_export/ a day later on the bucket catches the files it missed.
+func exportExpire() time.Duration {
+	if h := config.Global.ReportExport.ExpireHours; h > 0 {
+		return time.Duration(h) * time.Hour
+	}
+	return exportDefaultExpire
+}
+
+// CreateReportExport queues the export of a report too large to page through synchronously
+func (srv *publicApiService) CreateReportExport(ctx context.Context, req *view.CreateReportExportReq) (*view.CreateReportExportResp, error) {
+	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
+		xlog.Errorf("error to check timestamp, err:%+v", err)
+		return nil, err
+	}
+	avgResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	if err != nil {
+		xlog.Errorf("error to verify agent, err:%+v", err)
+		return nil, err
+	}
+	job, err := srv.newExportJob(avgResp, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.queueExport(ctx, job); err != nil {
+		return nil, err
+	}
+	return &view.CreateReportExportResp{Result: &job.ReportExport}, nil
+}
+
+// GetReportExport returns the state of an export of the vendor and a fresh download link once it is done
+func (srv *publicApiService) GetReportExport(ctx context.Context, req *view.GetReportExportReq) (*view.GetReportExportResp, error) {
+	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
+		xlog.Errorf("error to check timestamp, err:%+v", err)
+		return nil, err
+	}
+	avgResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	if err != nil {
+		xlog.Errorf("error to verify agent, err:%+v", err)
+		return nil, err
+	}
+	ret, err := srv.getExport(ctx, avgResp.Agent.ID, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	return &view.GetReportExportResp{Result: ret}, nil
+}
+
+func (srv *publicApiService) newExportJob(avResp *view.AgentVerifyResp, req *view.CreateReportExportReq) (*exportJob, error) {
+	if req.Format == "" {
+		req.Format = export.FormatCSV
+	}
+	if !export.Valid(req.Format) {
+		return nil, utils.ErrParamError
+	}
+	if req.StartTime <= 0 || req.EndTime <= req.StartTime {
+		return nil, utils.ErrParamError
+	}
+	if req.EndTime-req.StartTime > maxDateTimeReportRange {
+		return nil, utils.ErrFunctionOnlyQueryOneMonthReport
+	}
+
+	job := &exportJob{
+		VendorID:  avResp.Agent.VendorID,
+		AgentID:   avResp.Agent.ID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		TimeType:  req.TimeType,
+		DataType:  req.DataType,
+		Syslang:   req.Syslang,
+	}
+	switch req.Report {
+	case ReportExportDateTime:
+		filter := job.dateTimeReportReq()
+		filter.User = req.User
+		memberID, err := srv.checkDateTimeReportFilter(avResp, filter)
+		if err != nil {
+			return nil, err
+		}
+		job.MemberID = memberID
+	case ReportExportTrade:
+		if req.User != "" {
+			member, err := srv.userDao.QueryByAccount(srv.DB(), req.User)
+			if err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return nil, utils.ErrParamInvalidAccountNotExist
+				}
+				return nil, err
+			}
+			if member.Mem011 != avResp.Agent.ID {
+				return nil, utils.ErrParamInvalidAccountNotBelongToAgent
+			}
+			job.MemberID = member.ID
+		}
+	default:
+		return nil, utils.ErrParamError
+	}
+
+	now := time.Now()
+	job.ReportExport = view.ReportExport{
+		JobID:      strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Report:     req.Report,
+		Format:     req.Format,
+		Status:     ExportStatusPending,
+		CreateTime: now.Unix(),
+		ExpireTime: now.Add(exportExpire()).Unix(),
+	}
+	return job, nil
+}
+
+// queueExport saves the job and hands it to the workers, at most exportMaxActive per agent wait or run at once
+func (srv *publicApiService) queueExport(ctx context.Context, job *exportJob) error {
+	activeKey := exportActiveKey(job.AgentID)
+	active, err := srv.redisCli.Incr(ctx, activeKey).Result()
+	if err != nil {
+		xlog.Errorf("error to count report exports of agent %d, err:%+v", job.AgentID, err)
+		return utils.ErrRedisError
+	}
+	// A job lost for good never gives its slot back, the count lapses with the jobs
+	srv.redisCli.Expire(ctx, activeKey, exportExpire())
+	if active > exportMaxActive {
+		srv.releaseExport(ctx, job.AgentID)
+		return utils.ErrReportExportTooMany
+	}
+
+	if err := srv.saveExport(ctx, job); err != nil {
+		srv.releaseExport(ctx, job.AgentID)
+		return utils.ErrRedisError
+	}
+	if err := srv.redisCli.LPush(ctx, exportQueueKey, job.JobID).Err(); err != nil {
+		xlog.Errorf("error to queue report export %s, err:%+v", job.JobID, err)
+		srv.releaseExport(ctx, job.AgentID)
+		srv.redisCli.Del(ctx, exportJobKey(job.JobID))
+		return utils.ErrRedisError
+	}
+	xlog.Infof("report export %s queued, vendor:%s, report:%s", job.JobID, job.VendorID, job.Report)
+	return nil
+}
+
+func (srv *publicApiService) getExport(ctx context.Context, agentID int64, jobID string) (*view.ReportExport, error) {
+	job, err := srv.loadExport(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.AgentID != agentID {
+		return nil, utils.ErrReportExportNotExist
+	}
+	ret := job.ReportExport
+	if job.Status != ExportStatusDone {
+		return &ret, nil
+	}
+	url, err := awsS3.GetPresignedURL(ctx, srv.s3Presign, &s3.GetObjectInput{
+		Bucket: aws.String(config.Global.ReportExport.Bucket),
+		Key:    aws.String(job.Key),
+	}, exportURLExpire)
+	if err != nil {
+		xlog.Errorf("error to sign report export %s, err:%+v", jobID, err)
+		return nil, err
+	}
+	ret.URL = url
+	ret.URLExpireTime = time.Now().Add(exportURLExpire).Unix()
+	return &ret, nil
+}
+
+func (srv *publicApiService) saveExport(ctx context.Context, job *exportJob) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(time.Unix(job.ExpireTime, 0))
+	if ttl <= 0 {
+		return nil
+	}
+	if err := srv.redisCli.Set(ctx, exportJobKey(job.JobID), b, ttl).Err(); err != nil {
+		xlog.Errorf("error to save report export %s, err:%+v", job.JobID, err)
+		return err
+	}
+	return nil
+}
+
+// loadExport returns nil for a job that does not exist or expired
+func (srv *publicApiService) loadExport(ctx context.Context, jobID string) (*exportJob, error) {
+	if jobID == "" {
+		return nil, nil
+	}
+	b, err := srv.redisCli.Get(ctx, exportJobKey(jobID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		xlog.Errorf("error to get report export %s, err:%+v", jobID, err)
+		return nil, utils.ErrRedisError
+	}
+	var job exportJob
+	if err := json.Unmarshal(b, &job); err != nil {
+		xlog.Errorf("error to unmarshal report export %s, err:%+v", jobID, err)
+		return nil, err
+	}
+	return &job, nil
+}
+
+// releaseExport gives back the slot of an export of agentID
+func (srv *publicApiService) releaseExport(ctx context.Context, agentID int64) {
+	if err := releaseExportScript.Run(ctx, srv.redisCli, []string{exportActiveKey(agentID)}).Err(); err != nil {
+		xlog.Errorf("error to release report export of agent %d, err:%+v", agentID, err)
+	}
+}
+
+// RunReportExporter exports the queued jobs one at a time until ctx is done, every server runs one. A job is
+// moved to exportRunningKey while it runs, the reaper queues it again when its worker died.
+func (srv *publicApiService) RunReportExporter(ctx context.Context) {
+	if config.Global.ReportExport.Bucket == "" {
+		xlog.Warnf("no report export bucket configured, report exporter not started")
+		return
+	}
+	reaped := make(chan struct{})
+	go func() {
+		defer close(reaped)
+		srv.runExportReaper(ctx)
+	}()
+	defer func() { <-reaped }()
+
+	for ctx.Err() == nil {
+		jobID, err := srv.redisCli.BRPopLPush(ctx, exportQueueKey, exportRunningKey, exportPollTimeout).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				xlog.Errorf("error to pop report export, err:%+v", err)
+				time.Sleep(exportPollTimeout)
+			}
+			continue
+		}
+		srv.runExport(ctx, jobID)
+	}
+}
+
+// runExport runs a job taken from the queue. A job whose state cannot be saved stays in exportRunningKey, the
+// reaper queues it again.
+func (srv *publicApiService) runExport(ctx context.Context, jobID string) {
+	job, err := srv.loadExport(ctx, jobID)
+	if err != nil {
+		return
+	}
+	if job == nil {
+		xlog.Warnf("report export %s expired before it ran", jobID)
+		srv.redisCli.LRem(ctx, exportRunningKey, 1, jobID)
+		return
+	}
+	stop := srv.exportHeartbeat(ctx, jobID)
+	defer stop()
+
+	job.Attempts++
+	if job.Attempts > exportMaxAttempts {
+		xlog.Errorf("report export %s failed after %d attempts", jobID, exportMaxAttempts)
+		job.Status = ExportStatusFailed
+		job.Error = "export failed"
+		srv.finishExport(ctx, job)
+		return
+	}
+	job.Status = ExportStatusRunning
+	if err := srv.saveExport(ctx, job); err != nil {
+		return
+	}
+	start := time.Now()
+	err = srv.exportReport(ctx, job)
+	if ctx.Err() != nil {
+		// shutting down, the next worker runs it from the start
+		srv.requeueExport(job)
+		return
+	}
+	if err != nil {
+		xlog.Errorf("error to export report %s, err:%+v", jobID, err)
+		job.Status = ExportStatusFailed
+		job.Error = "export failed"
+		if err == errExportTooManyRows {
+			job.Error = fmt.Sprintf("more than %d rows, narrow the time range or the user", exportMaxRows())
+		}
+	} else {
+		job.Status = ExportStatusDone
+	}
+	if srv.finishExport(ctx, job) {
+		xlog.Infof("report export %s %s, rows:%d, took:%v", jobID, job.Status, job.Rows, time.Since(start))
+	}
+}
+
+// finishExport saves the result of job and gives its slot back, false when the result is not saved
+func (srv *publicApiService) finishExport(ctx context.Context, job *exportJob) bool {
+	job.FinishTime = time.Now().Unix()
+	if job.Key != "" {
+		err := srv.redisCli.ZAdd(ctx, exportFilesKey, &redis.Z{Score: float64(job.ExpireTime), Member: job.Key}).Err()
+		if err != nil {
+			xlog.Errorf("error to record report export file %s, err:%+v", job.Key, err)
+			return false
+		}
+	}
+	if err := srv.saveExport(ctx, job); err != nil {
+		return false
+	}
+	if err := srv.redisCli.LRem(ctx, exportRunningKey, 1, job.JobID).Err(); err != nil {
+		xlog.Errorf("error to remove running report export %s, err:%+v", job.JobID, err)
+	}
+	srv.releaseExport(ctx, job.AgentID)
+	return true
+}
+
+// requeueExport hands an interrupted job back to the queue, ctx is done already
+func (srv *publicApiService) requeueExport(job *exportJob) {
+	ctx := context.Background()
+	job.Attempts--
+	job.Status = ExportStatusPending
+	job.Rows = 0
+	job.Key = ""
+	if err := srv.saveExport(ctx, job); err != nil {
+		return
+	}
+	if err := requeueExportScript.Run(ctx, srv.redisCli, []string{exportRunningKey, exportQueueKey}, job.JobID).Err(); err != nil {
+		xlog.Errorf("error to queue report export %s again, err:%+v", job.JobID, err)
+		return
+	}
+	xlog.Infof("report export %s interrupted, queued again", job.JobID)
+}
+
+// exportHeartbeat keeps the heartbeat of a running job until the returned func is called
+func (srv *publicApiService) exportHeartbeat(ctx context.Context, jobID string) func() {
+	key := exportHeartbeatKey(jobID)
+	if err := srv.redisCli.Set(ctx, key, 1, exportHeartbeatTTL).Err(); err != nil {
+		xlog.Errorf("error to set heartbeat of report export %s, err:%+v", jobID, err)
+	}
+	beatCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(exportHeartbeatTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-beatCtx.Done():
+				return
+			case <-ticker.C:
+				srv.redisCli.Expire(beatCtx, key, exportHeartbeatTTL)
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+		srv.redisCli.Del(context.Background(), key)
+	}
+}
+
+// runExportReaper queues again the jobs of dead workers and deletes the files of expired jobs until ctx is done
+func (srv *publicApiService) runExportReaper(ctx context.Context) {
+	ticker := time.NewTicker(exportReapInterval)
+	defer ticker.Stop()
+	suspects := map[string]bool{}
+	for {
+		suspects = srv.reapExports(ctx, suspects)
+		srv.expireExportFiles(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reapExports queues again the running jobs without a heartbeat that were suspects already, and returns the
+// suspects of the next pass. Waiting a pass spares a job popped just before its first heartbeat.
+func (srv *publicApiService) reapExports(ctx context.Context, suspects map[string]bool) map[string]bool {
+	ids, err := srv.redisCli.LRange(ctx, exportRunningKey, 0, -1).Result()
+	if err != nil {
+		xlog.Errorf("error to list running report exports, err:%+v", err)
+		return suspects
+	}
+	next := map[string]bool{}
+	for _, id := range ids {
+		alive, err := srv.redisCli.Exists(ctx, exportHeartbeatKey(id)).Result()
+		if err != nil || alive > 0 {
+			continue
+		}
+		if !suspects[id] {
+			next[id] = true
+			continue
+		}
+		n, err := requeueExportScript.Run(ctx, srv.redisCli, []string{exportRunningKey, exportQueueKey}, id).Int()
+		if err != nil {
+			xlog.Errorf("error to queue report export %s again, err:%+v", id, err)
+			continue
+		}
+		if n == 1 {
+			xlog.Warnf("report export %s lost its worker, queued again", id)
+		}
+	}
+	return next
+}
+
+// expireExportFiles deletes the files of the jobs expired by now from the bucket
+func (srv *publicApiService) expireExportFiles(ctx context.Context, now time.Time) {
+	keys, err := srv.redisCli.ZRangeByScore(ctx, exportFilesKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   fmt.Sprint(now.Unix()),
+		Count: 100,
+	}).Result()
+	if err != nil {
+		xlog.Errorf("error to list expired report export files, err:%+v", err)
+		return
+	}
+	for _, key := range keys {
+		// the reaper that takes the key out deletes the file
+		if n, err := srv.redisCli.ZRem(ctx, exportFilesKey, key).Result(); err != nil || n == 0 {
+			continue
+		}
+		err := awsS3.DeleteFile(ctx, srv.s3API, &s3.DeleteObjectInput{
+			Bucket: aws.String(config.Global.ReportExport.Bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			xlog.Errorf("error to delete report export file %s, err:%+v", key, err)
+			srv.redisCli.ZAdd(ctx, exportFilesKey, &redis.Z{Score: float64(now.Add(exportReapInterval).Unix()), Member: key})
+		}
+	}
+}
+
+// exportReport writes the report into a temporary file and uploads it
+func (srv *publicApiService) exportReport(ctx context.Context, job *exportJob) error {
+	f, err := os.CreateTemp("", "report_export_*."+job.Format)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	header := dateTimeExportHeader
+	if job.Report == ReportExportTrade {
+		header = tradeExportHeader
+	}
+	w, err := export.NewWriter(job.Format, f, header)
+	if err != nil {
+		return err
+	}
+	job.Rows = 0
+	write := func(row []string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if job.Rows >= exportMaxRows() {
+			return errExportTooManyRows
+		}
+		job.Rows++
+		return w.WriteRow(row)
+	}
+	if job.Report == ReportExportTrade {
+		err = srv.exportTrade(job, write)
+	} else {
+		err = srv.exportDateTime(ctx, job, write)
+	}
+	if err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		return err
+	}
+	job.Key = exportFileKey(job)
+	_, err = awsS3.PutFile(ctx, srv.s3API, &s3.PutObjectInput{
+		Bucket:      aws.String(config.Global.ReportExport.Bucket),
+		Key:         aws.String(job.Key),
+		Body:        f,
+		ContentType: aws.String(export.ContentType(job.Format)),
+	})
+	return err
+}
+
+// exportDateTime pages through the date time report as GetDateTimeReport does, on the source of its first page
+func (srv *publicApiService) exportDateTime(ctx context.Context, job *exportJob, write func([]string) error) error {
+	req := job.dateTimeReportReq()
+	var after *db.ReportCursor
+	for {
+		bets, source, err := srv.getDateTimeReportPage(ctx, job.AgentID, job.MemberID, req, after)
+		if err != nil {
+			return err
+		}
+		next := nextReportCursor(bets, after, req.TimeType, source)
+		for _, bet := range bets {
+			item := gameUtil.ReportFormat(bet, req.Syslang)
+			err := write([]string{
+				item.User, item.BetID, item.BetTime, item.BeforeCash.String(), item.Bet.String(), item.ValidBet.String(),
+				item.Water.String(), item.Result.String(), item.BetCode, item.BetResult, item.WaterBet.String(),
+				item.WinLoss.String(), item.IP, item.GID, item.Event, item.EventChild, item.Round, item.Subround,
+				item.TableID, item.Commission.String(), item.Settime, item.Reset, item.GameResult, item.GName,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		after = next
+	}
+}
+
+// exportTrade pages through the transfers of the agent in MySQL by iom001
+func (srv *publicApiService) exportTrade(job *exportJob, write func([]string) error) error {
+	start, end := time.Unix(job.StartTime, 0), time.Unix(job.EndTime, 0)
+	var afterID int64
+	for {
+		records, err := srv.inOutMDao.GetInOutMListForExport(srv.DB(), job.AgentID, job.MemberID, start, end, afterID, exportTradePageSize)
+		if err != nil {
+			return err
+		}
+		for _, v := range records {
+			err := write([]string{
+				fmt.Sprint(v.Iom003), fmt.Sprint(v.Iom001), v.Iom008, v.Iom002.Format("2006-01-02 15:04:05"),
+				v.Iom004.String(), v.Iom005, v.Iom010.String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(records) < exportTradePageSize {
+			return nil
+		}
+		afterID = records[len(records)-1].Iom001
+	}
+}
+
+func (job *exportJob) dateTimeReportReq() *view.GetDateTimeReportReq {
+	return &view.GetDateTimeReportReq{
+		VendorID:  job.VendorID,
+		StartTime: job.StartTime,
+		EndTime:   job.EndTime,
+		TimeType:  job.TimeType,
+		DataType:  job.DataType,
+		Syslang:   job.Syslang,
+	}
+}
+
+func exportFileKey(job *exportJob) string {
+	keyDir := "dev"
+	if config.Global.GinMode == "test" {
+		keyDir = "test"
+	} else if config.Global.GinMode == "prod" {
+		keyDir = "prod"
+	}
+	return fmt.Sprintf("%s/report_export/%s/%s.%s", keyDir, job.VendorID, job.JobID, job.Format)
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/service"
+	"go-zrbc/view"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/glebarez/sqlite"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeS3 keeps the uploaded files and signs a link naming the key
+type fakeS3 struct {
+	files map[string][]byte
+}
+
+func (f *fakeS3) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	b, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.files[*params.Key] = b
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	delete(f.files, *params.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return &s3.GetObjectOutput{}, nil
+}
+
+func (f *fakeS3) PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	return &v4.PresignedHTTPRequest{URL: "https://s3.test/" + *params.Key + "?signed"}, nil
+}
+
+func TestPublicApiService_ReportExport(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "export.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT, mem011 INTEGER)",
+		"CREATE TABLE in_out_m (iom001 INTEGER PRIMARY KEY, iom002 DATETIME, iom003 INTEGER, iom004 DECIMAL(15,4), iom005 TEXT, iom006 INTEGER, iom007 INTEGER, iom008 TEXT, iom009 INTEGER, iom010 DECIMAL(15,4))",
+		"INSERT INTO member VALUES (5, 'tom', 9), (6, 'ann', 8)",
+		"INSERT INTO in_out_m VALUES (1, '2024-01-01 08:00:00', 5, 100.5, '121', 5, 9, 'order-1', 1, 100.5)",
+		"INSERT INTO in_out_m VALUES (2, '2024-01-02 08:00:00', 5, -50, '122', 5, 9, 'order-2', 1, 50.5)",
+		"INSERT INTO in_out_m VALUES (3, '2024-01-02 09:00:00', 5, 0, '301', 5, 9, 'rebate', 1, 50.5)",
+		"INSERT INTO in_out_m VALUES (4, '2024-01-02 10:00:00', 6, 20, '121', 5, 8, 'order-4', 1, 20)",
+		"INSERT INTO in_out_m VALUES (5, '2024-02-10 08:00:00', 5, 10, '121', 5, 9, 'order-5', 1, 60.5)",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	mr := miniredis.RunT(t)
+	bucket := &fakeS3{files: map[string][]byte{}}
+	srv := &publicApiService{
+		userDao:   db.NewMemberDao(),
+		inOutMDao: db.NewInOutMDao(),
+		redisCli:  redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		s3API:     bucket,
+		s3Presign: bucket,
+		Session:   service.NewSession(tx),
+	}
+	ctx := context.Background()
+	agent := &view.AgentVerifyResp{Agent: &view.Agent{ID: 9, VendorID: "demo"}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	req := &view.CreateReportExportReq{Report: ReportExportTrade, User: "tom", StartTime: start, EndTime: start + 31*86400}
+
+	job, err := srv.newExportJob(agent, req)
+	if err != nil {
+		t.Fatalf("new job err:(%+v)", err)
+	}
+	if job.Format != "csv" || job.MemberID != 5 || job.Status != ExportStatusPending {
+		t.Fatalf("job:(%+v)", job)
+	}
+	if err := srv.queueExport(ctx, job); err != nil {
+		t.Fatalf("queue err:(%+v)", err)
+	}
+	ret, err := srv.getExport(ctx, 9, job.JobID)
+	if err != nil || ret.Status != ExportStatusPending || ret.URL != "" {
+		t.Fatalf("pending export:(%+v), err:(%+v)", ret, err)
+	}
+
+	jobID, _ := srv.redisCli.RPopLPush(ctx, exportQueueKey, exportRunningKey).Result()
+	srv.runExport(ctx, jobID)
+	if n, _ := srv.redisCli.LLen(ctx, exportRunningKey).Result(); n != 0 || mr.Exists(exportHeartbeatKey(jobID)) {
+		t.Fatalf("finished export still running")
+	}
+	ret, err = srv.getExport(ctx, 9, job.JobID)
+	if err != nil || ret.Status != ExportStatusDone || ret.Rows != 2 || ret.URL != "https://s3.test/dev/report_export/demo/"+job.JobID+".csv?signed" {
+		t.Fatalf("done export:(%+v), err:(%+v)", ret, err)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(bucket.files["dev/report_export/demo/"+job.JobID+".csv"], []byte("\ufeff")))).ReadAll()
+	if err != nil || len(rows) != 3 {
+		t.Fatalf("csv rows:(%q), err:(%+v)", rows, err)
+	}
+	if strings.Join(rows[1], ",") != "5,1,order-1,2024-01-01 08:00:00,100.5,121,100.5" || rows[2][1] != "2" {
+		t.Fatalf("csv rows:(%q)", rows)
+	}
+	if active, _ := mr.Get(exportActiveKey(9)); active != "0" {
+		t.Fatalf("active exports:(%s), want the slot back", active)
+	}
+
+	// Another agent cannot poll the job
+	if _, err := srv.getExport(ctx, 8, job.JobID); err != utils.ErrReportExportNotExist {
+		t.Fatalf("export of another agent err:(%+v)", err)
+	}
+
+	// A member of another agent, a range over 31 days and an unknown report are refused
+	if _, err := srv.newExportJob(agent, &view.CreateReportExportReq{Report: ReportExportTrade, User: "ann", StartTime: start, EndTime: start + 86400}); err != utils.ErrParamInvalidAccountNotBelongToAgent {
+		t.Fatalf("member of another agent err:(%+v)", err)
+	}
+	if _, err := srv.newExportJob(agent, &view.CreateReportExportReq{Report: ReportExportTrade, StartTime: start, EndTime: start + 32*86400}); err != utils.ErrFunctionOnlyQueryOneMonthReport {
+		t.Fatalf("long range err:(%+v)", err)
+	}
+	if _, err := srv.newExportJob(agent, &view.CreateReportExportReq{Report: "bets", StartTime: start, EndTime: start + 86400}); err != utils.ErrParamError {
+		t.Fatalf("unknown report err:(%+v)", err)
+	}
+
+	// At most exportMaxActive jobs of an agent wait at once
+	for i := 0; i < exportMaxActive; i++ {
+		job, _ := srv.newExportJob(agent, &view.CreateReportExportReq{Report: ReportExportTrade, Format: "xlsx", StartTime: start, EndTime: start + 86400})
+		if err := srv.queueExport(ctx, job); err != nil {
+			t.Fatalf("queue %d err:(%+v)", i, err)
+		}
+	}
+	job, _ = srv.newExportJob(agent, &view.CreateReportExportReq{Report: ReportExportTrade, StartTime: start, EndTime: start + 86400})
+	if err := srv.queueExport(ctx, job); err != utils.ErrReportExportTooMany {
+		t.Fatalf("queue over the limit err:(%+v)", err)
+	}
+
+	// The job expires with its file
+	mr.FastForward(exportDefaultExpire)
+	if _, err := srv.getExport(ctx, 9, ret.JobID); err != utils.ErrReportExportNotExist {
+		t.Fatalf("expired export err:(%+v)", err)
+	}
+	file := "dev/report_export/demo/" + ret.JobID + ".csv"
+	srv.expireExportFiles(ctx, time.Unix(ret.ExpireTime, 0).Add(-time.Second))
+	if bucket.files[file] == nil {
+		t.Fatalf("file deleted before its job expired")
+	}
+	srv.expireExportFiles(ctx, time.Unix(ret.ExpireTime, 0))
+	if bucket.files[file] != nil {
+		t.Fatalf("file of an expired job kept")
+	}
+	if n, _ := srv.redisCli.ZCard(ctx, exportFilesKey).Result(); n != 0 {
+		t.Fatalf("%d expired files left", n)
+	}
+}
+
+func TestPublicApiService_ReportExportReaper(t *testing.T) {
+	mr := miniredis.RunT(t)
+	srv := &publicApiService{redisCli: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	ctx := context.Background()
+	newJob := func(id string) *exportJob {
+		job := &exportJob{AgentID: 9, ReportExport: view.ReportExport{JobID: id, Status: ExportStatusPending, ExpireTime: time.Now().Add(time.Hour).Unix()}}
+		srv.redisCli.Incr(ctx, exportActiveKey(9))
+		if err := srv.saveExport(ctx, job); err != nil {
+			t.Fatalf("save err:(%+v)", err)
+		}
+		srv.redisCli.LPush(ctx, exportRunningKey, id)
+		return job
+	}
+
+	// dead lost its worker, alive still beats
+	newJob("dead")
+	newJob("alive")
+	mr.Set(exportHeartbeatKey("alive"), "1")
+	suspects := srv.reapExports(ctx, map[string]bool{})
+	if !suspects["dead"] || suspects["alive"] {
+		t.Fatalf("suspects:(%v)", suspects)
+	}
+	if n, _ := srv.redisCli.LLen(ctx, exportQueueKey).Result(); n != 0 {
+		t.Fatalf("job queued again on the first pass")
+	}
+	suspects = srv.reapExports(ctx, suspects)
+	queued, _ := srv.redisCli.LRange(ctx, exportQueueKey, 0, -1).Result()
+	running, _ := srv.redisCli.LRange(ctx, exportRunningKey, 0, -1).Result()
+	if len(suspects) != 0 || len(queued) != 1 || queued[0] != "dead" || len(running) != 1 || running[0] != "alive" {
+		t.Fatalf("queued:(%v) running:(%v)", queued, running)
+	}
+	// Another reaper with the same suspects finds nothing left to move
+	srv.reapExports(ctx, map[string]bool{"dead": true})
+	if n, _ := srv.redisCli.LLen(ctx, exportQueueKey).Result(); n != 1 {
+		t.Fatalf("job queued twice")
+	}
+
+	// A job that keeps killing its worker fails and gives its slot back
+	job := newJob("poison")
+	job.Attempts = exportMaxAttempts
+	srv.saveExport(ctx, job)
+	srv.runExport(ctx, "poison")
+	job, _ = srv.loadExport(ctx, "poison")
+	if job.Status != ExportStatusFailed || job.FinishTime == 0 {
+		t.Fatalf("poison job:(%+v)", job.ReportExport)
+	}
+	if active, _ := mr.Get(exportActiveKey(9)); active != "2" {
+		t.Fatalf("active exports:(%s), want 2", active)
+	}
+
+	// An interrupted job goes back to the queue as it was
+	job = newJob("interrupted")
+	job.Attempts = 1
+	job.Status = ExportStatusRunning
+	job.Rows = 10
+	srv.requeueExport(job)
+	job, _ = srv.loadExport(ctx, "interrupted")
+	if job.Status != ExportStatusPending || job.Attempts != 0 || job.Rows != 0 {
+		t.Fatalf("interrupted job:(%+v)", job)
+	}
+	if n, _ := srv.redisCli.LRem(ctx, exportQueueKey, 0, "interrupted").Result(); n != 1 {
+		t.Fatalf("interrupted job not queued")
+	}
+
+	// The slot count lapsed, giving a slot back does not take it below zero
+	mr.Del(exportActiveKey(9))
+	srv.releaseExport(ctx, 9)
+	if active, _ := mr.Get(exportActiveKey(9)); active != "" {
+		t.Fatalf("active exports:(%s) after the count lapsed", active)
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// defaultLaunderRules apply to every agent unless the launder_rule table overrides them by code
+var defaultLaunderRules = []*db.LaunderRule{
+	{
+		Code:      "count_1m",
+		Type:      db.LaunderRuleCount,
+		Threshold: decimal.NewFromInt(9),
+		WindowSec: 60,
+		Action:    db.LaunderActionLock,
+		Message:   "請注意! 會員帳號 : {user}從{from}~{to}已有{value}次,轉帳紀錄! 請與代理商 : {agent}, skype群組{skype}  確認",
+		Status:    1,
+	},
+}
+
+var launderActionRank = map[string]int{
+	db.LaunderActionAlert:  1,
+	db.LaunderActionReject: 2,
+	db.LaunderActionLock:   3,
+}
+
+// resolveLaunderRules lays the table rows of every agent (aid 0) and then of aid over the defaults by code,
+// a disabled row switches the rule off
+func resolveLaunderRules(rows []*db.LaunderRule, aid int64) []*db.LaunderRule {
+	var codes []string
+	picked := map[string]*db.LaunderRule{}
+	pick := func(rule *db.LaunderRule) {
+		if _, ok := picked[rule.Code]; !ok {
+			codes = append(codes, rule.Code)
+		}
+		picked[rule.Code] = rule
+	}
+	for _, rule := range defaultLaunderRules {
+		pick(rule)
+	}
+	for _, rule := range rows {
+		if rule.Aid == 0 {
+			pick(rule)
+		}
+	}
+	for _, rule := range rows {
+		if rule.Aid == aid {
+			pick(rule)
+		}
+	}
+
+	var ret []*db.LaunderRule
+	for _, code := range codes {
+		if rule := picked[code]; rule.Status == 1 {
+			ret = append(ret, rule)
+		}
+	}
+	return ret
+}
+
+// renderLaunderMessage fills the placeholders of a rule message
+func renderLaunderMessage(rule *db.LaunderRule, vars map[string]string) string {
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(rule.Message)
+}
+
+// launderValue measures what rule looks at for a transfer of money, hit reports whether it crossed the threshold
+func (srv *publicApiService) launderValue(tx *gorm.DB, rule *db.LaunderRule, avResp *view.AgentVerifyResp, member *db.Member, money decimal.Decimal, since time.Time) (value decimal.Decimal, hit bool, err error) {
+	switch rule.Type {
+	case db.LaunderRuleCount:
+		stats, err := srv.inOutMDao.GetTransferStatsSince(tx, member.ID, since)
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		value = decimal.NewFromInt(stats.Count)
+		return value, value.GreaterThan(rule.Threshold), nil
+	case db.LaunderRuleAmount:
+		stats, err := srv.inOutMDao.GetTransferStatsSince(tx, member.ID, since)
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		value = stats.Amount.Add(money.Abs())
+		return value, value.GreaterThan(rule.Threshold), nil
+	case db.LaunderRuleLargeTransfer:
+		value = money.Abs()
+		return value, value.GreaterThanOrEqual(rule.Threshold), nil
+	case db.LaunderRuleDepositWithdraw:
+		// Only a withdrawal can close the loop, the threshold is the share of the deposits that must have been bet
+		if !money.IsNegative() {
+			return decimal.Zero, false, nil
+		}
+		stats, err := srv.inOutMDao.GetTransferStatsSince(tx, member.ID, since)
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		if !stats.Deposit.IsPositive() {
+			return decimal.Zero, false, nil
+		}
+		value, err = srv.bet02Dao.SumBetSince(tx, member.ID, since)
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		return value, value.LessThan(stats.Deposit.Mul(rule.Threshold)), nil
+	case db.LaunderRuleAgentSwitch:
+		others, err := srv.inOutMDao.CountOtherAgentsSince(tx, member.ID, avResp.Agent.ID, since)
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		value = decimal.NewFromInt(others + 1)
+		return value, value.GreaterThan(rule.Threshold), nil
+	default:
+		xlog.Warnf("unknown launder rule type, code:%s, type:%s", rule.Code, rule.Type)
+		return decimal.Zero, false, nil
+	}
+}
+
+// launderHits is what the launder rules found for one transfer, the alerts are not written yet
+type launderHits struct {
+	member *db.Member
+	alerts []*db.AlertMessage
+	action string
+}
+
+// err is the answer to the transfer the hits were found for
+func (h *launderHits) err() error {
+	switch h.action {
+	case db.LaunderActionLock:
+		return utils.ErrWalletTransferRiskLocked
+	case db.LaunderActionReject:
+		return utils.ErrWalletTransferRiskRejected
+	}
+	return nil
+}
+
+// CheckLaunder runs the launder rules of the agent against a transfer of money by member.
+// Every hit is written to alert_message; a reject rule refuses the transfer and a lock rule also locks the member.
+func (srv *publicApiService) CheckLaunder(tx *gorm.DB, avResp *view.AgentVerifyResp, member *db.Member, money decimal.Decimal) error {
+	hits, err := srv.evalLaunder(tx, avResp, member, money)
+	if err != nil {
+		return err
+	}
+	if err := srv.recordLaunder(tx, hits); err != nil {
+		return err
+	}
+	return hits.err()
+}
+
+// evalLaunder runs the launder rules without writing anything, tx only has to see the transfers of the window
+func (srv *publicApiService) evalLaunder(tx *gorm.DB, avResp *view.AgentVerifyResp, member *db.Member, money decimal.Decimal) (*launderHits, error) {
+	rows, err := srv.launderRuleDao.QueryByAids(tx, []int64{0, avResp.Agent.ID})
+	if err != nil {
+		xlog.Errorf("error to query launder rules, err:%+v", err)
+		return nil, err
+	}
+
+	// An operator who unlocked the member has cleared what came before, the windows start over
+	unlock, err := srv.memberLockAuditDao.QueryLastUnlock(tx, member.ID)
+	if err != nil {
+		xlog.Errorf("error to query last unlock of member %d, err:%+v", member.ID, err)
+		return nil, err
+	}
+
+	hits := &launderHits{member: member}
+	nowTime := time.Now()
+	for _, rule := range resolveLaunderRules(rows, avResp.Agent.ID) {
+		since := nowTime.Add(-time.Duration(rule.WindowSec) * time.Second)
+		if unlock != nil && unlock.CreateTime.After(since) {
+			since = unlock.CreateTime
+		}
+		value, hit, err := srv.launderValue(tx, rule, avResp, member, money, since)
+		if err != nil {
+			xlog.Errorf("error to check launder rule %s, err:%+v", rule.Code, err)
+			return nil, err
+		}
+		if !hit {
+			continue
+		}
+
+		// An alert only rule keeps firing on every transfer of the window, one alert per window is enough
+		if rule.Action == db.LaunderActionAlert && rule.WindowSec > 0 {
+			exists, err := srv.alertMessageDao.ExistsSince(tx, member.ID, rule.Code, since)
+			if err != nil {
+				xlog.Errorf("error to query alert message, err:%+v", err)
+				return nil, err
+			}
+			if exists {
+				continue
+			}
+		}
+
+		message := renderLaunderMessage(rule, map[string]string{
+			"user":      member.User,
+			"agent":     avResp.Agent.Name,
+			"skype":     avResp.AgentsLoginPass.Skyname,
+			"from":      since.Format("2006-01-02 15:04:05"),
+			"to":        nowTime.Format("2006-01-02 15:04:05"),
+			"value":     value.String(),
+			"threshold": rule.Threshold.String(),
+			"money":     money.String(),
+			"rule":      rule.Code,
+		})
+		hits.alerts = append(hits.alerts, &db.AlertMessage{
+			Mid:          member.ID,
+			Message:      message,
+			Status:       0,
+			ErrorTime:    nowTime,
+			UnierrorTime: nowTime.Unix(),
+			Operator:     "",
+			RuleCode:     rule.Code,
+			Action:       rule.Action,
+		})
+		if launderActionRank[rule.Action] > launderActionRank[hits.action] {
+			hits.action = rule.Action
+		}
+	}
+	return hits, nil
+}
+
+// recordLaunder writes the alerts of hits and locks the member when a lock rule fired
+func (srv *publicApiService) recordLaunder(tx *gorm.DB, hits *launderHits) error {
+	for _, alertMsg := range hits.alerts {
+		if _, err := srv.alertMessageDao.Create(tx, alertMsg); err != nil {
+			xlog.Errorf("error to insert alert message, err:%+v", err)
+			return err
+		}
+	}
+	if hits.action == db.LaunderActionLock {
+		err := srv.userDao.UpdatesMember(tx, hits.member.ID, map[string]interface{}{
+			"mem020": "Y",
+		})
+		if err != nil {
+			xlog.Errorf("error to update member status, err:%+v", err)
+			return err
+		}
+	}
+	return nil
+}
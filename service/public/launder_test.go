@@ -0,0 +1,47 @@
+package service
+
+import (
+	"go-zrbc/db"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestResolveLaunderRules(t *testing.T) {
+	rows := []*db.LaunderRule{
+		{Aid: 0, Code: "large", Type: db.LaunderRuleLargeTransfer, Threshold: decimal.NewFromInt(100000), Action: db.LaunderActionAlert, Status: 1},
+		{Aid: 9, Code: "count_1m", Type: db.LaunderRuleCount, Threshold: decimal.NewFromInt(20), WindowSec: 60, Action: db.LaunderActionReject, Status: 1},
+		{Aid: 9, Code: "large", Status: 0},
+		{Aid: 0, Code: "count_1m", Type: db.LaunderRuleCount, Threshold: decimal.NewFromInt(5), WindowSec: 60, Action: db.LaunderActionLock, Status: 1},
+	}
+
+	got := resolveLaunderRules(rows, 9)
+	if len(got) != 1 || got[0].Code != "count_1m" || !got[0].Threshold.Equal(decimal.NewFromInt(20)) {
+		t.Fatalf("agent 9 rules:(%+v), want its own count rule only", got)
+	}
+
+	got = resolveLaunderRules(rows, 8)
+	if len(got) != 2 || got[0].Code != "count_1m" || !got[0].Threshold.Equal(decimal.NewFromInt(5)) || got[1].Code != "large" {
+		t.Fatalf("agent 8 rules:(%+v), want the table defaults", got)
+	}
+
+	got = resolveLaunderRules(nil, 8)
+	if len(got) != 1 || got[0] != defaultLaunderRules[0] {
+		t.Fatalf("rules without rows:(%+v), want the code default", got)
+	}
+}
+
+func TestRenderLaunderMessage(t *testing.T) {
+	got := renderLaunderMessage(defaultLaunderRules[0], map[string]string{
+		"user":  "tom",
+		"agent": "demo",
+		"skype": "demo_group",
+		"from":  "2024-01-01 10:00:00",
+		"to":    "2024-01-01 10:01:00",
+		"value": "10",
+	})
+	want := "請注意! 會員帳號 : tom從2024-01-01 10:00:00~2024-01-01 10:01:00已有10次,轉帳紀錄! 請與代理商 : demo, skype群組demo_group  確認"
+	if got != want {
+		t.Fatalf("message:(%s), want:(%s)", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,9 @@ import (
 	"go-zrbc/db"
 	"go-zrbc/es"
 	"go-zrbc/pkg/gameUtil"
+	"go-zrbc/pkg/mq"
+	awsS3 "go-zrbc/pkg/oss"
+	"go-zrbc/pkg/ratelimit"
 	"go-zrbc/pkg/utils"
 	"go-zrbc/pkg/xlog"
 	"go-zrbc/service"
@@ -22,6 +26,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 
 	"gorm.io/gorm"
@@ -32,21 +38,42 @@ type PublicApiService interface {
 	GetUserInfo(ctx context.Context, userID int64) (*view.GetUserInfoResp, error)
 	GetUserByAccountAndPwd(ctx context.Context, account, pwd string) (*view.GetUserInfoResp, error)
 	GetUserByAccount(ctx context.Context, account string) (*view.MemberCache, error)
+	GetUserBySid(ctx context.Context, sid string) (*view.GetUserInfoResp, error)
 	SigninGame(ctx context.Context, req *view.SigninGameReq) (*view.SigninGameResp, error)
+	MemberLogin(ctx context.Context, req *view.MemberLoginReq) (*view.MemberLoginResp, error)
+	Hello(ctx context.Context, req *view.HelloReq) (*view.HelloResp, error)
 	MemberRegister(ctx context.Context, req *view.MemberRegisterReq) (*view.MemberRegisterResp, error)
 	AgentVerify(ctx context.Context, req *view.AgentVerifyReq) (*view.AgentVerifyResp, error)
+	CheckRateLimit(ctx context.Context, vendorID, command string, class ratelimit.Class, user string) error
 	EditLimit(ctx context.Context, req *view.EditLimitReq) (*view.EditLimitResp, error)
 	LogoutGame(ctx context.Context, req *view.LogoutGameReq) (*view.LogoutGameResp, error)
 	ChangePassword(ctx context.Context, req *view.ChangePasswordReq) (*view.ChangePasswordResp, error)
 	GetAgentBalance(ctx context.Context, req *view.GetAgentBalanceReq) (*view.GetAgentBalanceResp, error)
 	GetBalance(ctx context.Context, req *view.GetBalanceReq) (*view.GetBalanceResp, error)
 	ChangeBalance(ctx context.Context, req *view.ChangeBalanceReq) (*view.ChangeBalanceResp, error)
+	CheckTransfer(ctx context.Context, req *view.CheckTransferReq) (*view.CheckTransferResp, error)
+	BatchChangeBalance(ctx context.Context, req *view.BatchChangeBalanceReq) (*view.BatchChangeBalanceResp, error)
 	GetMemberTradeReport(ctx context.Context, req *view.GetMemberTradeReportReq) (*view.GetMemberTradeReportResp, error)
 	EnableOrDisableMem(ctx context.Context, req *view.EnableOrDisableMemReq) (*view.EnableOrDisableMemResp, error)
 	GetDateTimeReport(ctx context.Context, req *view.GetDateTimeReportReq) (*view.GetDateTimeReportResp, error)
+	GetDateTimeCountReport(ctx context.Context, req *view.GetDateTimeReportReq) (*view.GetDateTimeCountReportResp, error)
 	GetTipReport(ctx context.Context, req *view.GetTipReportReq) (*view.GetTipReportResp, error)
 	GetUnsettleReport(ctx context.Context, req *view.GetUnsettleReportReq) (*view.GetUnsettleReportResp, error)
 	GetReportDetail(ctx context.Context, req *view.GetReportDetailReq) (*view.GetReportDetailResp, error)
+
+	// 报表导出
+	CreateReportExport(ctx context.Context, req *view.CreateReportExportReq) (*view.CreateReportExportResp, error)
+	GetReportExport(ctx context.Context, req *view.GetReportExportReq) (*view.GetReportExportResp, error)
+	RunReportExporter(ctx context.Context)
+	RunTransferReaper(ctx context.Context)
+
+	// 报表索引偏差
+	ListEsDrift(ctx context.Context) (*view.ListEsDriftResp, error)
+	ClearEsDrift(ctx context.Context, req *view.ClearEsDriftReq) error
+
+	// 大厅长连接
+	GetTableEntry(ctx context.Context, req *view.WsTableEntryReq) (*view.WsTableEntryData, error)
+	PlaceBet(ctx context.Context, req *view.WsPlaceBetReq) (*view.WsBettingRespData, error)
 }
 
 type MemDtlDao interface {
@@ -75,10 +102,21 @@ type publicApiService struct {
 	alertMessageDao     db.AlertMessageDao
 	gameInfoDao         db.GameInfoDao
 	bet01Dao            db.Bet01Dao
+	transferRecordDao   db.TransferRecordDao
+	rateLimitRuleDao    db.RateLimitRuleDao
+	launderRuleDao      db.LaunderRuleDao
+	betSerialDao        db.BetSerialDao
+	memberLockAuditDao  db.MemberLockAuditDao
 
 	s3Client *s3.Client
 	redisCli *redis.Client
 	esClient *es.Client
+	limiter  *ratelimit.Limiter
+	// 报表导出上传与签名下载链接
+	s3API     awsS3.S3API
+	s3Presign awsS3.S3PresignAPI
+
+	rateLimitRuleCache rateLimitRuleCache
 	*service.Session
 }
 
@@ -100,6 +138,11 @@ func NewPublicApiService(
 	alertMessageDao db.AlertMessageDao,
 	gameInfoDao db.GameInfoDao,
 	bet01Dao db.Bet01Dao,
+	transferRecordDao db.TransferRecordDao,
+	rateLimitRuleDao db.RateLimitRuleDao,
+	launderRuleDao db.LaunderRuleDao,
+	betSerialDao db.BetSerialDao,
+	memberLockAuditDao db.MemberLockAuditDao,
 
 	s3Client *s3.Client,
 	redisCli *redis.Client,
@@ -122,10 +165,20 @@ func NewPublicApiService(
 		alertMessageDao:     alertMessageDao,
 		gameInfoDao:         gameInfoDao,
 		bet01Dao:            bet01Dao,
+		transferRecordDao:   transferRecordDao,
+		rateLimitRuleDao:    rateLimitRuleDao,
+		launderRuleDao:      launderRuleDao,
+		betSerialDao:        betSerialDao,
+		memberLockAuditDao:  memberLockAuditDao,
 
 		s3Client: s3Client,
 		redisCli: redisCli,
 		esClient: esClient,
+		limiter:  ratelimit.NewLimiter(redisCli),
+	}
+	if s3Client != nil {
+		srv.s3API = s3Client
+		srv.s3Presign = s3.NewPresignClient(s3Client)
 	}
 	srv.Session = sess
 	return srv
@@ -476,7 +529,12 @@ func (srv *publicApiService) SigninGame(ctx context.Context, req *view.SigninGam
 		// No action needed
 	case 7:
 		now := time.Now()
+		var oldSid string
 		err = srv.Tx(func(tx *gorm.DB) error {
+			oldSid, err = srv.loginSid(tx, mem.UID)
+			if err != nil {
+				return err
+			}
 			newMemLogin, err = srv.memLoginDao.CreateOrUpdateMemLogin(tx, mem.UID, 0, sid, GetClientIP(ctx.(*gin.Context)), now)
 			if err != nil {
 				return err
@@ -493,6 +551,7 @@ func (srv *publicApiService) SigninGame(ctx context.Context, req *view.SigninGam
 		if err != nil {
 			return nil, err
 		}
+		srv.dropSidCache(ctx, []string{oldSid})
 	}
 
 	if newMemLogin != nil {
@@ -569,7 +628,125 @@ func (srv *publicApiService) SigninGame(ctx context.Context, req *view.SigninGam
 	}, nil
 }
 
+// MemberLogin checks the member credentials and issues a sid the vendor can hand to the game client
+func (srv *publicApiService) MemberLogin(ctx context.Context, req *view.MemberLoginReq) (*view.MemberLoginResp, error) {
+	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
+		xlog.Errorf("error to check timestamp, err:%+v", err)
+		return nil, err
+	}
+	avResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	if err != nil {
+		xlog.Errorf("error to verify agent, err:%+v", err)
+		return nil, err
+	}
+
+	if req.Password == "" {
+		return nil, utils.ErrInvalidPasswordEmpty
+	}
+	if req.User == "" {
+		return nil, utils.ErrInvalidAccountEmpty
+	}
+	member, err := srv.userDao.QueryByAccount(srv.DB(), req.User)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrParamInvalidAccountNotExist
+		}
+		xlog.Errorf("error to query member by account, err:%+v", err)
+		return nil, utils.ErrSystemError
+	}
+	// Verify member belongs to agent before the password, an agent must not probe the members of another
+	if member.Mem011 != avResp.Agent.ID {
+		xlog.Errorf("error to verify member belongs to agent, err:%+v", utils.ErrParamInvalidAccountNotBelongToAgent)
+		return nil, utils.ErrParamInvalidAccountNotBelongToAgent
+	}
+	if member.Password != req.Password {
+		return nil, utils.ErrParamInvalidAccountPasswordError
+	}
+	if member.Mem016 == "N" {
+		return nil, utils.ErrParamInvalidAccountDeactivated
+	}
+	// Locked by the risk rules or an operator
+	if member.Mem020 == "Y" {
+		return nil, utils.ErrInvalidTransactionError
+	}
+
+	mem := DBToViewUserCache(member)
+	sid := utils.ProSIDCreate(config.Global.Wcode, strconv.Itoa(mem.ULV), mem.UTP, mem.UID, config.Global.SidLen)
+
+	clientIP := ""
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		clientIP = GetClientIP(ginCtx)
+	}
+	now := time.Now()
+	var memLogin *db.MemLogin
+	var oldSid string
+	err = srv.Tx(func(tx *gorm.DB) error {
+		oldSid, err = srv.loginSid(tx, mem.UID)
+		if err != nil {
+			return err
+		}
+		memLogin, err = srv.memLoginDao.CreateOrUpdateMemLogin(tx, mem.UID, 0, sid, clientIP, now)
+		if err != nil {
+			return err
+		}
+		if err := srv.userDao.UpdatesMember(tx, mem.UID, map[string]interface{}{
+			"mem013": now,
+			"mem014": clientIP,
+		}); err != nil {
+			xlog.Errorf("Failed to update member, err:%+v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		xlog.Errorf("error to create mem_login, uid:%d, err:%+v", mem.UID, err)
+		return nil, utils.ErrSystemError
+	}
+	// the replaced sid must not open websocket sessions from the cache
+	srv.dropSidCache(ctx, []string{oldSid})
+	if memLogin.Mlg003 != "" {
+		sid = memLogin.Mlg003
+	}
+
+	return &view.MemberLoginResp{
+		Result: &view.MemberLoginItem{
+			User: member.User,
+			Sid:  sid,
+			Cash: member.Cash,
+		},
+	}, nil
+}
+
+// Hello lets a vendor check its connectivity and signature without side effects
+func (srv *publicApiService) Hello(ctx context.Context, req *view.HelloReq) (*view.HelloResp, error) {
+	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
+		xlog.Errorf("error to check timestamp, err:%+v", err)
+		return nil, err
+	}
+	avResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	if err != nil {
+		xlog.Errorf("error to verify agent, err:%+v", err)
+		return nil, err
+	}
+
+	return &view.HelloResp{
+		Result: &view.HelloItem{
+			VendorID:   avResp.Agent.VendorID,
+			ServerTime: time.Now().Unix(),
+		},
+	}, nil
+}
+
+// AgentVerifyCtxKey is where the gateway keeps the agent it already verified for the request
+const AgentVerifyCtxKey = "agentVerify"
+
 func (srv *publicApiService) AgentVerify(ctx context.Context, req *view.AgentVerifyReq) (*view.AgentVerifyResp, error) {
+	// Reuse the result of the gateway check when it was made with the same credentials
+	if avResp, ok := ctx.Value(AgentVerifyCtxKey).(*view.AgentVerifyResp); ok &&
+		avResp.Agent.VendorID == req.VendorID && avResp.RequestSignature == req.Signature {
+		return avResp, nil
+	}
+
 	// Validate request parameters
 	if req.VendorID == "" && req.Signature == "" {
 		return nil, utils.ErrAgentIDAndSignatureFormatError
@@ -599,17 +776,59 @@ func (srv *publicApiService) AgentVerify(ctx context.Context, req *view.AgentVer
 	if err != nil {
 		return nil, err
 	}
-	if agentsLoginPass.Signature != req.Signature {
+	if agentsLoginPass.SignMode == db.SignModeHmac {
+		if err := srv.verifyHmacSignature(ctx, agentsLoginPass, req); err != nil {
+			xlog.Errorf("error to verify hmac signature, vendor id:%s, err:%+v", req.VendorID, err)
+			return nil, err
+		}
+	} else if agentsLoginPass.Signature != req.Signature {
 		err := utils.ErrAgentIDExistButSignatureError
 		xlog.Error(err)
 		return nil, err
 	}
 	return &view.AgentVerifyResp{
-		Agent:           DBToViewAgent(agent),
-		AgentsLoginPass: DBToViewAgentsLoginPass(agentsLoginPass),
+		Agent:            DBToViewAgent(agent),
+		AgentsLoginPass:  DBToViewAgentsLoginPass(agentsLoginPass),
+		RequestSignature: req.Signature,
 	}, nil
 }
 
+// agentNonceTTL keeps a nonce past the widest skew utils.CheckTimestamp accepts
+const agentNonceTTL = 60 * time.Second
+
+// verifyHmacSignature checks a request of an agent in HMAC mode: signature must be
+// utils.HmacSha256Hex over the canonical form params (cmd, timestamp and nonce included) keyed by the agent secret,
+// and the nonce must not have been seen within the timestamp window.
+func (srv *publicApiService) verifyHmacSignature(ctx context.Context, loginPass *db.AgentsLoginPass, req *view.AgentVerifyReq) error {
+	timestamp, err := strconv.ParseInt(req.Params.Get("timestamp"), 10, 64)
+	if err != nil {
+		return utils.ErrTimeFormatError
+	}
+	if err := utils.CheckTimestamp(timestamp); err != nil {
+		return err
+	}
+	nonce := req.Params.Get("nonce")
+	if nonce == "" {
+		return utils.ErrAgentNonceEmpty
+	}
+
+	expected := utils.HmacSha256Hex(utils.CanonicalParams(req.Params, "signature"), loginPass.Signature)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(req.Signature))) {
+		return utils.ErrAgentIDExistButSignatureError
+	}
+
+	// Only a correctly signed request may burn its nonce
+	ok, err := srv.redisCli.SetNX(ctx, fmt.Sprintf("AgentNonce_%s_%s", loginPass.VendorID, nonce), timestamp, agentNonceTTL).Result()
+	if err != nil {
+		xlog.Errorf("error to set nonce in Redis: %v", err)
+		return utils.ErrRedisError
+	}
+	if !ok {
+		return utils.ErrAgentNonceReplay
+	}
+	return nil
+}
+
 func (srv *publicApiService) validateMemberRegisterInput(req *view.MemberRegisterReq) error {
 	// Password validation
 	if req.Password == "" {
@@ -1581,7 +1800,16 @@ func (srv *publicApiService) LogoutGame(ctx context.Context, req *view.LogoutGam
 	}
 
 	// Delete mem_login records for the members
+	var sids []string
 	err = srv.Tx(func(tx *gorm.DB) error {
+		memLogins, err := srv.memLoginDao.QueryByIDs(tx, memberIDs)
+		if err != nil {
+			xlog.Errorf("error to query mem_login, memberIDs:%+v, err:%+v", memberIDs, err)
+			return err
+		}
+		for _, memLogin := range memLogins {
+			sids = append(sids, memLogin.Mlg003)
+		}
 		if err := srv.memLoginDao.UpdateMemLoginByMemIDs(tx, memberIDs); err != nil {
 			xlog.Errorf("error to delete mem_login, memberIDs:%+v, err:%+v", memberIDs, err)
 			return err
@@ -1592,6 +1820,8 @@ func (srv *publicApiService) LogoutGame(ctx context.Context, req *view.LogoutGam
 		xlog.Errorf("error to tx operation, err:%+v", err)
 		return nil, err
 	}
+	srv.dropSidCache(ctx, sids)
+	srv.kickMembers(ctx, memberIDs, mq.KickReasonLogout)
 
 	return &view.LogoutGameResp{
 		Result: "操作成功",
@@ -1762,21 +1992,9 @@ func (srv *publicApiService) ChangeBalance(ctx context.Context, req *view.Change
 		return nil, err
 	}
 
-	// Validate money parameter
-	if req.Money == "" {
-		return nil, utils.ErrWalletAddOrSubPointEmptyOrMoneyParamNotSet
-	}
-
-	// Check for Chinese characters in money parameter
-	matched, _ := regexp.MatchString(`[\p{Han}]`, req.Money)
-	if matched {
-		return nil, utils.ErrWalletAddOrSubPointChinese
-	}
-
-	// Parse money value
-	money, err := decimal.NewFromString(req.Money)
-	if err != nil || money.IsZero() {
-		return nil, utils.ErrWalletAddOrSubPointEmptyOrMoneyParamNotSet
+	money, err := parseTransferMoney(req.Money)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get member by account
@@ -1798,58 +2016,39 @@ func (srv *publicApiService) ChangeBalance(ctx context.Context, req *view.Change
 		return nil, utils.ErrInvalidTransactionError
 	}
 
-	// Check transaction timing
-	const ChangeBalance_chkTime = "ChangeBalance_chkTime"
-	currentTime := time.Now().Unix()
-
-	// Get last transaction time from Redis hash
-	lastTxTime, err := srv.redisCli.HGet(ctx, ChangeBalance_chkTime, strconv.FormatInt(member.ID, 10)).Int64()
-	if err == redis.Nil {
-		// No previous transaction time, set current time
-		err = srv.redisCli.HSet(ctx, ChangeBalance_chkTime, strconv.FormatInt(member.ID, 10), currentTime).Err()
-		if err != nil {
-			xlog.Errorf("error setting transaction time in Redis: %v", err)
+	// Answer retried orders from the transfer ledger before touching any throttle
+	if req.Order != "" {
+		record, err := srv.transferRecordDao.QueryByVendorOrder(srv.DB(), req.VendorID, req.Order)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			xlog.Errorf("error to query transfer record, err:%+v", err)
 			return nil, utils.ErrWalletTransferLineError
 		}
-	} else if err != nil {
-		xlog.Errorf("error getting transaction time from Redis: %v", err)
-		return nil, utils.ErrWalletTransferLineError
-	} else {
-		// Calculate time difference
-		timeDiff := currentTime - lastTxTime
-
-		// Special handling for specific vendors/agents
-		if req.VendorID == "igktwapi" || req.VendorID == "ocmsapi" || avResp.Agent.ID == 1717 {
-			if timeDiff < 2 {
-				return nil, utils.ErrWalletTransferRepeatIn2Error
+		if record != nil {
+			if record, err = srv.resolveTransfer(srv.DB(), record); err != nil {
+				return nil, utils.ErrWalletTransferLineError
 			}
-		} else {
-			if timeDiff < 5 {
-				return nil, utils.ErrWalletTransferRepeatIn5Error
+			switch record.Status {
+			case db.TransferStatusCommitted:
+				return nil, utils.ErrWalletTransferExist
+			case db.TransferStatusPending:
+				return nil, utils.ErrWalletTransferPending
 			}
 		}
+	}
 
-		// Update transaction time
-		err = srv.redisCli.HSet(ctx, ChangeBalance_chkTime, strconv.FormatInt(member.ID, 10), currentTime).Err()
-		if err != nil {
-			xlog.Errorf("error updating transaction time in Redis: %v", err)
-			return nil, utils.ErrWalletTransferLineError
-		}
+	// Only new orders count against the rate limit, the gateway leaves it to us
+	if err := srv.CheckRateLimit(ctx, avResp.Agent.VendorID, "ChangeBalance", ratelimit.ClassTransfer, req.User); err != nil {
+		return nil, err
 	}
 
 	// Check launder
-	launder, err := srv.CheckLaunder(srv.DB(), avResp, member)
-	if err != nil {
+	if err := srv.CheckLaunder(srv.DB(), avResp, member, money); err != nil {
 		xlog.Errorf("error to check launder, err:%+v", err)
 		return nil, err
 	}
-	if launder {
-		xlog.Errorf("error to check launder, err:%+v", utils.ErrWalletTransferLockError)
-		return nil, utils.ErrWalletTransferLockError
-	}
 
 	// Check 5 seconds duplicate transactions (same amount)
-	res, err := srv.CheckDoubleDeals(ctx, member.ID, money, req.Order)
+	res, err := srv.CheckDoubleDeals(srv.DB(), member.ID, money, req.Order)
 	if err != nil {
 		xlog.Errorf("error to check double deals, err:%+v", err)
 		return nil, err
@@ -1863,18 +2062,32 @@ func (srv *publicApiService) ChangeBalance(ctx context.Context, req *view.Change
 		return nil, utils.ErrWalletTransferExist
 	}
 
+	// Orders without a number still go through the ledger under one we make up, the answer carries it for
+	// CheckTransfer. A retry of such an order is a new order, only the 5 seconds check above catches it.
+	order := req.Order
+	if order == "" {
+		order = strings.ReplaceAll(uuid.New().String(), "-", "")
+	}
+	transfer, err := srv.claimTransfer(srv.DB(), req.VendorID, order, avResp, member, money)
+	if err != nil {
+		xlog.Errorf("error to claim transfer, err:%+v", err)
+		return nil, err
+	}
+
 	if money.IsPositive() {
-		err := srv.ProDealAddValue(ctx, money, avResp, member, req.Order)
+		err := srv.ProDealAddValue(ctx, money, avResp, member, order, transfer)
 		if err != nil {
 			xlog.Errorf("error to pro deal add value, err:%+v", err)
+			srv.failTransfer(srv.DB(), transfer, err)
 			return nil, err
 		}
 		xlog.Infof("pro deal add value result: %+v", res)
 	}
 	if money.IsNegative() {
-		err := srv.ProDealDecValue(ctx, money, avResp, member, req.Order)
+		err := srv.ProDealDecValue(ctx, money, avResp, member, order, transfer)
 		if err != nil {
 			xlog.Errorf("error to pro deal dec value, err:%+v", err)
+			srv.failTransfer(srv.DB(), transfer, err)
 			return nil, err
 		}
 		xlog.Infof("pro deal dec value result: %+v", res)
@@ -1891,66 +2104,32 @@ func (srv *publicApiService) ChangeBalance(ctx context.Context, req *view.Change
 
 	return &view.ChangeBalanceResp{
 		Result: result,
+		Order:  order,
 	}, nil
 }
 
-// CheckLaunder checks if a member has too many transactions in the last minute
-func (srv *publicApiService) CheckLaunder(tx *gorm.DB, avResp *view.AgentVerifyResp, member *db.Member) (bool, error) {
-	// Get transactions in last minute
-	count, err := srv.inOutMDao.CountTransactionsInLastMinute(tx, member.ID)
-	if err != nil {
-		xlog.Errorf("error to count transactions in last minute, err:%+v", err)
-		return false, err
-	}
-
-	if count > 9 {
-		// Create alert message
-		lastTime := time.Now().Add(-1 * time.Minute)
-		nowTime := time.Now()
-		message := fmt.Sprintf("請注意! 會員帳號 : %s從%s~%s已有%d次,轉帳紀錄!",
-			member.User,
-			lastTime.Format("2006-01-02 15:04:05"),
-			nowTime.Format("2006-01-02 15:04:05"),
-			count,
-		)
-		message += fmt.Sprintf(" 請與代理商 : %s, skype群組%s  確認",
-			avResp.Agent.Name,
-			avResp.AgentsLoginPass.Skyname,
-		)
-
-		// Insert alert message
-		alertMsg := &db.AlertMessage{
-			Mid:          member.ID,
-			Message:      message,
-			Status:       0,
-			ErrorTime:    nowTime,
-			UnierrorTime: nowTime.Unix(),
-			Operator:     "", // 设置默认操作者
-		}
-		_, err = srv.alertMessageDao.Create(tx, alertMsg)
-		if err != nil {
-			xlog.Errorf("error to insert alert message, err:%+v", err)
-			return false, err
-		}
-
-		// Update member status
-		err = srv.userDao.UpdatesMember(tx, member.ID, map[string]interface{}{
-			"mem020": "Y",
-		})
-		if err != nil {
-			xlog.Errorf("error to update member status, err:%+v", err)
-			return false, err
-		}
+// parseTransferMoney validates the money parameter of a transfer, it must be a non-zero number
+func parseTransferMoney(moneyStr string) (decimal.Decimal, error) {
+	if moneyStr == "" {
+		return decimal.Zero, utils.ErrWalletAddOrSubPointEmptyOrMoneyParamNotSet
+	}
 
-		return true, nil
+	// Check for Chinese characters in money parameter
+	matched, _ := regexp.MatchString(`[\p{Han}]`, moneyStr)
+	if matched {
+		return decimal.Zero, utils.ErrWalletAddOrSubPointChinese
 	}
 
-	return false, nil
+	money, err := decimal.NewFromString(moneyStr)
+	if err != nil || money.IsZero() {
+		return decimal.Zero, utils.ErrWalletAddOrSubPointEmptyOrMoneyParamNotSet
+	}
+	return money, nil
 }
 
 // CheckDoubleDeals checks for duplicate transactions within a short time window
-func (srv *publicApiService) CheckDoubleDeals(ctx context.Context, memberID int64, amount decimal.Decimal, orderNum string) (int, error) {
-	inOutM, err := srv.inOutMDao.GetLastTransaction(srv.DB(), memberID, orderNum)
+func (srv *publicApiService) CheckDoubleDeals(tx *gorm.DB, memberID int64, amount decimal.Decimal, orderNum string) (int, error) {
+	inOutM, err := srv.inOutMDao.GetLastTransaction(tx, memberID, orderNum)
 	if err != nil {
 		xlog.Errorf("error to get last transaction, err:%+v", err)
 		return 0, err
@@ -1991,125 +2170,115 @@ func (srv *publicApiService) GetUpLv5(ctx context.Context, member *db.Member) in
 	return member.Mem007
 }
 
-func (srv *publicApiService) ProDealAddValue(ctx context.Context, money decimal.Decimal, avResp *view.AgentVerifyResp, member *db.Member, orderNum string) error {
-	upid := srv.GetUpLv5(ctx, member)
+func (srv *publicApiService) ProDealAddValue(ctx context.Context, money decimal.Decimal, avResp *view.AgentVerifyResp, member *db.Member, orderNum string, transfer *db.TransferRecord) error {
 	lv5Before := avResp.Agent.Cash
 	if member.Type == 1 {
 		lv5Before = avResp.Agent.Credit
 	}
-	pointtype := 0
-	if member.Type == 1 {
-		pointtype = 1
-	}
 	if lv5Before.Sub(money).IsNegative() {
 		return utils.ErrWalletAgentOverLimit
 	}
-	var err error
-	err = srv.Tx(func(tx *gorm.DB) error {
-		logAgeCashChange := &db.LogAgeCashChange{
-			Lacc02:    member.Mem006,
-			Lacc03:    member.ID,
-			Lacc06:    money,
-			Lacc07:    time.Now(),
-			Lacc08:    "",
-			Lacc09:    member.Cash,
-			Lacc10:    upid,
-			Lacc11:    lv5Before,
-			Pointtype: pointtype,
-		}
-		_, err = srv.logAgeCashChangeDao.Create(tx, logAgeCashChange)
-		if err != nil {
-			xlog.Errorf("error to create log age cash change, err:%+v", err)
-			return err
-		}
-		if err = srv.userDao.UpdatesMember(tx, member.ID, map[string]interface{}{
-			"cash": gorm.Expr("cash + ?", money),
-		}); err != nil {
-			xlog.Errorf("error to update member cash, err:%+v", err)
-			return err
-		}
-		if member.Type != 1 {
-			if err = srv.agentDao.UpdatesAgent(tx, avResp.Agent.ID, map[string]interface{}{
-				"cash": gorm.Expr("cash - ?", money),
-			}); err != nil {
-				xlog.Errorf("error to update agent cash, err:%+v", err)
-				return err
-			}
-		}
-		_, err = srv.inOutMDao.DealInsRecord(tx, "121", 0, int64(avResp.Agent.ULV), avResp.Agent.ID, member.ID, money, orderNum, member.Cash)
-		if err != nil {
-			xlog.Errorf("error to deal ins record, err:%+v", err)
-			return err
+	err := srv.Tx(func(tx *gorm.DB) error {
+		return srv.applyTransfer(ctx, tx, "121", money, avResp, member, orderNum, transfer)
+	})
+	if err != nil {
+		if customErr, ok := err.(*utils.CustomError); ok {
+			return customErr
 		}
-		return nil
+		return utils.ErrWalletTransferLineError
+	}
+
+	return nil
+}
+
+func (srv *publicApiService) ProDealDecValue(ctx context.Context, money decimal.Decimal, avResp *view.AgentVerifyResp, member *db.Member, orderNum string, transfer *db.TransferRecord) error {
+	err := srv.Tx(func(tx *gorm.DB) error {
+		return srv.applyTransfer(ctx, tx, "122", money, avResp, member, orderNum, transfer)
 	})
 	if err != nil {
+		if customErr, ok := err.(*utils.CustomError); ok {
+			return customErr
+		}
 		return utils.ErrWalletTransferLineError
 	}
 
 	return nil
 }
 
-func (srv *publicApiService) ProDealDecValue(ctx context.Context, money decimal.Decimal, avResp *view.AgentVerifyResp, member *db.Member, orderNum string) error {
+// applyTransfer moves money between member and agent inside tx and writes the
+// cash change log, the in_out_m record (code 121 add / 122 dec) and the ledger entry
+func (srv *publicApiService) applyTransfer(ctx context.Context, tx *gorm.DB, code string, money decimal.Decimal, avResp *view.AgentVerifyResp, member *db.Member, orderNum string, transfer *db.TransferRecord) error {
 	upid := srv.GetUpLv5(ctx, member)
-	lv5Before := avResp.Agent.Cash
-	if member.Type == 1 {
-		lv5Before = avResp.Agent.Credit
+
+	// Re-read the balances under a row lock, the ones loaded before the transaction may be stale
+	current, err := srv.userDao.QueryByIDForUpdate(tx, member.ID)
+	if err != nil {
+		xlog.Errorf("error to lock member, err:%+v", err)
+		return err
+	}
+	agent, err := srv.agentDao.QueryByIDForUpdate(tx, avResp.Agent.ID)
+	if err != nil {
+		xlog.Errorf("error to lock agent, err:%+v", err)
+		return err
 	}
+	// Credit members draw on the agent credit, the others on its cash
+	lv5Before := agent.Cash
+	updateAgent := srv.agentDao.UpdateCash
 	pointtype := 0
 	if member.Type == 1 {
+		lv5Before = agent.Credit
+		updateAgent = srv.agentDao.UpdateCredit
 		pointtype = 1
 	}
-	if member.Cash.Add(money).IsNegative() {
-		return utils.ErrWalletTransferBalanceNotEnough
+	logAgeCashChange := &db.LogAgeCashChange{
+		Lacc02:    member.Mem006,
+		Lacc03:    member.ID,
+		Lacc06:    money,
+		Lacc07:    time.Now(),
+		Lacc08:    "",
+		Lacc09:    current.Cash,
+		Lacc10:    upid,
+		Lacc11:    lv5Before,
+		Pointtype: pointtype,
+	}
+	_, err = srv.logAgeCashChangeDao.Create(tx, logAgeCashChange)
+	if err != nil {
+		xlog.Errorf("error to create log age cash change, err:%+v", err)
+		return err
 	}
-	var err error
-	err = srv.Tx(func(tx *gorm.DB) error {
-		logAgeCashChange := &db.LogAgeCashChange{
-			Lacc02:    member.Mem006,
-			Lacc03:    member.ID,
-			Lacc06:    money,
-			Lacc07:    time.Now(),
-			Lacc08:    "",
-			Lacc09:    member.Cash,
-			Lacc10:    upid,
-			Lacc11:    lv5Before,
-			Pointtype: pointtype,
-		}
-		_, err = srv.logAgeCashChangeDao.Create(tx, logAgeCashChange)
-		if err != nil {
-			xlog.Errorf("error to create log age cash change, err:%+v", err)
-			return err
-		}
-		if err = srv.userDao.UpdatesMember(tx, member.ID, map[string]interface{}{
-			"cash": gorm.Expr("cash + ?", money),
-		}); err != nil {
-			xlog.Errorf("error to update member cash, err:%+v", err)
-			return err
-		}
-		if member.Type != 1 {
-			if err = srv.agentDao.UpdatesAgent(tx, avResp.Agent.ID, map[string]interface{}{
-				"cash": gorm.Expr("cash - ?", money),
-			}); err != nil {
-				xlog.Errorf("error to update agent cash, err:%+v", err)
-				return err
-			}
+	// Both updates refuse to go below zero, so concurrent transfers cannot overdraw either side
+	if err = srv.userDao.UpdateCash(tx, member.ID, money); err != nil {
+		if err == db.ErrInsufficientCash {
+			return utils.ErrWalletTransferBalanceNotEnough
 		}
-		_, err = srv.inOutMDao.DealInsRecord(tx, "122", 0, int64(avResp.Agent.ULV), avResp.Agent.ID, member.ID, money, orderNum, member.Cash)
-		if err != nil {
-			xlog.Errorf("error to deal ins record, err:%+v", err)
-			return err
+		xlog.Errorf("error to update member cash, err:%+v", err)
+		return err
+	}
+	if err = updateAgent(tx, avResp.Agent.ID, money.Neg()); err != nil {
+		if err == db.ErrInsufficientCash {
+			return utils.ErrWalletAgentOverLimit
 		}
-		return nil
-	})
+		xlog.Errorf("error to update agent cash, err:%+v", err)
+		return err
+	}
+	iomID, err := srv.inOutMDao.DealInsRecord(tx, code, 0, int64(avResp.Agent.ULV), avResp.Agent.ID, member.ID, money, orderNum, current.Cash)
 	if err != nil {
-		return utils.ErrWalletTransferLineError
+		xlog.Errorf("error to deal ins record, err:%+v", err)
+		return err
 	}
-
-	return nil
+	return srv.commitTransfer(tx, transfer, iomID, current.Cash, money)
 }
 
-func (srv *publicApiService) GetMemberTradeReport(ctx context.Context, req *view.GetMemberTradeReportReq) (*view.GetMemberTradeReportResp, error) {
+const (
+	// BatchChangeBalanceMaxItems caps the entries accepted by one BatchChangeBalance call
+	BatchChangeBalanceMaxItems = 200
+	// batchChangeBalanceTxSize bounds how many entries share one DB transaction
+	batchChangeBalanceTxSize = 20
+)
+
+// BatchChangeBalance applies many transfers of one agent in a single call. Every entry gets
+// its own result; entries are committed in chunks of batchChangeBalanceTxSize.
+func (srv *publicApiService) BatchChangeBalance(ctx context.Context, req *view.BatchChangeBalanceReq) (*view.BatchChangeBalanceResp, error) {
 	// Validate timestamp
 	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
 		xlog.Errorf("error to check timestamp, err:%+v", err)
@@ -2117,64 +2286,454 @@ func (srv *publicApiService) GetMemberTradeReport(ctx context.Context, req *view
 	}
 
 	// Verify agent
-	avgResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	avResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
 	if err != nil {
 		xlog.Errorf("error to verify agent, err:%+v", err)
 		return nil, err
 	}
 
-	if req.User != "" {
-		// Get member by account
-		member, err := srv.userDao.QueryByAccount(srv.DB(), req.User)
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return nil, utils.ErrParamInvalidAccountNotExist
+	if len(req.Items) == 0 {
+		return nil, utils.ErrWalletParamFormatError
+	}
+	if len(req.Items) > BatchChangeBalanceMaxItems {
+		return nil, utils.ErrWalletBatchTooLarge
+	}
+	if slices.Contains(req.Items, nil) {
+		return nil, utils.ErrWalletParamFormatError
+	}
+
+	accounts := make([]string, 0, len(req.Items))
+	for _, item := range req.Items {
+		if item.User != "" && !slices.Contains(accounts, item.User) {
+			accounts = append(accounts, item.User)
+		}
+	}
+	members, err := srv.userDao.QueryByAccounts(srv.DB(), accounts, avResp.Agent.ID)
+	if err != nil {
+		xlog.Errorf("error to query members by accounts, err:%+v", err)
+		return nil, err
+	}
+	memberMap := make(map[string]*db.Member, len(members))
+	for _, member := range members {
+		memberMap[member.User] = member
+	}
+
+	results := make([]*view.BatchChangeBalanceResult, len(req.Items))
+	for start := 0; start < len(req.Items); start += batchChangeBalanceTxSize {
+		end := min(start+batchChangeBalanceTxSize, len(req.Items))
+		srv.batchChangeBalanceChunk(ctx, req.VendorID, avResp, memberMap, req.Items[start:end], results[start:end])
+	}
+
+	return &view.BatchChangeBalanceResp{
+		Result: results,
+	}, nil
+}
+
+// batchChangeBalanceChunk runs one chunk of a batch in a single transaction, each entry
+// inside its own savepoint so a rejected entry does not undo the others
+func (srv *publicApiService) batchChangeBalanceChunk(ctx context.Context, vendorID string, avResp *view.AgentVerifyResp, members map[string]*db.Member, items []*view.BatchChangeBalanceItem, results []*view.BatchChangeBalanceResult) {
+	for i, item := range items {
+		results[i] = &view.BatchChangeBalanceResult{
+			User:  item.User,
+			Money: item.Money,
+			Order: item.Order,
+		}
+	}
+
+	// The launder alerts and locks are written once the chunk is done, the member rows are
+	// still locked by the chunk and a rollback must not take them back
+	var launders []*launderHits
+	alerted := map[string]bool{}
+	defer func() {
+		for _, hits := range launders {
+			if err := srv.recordLaunder(srv.DB(), hits); err != nil {
+				xlog.Errorf("error to record launder hits of member %d, err:%+v", hits.member.ID, err)
 			}
-			return nil, err
 		}
-		mIDs := []int64{member.ID}
-		var result []*db.InOutM
-		err = srv.Tx(func(tx *gorm.DB) error {
-			// Try to get data from Elasticsearch first
-			if srv.esClient != nil {
-				esResults, err := srv.esClient.GetInOutMsEs(ctx, mIDs, req.OrderID, req.Order, req.StartTime, req.EndTime)
-				if err != nil {
-					xlog.Errorf("error to get in_out_m from ES: %v", err)
-					// Fall back to database if ES fails
-				} else {
-					// Convert ES results to InOutM format
-					for _, esResult := range esResults {
-						xlog.Infof("esResult: %+v", esResult)
-						iom001, ok := esResult["iom001"].(float64)
-						if !ok {
-							xlog.Errorf("invalid type for iom001: %T", esResult["iom001"])
-							return utils.ErrEsError
-						}
-						inOutM := &db.InOutM{
-							Iom001: int64(iom001 * 1000000),
-							Iom002: func() time.Time {
-								t, _ := time.Parse(time.RFC3339, esResult["iom002"].(string))
-								return t
-							}(),
-							Iom003: int64(esResult["iom003"].(float64)),
-							Iom004: decimal.NewFromFloat(esResult["iom004"].(float64)),
-							Iom005: esResult["iom005"].(string),
-							Iom006: int64(esResult["iom006"].(float64)),
-							Iom007: int64(esResult["iom007"].(float64)),
-							Iom008: esResult["iom008"].(string),
-							Iom009: int64(esResult["iom009"].(float64)),
-							Iom010: decimal.NewFromFloat(esResult["iom010"].(float64)),
-						}
-						result = append(result, inOutM)
-					}
-					return nil
+	}()
+
+	err := srv.Tx(func(tx *gorm.DB) error {
+		for i, item := range items {
+			result := results[i]
+			money, err := parseTransferMoney(item.Money)
+			if err != nil {
+				setBatchResult(result, err)
+				continue
+			}
+			// Without an order a retried batch could not be told apart from a new one
+			if item.Order == "" {
+				setBatchResult(result, utils.ErrWalletSerialNumberEmpty)
+				continue
+			}
+			member, ok := members[item.User]
+			if !ok {
+				setBatchResult(result, utils.ErrParamInvalidAccountNotExist)
+				continue
+			}
+			if member.Mem020 == "Y" {
+				setBatchResult(result, utils.ErrInvalidTransactionError)
+				continue
+			}
+
+			hits, err := srv.evalLaunder(tx, avResp, member, money)
+			if err != nil {
+				xlog.Errorf("error to check launder, err:%+v", err)
+				setBatchResult(result, err)
+				if isTxAborted(err) {
+					return err
+				}
+				continue
+			}
+			// The alerts of the chunk are not written yet, an alert only rule fires once per member
+			alerts := hits.alerts[:0]
+			for _, alertMsg := range hits.alerts {
+				key := fmt.Sprintf("%d:%s", member.ID, alertMsg.RuleCode)
+				if alertMsg.Action == db.LaunderActionAlert && alerted[key] {
+					continue
 				}
+				alerted[key] = true
+				alerts = append(alerts, alertMsg)
 			}
-			// Fall back to database query
-			result, err = srv.inOutMDao.GetInOutMs(tx, mIDs, req.OrderID, req.Order, req.StartTime, req.EndTime)
+			hits.alerts = alerts
+			if len(hits.alerts) > 0 || hits.action != "" {
+				launders = append(launders, hits)
+			}
+			if err := hits.err(); err != nil {
+				xlog.Errorf("error to check launder, err:%+v", err)
+				if err == utils.ErrWalletTransferRiskLocked {
+					member.Mem020 = "Y"
+				}
+				setBatchResult(result, err)
+				continue
+			}
+
+			res, err := srv.CheckDoubleDeals(tx, member.ID, money, item.Order)
 			if err != nil {
-				return err
+				xlog.Errorf("error to check double deals, err:%+v", err)
+				setBatchResult(result, err)
+				if isTxAborted(err) {
+					return err
+				}
+				continue
 			}
+			if res == 1 {
+				setBatchResult(result, utils.ErrWalletTransferRepeatIn5Error)
+				continue
+			}
+			if res == 3 {
+				setBatchResult(result, utils.ErrWalletTransferExist)
+				continue
+			}
+
+			transfer, err := srv.claimTransfer(tx, vendorID, item.Order, avResp, member, money)
+			if err != nil {
+				xlog.Errorf("error to claim transfer, err:%+v", err)
+				setBatchResult(result, err)
+				if isTxAborted(err) {
+					return err
+				}
+				continue
+			}
+			// applyTransfer re-reads the member and the agent under a lock, an earlier entry may have moved them
+			code := "122"
+			if money.IsPositive() {
+				code = "121"
+			}
+			err = tx.Transaction(func(tx *gorm.DB) error {
+				return srv.applyTransfer(ctx, tx, code, money, avResp, member, item.Order, transfer)
+			})
+			if err != nil {
+				xlog.Errorf("error to apply batch transfer %s, err:%+v", item.Order, err)
+				setBatchResult(result, err)
+				// MySQL has rolled back the whole chunk, not just the savepoint
+				if isTxAborted(err) {
+					return err
+				}
+				srv.failTransfer(tx, transfer, err)
+				continue
+			}
+			result.Status = view.BatchTransferApplied
+			result.OrderID = transfer.Iom001
+			result.Cash = transfer.AfterCash
+		}
+		return nil
+	})
+	if err != nil {
+		// Nothing in the chunk was committed, the orders may be sent again
+		xlog.Errorf("error to commit batch change balance chunk, err:%+v", err)
+		for _, result := range results {
+			if result.Status == "" || result.Status == view.BatchTransferApplied {
+				setBatchResult(result, utils.ErrWalletTransferLineError)
+				result.OrderID = 0
+				result.Cash = decimal.Zero
+			}
+		}
+	}
+}
+
+// isTxAborted reports whether err made MySQL roll back the whole transaction, a deadlock
+// always does and a lock wait timeout is treated the same
+func isTxAborted(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return mysqlErr.Number == 1213 || mysqlErr.Number == 1205
+}
+
+func setBatchResult(result *view.BatchChangeBalanceResult, err error) {
+	customErr, ok := err.(*utils.CustomError)
+	if !ok {
+		customErr = utils.ErrWalletTransferLineError
+	}
+	result.Code = int(customErr.Code)
+	result.Message = customErr.Message
+	switch customErr.Code {
+	case utils.CodeWalletTransferBalanceNotEnough, utils.CodeWalletAgentOverLimit:
+		result.Status = view.BatchTransferInsufficient
+	case utils.CodeWalletTransferExist, utils.CodeWalletTransferPending, utils.CodeWalletTransferRepeatIn5Error:
+		result.Status = view.BatchTransferDuplicate
+	case utils.CodeWalletTransferLockError, utils.CodeInvalidTransactionError:
+		result.Status = view.BatchTransferLocked
+	default:
+		result.Status = view.BatchTransferFailed
+	}
+}
+
+// claimTransfer reserves (vendorID, order) in the transfer ledger as pending.
+// A failed order may be claimed again, as may one resolved as failed after staying pending too long;
+// a pending or committed one is rejected.
+func (srv *publicApiService) claimTransfer(tx *gorm.DB, vendorID, orderNum string, avResp *view.AgentVerifyResp, member *db.Member, money decimal.Decimal) (*db.TransferRecord, error) {
+	if orderNum == "" {
+		return nil, nil
+	}
+	now := time.Now()
+	record := &db.TransferRecord{
+		VendorID:   vendorID,
+		OrderNum:   orderNum,
+		Aid:        avResp.Agent.ID,
+		Mid:        member.ID,
+		User:       member.User,
+		Money:      money,
+		Status:     db.TransferStatusPending,
+		BeforeCash: member.Cash,
+		AfterCash:  member.Cash,
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	_, err := srv.transferRecordDao.Create(tx, record)
+	if err == nil {
+		return record, nil
+	}
+	xlog.Warnf("transfer record %s/%s not created, err:%+v", vendorID, orderNum, err)
+
+	// Most likely the unique key (vendor_id, order_num) already holds this order
+	existing, err := srv.transferRecordDao.QueryByVendorOrder(tx, vendorID, orderNum)
+	if err != nil {
+		xlog.Errorf("error to query transfer record, err:%+v", err)
+		return nil, utils.ErrWalletTransferLineError
+	}
+	if existing, err = srv.resolveTransfer(tx, existing); err != nil {
+		return nil, utils.ErrWalletTransferLineError
+	}
+	switch existing.Status {
+	case db.TransferStatusCommitted:
+		return nil, utils.ErrWalletTransferExist
+	case db.TransferStatusPending:
+		return nil, utils.ErrWalletTransferPending
+	}
+	rows, err := srv.transferRecordDao.UpdateStatus(tx, existing.ID, db.TransferStatusFailed, db.TransferStatusPending, map[string]interface{}{
+		"aid":         avResp.Agent.ID,
+		"mid":         member.ID,
+		"user":        member.User,
+		"money":       money,
+		"before_cash": member.Cash,
+		"after_cash":  member.Cash,
+		"message":     "",
+	})
+	if err != nil {
+		xlog.Errorf("error to reclaim transfer record, err:%+v", err)
+		return nil, utils.ErrWalletTransferLineError
+	}
+	if rows != 1 {
+		// Someone else reclaimed it first
+		return nil, utils.ErrWalletTransferPending
+	}
+	existing.Aid = avResp.Agent.ID
+	existing.Mid = member.ID
+	existing.User = member.User
+	existing.Money = money
+	existing.Status = db.TransferStatusPending
+	return existing, nil
+}
+
+// commitTransfer marks the ledger entry committed inside the cash transaction
+func (srv *publicApiService) commitTransfer(tx *gorm.DB, transfer *db.TransferRecord, iomID int64, beforeCash, money decimal.Decimal) error {
+	if transfer == nil {
+		return nil
+	}
+	rows, err := srv.transferRecordDao.UpdateStatus(tx, transfer.ID, db.TransferStatusPending, db.TransferStatusCommitted, map[string]interface{}{
+		"iom001":      iomID,
+		"before_cash": beforeCash,
+		"after_cash":  beforeCash.Add(money),
+	})
+	if err != nil {
+		xlog.Errorf("error to commit transfer record, err:%+v", err)
+		return err
+	}
+	if rows != 1 {
+		return fmt.Errorf("transfer record %d is no longer pending", transfer.ID)
+	}
+	transfer.Status = db.TransferStatusCommitted
+	transfer.Iom001 = iomID
+	transfer.BeforeCash = beforeCash
+	transfer.AfterCash = beforeCash.Add(money)
+	return nil
+}
+
+// failTransfer marks a pending ledger entry failed so the vendor may retry the order
+func (srv *publicApiService) failTransfer(tx *gorm.DB, transfer *db.TransferRecord, cause error) {
+	if transfer == nil {
+		return
+	}
+	message := cause.Error()
+	if customErr, ok := cause.(*utils.CustomError); ok {
+		message = customErr.Message
+	}
+	if _, err := srv.transferRecordDao.UpdateStatus(tx, transfer.ID, db.TransferStatusPending, db.TransferStatusFailed, map[string]interface{}{
+		"message": message,
+	}); err != nil {
+		xlog.Errorf("error to fail transfer record %d, err:%+v", transfer.ID, err)
+	}
+}
+
+func transferStatusText(status int) string {
+	switch status {
+	case db.TransferStatusCommitted:
+		return "committed"
+	case db.TransferStatusFailed:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// CheckTransfer reports the state of a ChangeBalance order so vendors can reconcile without retrying
+func (srv *publicApiService) CheckTransfer(ctx context.Context, req *view.CheckTransferReq) (*view.CheckTransferResp, error) {
+	// Validate timestamp
+	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
+		xlog.Errorf("error to check timestamp, err:%+v", err)
+		return nil, err
+	}
+
+	// Verify agent
+	avResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	if err != nil {
+		xlog.Errorf("error to verify agent, err:%+v", err)
+		return nil, err
+	}
+
+	if req.Order == "" {
+		return nil, utils.ErrWalletSerialNumberEmpty
+	}
+
+	record, err := srv.transferRecordDao.QueryByVendorOrder(srv.DB(), req.VendorID, req.Order)
+	if err == nil {
+		if record, err = srv.resolveTransfer(srv.DB(), record); err != nil {
+			return nil, err
+		}
+		return &view.CheckTransferResp{
+			Result: &view.TransferStatusItem{
+				Order:      record.OrderNum,
+				User:       record.User,
+				Money:      record.Money,
+				Status:     transferStatusText(record.Status),
+				OrderID:    record.Iom001,
+				BeforeCash: record.BeforeCash,
+				AfterCash:  record.AfterCash,
+				Message:    record.Message,
+				AddTime:    record.CreateTime.Unix(),
+				UpdateTime: record.UpdateTime.Unix(),
+			},
+		}, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		xlog.Errorf("error to query transfer record, err:%+v", err)
+		return nil, err
+	}
+
+	// Orders made before the ledger existed only live in in_out_m
+	inOutM, err := srv.inOutMDao.QueryByAgentAndOrder(srv.DB(), avResp.Agent.ID, req.Order)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrWalletSerialNumberNotExist
+		}
+		xlog.Errorf("error to query in_out_m by order, err:%+v", err)
+		return nil, err
+	}
+	accountInfo, err := srv.userDao.GetMemberAccountInfo(srv.DB(), inOutM.Iom003)
+	if err != nil {
+		xlog.Errorf("error to get member account info, err:%+v", err)
+		return nil, err
+	}
+	return &view.CheckTransferResp{
+		Result: &view.TransferStatusItem{
+			Order:      inOutM.Iom008,
+			User:       accountInfo.Account,
+			Money:      inOutM.Iom004,
+			Status:     transferStatusText(db.TransferStatusCommitted),
+			OrderID:    inOutM.Iom001,
+			BeforeCash: inOutM.Iom010.Sub(inOutM.Iom004),
+			AfterCash:  inOutM.Iom010,
+			AddTime:    inOutM.Iom002.Unix(),
+			UpdateTime: inOutM.Iom002.Unix(),
+		},
+	}, nil
+}
+
+func (srv *publicApiService) GetMemberTradeReport(ctx context.Context, req *view.GetMemberTradeReportReq) (*view.GetMemberTradeReportResp, error) {
+	// Validate timestamp
+	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
+		xlog.Errorf("error to check timestamp, err:%+v", err)
+		return nil, err
+	}
+
+	// Verify agent
+	avgResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	if err != nil {
+		xlog.Errorf("error to verify agent, err:%+v", err)
+		return nil, err
+	}
+
+	if req.User != "" {
+		// Get member by account
+		member, err := srv.userDao.QueryByAccount(srv.DB(), req.User)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, utils.ErrParamInvalidAccountNotExist
+			}
+			return nil, err
+		}
+		mIDs := []int64{member.ID}
+		var result []*db.InOutMReport
+		err = srv.Tx(func(tx *gorm.DB) error {
+			// Try to get data from Elasticsearch first
+			if srv.esClient != nil && srv.esReadable(ctx, avgResp.Agent.ID) {
+				esResults, err := srv.esClient.GetInOutMsEs(ctx, mIDs, req.OrderID, req.Order, req.StartTime, req.EndTime)
+				if err != nil {
+					xlog.Errorf("error to get in_out_m from ES: %v", err)
+					// Fall back to database if ES fails
+				} else {
+					result = esResults
+					return nil
+				}
+			}
+			// Fall back to database query
+			records, err := srv.inOutMDao.GetInOutMs(tx, mIDs, req.OrderID, req.Order, req.StartTime, req.EndTime)
+			if err != nil {
+				return err
+			}
+			result = inOutMReports(records)
 			return nil
 		})
 		if err != nil {
@@ -2205,52 +2764,29 @@ func (srv *publicApiService) GetMemberTradeReport(ctx context.Context, req *view
 		}
 
 		var mIDs []int64
-		result := []*db.InOutM{}
+		result := []*db.InOutMReport{}
 		err = srv.Tx(func(tx *gorm.DB) error {
 			mIDs, err = srv.bet02Dao.GetBet02List(tx, avgResp.Agent.ID, req.StartTime, req.EndTime)
 			if err != nil {
 				return err
 			}
 			// Try to get data from Elasticsearch first
-			if srv.esClient != nil {
+			if srv.esClient != nil && srv.esReadable(ctx, avgResp.Agent.ID) {
 				esResults, err := srv.esClient.GetInOutMsEs(ctx, mIDs, req.OrderID, req.Order, req.StartTime, req.EndTime)
 				if err != nil {
 					xlog.Errorf("error to get in_out_m from ES: %v", err)
 					// Fall back to database if ES fails
 				} else {
-					// Convert ES results to InOutM format
-					for _, esResult := range esResults {
-						xlog.Infof("esResult: %+v", esResult)
-						iom001, ok := esResult["iom001"].(float64)
-						if !ok {
-							xlog.Errorf("invalid type for iom001: %T", esResult["iom001"])
-							return utils.ErrRedisError
-						}
-						inOutM := &db.InOutM{
-							Iom001: int64(iom001 * 1000000),
-							Iom002: func() time.Time {
-								t, _ := time.Parse(time.RFC3339, esResult["iom002"].(string))
-								return t
-							}(),
-							Iom003: int64(esResult["iom003"].(float64)),
-							Iom004: decimal.NewFromFloat(esResult["iom004"].(float64)),
-							Iom005: esResult["iom005"].(string),
-							Iom006: int64(esResult["iom006"].(float64)),
-							Iom007: int64(esResult["iom007"].(float64)),
-							Iom008: esResult["iom008"].(string),
-							Iom009: int64(esResult["iom009"].(float64)),
-							Iom010: decimal.NewFromFloat(esResult["iom010"].(float64)),
-						}
-						result = append(result, inOutM)
-					}
+					result = esResults
 				}
 			}
 			if len(result) == 0 {
 				// Fall back to database query
-				result, err = srv.inOutMDao.GetInOutMs(tx, mIDs, req.OrderID, req.Order, req.StartTime, req.EndTime)
+				records, err := srv.inOutMDao.GetInOutMs(tx, mIDs, req.OrderID, req.Order, req.StartTime, req.EndTime)
 				if err != nil {
 					return err
 				}
+				result = inOutMReports(records)
 				return nil
 			}
 			return nil
@@ -2367,6 +2903,13 @@ func (srv *publicApiService) EnableOrDisableMem(ctx context.Context, req *view.E
 			return nil, err
 		}
 	}
+	if columnToUpdate == "mem016" && req.Status == "N" {
+		memberIDs := make([]int64, 0, len(members))
+		for _, member := range members {
+			memberIDs = append(memberIDs, member.ID)
+		}
+		srv.kickMembers(ctx, memberIDs, mq.KickReasonDisabled)
+	}
 
 	// Generate response message based on language
 	var memberText, result string
@@ -2397,20 +2940,152 @@ func (srv *publicApiService) GetDateTimeReport(ctx context.Context, req *view.Ge
 		return nil, err
 	}
 
+	memberID, err := srv.checkDateTimeReportFilter(avgResp, req)
+	if err != nil {
+		return nil, err
+	}
+	after, err := db.ParseReportCursor(req.PageToken)
+	if err != nil {
+		xlog.Errorf("error to parse page token, err:%+v", err)
+		return nil, utils.ErrParamInvalidPageToken
+	}
+
+	// igktwapi only pulls one minute per call
+	if req.VendorID == "igktwapi" {
+		req.EndTime = req.StartTime + 60
+	}
+
+	// // Get game info
+	// gameTypeList, err := srv.gameTypeDao.QueryByStatus(srv.DB(), 1)
+	// if err != nil {
+	// 	xlog.Errorf("error to get game type: %v", err)
+	// 	return nil, err
+	// }
+	// gameTypeMap := make(map[int64]string)
+	// for _, gameType := range gameTypeList {
+	// 	gameTypeMap[gameType.Code] = gameType.Cnname
+	// }
+
+	bet02List, source, err := srv.getDateTimeReportPage(ctx, avgResp.Agent.ID, memberID, req, after)
+	if err != nil {
+		return nil, err
+	}
+	next := nextReportCursor(bet02List, after, req.TimeType, source)
+
+	// Convert to response format
+	var reportItems []*view.DateTimeReportItem
+	for _, bet := range bet02List {
+		reportItems = append(reportItems, gameUtil.ReportFormat(bet, req.Syslang))
+	}
+
+	return &view.GetDateTimeReportResp{
+		Result:        reportItems,
+		NextPageToken: next.Token(),
+	}, nil
+}
+
+// getDateTimeReportPage reads the page of a date time report after the cursor and the source it came from
+func (srv *publicApiService) getDateTimeReportPage(ctx context.Context, agentID, memberID int64, req *view.GetDateTimeReportReq, after *db.ReportCursor) ([]*db.Bet02Report, string, error) {
+	return srv.getReportPage(ctx, agentID, after, func(limit int) ([]*db.Bet02Report, error) {
+		return srv.esClient.GetBet02ListForDateTimeReportEs(ctx, memberID, agentID, req.StartTime, req.EndTime, req.DataType, req.TimeType, req.GameNo1, req.GameNo2, after, limit)
+	}, func(tx *gorm.DB, limit int) ([]*db.Bet02Extra, error) {
+		return srv.bet02Dao.GetBet02ListForDateTimeReport(tx, memberID, agentID, req.StartTime, req.EndTime, req.DataType, req.TimeType, req.GameNo1, req.GameNo2, after, limit)
+	})
+}
+
+// getReportPage reads a page of db.ReportPageLimit bets of a bet02 report. The first page tries ES and falls back
+// to MySQL, every later page reads the source the cursor names so the scan never switches stores halfway.
+func (srv *publicApiService) getReportPage(ctx context.Context, agentID int64, after *db.ReportCursor,
+	fromES func(limit int) ([]*db.Bet02Report, error), fromDB func(tx *gorm.DB, limit int) ([]*db.Bet02Extra, error)) ([]*db.Bet02Report, string, error) {
+	limit := db.ReportPageLimit(after)
+	source := ""
+	if after != nil {
+		source = after.Source
+	}
+
+	switch {
+	case source == db.ReportSourceES:
+		if srv.esClient == nil {
+			return nil, "", utils.ErrParamInvalidPageToken
+		}
+		bet02List, err := fromES(limit)
+		if err != nil {
+			xlog.Errorf("error to get bet02 list from ES: %v", err)
+			return nil, "", err
+		}
+		return bet02List, db.ReportSourceES, nil
+	case source == "" && srv.esClient != nil && srv.esReadable(ctx, agentID):
+		bet02List, err := fromES(limit)
+		if err != nil {
+			xlog.Errorf("error to get bet02 list from ES: %v", err)
+		} else if len(bet02List) > 0 {
+			return bet02List, db.ReportSourceES, nil
+		}
+	}
+
+	// ES is not available, failed or has nothing, the scan goes on in the database
+	var bet02List []*db.Bet02Report
+	err := srv.Tx(func(tx *gorm.DB) error {
+		bets, err := fromDB(tx, limit)
+		if err != nil {
+			xlog.Errorf("error to get bet02 list: %v", err)
+			return err
+		}
+		bet02List = bet02Reports(bets)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return bet02List, db.ReportSourceDB, nil
+}
+
+// nextReportCursor is the cursor of the page after a report page read from source, nil once the report is done.
+// A full page may be followed by an empty one.
+func nextReportCursor(bet02List []*db.Bet02Report, after *db.ReportCursor, timeType int, source string) *db.ReportCursor {
+	if len(bet02List) < db.ReportPageLimit(after) {
+		return nil
+	}
+	next := bet02List[len(bet02List)-1].Cursor(timeType)
+	next.Source = source
+	return next
+}
+
+func bet02Reports(bets []*db.Bet02Extra) []*db.Bet02Report {
+	ret := make([]*db.Bet02Report, 0, len(bets))
+	for _, bet := range bets {
+		ret = append(ret, db.NewBet02Report(bet))
+	}
+	return ret
+}
+
+func inOutMReports(records []*db.InOutM) []*db.InOutMReport {
+	ret := make([]*db.InOutMReport, 0, len(records))
+	for _, record := range records {
+		ret = append(ret, db.NewInOutMReport(record))
+	}
+	return ret
+}
+
+// maxDateTimeReportRange is the longest period in seconds a date time report covers, the report is read page by page
+const maxDateTimeReportRange = 31 * 86400
+
+// checkDateTimeReportFilter validates the filters of a date time report and resolves its optional member
+func (srv *publicApiService) checkDateTimeReportFilter(avResp *view.AgentVerifyResp, req *view.GetDateTimeReportReq) (int64, error) {
 	// Get member by account if user is specified
 	var memberID int64 = 0
 	if req.User != "" {
 		member, err := srv.userDao.QueryByAccount(srv.DB(), req.User)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
-				return nil, utils.ErrParamInvalidAccountNotExist
+				return 0, utils.ErrParamInvalidAccountNotExist
 			}
-			return nil, err
+			return 0, err
 		}
 		// Verify member belongs to agent
-		if member.Mem011 != avgResp.Agent.ID {
+		if member.Mem011 != avResp.Agent.ID {
 			xlog.Errorf("error to verify member belongs to agent, err:%+v", utils.ErrParamInvalidAccountNotBelongToAgent)
-			return nil, utils.ErrParamInvalidAccountNotBelongToAgent
+			return 0, utils.ErrParamInvalidAccountNotBelongToAgent
 		}
 		memberID = member.ID
 	}
@@ -2418,278 +3093,66 @@ func (srv *publicApiService) GetDateTimeReport(ctx context.Context, req *view.Ge
 	// Validate time range
 	if req.GameNo1 == "" && req.GameNo2 == "" {
 		if req.StartTime == 0 || req.EndTime == 0 {
-			return nil, utils.ErrCommandSuccessButNoData
+			return 0, utils.ErrCommandSuccessButNoData
 		}
-		if req.EndTime-req.StartTime > 86400 {
-			return nil, utils.ErrFunctionOnlyQueryOneDayReport
+		if req.EndTime-req.StartTime > maxDateTimeReportRange {
+			return 0, utils.ErrFunctionOnlyQueryOneMonthReport
 		}
 	} else if req.GameNo1 == "" && req.GameNo2 != "" {
-		return nil, utils.ErrInvalidPeriodEmpty
+		return 0, utils.ErrInvalidPeriodEmpty
 	}
+	return memberID, nil
+}
 
-	// Get report key
-	GetDateTimeReportChkTime := "GetDateTimeReport_chkTime"
-	GetDateTimeReport10OR30 := "GetDateTimeReport_10OR30"
-	vid := req.VendorID
-
-	// Get last check time from Redis
-	chkt, err := srv.redisCli.HGet(ctx, GetDateTimeReportChkTime, vid).Result()
-	if err != nil && err != redis.Nil {
-		xlog.Errorf("error to get chkTime from Redis: %v", err)
-		return nil, utils.ErrRedisError
-	}
-	chk10or30, err := srv.redisCli.HGet(ctx, GetDateTimeReport10OR30, vid).Int()
-	if err != nil && err != redis.Nil {
-		xlog.Errorf("error to get chk10or30 from Redis: %v", err)
-		return nil, utils.ErrRedisError
-	}
-	// Get current time
-	chkTime := time.Now().Unix()
-	// Check if it's igktwapi or no result
-	if req.VendorID == "igktwapi" {
-		req.EndTime = req.StartTime + 60
-		if chkt == "" {
-			// First time access, set the time
-			err = srv.redisCli.HSet(ctx, GetDateTimeReportChkTime, vid, chkTime).Err()
-			if err != nil {
-				xlog.Errorf("error setting check time in Redis: %v", err)
-				return nil, utils.ErrRedisError
-			}
-			err = srv.redisCli.HSet(ctx, GetDateTimeReport10OR30, vid, 10).Err()
-			if err != nil {
-				xlog.Errorf("error setting check time in Redis: %v", err)
-				return nil, utils.ErrRedisError
-			}
-		} else {
-			lastChkTime, err := strconv.ParseInt(chkt, 10, 64)
-			if err != nil {
-				xlog.Errorf("error parsing last check time: %v", err)
-				return nil, utils.ErrRedisError
-			}
-			ct := chkTime - lastChkTime
-			if chk10or30 == 30 {
-				if ct < 30 {
-					return nil, utils.ErrInvalidTransactionTimeout
-				} else {
-					err = srv.redisCli.HSet(ctx, GetDateTimeReportChkTime, vid, chkTime).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-					err = srv.redisCli.HSet(ctx, GetDateTimeReport10OR30, vid, 10).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-				}
-			} else {
-				if ct < 10 {
-					return nil, utils.ErrInvalidTransactionTimeoutRepeat
-				} else {
-					err = srv.redisCli.HSet(ctx, GetDateTimeReportChkTime, vid, chkTime).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-					err = srv.redisCli.HSet(ctx, GetDateTimeReport10OR30, vid, 10).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-				}
-			}
-		}
-	} else {
-		if chkt == "" {
-			// First time access, set the time
-			err = srv.redisCli.HSet(ctx, GetDateTimeReportChkTime, vid, chkTime).Err()
-			if err != nil {
-				xlog.Errorf("error setting check time in Redis: %v", err)
-				return nil, utils.ErrRedisError
-			}
-			err = srv.redisCli.HSet(ctx, GetDateTimeReport10OR30, vid, 10).Err()
-			if err != nil {
-				xlog.Errorf("error setting check time in Redis: %v", err)
-				return nil, utils.ErrRedisError
-			}
-		} else {
-			// Check time difference
-			lastChkTime, err := strconv.ParseInt(chkt, 10, 64)
-			if err != nil {
-				xlog.Errorf("error parsing last check time: %v", err)
-				return nil, utils.ErrRedisError
-			}
-			ct := chkTime - lastChkTime
-			if chk10or30 == 10 {
-				if ct < 10 {
-					return nil, utils.ErrInvalidTransactionTimeoutRepeat
-				} else {
-					err = srv.redisCli.HSet(ctx, GetDateTimeReportChkTime, vid, chkTime).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-					err = srv.redisCli.HSet(ctx, GetDateTimeReport10OR30, vid, 10).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-				}
-			} else {
-				if ct < 30 {
-					return nil, utils.ErrInvalidTransactionTimeout
-				} else {
-					err = srv.redisCli.HSet(ctx, GetDateTimeReportChkTime, vid, chkTime).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-					err = srv.redisCli.HSet(ctx, GetDateTimeReport10OR30, vid, 10).Err()
-					if err != nil {
-						xlog.Errorf("error setting check time in Redis: %v", err)
-						return nil, utils.ErrRedisError
-					}
-				}
-			}
-		}
+// GetDateTimeCountReport returns the bet count and totals of the bets GetDateTimeReport lists for the same filters
+func (srv *publicApiService) GetDateTimeCountReport(ctx context.Context, req *view.GetDateTimeReportReq) (*view.GetDateTimeCountReportResp, error) {
+	if err := utils.CheckTimestamp(req.Timestamp); err != nil {
+		xlog.Errorf("error to check timestamp, err:%+v", err)
+		return nil, err
 	}
 
-	// // Get game info
-	// gameTypeList, err := srv.gameTypeDao.QueryByStatus(srv.DB(), 1)
-	// if err != nil {
-	// 	xlog.Errorf("error to get game type: %v", err)
-	// 	return nil, err
-	// }
-	// gameTypeMap := make(map[int64]string)
-	// for _, gameType := range gameTypeList {
-	// 	gameTypeMap[gameType.Code] = gameType.Cnname
-	// }
+	avResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	if err != nil {
+		xlog.Errorf("error to verify agent, err:%+v", err)
+		return nil, err
+	}
 
-	// Try to get data from Elasticsearch first
-	var bet02List []*db.Bet02Extra
-	if srv.esClient != nil {
-		// Use ES client to get data
-		results, err := srv.esClient.GetBet02ListForDateTimeReportEs(ctx, memberID, avgResp.Agent.ID, req.StartTime, req.EndTime, req.DataType, req.TimeType, req.GameNo1, req.GameNo2)
-		if err != nil {
-			xlog.Errorf("error to get bet02 list from ES: %v", err)
-		} else {
-			// Convert ES results to Bet02Extra format
-			for _, result := range results {
-				xlog.Infof("result from es : %v", result)
-				bet02 := &db.Bet02Extra{
-					Bet02: db.Bet02{
-						Bet01: int64(result["bet01"].(float64)),
-						Bet02: int(result["bet02"].(float64)),
-						//Bet03:      decimal.NewFromFloat(result["bet03"].(float64)),
-						Bet04: int(result["bet04"].(float64)),
-						Bet05: int(result["bet05"].(float64)),
-						Bet08: time.Unix(int64(result["bet08"].(float64))/1000, 0),
-						Bet09: result["bet09"].(string),
-						//Bet12:      decimal.NewFromFloat(result["bet12"].(float64)),
-						//Bet13:      decimal.NewFromFloat(result["bet13"].(float64)),
-						//Bet14:      decimal.NewFromFloat(result["bet14"].(float64)),
-						//Bet16:      decimal.NewFromFloat(result["bet16"].(float64)),
-						//Bet17: decimal.NewFromFloat(result["bet17"].(float64)),
-						Bet38: result["bet38"].(string),
-						Bet39: int(result["bet39"].(float64)),
-						//Bet41:      decimal.NewFromFloat(result["bet41"].(float64)),
-						IP:         result["ip"].(string),
-						Updatetime: time.Unix(int64(result["updatetime"].(float64))/1000, 0),
-					},
-					Result: result["result"].(string),
-					User:   result["username"].(string),
-					GName:  result["gameName"].(string),
-				}
-				bet02.Bet03, err = decimal.NewFromString(result["bet03"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet03 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet12, err = decimal.NewFromString(result["bet12"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet12 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet13, err = decimal.NewFromString(result["bet13"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet13 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet14, err = decimal.NewFromString(result["bet14"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet14 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet16, err = decimal.NewFromString(result["bet16"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet16 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet17, err = decimal.NewFromString(result["bet17"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet17 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet41, err = decimal.NewFromString(result["bet41"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet41 to decimal: %v", err)
-					return nil, err
-				}
-				bet02List = append(bet02List, bet02)
-			}
-		}
+	memberID, err := srv.checkDateTimeReportFilter(avResp, req)
+	if err != nil {
+		return nil, err
 	}
 
-	// If ES is not available or failed, fall back to database
-	if srv.esClient == nil || len(bet02List) == 0 {
-		err = srv.Tx(func(tx *gorm.DB) error {
-			bet02List, err = srv.bet02Dao.GetBet02ListForDateTimeReport(tx, memberID, avgResp.Agent.ID, req.StartTime, req.EndTime, req.DataType, req.TimeType, req.GameNo1, req.GameNo2)
-			if err != nil {
-				xlog.Errorf("error to get bet02 list: %v", err)
-				return err
-			}
-			return nil
-		})
-		if err != nil {
-			return nil, err
-		}
+	counts, err := srv.bet02Dao.GetBet02CountForDateTimeReport(srv.DB(), memberID, avResp.Agent.ID, req.StartTime, req.EndTime, req.DataType, req.TimeType, req.GameNo1, req.GameNo2)
+	if err != nil {
+		xlog.Errorf("error to get bet02 count: %v", err)
+		return nil, err
 	}
 
-	// Convert to response format
-	var reportItems []*view.DateTimeReportItem
-	for _, bet := range bet02List {
-		bet02 := bet.Bet02
-		item := &view.DateTimeReportItem{
-			User:       bet.User,
-			BetID:      strconv.FormatInt(bet.Bet01, 10),
-			BetTime:    bet02.Bet08.Format("2006-01-02 15:04:05"),
-			BeforeCash: bet02.Bet12,
-			Bet:        bet02.Bet13,
-			ValidBet:   bet02.Bet41,
-			Water:      bet02.Bet16,
-			Result:     bet02.Bet17,
-			BetCode:    bet02.Bet09,
-			BetResult:  gameUtil.GetBetContent(strconv.Itoa(bet02.Bet02), bet02.Bet09, req.Syslang),
-			WaterBet:   bet02.Bet41,
-			WinLoss:    bet02.Bet14.Sub(bet02.Bet13),
-			IP:         bet02.IP,
-			GID:        strconv.Itoa(bet02.Bet02),
-			Event:      bet02.Bet03.String(),
-			EventChild: strconv.Itoa(bet02.Bet04),
-			Round:      bet02.Bet03.String(),
-			Subround:   strconv.Itoa(bet02.Bet04),
-			TableID:    strconv.Itoa(bet02.Bet39),
-			Commission: decimal.NewFromInt(int64(bet02.Commission)),
-			Settime:    bet02.Updatetime.Format("2006-01-02 15:04:05"),
-			Reset:      bet02.Bet38,
-			GameResult: gameUtil.GetGameResultString(strconv.Itoa(bet02.Bet02), bet.Result, req.Syslang),
-			GName:      gameUtil.GetLangText(bet.GName, req.Syslang),
-		}
-		reportItems = append(reportItems, item)
+	report := &view.DateTimeCountReport{
+		Total: &view.DateTimeCountReportItem{},
+		Games: []*view.DateTimeCountReportItem{},
+	}
+	for _, count := range counts {
+		report.Games = append(report.Games, &view.DateTimeCountReportItem{
+			GID:      strconv.Itoa(count.GID),
+			GName:    gameUtil.GetLangText(count.GName, req.Syslang),
+			Count:    count.Count,
+			Bet:      count.Bet,
+			ValidBet: count.ValidBet,
+			Water:    count.Water,
+			Result:   count.Result,
+			WinLoss:  count.WinLoss,
+		})
+		report.Total.Count += count.Count
+		report.Total.Bet = report.Total.Bet.Add(count.Bet)
+		report.Total.ValidBet = report.Total.ValidBet.Add(count.ValidBet)
+		report.Total.Water = report.Total.Water.Add(count.Water)
+		report.Total.Result = report.Total.Result.Add(count.Result)
+		report.Total.WinLoss = report.Total.WinLoss.Add(count.WinLoss)
 	}
 
-	return &view.GetDateTimeReportResp{
-		Result: reportItems,
+	return &view.GetDateTimeCountReportResp{
+		Result: report,
 	}, nil
 }
 
@@ -2730,172 +3193,48 @@ func (srv *publicApiService) GetTipReport(ctx context.Context, req *view.GetTipR
 		xlog.Errorf("error to start or end time is empty, err:%+v", utils.ErrCommandSuccessButNoData)
 		return nil, utils.ErrCommandSuccessButNoData
 	}
-
-	// Get report key
-	GetTipReportChkTime := "GetTipReport"
-	vid := req.VendorID
-
-	// Get last check time from Redis
-	chkt, err := srv.redisCli.HGet(ctx, GetTipReportChkTime, vid).Result()
-	if err != nil && err != redis.Nil {
-		xlog.Errorf("error to get chkTime from Redis: %v", err)
-		return nil, utils.ErrRedisError
-	}
-
-	// Check for duplicate requests
-	chkTime := time.Now().Unix()
-	if req.VendorID == "igktwapi" {
-		if chkt != "" {
-			lastChkTime, err := strconv.ParseInt(chkt, 10, 64)
-			if err != nil {
-				xlog.Errorf("error to parse chkTime: %v", err)
-				return nil, utils.ErrRedisError
-			}
-			timeDiff := chkTime - lastChkTime
-			if timeDiff < 10 {
-				return nil, utils.ErrInvalidTransactionTimeoutRepeat
-			}
-		} else {
-			err = srv.redisCli.HSet(ctx, GetTipReportChkTime, vid, chkTime).Err()
-			if err != nil {
-				xlog.Errorf("error to set chkTime in Redis: %v", err)
-				return nil, utils.ErrRedisError
-			}
-		}
-	} else {
-		if chkt != "" {
-			lastChkTime, err := strconv.ParseInt(chkt, 10, 64)
-			if err != nil {
-				xlog.Errorf("error to parse chkTime: %v", err)
-				return nil, utils.ErrRedisError
-			}
-			timeDiff := chkTime - lastChkTime
-			if timeDiff < 30 {
-				return nil, utils.ErrInvalidTransactionTimeout
-			}
-		} else {
-			err = srv.redisCli.HSet(ctx, GetTipReportChkTime, vid, chkTime).Err()
-			if err != nil {
-				xlog.Errorf("error to set chkTime in Redis: %v", err)
-				return nil, utils.ErrRedisError
-			}
-		}
-	}
-
-	// Try to get data from Elasticsearch first
-	var bet02List []*db.Bet02Extra
-	if srv.esClient != nil {
-		// Use ES client to get data
-		results, err := srv.esClient.GetBet02ListForDateTimeReportEs(ctx, memberID, avgResp.Agent.ID, req.StartTime, req.EndTime, 1, 0, "", "")
-		if err != nil {
-			xlog.Errorf("error to get bet02 list from ES: %v", err)
-		} else {
-			// Convert ES results to Bet02Extra format
-			for _, result := range results {
-				bet02 := &db.Bet02Extra{
-					Bet02: db.Bet02{
-						Bet01: int64(result["bet01"].(float64)),
-						Bet02: int(result["bet02"].(float64)),
-						// Bet03: decimal.NewFromFloat(result["bet03"].(float64)),
-						Bet04: int(result["bet04"].(float64)),
-						Bet05: int(result["bet05"].(float64)),
-						Bet08: time.Unix(int64(result["bet08"].(float64)), 0),
-						Bet09: result["bet09"].(string),
-						// Bet12:      decimal.NewFromFloat(result["bet12"].(float64)),
-						// Bet13:      decimal.NewFromFloat(result["bet13"].(float64)),
-						// Bet14:      decimal.NewFromFloat(result["bet14"].(float64)),
-						// Bet16:      decimal.NewFromFloat(result["bet16"].(float64)),
-						// Bet17:      decimal.NewFromFloat(result["bet17"].(float64)),
-						// Bet38:      result["bet38"].(string),
-						Bet39: int(result["bet39"].(float64)),
-						// Bet41:      decimal.NewFromFloat(result["bet41"].(float64)),
-						IP:         result["ip"].(string),
-						Updatetime: time.Unix(int64(result["updatetime"].(float64))/1000, 0),
-					},
-					User:  result["username"].(string),
-					GName: result["gameName"].(string),
-				}
-				bet02.Bet03, err = decimal.NewFromString(result["bet03"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet03 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet12, err = decimal.NewFromString(result["bet12"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet12 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet13, err = decimal.NewFromString(result["bet13"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet13 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet14, err = decimal.NewFromString(result["bet14"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet14 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet16, err = decimal.NewFromString(result["bet16"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet16 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet17, err = decimal.NewFromString(result["bet17"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet17 to decimal: %v", err)
-					return nil, err
-				}
-				bet02.Bet41, err = decimal.NewFromString(result["bet41"].(string))
-				if err != nil {
-					xlog.Errorf("error to convert bet41 to decimal: %v", err)
-					return nil, err
-				}
-				bet02List = append(bet02List, bet02)
-			}
-		}
+	after, err := db.ParseReportCursor(req.PageToken)
+	if err != nil {
+		xlog.Errorf("error to parse page token, err:%+v", err)
+		return nil, utils.ErrParamInvalidPageToken
 	}
 
-	// If ES is not available or failed, fall back to database
-	if srv.esClient == nil || len(bet02List) == 0 {
-		err = srv.Tx(func(tx *gorm.DB) error {
-			bet02List, err = srv.bet02Dao.GetBet02ListForTipReport(tx, memberID, avgResp.Agent.ID, req.StartTime, req.EndTime, 1, 0, "", "")
-			if err != nil {
-				xlog.Errorf("error to get bet02 list: %v", err)
-				return err
-			}
-			return nil
-		})
-		if err != nil {
-			return nil, err
-		}
+	bet02List, source, err := srv.getReportPage(ctx, avgResp.Agent.ID, after, func(limit int) ([]*db.Bet02Report, error) {
+		return srv.esClient.GetBet02ListForDateTimeReportEs(ctx, memberID, avgResp.Agent.ID, req.StartTime, req.EndTime, 1, 0, "", "", after, limit)
+	}, func(tx *gorm.DB, limit int) ([]*db.Bet02Extra, error) {
+		return srv.bet02Dao.GetBet02ListForTipReport(tx, memberID, avgResp.Agent.ID, req.StartTime, req.EndTime, 1, 0, "", "", after, limit)
+	})
+	if err != nil {
+		return nil, err
 	}
+	next := nextReportCursor(bet02List, after, 0, source)
 
 	// Convert to response format
 	var reportItems []*view.TipReportItem
 	for _, bet := range bet02List {
-		bet02 := bet.Bet02
 		item := &view.TipReportItem{
 			BetID:      strconv.FormatInt(bet.Bet01, 10),
-			ID:         int64(bet02.Bet05),
-			BetTime:    bet02.Bet08.Format("2006-01-02 15:04:05"),
-			Tip:        bet02.Bet13,
-			BetResult:  bet02.Bet09,
-			WinLoss:    bet02.Bet14.Sub(bet02.Bet13),
-			IP:         bet02.IP,
-			GID:        strconv.Itoa(bet02.Bet02),
-			Event:      bet02.Bet03.String(),
-			Round:      bet02.Bet03.String(),
-			Subround:   strconv.Itoa(bet02.Bet04),
-			EventChild: strconv.Itoa(bet02.Bet04),
-			TableID:    strconv.Itoa(bet02.Bet39),
-			Username:   bet.User,
-			GName:      gameUtil.GetLangText(bet.GName, req.Syslang),
+			ID:         int64(bet.Bet05),
+			BetTime:    bet.Bet08.Format("2006-01-02 15:04:05"),
+			Tip:        bet.Bet13,
+			BetResult:  bet.Bet09,
+			WinLoss:    bet.Bet14.Sub(bet.Bet13),
+			IP:         bet.IP,
+			GID:        strconv.Itoa(bet.Bet02),
+			Event:      bet.Bet03.String(),
+			Round:      bet.Bet03.String(),
+			Subround:   strconv.Itoa(bet.Bet04),
+			EventChild: strconv.Itoa(bet.Bet04),
+			TableID:    strconv.Itoa(bet.Bet39),
+			Username:   bet.Username,
+			GName:      gameUtil.GetLangText(bet.GameName, req.Syslang),
 		}
 		reportItems = append(reportItems, item)
 	}
 
 	return &view.GetTipReportResp{
-		Result: reportItems,
+		Result:        reportItems,
+		NextPageToken: next.Token(),
 	}, nil
 }
 
@@ -2907,7 +3246,7 @@ func (srv *publicApiService) GetUnsettleReport(ctx context.Context, req *view.Ge
 	}
 
 	// Verify agent
-	_, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
+	avgResp, err := srv.AgentVerify(ctx, &view.AgentVerifyReq{VendorID: req.VendorID, Signature: req.Signature})
 	if err != nil {
 		xlog.Errorf("error to verify agent, err:%+v", err)
 		return nil, err
@@ -2959,7 +3298,7 @@ func (srv *publicApiService) GetUnsettleReport(ctx context.Context, req *view.Ge
 
 	// 优先从ES查询
 	var bet01List []*db.Bet01Summary
-	if srv.esClient != nil {
+	if srv.esClient != nil && srv.esReadable(ctx, avgResp.Agent.ID) {
 		bet01List, err = srv.esClient.GetBet01ListForUnsettledReportEs(ctx, timeObj)
 		if err != nil {
 			xlog.Errorf("error to get bet01 list from ES: %v", err)
@@ -3052,90 +3391,33 @@ func (srv *publicApiService) GetReportDetail(ctx context.Context, req *view.GetR
 	}
 
 	// Get bet data
-	var bet02 *db.Bet02Extra
+	var bet02 *db.Bet02Report
 	var member *db.Member
 	var gameType *db.GameType
 
 	xlog.Debugf("debug to get bet02")
 	// Try to get data from Elasticsearch first
-	var esResult map[string]interface{}
-	if srv.esClient != nil {
-		esResult, err = srv.esClient.GetBet02ListForReportDetailEs(ctx, req.BetID)
+	if srv.esClient != nil && srv.esReadable(ctx, avgResp.Agent.ID) {
+		bet02, err = srv.esClient.GetBet02ListForReportDetailEs(ctx, req.BetID)
 		if err != nil {
 			xlog.Errorf("error to get bet02 from ES: %v", err)
-		} else if esResult != nil {
-			// Convert ES result to Bet02Extra format
-			xlog.Debugf("debug to get bet02 from ES: %v", esResult)
-			bet02 = &db.Bet02Extra{
-				Bet02: db.Bet02{
-					Bet01: int64(esResult["bet01"].(float64)),
-					Bet02: int(esResult["bet02"].(float64)),
-					//Bet03:      decimal.NewFromString(esResult["bet03"].(string)),
-					Bet04: int(esResult["bet04"].(float64)),
-					Bet05: int(esResult["bet05"].(float64)),
-					Bet08: time.Unix(int64(esResult["bet08"].(float64))/1000, 0),
-					Bet09: esResult["bet09"].(string),
-					// Bet12:      decimal.NewFromFloat(esResult["bet12"].(float64)),
-					// Bet13:      decimal.NewFromFloat(esResult["bet13"].(float64)),
-					// Bet14:      decimal.NewFromFloat(esResult["bet14"].(float64)),
-					// Bet16:      decimal.NewFromFloat(esResult["bet16"].(float64)),
-					// Bet17:      decimal.NewFromFloat(esResult["bet17"].(float64)),
-					Bet38: esResult["bet38"].(string),
-					Bet39: int(esResult["bet39"].(float64)),
-					//Bet41:      decimal.NewFromFloat(esResult["bet41"].(float64)),
-					IP:         esResult["ip"].(string),
-					Updatetime: time.Unix(int64(esResult["updatetime"].(float64))/1000, 0),
-				},
-				Result: esResult["result"].(string),
-			}
-			bet02.Bet03, err = decimal.NewFromString(esResult["bet03"].(string))
-			if err != nil {
-				xlog.Errorf("error to convert bet03 to decimal: %v", err)
-				return nil, err
-			}
-			bet02.Bet12, err = decimal.NewFromString(esResult["bet12"].(string))
-			if err != nil {
-				xlog.Errorf("error to convert bet12 to decimal: %v", err)
-				return nil, err
-			}
-			bet02.Bet13, err = decimal.NewFromString(esResult["bet13"].(string))
-			if err != nil {
-				xlog.Errorf("error to convert bet13 to decimal: %v", err)
-				return nil, err
-			}
-			bet02.Bet14, err = decimal.NewFromString(esResult["bet14"].(string))
-			if err != nil {
-				xlog.Errorf("error to convert bet14 to decimal: %v", err)
-				return nil, err
-			}
-			bet02.Bet16, err = decimal.NewFromString(esResult["bet16"].(string))
-			if err != nil {
-				xlog.Errorf("error to convert bet16 to decimal: %v", err)
-				return nil, err
-			}
-			bet02.Bet17, err = decimal.NewFromString(esResult["bet17"].(string))
-			if err != nil {
-				xlog.Errorf("error to convert bet17 to decimal: %v", err)
-				return nil, err
-			}
-			bet02.Bet41, err = decimal.NewFromString(esResult["bet41"].(string))
-			if err != nil {
-				xlog.Errorf("error to convert bet41 to decimal: %v", err)
-				return nil, err
-			}
+			bet02 = nil
+		} else if bet02 != nil {
+			xlog.Debugf("debug to get bet02 from ES: %+v", bet02)
 		}
 	}
 
 	// If ES is not available or failed, fall back to database
-	if srv.esClient == nil || bet02 == nil {
+	if bet02 == nil {
 		err = srv.Tx(func(tx *gorm.DB) error {
-			bet02, err = srv.bet02Dao.GetBet02ListForReportDetail(tx, req.BetID)
+			bet, err := srv.bet02Dao.GetBet02ListForReportDetail(tx, req.BetID)
 			if err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return utils.ErrWalletBetNumberNotExist
 				}
 				return err
 			}
+			bet02 = db.NewBet02Report(bet)
 			return nil
 		})
 		if err != nil {
@@ -3149,7 +3431,7 @@ func (srv *publicApiService) GetReportDetail(ctx context.Context, req *view.GetR
 		if err != nil {
 			return err
 		}
-		gameType, err = srv.gameTypeDao.QueryByID(tx, int64(bet02.Bet02.Bet02))
+		gameType, err = srv.gameTypeDao.QueryByID(tx, int64(bet02.Bet02))
 		if err != nil {
 			return err
 		}
@@ -3175,11 +3457,11 @@ func (srv *publicApiService) GetReportDetail(ctx context.Context, req *view.GetR
 			ValidBet:   bet02.Bet41,
 			Water:      bet02.Bet16,
 			Result:     bet02.Bet17,
-			BetResult:  gameUtil.GetBetContent(strconv.Itoa(bet02.Bet02.Bet02), bet02.Bet09, req.Syslang),
+			BetResult:  gameUtil.GetBetContent(strconv.Itoa(bet02.Bet02), bet02.Bet09, req.Syslang),
 			WaterBet:   bet02.Bet41,
 			WinLoss:    bet02.Bet14.Sub(bet02.Bet13),
 			IP:         bet02.IP,
-			GID:        strconv.Itoa(bet02.Bet02.Bet02),
+			GID:        strconv.Itoa(bet02.Bet02),
 			Event:      bet02.Bet03.String(),
 			EventChild: strconv.Itoa(bet02.Bet04),
 			Round:      bet02.Bet03.String(),
@@ -3188,7 +3470,7 @@ func (srv *publicApiService) GetReportDetail(ctx context.Context, req *view.GetR
 			Commission: decimal.NewFromInt(int64(bet02.Commission)),
 			Settime:    bet02.Updatetime.Format("2006-01-02 15:04:05"),
 			Reset:      bet02.Bet38,
-			GameResult: gameUtil.GetGameResultString(strconv.Itoa(bet02.Bet02.Bet02), bet02.Result, req.Syslang),
+			GameResult: gameUtil.GetGameResultString(strconv.Itoa(bet02.Bet02), bet02.Result, req.Syslang),
 			GName:      gameUtil.GetLangText(gameType.Cnname, req.Syslang),
 		}
 		return &view.GetReportDetailResp{
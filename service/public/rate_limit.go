@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go-zrbc/config"
+	"go-zrbc/pkg/ratelimit"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"sync"
+	"time"
+)
+
+// defaultRateLimits apply to every vendor unless config or the rate_limit_rule table overrides them
+var defaultRateLimits = []ratelimit.Rule{
+	{Command: "ChangeBalance", Scope: ratelimit.ScopeMember, Algorithm: ratelimit.SlidingWindow, Limit: 1, Window: 5},
+	{Command: "BatchChangeBalance", Scope: ratelimit.ScopeVendor, Algorithm: ratelimit.SlidingWindow, Limit: 1, Window: 5},
+	{Command: "GetDateTimeReport", Scope: ratelimit.ScopeVendor, Algorithm: ratelimit.SlidingWindow, Limit: 1, Window: 10},
+	{Command: "GetDateTimeCountReport", Scope: ratelimit.ScopeVendor, Algorithm: ratelimit.SlidingWindow, Limit: 1, Window: 10},
+	{Command: "GetTipReport", Scope: ratelimit.ScopeVendor, Algorithm: ratelimit.SlidingWindow, Limit: 1, Window: 30},
+}
+
+// rateLimitRuleTTL is how long the table rules are cached before they are read again
+const rateLimitRuleTTL = 30 * time.Second
+
+type rateLimitRuleCache struct {
+	mu       sync.Mutex
+	rules    []ratelimit.Rule
+	loadedAt time.Time
+}
+
+// rateLimitRules returns the defaults, then the config rules, then the table rules, so later ones win in ratelimit.Resolve
+func (srv *publicApiService) rateLimitRules() []ratelimit.Rule {
+	cache := &srv.rateLimitRuleCache
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.rules != nil && time.Since(cache.loadedAt) < rateLimitRuleTTL {
+		return cache.rules
+	}
+
+	dbRules, err := srv.rateLimitRuleDao.QueryEnabled(srv.DB())
+	if err != nil {
+		xlog.Errorf("error to query rate limit rules, err:%+v", err)
+		if cache.rules != nil {
+			// Keep enforcing the last good rules until the table can be read again
+			return cache.rules
+		}
+	}
+
+	rules := append([]ratelimit.Rule{}, defaultRateLimits...)
+	for _, rule := range config.Global.RateLimits {
+		rules = append(rules, ratelimit.Rule{
+			VendorID:  rule.VendorID,
+			Command:   rule.Command,
+			Scope:     ratelimit.Scope(rule.Scope),
+			Algorithm: ratelimit.Algorithm(rule.Algorithm),
+			Limit:     rule.Limit,
+			Window:    rule.Window,
+		})
+	}
+	for _, rule := range dbRules {
+		rules = append(rules, ratelimit.Rule{
+			VendorID:  rule.VendorID,
+			Command:   rule.Command,
+			Scope:     ratelimit.Scope(rule.Scope),
+			Algorithm: ratelimit.Algorithm(rule.Algorithm),
+			Limit:     rule.Quota,
+			Window:    rule.WindowSec,
+		})
+	}
+	cache.rules = rules
+	cache.loadedAt = time.Now()
+	return rules
+}
+
+// CheckRateLimit takes one call of command by vendorID (and user, for member scoped rules) from every rule that applies
+func (srv *publicApiService) CheckRateLimit(ctx context.Context, vendorID, command string, class ratelimit.Class, user string) error {
+	for _, rule := range ratelimit.Resolve(srv.rateLimitRules(), vendorID, command) {
+		if rule.Limit <= 0 || rule.Window <= 0 {
+			continue
+		}
+		key := fmt.Sprintf("RateLimit_%s_%s_%s", rule.Algorithm, vendorID, command)
+		if rule.Scope == ratelimit.ScopeMember {
+			if user == "" {
+				continue
+			}
+			key += "_" + user
+		}
+		ok, err := srv.limiter.Allow(ctx, key, rule.Algorithm, rule.Limit, time.Duration(rule.Window)*time.Second)
+		if err != nil {
+			xlog.Errorf("error to check rate limit, key:%s, err:%+v", key, err)
+			return utils.ErrRedisError
+		}
+		if !ok {
+			return rateLimitError(class, rule.Window)
+		}
+	}
+	return nil
+}
+
+// rateLimitError answers a throttled call with the code vendors already handle for its kind of command
+func rateLimitError(class ratelimit.Class, window int) error {
+	switch class {
+	case ratelimit.ClassTransfer:
+		if window <= 2 {
+			return utils.ErrWalletTransferRepeatIn2Error
+		}
+		return utils.ErrWalletTransferRepeatIn5Error
+	case ratelimit.ClassReport:
+		if window <= 10 {
+			return utils.ErrInvalidTransactionTimeoutRepeat
+		}
+		return utils.ErrInvalidTransactionTimeout
+	default:
+		return utils.ErrInvalidRequestTooFrequent
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/es"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/service"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPublicApiService_GetReportPageSource(t *testing.T) {
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "report.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	srv := &publicApiService{
+		esClient: &es.Client{},
+		redisCli: redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()}),
+		Session:  service.NewSession(tx),
+	}
+	ctx := context.Background()
+
+	// Each source hands back a full page of whatever limit it is asked for
+	var esLimit, dbLimit int
+	esBets := func(limit int) ([]*db.Bet02Report, error) {
+		esLimit = limit
+		bets := make([]*db.Bet02Report, limit)
+		for i := range bets {
+			bets[i] = &db.Bet02Report{Bet01: int64(i + 1), Bet08: time.Unix(1704103200, 0)}
+		}
+		return bets, nil
+	}
+	dbBets := func(tx *gorm.DB, limit int) ([]*db.Bet02Extra, error) {
+		dbLimit = limit
+		bets := make([]*db.Bet02Extra, limit)
+		for i := range bets {
+			bets[i] = &db.Bet02Extra{Bet02: db.Bet02{Bet01: int64(i + 1), Bet08: time.Unix(1704103200, 0)}}
+		}
+		return bets, nil
+	}
+
+	// The first page keeps the cap vendors without a page token always had
+	bets, source, err := srv.getReportPage(ctx, 9, nil, esBets, dbBets)
+	if err != nil || source != db.ReportSourceES || esLimit != db.ReportFirstPageSize {
+		t.Fatalf("first page source:%s, es limit:%d, err:(%+v)", source, esLimit, err)
+	}
+	next := nextReportCursor(bets, nil, 0, source)
+	if next == nil || next.Source != db.ReportSourceES || next.Bet01 != db.ReportFirstPageSize {
+		t.Fatalf("cursor after a full first page:(%+v)", next)
+	}
+
+	// A drift found halfway does not move a scan that started on ES
+	es.SaveDrift(ctx, srv.redisCli, 9, "2024-01-01", []byte(`{"drift":true}`))
+	esLimit = 0
+	bets, source, err = srv.getReportPage(ctx, 9, next, esBets, dbBets)
+	if err != nil || source != db.ReportSourceES || esLimit != db.ReportPageSize || dbLimit != 0 {
+		t.Fatalf("es page source:%s, es limit:%d, db limit:%d, err:(%+v)", source, esLimit, dbLimit, err)
+	}
+	if next := nextReportCursor(bets[:10], next, 0, source); next != nil {
+		t.Fatalf("cursor after a short page:(%+v)", next)
+	}
+
+	// A new scan of the drifted agent starts on MySQL and stays there
+	esLimit = 0
+	_, source, err = srv.getReportPage(ctx, 9, nil, esBets, dbBets)
+	if err != nil || source != db.ReportSourceDB || esLimit != 0 || dbLimit != db.ReportFirstPageSize {
+		t.Fatalf("drifted first page source:%s, es limit:%d, err:(%+v)", source, esLimit, err)
+	}
+	es.ClearDrift(ctx, srv.redisCli, 9, "2024-01-01")
+	_, source, err = srv.getReportPage(ctx, 9, &db.ReportCursor{Time: 1704103200000, Bet01: 1, Source: db.ReportSourceDB}, esBets, dbBets)
+	if err != nil || source != db.ReportSourceDB || esLimit != 0 || dbLimit != db.ReportPageSize {
+		t.Fatalf("db page source:%s, es limit:%d, err:(%+v)", source, esLimit, err)
+	}
+
+	srv.esClient = nil
+	if _, _, err := srv.getReportPage(ctx, 9, next, esBets, dbBets); err != utils.ErrParamInvalidPageToken {
+		t.Fatalf("es token without es err:(%+v)", err)
+	}
+}
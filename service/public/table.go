@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// tableLimitAreas is how many bet areas a bet_limit_default row sets (set01~set14)
+const tableLimitAreas = 14
+
+// TableLimits holds the min and max stake of every bet area, index 0 is area 01
+type TableLimits [tableLimitAreas][2]int
+
+// parseBetLimitSets reads set01~set14, each holding "min,max"
+func parseBetLimitSets(bl *db.BetLimitDefault) TableLimits {
+	var ret TableLimits
+	sets := []string{bl.Set01, bl.Set02, bl.Set03, bl.Set04, bl.Set05, bl.Set06, bl.Set07,
+		bl.Set08, bl.Set09, bl.Set10, bl.Set11, bl.Set12, bl.Set13, bl.Set14}
+	for i, set := range sets {
+		fields := strings.FieldsFunc(set, func(r rune) bool { return !unicode.IsDigit(r) })
+		if len(fields) < 2 {
+			continue
+		}
+		ret[i][0], _ = strconv.Atoi(fields[0])
+		ret[i][1], _ = strconv.Atoi(fields[1])
+	}
+	return ret
+}
+
+// memberTableLimits picks the limit the member selected for gameID among the ones opened to it
+// in userDtl (see GetOneUserDtl), falling back to the first opened one
+func (srv *publicApiService) memberTableLimits(userDtl map[string]map[string]string, gameID int, selected map[string]int) (*TableLimits, error) {
+	gameKey := strconv.Itoa(gameID)
+	var opened []string
+	for _, id := range strings.Split(userDtl[gameKey]["nbetlimit"], ",") {
+		if id = strings.TrimSpace(id); id != "" && id != "0" {
+			opened = append(opened, id)
+		}
+	}
+	if len(opened) == 0 {
+		return nil, utils.ErrInvalidLimitTypeNotOpen
+	}
+	pick := opened[0]
+	if id, ok := selected[gameKey]; ok {
+		for _, openedID := range opened {
+			if openedID == strconv.Itoa(id) {
+				pick = openedID
+				break
+			}
+		}
+	}
+
+	limitID, _ := strconv.ParseInt(pick, 10, 64)
+	betLimit, err := srv.betLimitDefaultDao.QueryByID(srv.DB(), limitID)
+	if err != nil {
+		xlog.Errorf("error to query bet limit, id:%d, err:%+v", limitID, err)
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrInvalidLimitType
+		}
+		return nil, err
+	}
+	limits := parseBetLimitSets(betLimit)
+	return &limits, nil
+}
+
+// gameTypeOdds lists the payout settings ps003~ps011 of a game type in column order
+func gameTypeOdds(gt *db.GameType) []decimal.Decimal {
+	return []decimal.Decimal{
+		gt.Ps003,
+		decimal.NewFromInt(int64(gt.Ps004)),
+		decimal.NewFromInt(int64(gt.Ps005)),
+		decimal.NewFromInt(int64(gt.Ps006)),
+		decimal.NewFromInt(int64(gt.Ps007)),
+		decimal.NewFromInt(int64(gt.Ps008)),
+		decimal.NewFromInt(int64(gt.Ps009)),
+		decimal.NewFromInt(int64(gt.Ps010)),
+		decimal.NewFromInt(int64(gt.Ps011)),
+	}
+}
+
+// GetTableEntry builds the snapshot a member gets when entering a table: limits, balance and odds
+func (srv *publicApiService) GetTableEntry(ctx context.Context, req *view.WsTableEntryReq) (*view.WsTableEntryData, error) {
+	member, err := srv.userDao.QueryByID(srv.DB(), req.MemberID)
+	if err != nil {
+		xlog.Errorf("error to query member, mid:%d, err:%+v", req.MemberID, err)
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrParamInvalidAccountNotExist
+		}
+		return nil, err
+	}
+	// the group is the table, it must be dealing the game the client asked for
+	game, err := srv.gameInfoDao.QueryLatestByTable(srv.DB(), req.GroupID)
+	if err != nil {
+		xlog.Errorf("error to query table round, group:%d, err:%+v", req.GroupID, err)
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrTableNotExist
+		}
+		return nil, err
+	}
+	if game.Gi001 != req.GameID {
+		return nil, utils.ErrTableGameMismatch
+	}
+	userDtl, err := srv.GetOneUserDtl(member.ID, 7)
+	if err != nil {
+		xlog.Errorf("error to get member dtl, mid:%d, err:%+v", member.ID, err)
+		return nil, err
+	}
+	limits, err := srv.memberTableLimits(userDtl, req.GameID, req.DtBetLimitSelectID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &view.WsTableEntryData{
+		BOk:       true,
+		GameID:    req.GameID,
+		GroupID:   req.GroupID,
+		MemberID:  member.ID,
+		Account:   member.User,
+		UserName:  member.UserName,
+		HeadID:    member.Head,
+		Balance:   member.Cash.InexactFloat64(),
+		SeatIDArr: []int{},
+		DtCard:    map[string]interface{}{},
+	}
+	mins := []*int{&ret.MinBet01, &ret.MinBet02, &ret.MinBet03, &ret.MinBet04, &ret.MinBet05, &ret.MinBet06, &ret.MinBet07,
+		&ret.MinBet08, &ret.MinBet09, &ret.MinBet10, &ret.MinBet11, &ret.MinBet12, &ret.MinBet13, &ret.MinBet14}
+	maxs := []*int{&ret.MaxBet01, &ret.MaxBet02, &ret.MaxBet03, &ret.MaxBet04, &ret.MaxBet05, &ret.MaxBet06, &ret.MaxBet07,
+		&ret.MaxBet08, &ret.MaxBet09, &ret.MaxBet10, &ret.MaxBet11, &ret.MaxBet12, &ret.MaxBet13, &ret.MaxBet14}
+	for i := range limits {
+		*mins[i], *maxs[i] = limits[i][0], limits[i][1]
+	}
+
+	gameType, err := srv.gameTypeDao.QueryByID(srv.DB(), int64(req.GameID))
+	if err != nil && err != gorm.ErrRecordNotFound {
+		xlog.Errorf("error to query game type, gid:%d, err:%+v", req.GameID, err)
+		return nil, err
+	}
+	ret.DtOdds = map[string][]decimal.Decimal{}
+	if gameType != nil {
+		ret.DtOdds[strconv.Itoa(req.GameID)] = gameTypeOdds(gameType)
+	}
+	return ret, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/service"
+	"go-zrbc/view"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTableTestService serves member tom (id 5, cash 100) with bet limit 1 (area 1: 10~1000) on
+// game 1, dealt on table 101 at round 2000/3 and last on table 102 as game 2
+func newTableTestService(t *testing.T) (*publicApiService, *gorm.DB) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "table.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT, mem011 INTEGER, mem016 TEXT NOT NULL DEFAULT 'Y', mem017 TEXT NOT NULL DEFAULT 'Y', cash DECIMAL(15,4) NOT NULL DEFAULT 0)",
+		"CREATE TABLE member_dtl (mem001 INTEGER, mem002 INTEGER, mem003 DECIMAL(15,4) NOT NULL DEFAULT 0, mem015 TEXT NOT NULL DEFAULT '', mem016 DECIMAL(15,4) NOT NULL DEFAULT 0)",
+		"CREATE TABLE bet_limit_default (id INTEGER PRIMARY KEY, gtype INTEGER, set01 TEXT, set02 TEXT, set03 TEXT, set04 TEXT, set05 TEXT, set06 TEXT, set07 TEXT, set08 TEXT, set09 TEXT, set10 TEXT, set11 TEXT, set12 TEXT, set13 TEXT, set14 TEXT, status INTEGER, sort INTEGER)",
+		"CREATE TABLE game_type (code INTEGER PRIMARY KEY)",
+		"CREATE TABLE game_info (gi001 INTEGER, gi002 INTEGER, gi003 INTEGER, gi004 DATETIME, gi011 INTEGER, gi013 INTEGER NOT NULL DEFAULT 0, is_lock TEXT NOT NULL DEFAULT 'N')",
+		"CREATE TABLE bet01 (bet01 INTEGER PRIMARY KEY, bet02 INTEGER, bet03 DECIMAL(15,0), bet04 INTEGER, bet05 INTEGER, bet06 DATETIME, bet07 DATETIME, bet08 DATETIME, bet09 TEXT, bet10 INTEGER, bet11 DECIMAL(15,4), " +
+			"bet12 DECIMAL(15,4), bet12a DECIMAL(15,4), bet13 DECIMAL(15,4), bet14 DECIMAL(15,4), bet15 INTEGER, bet16 INTEGER, bet17 INTEGER, bet18 INTEGER, bet19 INTEGER, " +
+			"bet20 DECIMAL(15,4), bet21 DECIMAL(15,4), bet22 DECIMAL(15,4), bet23 DECIMAL(15,4), bet24 DECIMAL(15,4), bet25 DECIMAL(15,4), bet26 DECIMAL(15,4), bet27 DECIMAL(15,4), bet28 DECIMAL(15,4), bet29 DECIMAL(15,4), " +
+			"bet30 TEXT, bet31 INTEGER, bet32 INTEGER, betwalletid TEXT, gametype INTEGER, commission INTEGER, category INTEGER, eid INTEGER, serid INTEGER, ip TEXT, partnerBetId TEXT, gameId TEXT, updatetime DATETIME)",
+		"CREATE TABLE bet_serial (id INTEGER PRIMARY KEY, mid INTEGER, serial INTEGER, result TEXT, create_time DATETIME, UNIQUE (mid, serial))",
+		"INSERT INTO member (mem001, mem002, mem011, cash) VALUES (5, 'tom', 9, 100)",
+		"INSERT INTO member_dtl (mem001, mem002, mem015) VALUES (5, 1, '1')",
+		"INSERT INTO bet_limit_default (id, gtype, set01) VALUES (1, 1, '10,1000')",
+		"INSERT INTO game_info VALUES (1, 1999, 9, '2024-01-01 10:00:00', 101, 1, 'N')",
+		"INSERT INTO game_info VALUES (1, 2000, 3, '2024-01-01 10:01:00', 101, 0, 'N')",
+		"INSERT INTO game_info VALUES (1, 2000, 1, '2024-01-01 10:00:00', 102, 1, 'N')",
+		"INSERT INTO game_info VALUES (2, 500, 1, '2024-01-01 10:02:00', 102, 0, 'N')",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	srv := &publicApiService{
+		userDao:            db.NewMemberDao(),
+		memDtlDao:          db.NewMemberDtlDao(),
+		betLimitDefaultDao: db.NewBetLimitDefaultDao(),
+		gameTypeDao:        db.NewGameTypeDao(),
+		gameInfoDao:        db.NewGameInfoDao(),
+		bet01Dao:           db.NewBet01Dao(),
+		betSerialDao:       db.NewBetSerialDao(),
+		Session:            service.NewSession(tx),
+	}
+	return srv, tx
+}
+
+func TestPublicApiService_GetTableEntry(t *testing.T) {
+	srv, _ := newTableTestService(t)
+	ctx := context.Background()
+
+	entry, err := srv.GetTableEntry(ctx, &view.WsTableEntryReq{MemberID: 5, GameID: 1, GroupID: 101})
+	if err != nil || entry.MinBet01 != 10 || entry.MaxBet01 != 1000 || entry.Balance != 100 {
+		t.Fatalf("entry:(%+v), err:(%+v)", entry, err)
+	}
+	// table 102 moved on to game 2
+	if _, err := srv.GetTableEntry(ctx, &view.WsTableEntryReq{MemberID: 5, GameID: 1, GroupID: 102}); err != utils.ErrTableGameMismatch {
+		t.Fatalf("join another game err:(%+v), want ErrTableGameMismatch", err)
+	}
+	if _, err := srv.GetTableEntry(ctx, &view.WsTableEntryReq{MemberID: 5, GameID: 1, GroupID: 103}); err != utils.ErrTableNotExist {
+		t.Fatalf("join unknown table err:(%+v), want ErrTableNotExist", err)
+	}
+}
+
+func TestParseBetLimitSets(t *testing.T) {
+	got := parseBetLimitSets(&db.BetLimitDefault{
+		Set01: "10,1000",
+		Set02: "20 , 2000",
+		Set14: "5~500",
+		Set07: "bad",
+	})
+	if got[0] != [2]int{10, 1000} || got[1] != [2]int{20, 2000} || got[13] != [2]int{5, 500} {
+		t.Fatalf("limits:(%+v)", got)
+	}
+	if got[6] != [2]int{} || got[2] != [2]int{} {
+		t.Fatalf("unset areas:(%+v), want zero", got)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/pkg/xlog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// transferPendingTimeout is how long a ledger entry may stay pending before it is resolved,
+	// the cash transaction of a live ChangeBalance ends long before it
+	transferPendingTimeout = time.Minute
+	transferReapInterval   = 30 * time.Second
+	transferReapBatch      = 100
+)
+
+// resolveTransfer settles a ledger entry left pending by a crashed ChangeBalance. The cash
+// transaction commits the entry together with its in_out_m record, so an entry pending past
+// transferPendingTimeout is committed when that record exists and failed otherwise. A transfer
+// still running loses the race on the conditional status update and rolls back.
+func (srv *publicApiService) resolveTransfer(tx *gorm.DB, record *db.TransferRecord) (*db.TransferRecord, error) {
+	if record.Status != db.TransferStatusPending || time.Since(record.UpdateTime) < transferPendingTimeout {
+		return record, nil
+	}
+
+	to := db.TransferStatusFailed
+	data := map[string]interface{}{"message": "transfer timed out"}
+	inOutM, err := srv.inOutMDao.QueryByAgentAndOrder(tx, record.Aid, record.OrderNum)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		xlog.Errorf("error to query in_out_m by order, err:%+v", err)
+		return nil, err
+	}
+	if inOutM != nil {
+		to = db.TransferStatusCommitted
+		data = map[string]interface{}{
+			"iom001":      inOutM.Iom001,
+			"before_cash": inOutM.Iom010.Sub(inOutM.Iom004),
+			"after_cash":  inOutM.Iom010,
+		}
+	}
+	rows, err := srv.transferRecordDao.UpdateStatus(tx, record.ID, db.TransferStatusPending, to, data)
+	if err != nil {
+		xlog.Errorf("error to resolve transfer record %d, err:%+v", record.ID, err)
+		return nil, err
+	}
+	if rows == 1 {
+		xlog.Warnf("transfer record %s/%s pending since %s resolved as %s", record.VendorID, record.OrderNum, record.UpdateTime, transferStatusText(to))
+	}
+
+	// Reload either way, whoever won the update decided the status
+	return srv.transferRecordDao.QueryByID(tx, record.ID)
+}
+
+// RunTransferReaper resolves the ledger entries left pending until ctx is done, so vendors
+// polling CheckTransfer get an answer without retrying the order
+func (srv *publicApiService) RunTransferReaper(ctx context.Context) {
+	ticker := time.NewTicker(transferReapInterval)
+	defer ticker.Stop()
+	for {
+		srv.reapTransfers()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srv *publicApiService) reapTransfers() {
+	records, err := srv.transferRecordDao.QueryPendingBefore(srv.DB(), time.Now().Add(-transferPendingTimeout), transferReapBatch)
+	if err != nil {
+		xlog.Errorf("error to query pending transfer records, err:%+v", err)
+		return
+	}
+	for _, record := range records {
+		if _, err := srv.resolveTransfer(srv.DB(), record); err != nil {
+			xlog.Errorf("error to resolve transfer record %d, err:%+v", record.ID, err)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go-zrbc/db"
+	"go-zrbc/pkg/ratelimit"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/service"
+	"go-zrbc/view"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/go-redis/redis/v8"
+	"github.com/go-sql-driver/mysql"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTransferTestService serves agent 9 (vendor demo, cash 1000, credit 30) with member tom (id 5, cash 100)
+// and credit member ann (id 6) from sqlite, ctx carries the agent the gateway verified
+func newTransferTestService(t *testing.T) (*publicApiService, *gorm.DB, context.Context) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "transfer.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT, mem006 INTEGER NOT NULL DEFAULT 0, mem011 INTEGER, mem020 TEXT NOT NULL DEFAULT 'N', type INTEGER NOT NULL DEFAULT 0, cash DECIMAL(15,4) NOT NULL DEFAULT 0)",
+		"CREATE TABLE agent (age001 INTEGER PRIMARY KEY, cash DECIMAL(15,4) NOT NULL DEFAULT 0, credit DECIMAL(15,4) NOT NULL DEFAULT 0)",
+		"CREATE TABLE log_age_cash_change (lacc01 INTEGER PRIMARY KEY, lacc02 INTEGER, lacc03 INTEGER, op_utp TEXT, lacc04 INTEGER, lacc05 INTEGER, lacc06 DECIMAL(15,4), lacc07 DATETIME, lacc08 TEXT, lacc09 DECIMAL(15,4), lacc10 INTEGER, lacc11 DECIMAL(15,4), pointtype INTEGER)",
+		"CREATE TABLE in_out_m (iom001 INTEGER PRIMARY KEY, iom002 DATETIME, iom003 INTEGER, iom004 DECIMAL(15,4), iom005 TEXT, iom006 INTEGER, iom007 INTEGER, iom008 TEXT, iom009 INTEGER, iom010 DECIMAL(15,4))",
+		"CREATE TABLE transfer_record (id INTEGER PRIMARY KEY, vendor_id TEXT, order_num TEXT, aid INTEGER, mid INTEGER, user TEXT, money DECIMAL(15,4), status INTEGER NOT NULL DEFAULT 0, iom001 INTEGER NOT NULL DEFAULT 0, before_cash DECIMAL(15,4), after_cash DECIMAL(15,4), message TEXT NOT NULL DEFAULT '', create_time DATETIME, update_time DATETIME, UNIQUE (vendor_id, order_num))",
+		"CREATE TABLE launder_rule (id INTEGER PRIMARY KEY, aid INTEGER, code TEXT, type TEXT, threshold DECIMAL(15,4), window_sec INTEGER, action TEXT, message TEXT, status INTEGER)",
+		"CREATE TABLE alertMessage (id INTEGER PRIMARY KEY, mid INTEGER, message TEXT, errorTime DATETIME, unierrorTime INTEGER, operator TEXT, status INTEGER, rule_code TEXT, action TEXT, push_status INTEGER NOT NULL DEFAULT 0, push_retries INTEGER NOT NULL DEFAULT 0, next_push_time INTEGER NOT NULL DEFAULT 0, push_error TEXT NOT NULL DEFAULT '', pushed_hooks TEXT NOT NULL DEFAULT '', ack_time INTEGER NOT NULL DEFAULT 0, resolve_time INTEGER NOT NULL DEFAULT 0, remark TEXT NOT NULL DEFAULT '')",
+		"CREATE TABLE member_lock_audit (id INTEGER PRIMARY KEY, mid INTEGER, operator TEXT, decision TEXT, reason TEXT, alert_ids TEXT, create_time DATETIME)",
+		"CREATE TABLE rate_limit_rule (id INTEGER PRIMARY KEY, vendor_id TEXT, command TEXT, scope TEXT, algorithm TEXT, quota INTEGER, window_sec INTEGER, status INTEGER)",
+		// Lift the default of one transfer per member every 5 seconds, the tests move tom several times a second
+		"INSERT INTO rate_limit_rule VALUES (1, 'demo', 'ChangeBalance', 'member', 'window', 100, 5, 1)",
+		"INSERT INTO member (mem001, mem002, mem011, cash) VALUES (5, 'tom', 9, 100)",
+		"INSERT INTO member (mem001, mem002, mem011, type, cash) VALUES (6, 'ann', 9, 1, 0)",
+		"INSERT INTO agent VALUES (9, 1000, 30)",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	redisCli := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	srv := &publicApiService{
+		userDao:             db.NewMemberDao(),
+		agentDao:            db.NewAgentDao(),
+		inOutMDao:           db.NewInOutMDao(),
+		logAgeCashChangeDao: db.NewLogAgeCashChangeDao(),
+		transferRecordDao:   db.NewTransferRecordDao(),
+		launderRuleDao:      db.NewLaunderRuleDao(),
+		alertMessageDao:     db.NewAlertMessageDao(),
+		rateLimitRuleDao:    db.NewRateLimitRuleDao(),
+		memberLockAuditDao:  db.NewMemberLockAuditDao(),
+		redisCli:            redisCli,
+		limiter:             ratelimit.NewLimiter(redisCli),
+		Session:             service.NewSession(tx),
+	}
+	agent := &view.AgentVerifyResp{
+		Agent:            &view.Agent{ID: 9, VendorID: "demo", Cash: decimal.NewFromInt(1000), Credit: decimal.NewFromInt(30)},
+		AgentsLoginPass:  &view.AgentsLoginPass{},
+		RequestSignature: "sig",
+	}
+	return srv, tx, context.WithValue(context.Background(), AgentVerifyCtxKey, agent)
+}
+
+func changeBalance(srv *publicApiService, ctx context.Context, money, order string) error {
+	_, err := srv.ChangeBalance(ctx, &view.ChangeBalanceReq{VendorID: "demo", Signature: "sig", User: "tom", Money: money, Order: order, Timestamp: time.Now().Unix()})
+	return err
+}
+
+func checkTransfer(srv *publicApiService, ctx context.Context, order string) (*view.TransferStatusItem, error) {
+	resp, err := srv.CheckTransfer(ctx, &view.CheckTransferReq{VendorID: "demo", Signature: "sig", Order: order, Timestamp: time.Now().Unix()})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+func TestPublicApiService_ChangeBalanceLedger(t *testing.T) {
+	srv, tx, ctx := newTransferTestService(t)
+
+	// pending -> committed
+	if err := changeBalance(srv, ctx, "50", "order-1"); err != nil {
+		t.Fatalf("deposit err:(%+v)", err)
+	}
+	item, err := checkTransfer(srv, ctx, "order-1")
+	if err != nil || item.Status != "committed" || item.OrderID == 0 || !item.BeforeCash.Equal(decimal.NewFromInt(100)) || !item.AfterCash.Equal(decimal.NewFromInt(150)) {
+		t.Fatalf("committed transfer:(%+v), err:(%+v)", item, err)
+	}
+	var agentCash decimal.Decimal
+	tx.Raw("SELECT cash FROM agent WHERE age001 = 9").Scan(&agentCash)
+	if !agentCash.Equal(decimal.NewFromInt(950)) {
+		t.Fatalf("agent cash:(%s), want 950", agentCash)
+	}
+	if err := changeBalance(srv, ctx, "50", "order-1"); err != utils.ErrWalletTransferExist {
+		t.Fatalf("retried order err:(%+v), want ErrWalletTransferExist", err)
+	}
+
+	// pending -> failed, the cash stays untouched
+	if err := changeBalance(srv, ctx, "-500", "order-2"); err != utils.ErrWalletTransferBalanceNotEnough {
+		t.Fatalf("overdraw err:(%+v), want ErrWalletTransferBalanceNotEnough", err)
+	}
+	item, err = checkTransfer(srv, ctx, "order-2")
+	if err != nil || item.Status != "failed" || item.OrderID != 0 || item.Message == "" {
+		t.Fatalf("failed transfer:(%+v), err:(%+v)", item, err)
+	}
+
+	// failed -> pending -> committed when the vendor retries the order
+	if err := changeBalance(srv, ctx, "-30", "order-2"); err != nil {
+		t.Fatalf("retried failed order err:(%+v)", err)
+	}
+	item, err = checkTransfer(srv, ctx, "order-2")
+	if err != nil || item.Status != "committed" || !item.Money.Equal(decimal.NewFromInt(-30)) || !item.AfterCash.Equal(decimal.NewFromInt(120)) {
+		t.Fatalf("reclaimed transfer:(%+v), err:(%+v)", item, err)
+	}
+
+	if _, err := checkTransfer(srv, ctx, "order-3"); err != utils.ErrWalletSerialNumberNotExist {
+		t.Fatalf("unknown order err:(%+v), want ErrWalletSerialNumberNotExist", err)
+	}
+	if _, err := checkTransfer(srv, ctx, ""); err != utils.ErrWalletSerialNumberEmpty {
+		t.Fatalf("empty order err:(%+v), want ErrWalletSerialNumberEmpty", err)
+	}
+}
+
+func TestPublicApiService_ChangeBalanceWithoutOrder(t *testing.T) {
+	srv, _, ctx := newTransferTestService(t)
+
+	resp, err := srv.ChangeBalance(ctx, &view.ChangeBalanceReq{VendorID: "demo", Signature: "sig", User: "tom", Money: "20", Timestamp: time.Now().Unix()})
+	if err != nil || len(resp.Order) != 32 {
+		t.Fatalf("deposit without order:(%+v), err:(%+v)", resp, err)
+	}
+	// The made up order is in the ledger like any other
+	item, err := checkTransfer(srv, ctx, resp.Order)
+	if err != nil || item.Status != "committed" || !item.AfterCash.Equal(decimal.NewFromInt(120)) {
+		t.Fatalf("transfer without order:(%+v), err:(%+v)", item, err)
+	}
+	// The 5 seconds check still guards a resend of the same amount
+	if err := changeBalance(srv, ctx, "20", ""); err != utils.ErrWalletTransferRepeatIn5Error {
+		t.Fatalf("resend without order err:(%+v), want ErrWalletTransferRepeatIn5Error", err)
+	}
+}
+
+func TestPublicApiService_ChangeBalanceAfterUnlock(t *testing.T) {
+	srv, tx, ctx := newTransferTestService(t)
+	tx.Exec("INSERT INTO launder_rule VALUES (1, 9, 'count_1m', 'count', 1, 600, 'lock', '{user} {value}', 1)")
+
+	for i, money := range []string{"10", "20"} {
+		if err := changeBalance(srv, ctx, money, fmt.Sprintf("order-%d", i)); err != nil {
+			t.Fatalf("transfer %d err:(%+v)", i, err)
+		}
+	}
+	if err := changeBalance(srv, ctx, "30", "order-2"); err != utils.ErrWalletTransferRiskLocked {
+		t.Fatalf("third transfer err:(%+v), want ErrWalletTransferRiskLocked", err)
+	}
+
+	// What the operator reviewed no longer counts, the window starts at the unlock
+	tx.Exec("UPDATE in_out_m SET iom002 = ?", time.Now().Add(-30*time.Second))
+	tx.Exec("UPDATE member SET mem020 = 'N' WHERE mem001 = 5")
+	srv.memberLockAuditDao.Create(tx, &db.MemberLockAudit{Mid: 5, Operator: "amy", Decision: db.LockDecisionUnlock, Reason: "vendor confirmed", CreateTime: time.Now()})
+	for i, money := range []string{"40", "50"} {
+		if err := changeBalance(srv, ctx, money, fmt.Sprintf("order-%d", i+3)); err != nil {
+			t.Fatalf("transfer %d after unlock err:(%+v)", i, err)
+		}
+	}
+	if err := changeBalance(srv, ctx, "60", "order-5"); err != utils.ErrWalletTransferRiskLocked {
+		t.Fatalf("third transfer after unlock err:(%+v), want ErrWalletTransferRiskLocked", err)
+	}
+}
+
+func TestPublicApiService_ChangeBalanceReplayBeforeThrottle(t *testing.T) {
+	srv, tx, ctx := newTransferTestService(t)
+	tx.Exec("UPDATE rate_limit_rule SET quota = 1 WHERE id = 1")
+
+	if err := changeBalance(srv, ctx, "50", "order-1"); err != nil {
+		t.Fatalf("deposit err:(%+v)", err)
+	}
+	// A retried order is answered from the ledger, it neither waits for nor takes the throttle
+	for i := 0; i < 3; i++ {
+		if err := changeBalance(srv, ctx, "50", "order-1"); err != utils.ErrWalletTransferExist {
+			t.Fatalf("retry %d err:(%+v), want ErrWalletTransferExist", i, err)
+		}
+	}
+	if err := changeBalance(srv, ctx, "60", "order-2"); err != utils.ErrWalletTransferRepeatIn5Error {
+		t.Fatalf("new order inside the window err:(%+v), want ErrWalletTransferRepeatIn5Error", err)
+	}
+	if _, err := srv.transferRecordDao.QueryByVendorOrder(tx, "demo", "order-2"); err != gorm.ErrRecordNotFound {
+		t.Fatalf("throttled order reached the ledger, err:(%+v)", err)
+	}
+}
+
+func TestPublicApiService_ResolveTransfer(t *testing.T) {
+	srv, tx, ctx := newTransferTestService(t)
+	now := time.Now()
+	stale := now.Add(-2 * transferPendingTimeout)
+	for _, record := range []*db.TransferRecord{
+		{VendorID: "demo", OrderNum: "running", Aid: 9, Mid: 5, User: "tom", Money: decimal.NewFromInt(10), CreateTime: now, UpdateTime: now},
+		{VendorID: "demo", OrderNum: "crashed", Aid: 9, Mid: 5, User: "tom", Money: decimal.NewFromInt(10), CreateTime: stale, UpdateTime: stale},
+		{VendorID: "demo", OrderNum: "landed", Aid: 9, Mid: 5, User: "tom", Money: decimal.NewFromInt(10), CreateTime: stale, UpdateTime: stale},
+		{VendorID: "demo", OrderNum: "reaped", Aid: 9, Mid: 5, User: "tom", Money: decimal.NewFromInt(10), CreateTime: stale, UpdateTime: stale},
+	} {
+		if _, err := srv.transferRecordDao.Create(tx, record); err != nil {
+			t.Fatalf("create record err:(%+v)", err)
+		}
+	}
+	tx.Exec("INSERT INTO in_out_m VALUES (7, ?, 5, 10, '121', 0, 9, 'landed', 0, 110)", stale)
+
+	// A transfer still inside its timeout is left alone
+	item, err := checkTransfer(srv, ctx, "running")
+	if err != nil || item.Status != "pending" {
+		t.Fatalf("running transfer:(%+v), err:(%+v)", item, err)
+	}
+	if err := changeBalance(srv, ctx, "10", "running"); err != utils.ErrWalletTransferPending {
+		t.Fatalf("running order err:(%+v), want ErrWalletTransferPending", err)
+	}
+
+	// Pending past the timeout: failed without a cash change, committed with one
+	item, err = checkTransfer(srv, ctx, "crashed")
+	if err != nil || item.Status != "failed" || item.Message != "transfer timed out" {
+		t.Fatalf("crashed transfer:(%+v), err:(%+v)", item, err)
+	}
+	item, err = checkTransfer(srv, ctx, "landed")
+	if err != nil || item.Status != "committed" || item.OrderID != 7 || !item.BeforeCash.Equal(decimal.NewFromInt(100)) || !item.AfterCash.Equal(decimal.NewFromInt(110)) {
+		t.Fatalf("landed transfer:(%+v), err:(%+v)", item, err)
+	}
+
+	// The reaper resolves what nobody asked about
+	srv.reapTransfers()
+	record, err := srv.transferRecordDao.QueryByVendorOrder(tx, "demo", "reaped")
+	if err != nil || record.Status != db.TransferStatusFailed {
+		t.Fatalf("reaped record:(%+v), err:(%+v)", record, err)
+	}
+	record, _ = srv.transferRecordDao.QueryByVendorOrder(tx, "demo", "running")
+	if record.Status != db.TransferStatusPending {
+		t.Fatalf("running record reaped:(%+v)", record)
+	}
+
+	// The crashed order may be retried once resolved
+	if err := changeBalance(srv, ctx, "20", "crashed"); err != nil {
+		t.Fatalf("retried crashed order err:(%+v)", err)
+	}
+	item, _ = checkTransfer(srv, ctx, "crashed")
+	if item.Status != "committed" || item.Message != "" {
+		t.Fatalf("retried crashed transfer:(%+v)", item)
+	}
+}
+
+func TestPublicApiService_BatchChangeBalance(t *testing.T) {
+	srv, tx, ctx := newTransferTestService(t)
+
+	resp, err := srv.BatchChangeBalance(ctx, &view.BatchChangeBalanceReq{
+		VendorID:  "demo",
+		Signature: "sig",
+		Timestamp: time.Now().Unix(),
+		Items: []*view.BatchChangeBalanceItem{
+			{User: "tom", Money: "600", Order: "b-1"},
+			// The agent has 400 left after b-1, the verified 1000 is stale
+			{User: "tom", Money: "600", Order: "b-2"},
+			{User: "tom", Money: "-1000", Order: "b-3"},
+			// Credit members draw on the agent credit
+			{User: "ann", Money: "20", Order: "b-4"},
+			{User: "ann", Money: "25", Order: "b-5"},
+			{User: "tom", Money: "10", Order: "b-1"},
+			{User: "bob", Money: "10", Order: "b-6"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("batch err:(%+v)", err)
+	}
+	want := []string{
+		view.BatchTransferApplied,
+		view.BatchTransferInsufficient,
+		view.BatchTransferInsufficient,
+		view.BatchTransferApplied,
+		view.BatchTransferInsufficient,
+		view.BatchTransferDuplicate,
+		view.BatchTransferFailed,
+	}
+	for i, result := range resp.Result {
+		if result.Status != want[i] {
+			t.Fatalf("result %d:(%+v), want status %s", i, result, want[i])
+		}
+	}
+	if !resp.Result[0].Cash.Equal(decimal.NewFromInt(700)) || resp.Result[0].OrderID == 0 {
+		t.Fatalf("applied result:(%+v)", resp.Result[0])
+	}
+
+	var agent struct{ Cash, Credit decimal.Decimal }
+	tx.Raw("SELECT cash, credit FROM agent WHERE age001 = 9").Scan(&agent)
+	if !agent.Cash.Equal(decimal.NewFromInt(400)) || !agent.Credit.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("agent:(%+v), want cash 400 and credit 10", agent)
+	}
+
+	// Every rejected entry that reached the ledger is failed so the vendor may retry it
+	for order, status := range map[string]int{
+		"b-1": db.TransferStatusCommitted,
+		"b-2": db.TransferStatusFailed,
+		"b-3": db.TransferStatusFailed,
+		"b-4": db.TransferStatusCommitted,
+		"b-5": db.TransferStatusFailed,
+	} {
+		record, err := srv.transferRecordDao.QueryByVendorOrder(tx, "demo", order)
+		if err != nil || record.Status != status {
+			t.Fatalf("record %s:(%+v), err:(%+v), want status %d", order, record, err, status)
+		}
+	}
+	if _, err := srv.transferRecordDao.QueryByVendorOrder(tx, "demo", "b-6"); err != gorm.ErrRecordNotFound {
+		t.Fatalf("record of an unknown member err:(%+v)", err)
+	}
+	item, _ := checkTransfer(srv, ctx, "b-2")
+	if item.Status != "failed" || item.Message == "" {
+		t.Fatalf("check failed entry:(%+v)", item)
+	}
+}
+
+func TestPublicApiService_BatchChangeBalanceDeadlock(t *testing.T) {
+	srv, tx, ctx := newTransferTestService(t)
+	tx.Exec("INSERT INTO launder_rule VALUES (1, 9, 'large', 'large_transfer', 500, 0, 'alert', '{user} {money}', 1)")
+
+	// The second ledger insert of the chunk is picked as the deadlock victim
+	inserts := 0
+	tx.Callback().Create().Before("gorm:create").Register("test:deadlock", func(db *gorm.DB) {
+		if db.Statement.Table == "in_out_m" {
+			inserts++
+			if inserts == 2 {
+				db.AddError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+			}
+		}
+	})
+
+	resp, err := srv.BatchChangeBalance(ctx, &view.BatchChangeBalanceReq{
+		VendorID:  "demo",
+		Signature: "sig",
+		Timestamp: time.Now().Unix(),
+		Items: []*view.BatchChangeBalanceItem{
+			{User: "tom", Money: "600", Order: "d-1"},
+			{User: "tom", Money: "10", Order: "d-2"},
+			{User: "tom", Money: "10", Order: "d-3"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("batch err:(%+v)", err)
+	}
+	for i, result := range resp.Result {
+		if result.Status != view.BatchTransferFailed || result.Code != int(utils.CodeWalletTransferLineError) || result.OrderID != 0 {
+			t.Fatalf("result %d:(%+v), want a line error", i, result)
+		}
+	}
+
+	var cash decimal.Decimal
+	tx.Raw("SELECT cash FROM member WHERE mem001 = 5").Scan(&cash)
+	if !cash.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("member cash:%s, want 100", cash)
+	}
+	var records int64
+	tx.Raw("SELECT COUNT(*) FROM transfer_record").Scan(&records)
+	if records != 0 {
+		t.Fatalf("transfer records:%d, want none after the rollback", records)
+	}
+	// The alert of d-1 is kept, it was raised before the chunk went down
+	var alerts int64
+	tx.Raw("SELECT COUNT(*) FROM alertMessage WHERE mid = 5 AND rule_code = 'large'").Scan(&alerts)
+	if alerts != 1 {
+		t.Fatalf("alerts:%d, want 1", alerts)
+	}
+}
@@ -153,6 +153,7 @@ func DBToViewAgentsLoginPass(dAgentsLoginPass *db.AgentsLoginPass) *view.AgentsL
 		PrefixSwitch: dAgentsLoginPass.PrefixSwitch,
 		OpenGameURL:  dAgentsLoginPass.OpenGameURL,
 		Subdomain:    dAgentsLoginPass.Subdomain,
+		SignMode:     dAgentsLoginPass.SignMode,
 	}
 	return &vAgentsLoginPass
 }
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-zrbc/pkg/mq"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+const (
+	// sid -> member id of mem_login
+	wsSidCacheKey = "WsSid_%s"
+	// how long a cached sid resolves without checking mem_login, logins and logouts drop it at once
+	wsSidCacheTTL = time.Minute
+	// a sid expires this long after the login that issued it (mlg004), as the SigninGame cookie does
+	wsSidMaxAge = 18 * time.Hour
+)
+
+// GetUserBySid resolves the sid SigninGame/MemberLogin stored in mem_login to its member, until
+// wsSidMaxAge after that login
+func (srv *publicApiService) GetUserBySid(ctx context.Context, sid string) (*view.GetUserInfoResp, error) {
+	if sid == "" {
+		return nil, utils.ErrUnauthorized
+	}
+	key := fmt.Sprintf(wsSidCacheKey, sid)
+	memberID, err := srv.redisCli.Get(ctx, key).Int64()
+	if err != nil {
+		if err != redis.Nil {
+			xlog.Errorf("error to get sid cache, err:%+v", err)
+		}
+		memLogin, err := srv.memLoginDao.QueryBySID(srv.DB(), sid)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, utils.ErrUnauthorized
+			}
+			xlog.Errorf("error to query mem_login by sid, err:%+v", err)
+			return nil, err
+		}
+		left := wsSidMaxAge - time.Since(memLogin.Mlg004)
+		if left <= 0 {
+			return nil, utils.ErrUnauthorized
+		}
+		memberID = memLogin.Mlg001
+		ttl := wsSidCacheTTL
+		if left < ttl {
+			ttl = left
+		}
+		if err := srv.redisCli.Set(ctx, key, memberID, ttl).Err(); err != nil {
+			xlog.Errorf("error to set sid cache, mid:%d, err:%+v", memberID, err)
+		}
+	}
+
+	member, err := srv.userDao.QueryByID(srv.DB(), memberID)
+	if err != nil {
+		xlog.Errorf("error to query member, mid:%d, err:%+v", memberID, err)
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if member.Mem016 == "N" {
+		return nil, utils.ErrParamInvalidAccountDeactivated
+	}
+	return &view.GetUserInfoResp{User: DBToViewUser(member)}, nil
+}
+
+// loginSid is the sid mem_login holds for memberID before a new login replaces it, "" for none
+func (srv *publicApiService) loginSid(tx *gorm.DB, memberID int64) (string, error) {
+	memLogin, err := srv.memLoginDao.QueryByID(tx, memberID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return memLogin.Mlg003, nil
+}
+
+// dropSidCache forgets the sids so a logged out or logged in again member cannot open new websocket
+// sessions with them
+func (srv *publicApiService) dropSidCache(ctx context.Context, sids []string) {
+	keys := make([]string, 0, len(sids))
+	for _, sid := range sids {
+		if sid != "" {
+			keys = append(keys, fmt.Sprintf(wsSidCacheKey, sid))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := srv.redisCli.Del(ctx, keys...).Err(); err != nil {
+		xlog.Errorf("error to drop sid cache, err:%+v", err)
+	}
+}
+
+// kickMembers asks every ws-channel node to close the live connections of memberIDs
+func (srv *publicApiService) kickMembers(ctx context.Context, memberIDs []int64, reason int) {
+	if len(memberIDs) == 0 {
+		return
+	}
+	b, _ := json.Marshal(mq.MQMsg{
+		MsgType: mq.MQMsgTypeBatchKickUser,
+		Payload: mq.BatchKickUser{UserIDs: memberIDs, Reason: reason, Timestamp: time.Now().Unix()},
+	})
+	if err := srv.redisCli.Publish(ctx, mq.ChannelWsKick, b).Err(); err != nil {
+		xlog.Errorf("error to publish ws kick, memberIDs:%+v, err:%+v", memberIDs, err)
+	}
+}
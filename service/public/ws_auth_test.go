@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"go-zrbc/db"
+	"go-zrbc/pkg/utils"
+	"go-zrbc/service"
+	"go-zrbc/view"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/go-redis/redis/v8"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPublicApiService_GetUserBySid(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "auth.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT, mem004 TEXT, mem016 TEXT)",
+		"CREATE TABLE mem_login (mlg001 INTEGER PRIMARY KEY, mlg002 INTEGER, mlg003 TEXT, mlg004 DATETIME)",
+		"INSERT INTO member VALUES (1, 'tom', 'Tom', 'Y'), (2, 'ann', 'Ann', 'N'), (3, 'bob', 'Bob', 'Y')",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	now := time.Now()
+	tx.Exec("INSERT INTO mem_login VALUES (1, 0, 'SID-TOM', ?), (2, 0, 'SID-ANN', ?), (3, 0, 'SID-BOB', ?)",
+		now, now, now.Add(-wsSidMaxAge-time.Minute))
+	mr := miniredis.RunT(t)
+	srv := &publicApiService{
+		userDao:     db.NewMemberDao(),
+		memLoginDao: db.NewMemLoginDao(),
+		redisCli:    redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		Session:     service.NewSession(tx),
+	}
+	ctx := context.Background()
+
+	resp, err := srv.GetUserBySid(ctx, "SID-TOM")
+	if err != nil {
+		t.Fatalf("get user by sid err:(%+v)", err)
+	}
+	if resp.User.ID != 1 || resp.User.User != "tom" {
+		t.Fatalf("user:(%+v)", resp.User)
+	}
+	if got, _ := mr.Get("WsSid_SID-TOM"); got != "1" {
+		t.Fatalf("sid cache:(%s), want the member id", got)
+	}
+
+	// Served from the cache while mem_login still holds the sid
+	tx.Exec("UPDATE mem_login SET mlg003 = 'SID-TOM-2' WHERE mlg001 = 1")
+	if _, err := srv.GetUserBySid(ctx, "SID-TOM"); err != nil {
+		t.Fatalf("cached sid err:(%+v)", err)
+	}
+	srv.dropSidCache(ctx, []string{"SID-TOM"})
+	if _, err := srv.GetUserBySid(ctx, "SID-TOM"); err != utils.ErrUnauthorized {
+		t.Fatalf("replaced sid err:(%+v), want ErrUnauthorized", err)
+	}
+
+	if _, err := srv.GetUserBySid(ctx, "SID-ANN"); err != utils.ErrParamInvalidAccountDeactivated {
+		t.Fatalf("disabled member err:(%+v), want ErrParamInvalidAccountDeactivated", err)
+	}
+	if _, err := srv.GetUserBySid(ctx, ""); err != utils.ErrUnauthorized {
+		t.Fatalf("empty sid err:(%+v), want ErrUnauthorized", err)
+	}
+
+	// A sid past wsSidMaxAge is refused, one about to expire is cached only until then
+	if _, err := srv.GetUserBySid(ctx, "SID-BOB"); err != utils.ErrUnauthorized {
+		t.Fatalf("expired sid err:(%+v), want ErrUnauthorized", err)
+	}
+	tx.Exec("UPDATE mem_login SET mlg004 = ? WHERE mlg001 = 3", now.Add(-wsSidMaxAge+10*time.Second))
+	if _, err := srv.GetUserBySid(ctx, "SID-BOB"); err != nil {
+		t.Fatalf("sid about to expire err:(%+v)", err)
+	}
+	if ttl := mr.TTL("WsSid_SID-BOB"); ttl <= 0 || ttl > 10*time.Second {
+		t.Fatalf("sid cache ttl:(%s), want at most 10s", ttl)
+	}
+}
+
+func TestPublicApiService_MemberLogin(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	tx, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "login.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite err:(%+v)", err)
+	}
+	for _, ddl := range []string{
+		"CREATE TABLE member (mem001 INTEGER PRIMARY KEY, mem002 TEXT, mem003 TEXT, mem006 INTEGER NOT NULL DEFAULT 5, mem011 INTEGER, mem013 DATETIME, mem014 TEXT, mem016 TEXT NOT NULL DEFAULT 'Y', mem020 TEXT NOT NULL DEFAULT 'N', cash DECIMAL(15,4) NOT NULL DEFAULT 0)",
+		"CREATE TABLE mem_login (mlg001 INTEGER, mlg002 INTEGER, mlg003 TEXT, mlg004 DATETIME, mlg005 DATETIME, mlg006 TEXT, mlg007 TEXT NOT NULL DEFAULT '', mlg008 INTEGER NOT NULL DEFAULT 0, PRIMARY KEY (mlg001, mlg002))",
+		"INSERT INTO member (mem001, mem002, mem003, mem011, cash) VALUES (1, 'tom', 'pw', 9, 100)",
+		"INSERT INTO member (mem001, mem002, mem003, mem011) VALUES (2, 'ann', 'pw', 8)",
+		"INSERT INTO member (mem001, mem002, mem003, mem011, mem020) VALUES (3, 'bob', 'pw', 9, 'Y')",
+	} {
+		if err := tx.Exec(ddl).Error; err != nil {
+			t.Fatalf("exec %q err:(%+v)", ddl, err)
+		}
+	}
+	mr := miniredis.RunT(t)
+	srv := &publicApiService{
+		userDao:     db.NewMemberDao(),
+		memLoginDao: db.NewMemLoginDao(),
+		redisCli:    redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		Session:     service.NewSession(tx),
+	}
+	ctx := context.WithValue(context.Background(), AgentVerifyCtxKey, &view.AgentVerifyResp{
+		Agent:            &view.Agent{ID: 9, VendorID: "demo"},
+		RequestSignature: "sig",
+	})
+	login := func(user, password string) (*view.MemberLoginResp, error) {
+		return srv.MemberLogin(ctx, &view.MemberLoginReq{VendorID: "demo", Signature: "sig", User: user, Password: password, Timestamp: time.Now().Unix()})
+	}
+
+	resp, err := login("tom", "pw")
+	if err != nil || resp.Result.User != "tom" || resp.Result.Sid == "" || !resp.Result.Cash.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("login:(%+v), err:(%+v)", resp, err)
+	}
+	var sid string
+	tx.Raw("SELECT mlg003 FROM mem_login WHERE mlg001 = 1").Scan(&sid)
+	if sid != resp.Result.Sid {
+		t.Fatalf("mem_login sid:(%s), want:(%s)", sid, resp.Result.Sid)
+	}
+
+	// Logging in again forgets the cached sid it replaces
+	tx.Exec("UPDATE mem_login SET mlg003 = 'SID-OLD' WHERE mlg001 = 1")
+	mr.Set("WsSid_SID-OLD", "1")
+	if _, err := login("tom", "pw"); err != nil {
+		t.Fatalf("login again err:(%+v)", err)
+	}
+	if mr.Exists("WsSid_SID-OLD") {
+		t.Fatalf("replaced sid still cached")
+	}
+
+	// The owner is checked before the password, a wrong password of another agent's member tells nothing
+	for _, c := range []struct {
+		user, password string
+		want           error
+	}{
+		{"ann", "wrong", utils.ErrParamInvalidAccountNotBelongToAgent},
+		{"tom", "wrong", utils.ErrParamInvalidAccountPasswordError},
+		{"bob", "pw", utils.ErrInvalidTransactionError},
+		{"eve", "pw", utils.ErrParamInvalidAccountNotExist},
+		{"tom", "", utils.ErrInvalidPasswordEmpty},
+	} {
+		if _, err := login(c.user, c.password); err != c.want {
+			t.Fatalf("login %s/%s err:(%+v), want:(%+v)", c.user, c.password, err, c.want)
+		}
+	}
+}
@@ -58,4 +58,120 @@ CREATE TABLE `member` (
   KEY `mem010` (`mem010`),
   KEY `mem011` (`mem011`),
   KEY `mem006_mem022` (`mem006`,`mem022`)
-) ENGINE=InnoDB AUTO_INCREMENT=941471 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci;
\ No newline at end of file
+) ENGINE=InnoDB AUTO_INCREMENT=941471 DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci;
+
+-- a168.`transfer_record` definition
+
+CREATE TABLE `transfer_record` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `vendor_id` varchar(30) NOT NULL COMMENT '代理商ID',
+  `order_num` varchar(32) NOT NULL COMMENT '代理商订单号',
+  `aid` int(11) NOT NULL COMMENT '代理ID',
+  `mid` int(11) NOT NULL COMMENT '会员ID',
+  `user` varchar(30) NOT NULL COMMENT '会员帐号',
+  `money` decimal(15,4) NOT NULL COMMENT '加扣点金额',
+  `status` tinyint(4) NOT NULL DEFAULT 0 COMMENT '0:处理中1:已完成2:失败',
+  `iom001` int(11) NOT NULL DEFAULT 0 COMMENT 'in_out_m流水号',
+  `before_cash` decimal(15,4) NOT NULL DEFAULT 0.0000 COMMENT '异动前余额',
+  `after_cash` decimal(15,4) NOT NULL DEFAULT 0.0000 COMMENT '异动后余额',
+  `message` varchar(255) NOT NULL DEFAULT '' COMMENT '失败原因',
+  `create_time` datetime NOT NULL DEFAULT current_timestamp(),
+  `update_time` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `vendor_order` (`vendor_id`,`order_num`),
+  KEY `mid` (`mid`),
+  KEY `status_update_time` (`status`,`update_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci;
+
+
+ALTER TABLE `agents_LoginPass` ADD COLUMN `sign_mode` tinyint(4) NOT NULL DEFAULT 0 COMMENT '0:明文密鑰,1:HMAC簽名';
+
+CREATE TABLE `rate_limit_rule` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `vendor_id` varchar(50) NOT NULL DEFAULT '' COMMENT '代理商ID,空值為全部代理商',
+  `command` varchar(50) NOT NULL COMMENT '指令',
+  `scope` varchar(10) NOT NULL DEFAULT 'vendor' COMMENT 'vendor:每代理商,member:每會員',
+  `algorithm` varchar(10) NOT NULL DEFAULT 'window' COMMENT 'window:滑動窗口,bucket:令牌桶',
+  `quota` int(11) NOT NULL COMMENT '窗口內次數,0為不限',
+  `window_sec` int(11) NOT NULL COMMENT '窗口秒數',
+  `status` tinyint(4) NOT NULL DEFAULT 1 COMMENT '0:停用,1:啟用',
+  `create_time` datetime NOT NULL DEFAULT current_timestamp(),
+  `update_time` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `vendor_command` (`vendor_id`,`command`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci;
+
+-- 原本寫死在程式裡的代理商限流
+INSERT INTO `rate_limit_rule` (`vendor_id`, `command`, `scope`, `quota`, `window_sec`) VALUES
+  ('igktwapi', 'ChangeBalance', 'member', 1, 2),
+  ('ocmsapi', 'ChangeBalance', 'member', 1, 2),
+  ('igktwapi', 'GetTipReport', 'vendor', 1, 10);
+INSERT INTO `rate_limit_rule` (`vendor_id`, `command`, `scope`, `quota`, `window_sec`)
+  SELECT `age002`, 'ChangeBalance', 'member', 1, 2 FROM `agent` WHERE `age001` = 1717;
+
+CREATE TABLE `launder_rule` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `aid` bigint(20) NOT NULL DEFAULT 0 COMMENT '代理ID,0為全部代理',
+  `code` varchar(50) NOT NULL COMMENT '規則代碼,代理以相同代碼覆蓋預設規則',
+  `type` varchar(20) NOT NULL DEFAULT '' COMMENT 'count:次數,amount:金額,large_transfer:單筆大額,deposit_withdraw:加點後扣點,agent_switch:代理切換',
+  `threshold` decimal(15,4) NOT NULL DEFAULT 0 COMMENT '門檻',
+  `window_sec` int(11) NOT NULL DEFAULT 0 COMMENT '統計秒數',
+  `action` varchar(10) NOT NULL DEFAULT 'alert' COMMENT 'alert:告警,reject:拒絕,lock:鎖定',
+  `message` varchar(500) NOT NULL DEFAULT '' COMMENT '告警訊息模板',
+  `status` tinyint(4) NOT NULL DEFAULT 1 COMMENT '0:停用,1:啟用',
+  `create_time` datetime NOT NULL DEFAULT current_timestamp(),
+  `update_time` datetime NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `aid_code` (`aid`,`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci;
+
+ALTER TABLE `alertMessage`
+  ADD COLUMN `rule_code` varchar(50) NOT NULL DEFAULT '' COMMENT '觸發的風控規則',
+  ADD COLUMN `action` varchar(10) NOT NULL DEFAULT '' COMMENT '規則處理方式',
+  ADD KEY `mid_rule` (`mid`,`rule_code`,`unierrorTime`);
+
+ALTER TABLE `alertMessage`
+  MODIFY COLUMN `status` tinyint(4) NOT NULL DEFAULT 0 COMMENT '0:未處理1:已處理2:已確認',
+  ADD COLUMN `push_status` tinyint(4) NOT NULL DEFAULT 0 COMMENT '0:待推送1:已推送2:推送失敗',
+  ADD COLUMN `push_retries` int(11) NOT NULL DEFAULT 0 COMMENT '推送失敗次數',
+  ADD COLUMN `next_push_time` int(11) NOT NULL DEFAULT 0 COMMENT '下次推送時間',
+  ADD COLUMN `push_error` varchar(255) NOT NULL DEFAULT '' COMMENT '最後推送錯誤',
+  ADD COLUMN `pushed_hooks` varchar(255) NOT NULL DEFAULT '' COMMENT '已送達的webhook',
+  ADD COLUMN `ack_time` int(11) NOT NULL DEFAULT 0 COMMENT '確認時間',
+  ADD COLUMN `resolve_time` int(11) NOT NULL DEFAULT 0 COMMENT '處理時間',
+  ADD COLUMN `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '處理備註',
+  ADD KEY `push_status` (`push_status`,`next_push_time`);
+-- 上線前的告警不再補推
+UPDATE `alertMessage` SET `push_status` = 1;
+
+CREATE TABLE `member_lock_audit` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `mid` bigint(20) NOT NULL COMMENT '會員ID',
+  `operator` varchar(50) NOT NULL COMMENT '操作者',
+  `decision` varchar(10) NOT NULL COMMENT 'unlock:解除鎖定,keep:維持鎖定',
+  `reason` varchar(255) NOT NULL COMMENT '原因',
+  `alert_ids` varchar(500) NOT NULL DEFAULT '' COMMENT '一併結案的告警',
+  `create_time` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `mid` (`mid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci;
+
+-- 依桌台查詢目前局號
+ALTER TABLE `game_info`
+  ADD KEY `gi011_gi004` (`gi011`,`gi004`);
+
+CREATE TABLE `bet_serial` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `mid` int(11) NOT NULL COMMENT '会员ID',
+  `serial` int(11) NOT NULL COMMENT '下注序号',
+  `result` text NOT NULL COMMENT '下注结果',
+  `create_time` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `mid_serial` (`mid`,`serial`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb3 COLLATE=utf8mb3_general_ci;
+
+-- 報表索引同步依 updatetime 續讀
+ALTER TABLE `bet01`
+  ADD KEY `updatetime_bet01` (`updatetime`,`bet01`);
+ALTER TABLE `bet02`
+  ADD KEY `updatetime_bet01` (`updatetime`,`bet01`);
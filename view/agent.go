@@ -1,6 +1,10 @@
 package view
 
-import "github.com/shopspring/decimal"
+import (
+	"net/url"
+
+	"github.com/shopspring/decimal"
+)
 
 //swagger:model
 type Agent struct {
@@ -136,18 +140,24 @@ type AgentsLoginPass struct {
 	OpenGameURL string `json:"openGameUrl"`
 	// 子域名
 	Subdomain string `json:"subdomain"`
+	// 验签方式 0:明文密钥,1:HMAC签名
+	SignMode int `json:"signMode"`
 }
 
 //swagger:model
 type AgentVerifyReq struct {
 	VendorID  string `json:"vendorId"`
 	Signature string `json:"signature"`
+	// HMAC验签用的全部表单参数
+	Params url.Values `json:"-"`
 }
 
 //swagger:model
 type AgentVerifyResp struct {
 	Agent           *Agent           `json:"agent"`
 	AgentsLoginPass *AgentsLoginPass `json:"agentsLoginPass"`
+	// 通过验证的signature参数
+	RequestSignature string `json:"-"`
 }
 
 //swagger:parameters GetAgentBalance
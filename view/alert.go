@@ -0,0 +1,189 @@
+package view
+
+import "github.com/shopspring/decimal"
+
+// swagger:model
+type AlertMessage struct {
+	// sn
+	ID int64 `json:"id"`
+	// 会员ID
+	Mid int64 `json:"mid"`
+	// 告警内容
+	Message string `json:"message"`
+	// 触发的风控规则
+	RuleCode string `json:"ruleCode"`
+	// 规则处理方式 alert:告警 reject:拒绝 lock:锁定
+	Action string `json:"action"`
+	// 告警时间
+	ErrorTime int64 `json:"errorTime"`
+	// 0:未处理 1:已处理 2:已确认
+	Status int `json:"status"`
+	// 0:待推送 1:已推送 2:推送失败
+	PushStatus int `json:"pushStatus"`
+	// 最后推送错误
+	PushError string `json:"pushError"`
+	// 操作者
+	Operator string `json:"operator"`
+	// 确认时间
+	AckTime int64 `json:"ackTime"`
+	// 处理时间
+	ResolveTime int64 `json:"resolveTime"`
+	// 处理备注
+	Remark string `json:"remark"`
+}
+
+// swagger:parameters ListUnhandledAlerts
+type ListUnhandledAlertsReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 上一页的nextCursor, 第一页不传
+	// in:query
+	Cursor int64 `json:"cursor"`
+	// 笔数, 默认50, 最多200
+	// in:query
+	Limit int `json:"limit"`
+}
+
+// swagger:model
+type ListUnhandledAlertsResp struct {
+	List []*AlertMessage `json:"list"`
+	// 下一页的cursor, 0为已无下一页
+	NextCursor int64 `json:"nextCursor"`
+}
+
+// swagger:parameters AckAlert
+type AckAlertReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 告警ID
+	// in:formData
+	ID int64 `json:"id"`
+}
+
+// swagger:parameters ResolveAlert
+type ResolveAlertReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 告警ID
+	// in:formData
+	ID int64 `json:"id"`
+	// 处理备注
+	// in:formData
+	Remark string `json:"remark"`
+}
+
+// swagger:model
+type LockedMember struct {
+	// 会员ID
+	Mid int64 `json:"mid"`
+	// 会员帐号
+	User string `json:"user"`
+	// 会员名称
+	UserName string `json:"userName"`
+	// 代理ID
+	AgentID int64 `json:"agentId"`
+	// 余额
+	Cash decimal.Decimal `json:"cash"`
+	// 未结案的告警
+	Alerts []*AlertMessage `json:"alerts"`
+}
+
+// swagger:parameters ListLockedMembers
+type ListLockedMembersReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 上一页的nextCursor, 第一页不传
+	// in:query
+	Cursor int64 `json:"cursor"`
+	// 笔数, 默认50, 最多200
+	// in:query
+	Limit int `json:"limit"`
+}
+
+// swagger:model
+type ListLockedMembersResp struct {
+	List []*LockedMember `json:"list"`
+	// 下一页的cursor, 0为已无下一页
+	NextCursor int64 `json:"nextCursor"`
+}
+
+// swagger:parameters GetMemberTransfers
+type GetMemberTransfersReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 会员ID
+	// in:query
+	Mid int64 `json:"mid"`
+	// 笔数, 默认50, 最多200
+	// in:query
+	Limit int `json:"limit"`
+}
+
+// swagger:model
+type MemberTransfer struct {
+	// sn
+	ID int64 `json:"id"`
+	// 时间
+	Time int64 `json:"time"`
+	// 金额, 扣点为负
+	Money decimal.Decimal `json:"money"`
+	// 操作代码 121:加点 122:扣点
+	Code string `json:"code"`
+	// 操作代理ID
+	AgentID int64 `json:"agentId"`
+	// 单号
+	Memo string `json:"memo"`
+	// 操作后余额
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// swagger:model
+type GetMemberTransfersResp struct {
+	List []*MemberTransfer `json:"list"`
+}
+
+// swagger:parameters ReviewMemberLock
+type ReviewMemberLockReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 会员ID
+	// in:formData
+	Mid int64 `json:"mid"`
+	// unlock:解除锁定 keep:维持锁定
+	// in:formData
+	Decision string `json:"decision"`
+	// 原因
+	// in:formData
+	Reason string `json:"reason"`
+}
+
+// swagger:parameters GetMemberLockAudits
+type GetMemberLockAuditsReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 会员ID
+	// in:query
+	Mid int64 `json:"mid"`
+}
+
+// swagger:model
+type MemberLockAudit struct {
+	ID int64 `json:"id"`
+	// 会员ID
+	Mid int64 `json:"mid"`
+	// 操作者
+	Operator string `json:"operator"`
+	// unlock:解除锁定 keep:维持锁定
+	Decision string `json:"decision"`
+	// 原因
+	Reason string `json:"reason"`
+	// 一并结案的告警
+	AlertIDs string `json:"alertIds"`
+	// 时间
+	CreateTime int64 `json:"createTime"`
+}
+
+// swagger:model
+type GetMemberLockAuditsResp struct {
+	List []*MemberLockAudit `json:"list"`
+}
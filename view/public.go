@@ -109,6 +109,41 @@ type LogoutGameResp struct {
 	Result string `json:"result"`
 }
 
+// swagger:parameters MemberLogin
+type MemberLoginReq struct {
+	// 代理商(aid)
+	// in:formData
+	VendorID string `json:"vendorId" form:"vendorId"`
+	// 代理商标识符
+	// in:formData
+	Signature string `json:"signature" form:"signature"`
+	// 帐号
+	// in:formData
+	User string `json:"user" form:"user"`
+	// 密码
+	// in:formData
+	Password string `json:"password" form:"password"`
+	// 时间戳
+	// in:formData
+	Timestamp int64 `json:"timestamp" form:"timestamp"`
+	// 0:中文, 1:英文 (非必要)
+	// in:formData
+	SyslangStr string `json:"syslang" form:"syslang"`
+	// swagger:ignore
+	Syslang string
+}
+
+type MemberLoginItem struct {
+	User string          `json:"user"`
+	Sid  string          `json:"sid"`
+	Cash decimal.Decimal `json:"cash"`
+}
+
+// swagger:model
+type MemberLoginResp struct {
+	Result *MemberLoginItem `json:"result"`
+}
+
 //swagger:parameters GetBalance
 type GetBalanceReq struct {
 	// 代理商(aid)
@@ -149,7 +184,7 @@ type ChangeBalanceReq struct {
 	// 加扣点金额(money)
 	// in:formData
 	Money string `json:"money" form:"money"`
-	// 贵公司产生的订单序号(非必要)最大值:32字符
+	// 贵公司产生的订单序号(非必要)最大值:32字符, 未带则由系统产生并于回应的order带回
 	// in:formData
 	Order string `json:"order" form:"order"`
 	// 时间戳
@@ -165,6 +200,119 @@ type ChangeBalanceReq struct {
 // swagger:model
 type ChangeBalanceResp struct {
 	Result string `json:"result"`
+	// 订单序号, 供CheckTransfer查询. 未带单号时为系统产生, 重送视为新订单
+	Order string `json:"order"`
+}
+
+// 批次加扣点单笔结果
+const (
+	BatchTransferApplied      = "applied"      // 已完成
+	BatchTransferDuplicate    = "duplicate"    // 单号重复或重复转帐
+	BatchTransferInsufficient = "insufficient" // 会员余额或代理点数不足
+	BatchTransferLocked       = "locked"       // 会员已锁定
+	BatchTransferFailed       = "failed"       // 其他错误
+)
+
+// swagger:parameters BatchChangeBalance
+type BatchChangeBalanceReq struct {
+	// 代理商(aid)
+	// in:formData
+	VendorID string `json:"vendorId" form:"vendorId"`
+	// 代理商标识符
+	// in:formData
+	Signature string `json:"signature" form:"signature"`
+	// 加扣点明细, JSON数组: [{"user":"xxx","money":"100","order":"xxx"}]
+	// in:formData
+	Data string `json:"data" form:"data"`
+	// swagger:ignore
+	Items []*BatchChangeBalanceItem
+	// 时间戳
+	// in:formData
+	Timestamp int64 `json:"timestamp" form:"timestamp"`
+	// 0:中文, 1:英文 (非必要)
+	// in:formData
+	SyslangStr string `json:"syslang" form:"syslang"`
+	// swagger:ignore
+	Syslang string
+}
+
+type BatchChangeBalanceItem struct {
+	// 会员(user)
+	User string `json:"user"`
+	// 加扣点金额(money)
+	Money string `json:"money"`
+	// 贵公司产生的订单序号,最大值:32字符
+	Order string `json:"order"`
+}
+
+type BatchChangeBalanceResult struct {
+	User  string `json:"user"`
+	Money string `json:"money"`
+	Order string `json:"order"`
+	// applied, duplicate, insufficient, locked, failed
+	Status string `json:"status"`
+	// 错误码, 成功时为0
+	Code int `json:"code"`
+	// 错误信息
+	Message string `json:"message"`
+	// 交易流水号(in_out_m)
+	OrderID int64 `json:"orderid"`
+	// 异动后余额
+	Cash decimal.Decimal `json:"cash"`
+}
+
+// swagger:model
+type BatchChangeBalanceResp struct {
+	Result []*BatchChangeBalanceResult `json:"result"`
+}
+
+// swagger:parameters CheckTransfer
+type CheckTransferReq struct {
+	// 代理商(aid)
+	// in:formData
+	VendorID string `json:"vendorId" form:"vendorId"`
+	// 代理商标识符
+	// in:formData
+	Signature string `json:"signature" form:"signature"`
+	// 贵公司产生的订单序号(ChangeBalance时传入的order)
+	// in:formData
+	Order string `json:"order" form:"order"`
+	// 时间戳
+	// in:formData
+	Timestamp int64 `json:"timestamp" form:"timestamp"`
+	// 0:中文, 1:英文 (非必要)
+	// in:formData
+	SyslangStr string `json:"syslang" form:"syslang"`
+	// swagger:ignore
+	Syslang string
+}
+
+type TransferStatusItem struct {
+	// 贵公司订单序号
+	Order string `json:"order"`
+	// 会员帐号
+	User string `json:"user"`
+	// 加扣点金额
+	Money decimal.Decimal `json:"money"`
+	// 状态 pending:处理中 committed:已完成 failed:失败
+	Status string `json:"status"`
+	// 交易流水号(in_out_m)
+	OrderID int64 `json:"orderid"`
+	// 异动前余额
+	BeforeCash decimal.Decimal `json:"beforeCash"`
+	// 异动后余额
+	AfterCash decimal.Decimal `json:"afterCash"`
+	// 失败原因
+	Message string `json:"message"`
+	// 建立时间
+	AddTime int64 `json:"addtime"`
+	// 最后更新时间
+	UpdateTime int64 `json:"updatetime"`
+}
+
+// swagger:model
+type CheckTransferResp struct {
+	Result *TransferStatusItem `json:"result"`
 }
 
 // swagger:parameters GetMemberTradeReport
@@ -301,6 +449,9 @@ type GetTipReportReq struct {
 	EndTimeStr string `json:"endTime" form:"endTime"`
 	// swagger:ignore
 	EndTime int64
+	// 分页标记, 首页留空最多10000笔, 之后带上一页返回的nextPageToken每页最多5000笔
+	// in:formData
+	PageToken string `json:"pageToken" form:"pageToken"`
 	// 时间戳
 	// in:formData
 	Timestamp int64 `json:"timestamp" form:"timestamp"`
@@ -331,7 +482,8 @@ type TipReportItem struct {
 
 // swagger:model
 type GetTipReportResp struct {
-	Result []*TipReportItem `json:"result"` // Result data
+	Result        []*TipReportItem `json:"result"`        // Result data
+	NextPageToken string           `json:"nextPageToken"` // 下一页的分页标记, 空为最后一页, 不为空时下一页仍可能为空
 }
 
 // swagger:parameters EnableOrDisableMem
@@ -366,7 +518,7 @@ type EnableOrDisableMemResp struct {
 	Result string `json:"result"`
 }
 
-// swagger:parameters GetDateTimeReport
+// swagger:parameters GetDateTimeReport GetDateTimeCountReport
 type GetDateTimeReportReq struct {
 	// 代理商(aid)
 	// in:formData
@@ -399,6 +551,9 @@ type GetDateTimeReportReq struct {
 	// 游戏编号2
 	// in:formData
 	GameNo2 string `json:"gameno2" form:"gameno2"`
+	// 分页标记, 首页留空最多10000笔, 之后带上一页返回的nextPageToken每页最多5000笔
+	// in:formData
+	PageToken string `json:"pageToken" form:"pageToken"`
 	// 时间戳
 	// in:formData
 	Timestamp int64 `json:"timestamp" form:"timestamp"`
@@ -438,7 +593,31 @@ type DateTimeReportItem struct {
 
 // swagger:model
 type GetDateTimeReportResp struct {
-	Result []*DateTimeReportItem `json:"result"`
+	Result        []*DateTimeReportItem `json:"result"`
+	NextPageToken string                `json:"nextPageToken"` // 下一页的分页标记, 空为最后一页, 不为空时下一页仍可能为空
+}
+
+type DateTimeCountReportItem struct {
+	GID      string          `json:"gid,omitempty"`
+	GName    string          `json:"gname,omitempty"`
+	Count    int64           `json:"count"`
+	Bet      decimal.Decimal `json:"bet"`
+	ValidBet decimal.Decimal `json:"validbet"`
+	Water    decimal.Decimal `json:"water"`
+	Result   decimal.Decimal `json:"result"`
+	WinLoss  decimal.Decimal `json:"winLoss"`
+}
+
+type DateTimeCountReport struct {
+	// 全部游戏合计
+	Total *DateTimeCountReportItem `json:"total"`
+	// 各游戏合计
+	Games []*DateTimeCountReportItem `json:"games"`
+}
+
+// swagger:model
+type GetDateTimeCountReportResp struct {
+	Result *DateTimeCountReport `json:"result"`
 }
 
 // swagger:parameters GetUnsettleReport
@@ -483,3 +662,213 @@ type UnsettleReportItem struct {
 type GetUnsettleReportResp struct {
 	Result []*UnsettleReportItem `json:"result"` // Result data
 }
+
+// swagger:parameters CreateReportExport
+type CreateReportExportReq struct {
+	// 代理商(aid)
+	// in:formData
+	VendorID string `json:"vendorId" form:"vendorId"`
+	// 代理商标识符
+	// in:formData
+	Signature string `json:"signature" form:"signature"`
+	// 报表 datetime:日期时间报表(GetDateTimeReport) trade:会员交易报表(GetMemberTradeReport)
+	// in:formData
+	Report string `json:"report" form:"report"`
+	// 档案格式 csv/xlsx, 默认csv
+	// in:formData
+	Format string `json:"format" form:"format"`
+	// 会员(user), 空为代理商下全部会员
+	// in:formData
+	User string `json:"user" form:"user"`
+	// 开始时间戳
+	// in:formData
+	StartTimeStr string `json:"startTime" form:"startTime"`
+	// swagger:ignore
+	StartTime int64
+	// 结束时间戳, 最多31天
+	// in:formData
+	EndTimeStr string `json:"endTime" form:"endTime"`
+	// swagger:ignore
+	EndTime int64
+	// 时间类型 0:下注时间 1:结算时间 (datetime)
+	// in:formData
+	TimeType int `json:"timetype" form:"timetype"`
+	// 数据类型 0:一般数据 1:小费数据 (datetime)
+	// in:formData
+	DataType int `json:"datatype" form:"datatype"`
+	// 时间戳
+	// in:formData
+	Timestamp int64 `json:"timestamp" form:"timestamp"`
+	// 0:中文, 1:英文 (非必要)
+	// in:formData
+	SyslangStr string `json:"syslang" form:"syslang"`
+	// swagger:ignore
+	Syslang string
+}
+
+// swagger:parameters GetReportExport
+type GetReportExportReq struct {
+	// 代理商(aid)
+	// in:formData
+	VendorID string `json:"vendorId" form:"vendorId"`
+	// 代理商标识符
+	// in:formData
+	Signature string `json:"signature" form:"signature"`
+	// 导出任务ID
+	// in:formData
+	JobID string `json:"jobId" form:"jobId"`
+	// 时间戳
+	// in:formData
+	Timestamp int64 `json:"timestamp" form:"timestamp"`
+}
+
+type ReportExport struct {
+	JobID  string `json:"jobId"`
+	Report string `json:"report"`
+	Format string `json:"format"`
+	// pending:排队中 running:导出中 done:完成 failed:失败
+	Status string `json:"status"`
+	Rows   int64  `json:"rows"`
+	// 下载链接, 完成后才有, 于urlExpireTime失效, 失效后可再查询取得新链接
+	URL           string `json:"url,omitempty"`
+	URLExpireTime int64  `json:"urlExpireTime,omitempty"`
+	Error         string `json:"error,omitempty"`
+	CreateTime    int64  `json:"createTime"`
+	FinishTime    int64  `json:"finishTime,omitempty"`
+	// 任务与档案于此时间后删除
+	ExpireTime int64 `json:"expireTime"`
+}
+
+// swagger:model
+type CreateReportExportResp struct {
+	Result *ReportExport `json:"result"`
+}
+
+// swagger:model
+type GetReportExportResp struct {
+	Result *ReportExport `json:"result"`
+}
+
+// swagger:parameters ListCommands
+type ListCommandsReq struct{}
+
+type GatewayCommandInfo struct {
+	// 指令名称(cmd)
+	Cmd string `json:"cmd"`
+	// 对应的/v1路径
+	Path string `json:"path"`
+	// 是否需要代理商验证
+	NeedAgentVerify bool `json:"needAgentVerify"`
+	// 限流类别
+	RateLimit string `json:"rateLimit"`
+}
+
+// swagger:model
+type ListCommandsResp struct {
+	Result []*GatewayCommandInfo `json:"result"`
+}
+
+// swagger:parameters Hello
+type HelloReq struct {
+	// 代理商(aid)
+	// in:formData
+	VendorID string `json:"vendorId" form:"vendorId"`
+	// 代理商标识符
+	// in:formData
+	Signature string `json:"signature" form:"signature"`
+	// 时间戳
+	// in:formData
+	Timestamp int64 `json:"timestamp" form:"timestamp"`
+	// 0:中文, 1:英文 (非必要)
+	// in:formData
+	SyslangStr string `json:"syslang" form:"syslang"`
+	// swagger:ignore
+	Syslang string
+}
+
+type HelloItem struct {
+	VendorID   string `json:"vendorId"`
+	ServerTime int64  `json:"serverTime"`
+}
+
+// swagger:model
+type HelloResp struct {
+	Result *HelloItem `json:"result"`
+}
+
+// swagger:model
+type EsReconcileTotals struct {
+	// 笔数
+	Count int64 `json:"count"`
+	// 下注金额 bet13
+	Bet decimal.Decimal `json:"bet"`
+	// 派彩 bet17
+	Result decimal.Decimal `json:"result"`
+	// 洗码 bet16
+	Water decimal.Decimal `json:"water"`
+	// 加扣点金额 iom004
+	Money decimal.Decimal `json:"money"`
+}
+
+// swagger:model
+type EsReconcileTable struct {
+	// 表名 bet02 或 in_out_m
+	Table string `json:"table"`
+	// MySQL 合计
+	MySQL *EsReconcileTotals `json:"mysql"`
+	// ES 合计
+	ES *EsReconcileTotals `json:"es"`
+	// 合计是否一致
+	Match bool `json:"match"`
+	// ES 缺少的单号, 最多列1000笔
+	Missing []int64 `json:"missing"`
+	// ES 未更新的单号, 最多列1000笔
+	Stale []int64 `json:"stale"`
+	// ES 多出的单号, 最多列1000笔
+	Extra []int64 `json:"extra"`
+	// ES 缺少的笔数
+	MissingCount int `json:"missingCount"`
+	// ES 未更新的笔数
+	StaleCount int `json:"staleCount"`
+	// ES 多出的笔数
+	ExtraCount int `json:"extraCount"`
+}
+
+// swagger:model
+type EsReconcileReport struct {
+	// 代理ID
+	AgentID int64 `json:"agentId"`
+	// 日期 2006-01-02
+	Day string `json:"day"`
+	// 检查时间
+	CheckTime int64 `json:"checkTime"`
+	// 是否有偏差, 有偏差的代理报表改查 MySQL
+	Drift bool `json:"drift"`
+	// 是否已重建不一致的文件
+	Fixed bool `json:"fixed"`
+	// 各表比对结果
+	Tables []*EsReconcileTable `json:"tables"`
+}
+
+// swagger:parameters ListEsDrift
+type ListEsDriftReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+}
+
+// swagger:model
+type ListEsDriftResp struct {
+	List []*EsReconcileReport `json:"list"`
+}
+
+// swagger:parameters ClearEsDrift
+type ClearEsDriftReq struct {
+	// in:header
+	Token string `json:"Authorization"`
+	// 代理ID
+	// in:formData
+	AgentID int64 `json:"agentId"`
+	// 日期 2006-01-02, 不填解除该代理所有日期
+	// in:formData
+	Day string `json:"day"`
+}
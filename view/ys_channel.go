@@ -13,9 +13,14 @@ type WsReq struct {
 type WsResp struct {
 	Protocol int         `json:"protocol"`
 	Data     interface{} `json:"data"`
+	// 连线内递增的序号, 登录与续传回应不带
+	Seq int64 `json:"seq,omitempty"`
 }
 
 type AuthData struct {
+	// SigninGame/MemberLogin 发出的sid
+	Sid string `json:"sid"`
+	// 帐密与万用token仅开发模式可用
 	Account  string `json:"account"`
 	Password string `json:"password"`
 }
@@ -27,6 +32,8 @@ type AuthResp struct {
 	Sid            string `json:"sid"`
 	BOk            bool   `json:"bOk"`
 	BValidPassword bool   `json:"bValidPassword"`
+	// 断线后以此与最后收到的seq续传
+	ResumeID string `json:"resumeID"`
 }
 
 // swagger:model
@@ -99,6 +106,41 @@ type WsTableData21 struct {
 type WsJoinTableData struct {
 	DtBetLimitSelectID map[string]int `json:"dtBetLimitSelectID"`
 	GroupID            int            `json:"groupID"`
+	// 桌台尚未推送桌况时由客户端带入
+	GameID int `json:"gameID"`
+}
+
+// WsTableEntryReq is what the lobby knows when a member enters a table
+type WsTableEntryReq struct {
+	MemberID           int64
+	GameID             int
+	GroupID            int
+	DtBetLimitSelectID map[string]int
+}
+
+type WsLeaveTableData struct {
+	GroupID int  `json:"groupID"`
+	BOk     bool `json:"bOk"`
+}
+
+// WsResumeData 断线重连后续传, 补发LastSeq之后的资料
+type WsResumeData struct {
+	ResumeID string `json:"resumeID"`
+	LastSeq  int64  `json:"lastSeq"`
+}
+
+// WsResumeRespData Resync为true时缺漏已无法补发, 需重新进桌取完整资料
+type WsResumeRespData struct {
+	BOk      bool   `json:"bOk"`
+	Resync   bool   `json:"resync"`
+	ResumeID string `json:"resumeID"`
+	// 目前最新序号, 补发的资料随后送出
+	Seq int64 `json:"seq"`
+}
+
+// WsReconnectData 节点下线时推送, 客户端等待DelayMs后断开并重连
+type WsReconnectData struct {
+	DelayMs int `json:"delayMs"`
 }
 
 type WsBettingCh struct {
@@ -129,6 +171,26 @@ type WsBettingRespData struct {
 	AreaID   int  `json:"areaID"`
 	AreaType int  `json:"areaType"`
 	BOk      bool `json:"bOk"`
+	// 以下为服务端下注新增
+	BetSerialNumber int                   `json:"betSerialNumber"`
+	Balance         float64               `json:"balance"`
+	BetArr          []WsBettingResultItem `json:"betArr"`
+}
+
+// WsBettingResultItem is the outcome of one bet area of a WsBettingData
+type WsBettingResultItem struct {
+	BetArea     int    `json:"betArea"`
+	AddBetMoney int    `json:"addBetMoney"`
+	BOk         bool   `json:"bOk"`
+	Code        int    `json:"code"`
+	Msg         string `json:"msg"`
+}
+
+// WsPlaceBetReq is a WsBettingData with the table the member sits at
+type WsPlaceBetReq struct {
+	Table *WsTableEntryReq
+	IP    string
+	Data  WsBettingData
 }
 
 // WsYsChannalRespData represents the data field in the channel response
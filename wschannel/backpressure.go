@@ -0,0 +1,94 @@
+package wschannel
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// Frames a client may have waiting for writePump.
+	sendQueueSize = 256
+
+	// A client whose queue stays full this long is evicted.
+	slowConsumerWait = 10 * time.Second
+)
+
+var (
+	wsFramesDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ws_frames_dropped_total",
+		Help: "Frames dropped because the send queue of the client was full",
+	})
+
+	wsSlowConsumersEvicted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ws_slow_consumers_evicted_total",
+		Help: "Clients closed for staying slow past slowConsumerWait",
+	})
+
+	wsSendQueueDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "ws_send_queue_depth",
+		Help:    "Frames already waiting in the send queue when one more is queued",
+		Buckets: []float64{0, 1, 4, 16, 64, 128, 192, 255},
+	})
+)
+
+// enqueue never blocks, it reports whether b was queued. A full queue drops b, and a
+// client that keeps its queue full for slowConsumerWait is evicted.
+func (cli *Client) enqueue(b []byte) bool {
+	if atomic.LoadInt64(&cli.status) == statusClosed {
+		return false
+	}
+	wsSendQueueDepth.Observe(float64(len(cli.bytesSend)))
+	select {
+	case <-cli.done:
+		return false
+	case cli.bytesSend <- b:
+		return true
+	default:
+	}
+
+	atomic.AddInt64(&cli.dropped, 1)
+	wsFramesDropped.Inc()
+	now := time.Now()
+	if atomic.CompareAndSwapInt64(&cli.slowSince, 0, now.UnixNano()) {
+		cli.logger.Errorf("client(%s) send queue full, dropping frames", cli)
+		return false
+	}
+	if now.Sub(time.Unix(0, atomic.LoadInt64(&cli.slowSince))) > slowConsumerWait {
+		cli.evict()
+	}
+	return false
+}
+
+// drained is called by writePump once the queue is empty, the client caught up
+func (cli *Client) drained() {
+	if len(cli.bytesSend) == 0 {
+		atomic.StoreInt64(&cli.slowSince, 0)
+	}
+}
+
+// Dropped is the number of frames the client missed on a full queue
+func (cli *Client) Dropped() int64 {
+	return atomic.LoadInt64(&cli.dropped)
+}
+
+// evict closes a slow consumer with ErrCodeSlowConsumer. The close frame bypasses the full
+// queue, readPump then fails and unregisters the client.
+func (cli *Client) evict() {
+	if !atomic.CompareAndSwapInt64(&cli.evicted, 0, 1) {
+		return
+	}
+	wsSlowConsumersEvicted.Inc()
+	cli.logger.Errorf("client(%s) evicted as a slow consumer, dropped(%d)", cli, cli.Dropped())
+	if cli.conn == nil {
+		return
+	}
+	msg := websocket.FormatCloseMessage(int(ErrCodeSlowConsumer), ErrCodeSlowConsumer.String())
+	if err := cli.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait)); err != nil {
+		cli.logger.Errorf("client(%s) write evict close frame err:(%+v)", cli, err)
+	}
+	cli.conn.Close()
+}
@@ -0,0 +1,140 @@
+package wschannel
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-zrbc/view"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestClient_EnqueueBackpressure(t *testing.T) {
+	cli := &Client{
+		connID:    "slow",
+		bytesSend: make(chan []byte, 1),
+		done:      make(chan struct{}),
+		logger:    &Logger{ServiceID: "ws-channel", UUID: "slow"},
+	}
+	dropped := testutil.ToFloat64(wsFramesDropped)
+	evicted := testutil.ToFloat64(wsSlowConsumersEvicted)
+
+	if !cli.enqueue([]byte("1")) {
+		t.Fatalf("frame not queued on an empty queue")
+	}
+	if cli.enqueue([]byte("2")) {
+		t.Fatalf("frame queued on a full queue")
+	}
+	if cli.Dropped() != 1 || atomic.LoadInt64(&cli.slowSince) == 0 {
+		t.Fatalf("dropped:(%d) slowSince:(%d)", cli.Dropped(), cli.slowSince)
+	}
+
+	// Catching up forgives the slow spell
+	<-cli.bytesSend
+	cli.drained()
+	if atomic.LoadInt64(&cli.slowSince) != 0 {
+		t.Fatalf("slowSince kept after the queue drained")
+	}
+
+	cli.enqueue([]byte("3"))
+	cli.enqueue([]byte("4"))
+	if atomic.LoadInt64(&cli.evicted) != 0 {
+		t.Fatalf("client evicted on its first drop")
+	}
+	atomic.StoreInt64(&cli.slowSince, time.Now().Add(-slowConsumerWait-time.Second).UnixNano())
+	cli.enqueue([]byte("5"))
+	cli.enqueue([]byte("6"))
+	if atomic.LoadInt64(&cli.evicted) != 1 || cli.Dropped() != 4 {
+		t.Fatalf("evicted:(%d) dropped:(%d), want evicted once after 4 drops", cli.evicted, cli.Dropped())
+	}
+	if got := testutil.ToFloat64(wsFramesDropped) - dropped; got != 4 {
+		t.Fatalf("dropped metric:(%v), want 4", got)
+	}
+	if got := testutil.ToFloat64(wsSlowConsumersEvicted) - evicted; got != 1 {
+		t.Fatalf("evicted metric:(%v), want 1", got)
+	}
+}
+
+func TestClient_CloseWhileSending(t *testing.T) {
+	srv := newTestServer()
+	clients := make([]*Client, 50)
+	for i := range clients {
+		clients[i] = newUserClient(srv, fmt.Sprintf("c%d", i), 1)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				srv.deliverToUser(1, []byte(`{"protocol":31,"data":{"memberID":1}}`))
+				srv.kickLocalUser(1, ErrCodeSlowConsumer, "c0")
+			}
+		}()
+	}
+	for _, cli := range clients {
+		cli := cli
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			cli.Close("test")
+		}()
+		go func() {
+			defer wg.Done()
+			cli.Close("again")
+			srv.RemoveClient(cli)
+		}()
+	}
+	wg.Wait()
+
+	for _, cli := range clients {
+		if cli.enqueue([]byte("late")) {
+			t.Fatalf("frame queued on closed client %s", cli.connID)
+		}
+	}
+}
+
+func TestClient_SendWhileRebinding(t *testing.T) {
+	srv := newTestServer()
+	a := newUserClient(srv, "a", 1)
+	b := newUserClient(srv, "b", 1)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for _, cli := range []*Client{a, b} {
+		cli := cli
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					cli.Send(&view.WsResp{Protocol: ProtocolPayout})
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-cli.bytesSend:
+				}
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		srv.TakeStream(a, "b")
+		srv.TakeStream(b, "a")
+		srv.BindUser(b, &view.WsUser{ID: 1})
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"go-zrbc/config"
 	"go-zrbc/pkg/utils"
 	"go-zrbc/pkg/xlog"
 	"go-zrbc/view"
@@ -43,6 +46,7 @@ var upgrader = websocket.Upgrader{
 	ReadBufferSize:    128,
 	WriteBufferSize:   128,
 	EnableCompression: true,
+	Subprotocols:      []string{SubprotocolProtobuf, SubprotocolJSON},
 	CheckOrigin: func(r *http.Request) bool {
 		return true
 	},
@@ -55,14 +59,27 @@ type Client struct {
 	Room     *Room
 	User     *view.WsUser
 	DeviceID string
+	// table the client joined, set with Room
+	table *view.WsTableEntryReq
+	// mu guards Room, table and stream. The server changes them with srv locked too, so either
+	// lock is enough to read them.
+	mu sync.Mutex
+	// numbers and keeps the frames sent to the user, set with User
+	stream *replayStream
 
 	logger *Logger
 
 	mgr  *Server
 	conn *websocket.Conn
 
-	// used to send json text msg
+	// frames encoded in framing. It is never closed, senders give up once done is closed.
 	bytesSend chan []byte
+	done      chan struct{}
+	framing   Framing
+	// frames dropped on a full bytesSend, and since when it stays full (unix nano, 0 when not)
+	dropped   int64
+	slowSince int64
+	evicted   int64
 
 	LastActive       int64 // 最后活动时间
 	HeartbeatRetried int64 // 已连续 N 次没有响应服务端的主动 ping 消息（服务端会在将要超时的时候主动 ping）
@@ -87,7 +104,8 @@ func NewClient(ginCtx *gin.Context, mgr *Server) *Client {
 		connID:    uuid.New().String(),
 		ginCtx:    ginCtx,
 		mgr:       mgr,
-		bytesSend: make(chan []byte, 256),
+		bytesSend: make(chan []byte, sendQueueSize),
+		done:      make(chan struct{}),
 		CreatedAt: time.Now().Unix(),
 	}
 	cli.logger = cli.NewLogger()
@@ -95,12 +113,12 @@ func NewClient(ginCtx *gin.Context, mgr *Server) *Client {
 }
 
 func (cli *Client) Response(resp *ConnMessageResp) {
-	b, err := json.Marshal(resp)
+	b, err := encodeConnMessage(cli.framing, resp)
 	if err != nil {
 		xlog.Error(err)
 		return
 	}
-	cli.bytesSend <- b
+	cli.enqueue(b)
 }
 
 func (cli *Client) ConnID() string {
@@ -132,6 +150,7 @@ func (cli *Client) Register() {
 		return
 	}
 	cli.conn = conn
+	cli.framing = negotiateFraming(conn.Subprotocol(), cli.ginCtx.Request.URL.Query())
 	cli.logger.Debugf("client(%s) enter ws channel, let's welcome", cli)
 
 	go cli.writePump()
@@ -141,6 +160,7 @@ func (cli *Client) Register() {
 func (cli *Client) Unregister() {
 	cli.mgr.Desc()
 	xlog.Infof("receive unregister msg, client(%s) will leave\n", cli)
+	cli.mgr.LeaveRoom(cli)
 	cli.mgr.RemoveClient(cli)
 	cli.Close("unregister")
 }
@@ -162,20 +182,45 @@ const (
 	statusClosed = 1
 )
 
+// Close stops writePump and closes the connection, only the first call does anything
 func (cli *Client) Close(reason string) {
-	if atomic.LoadInt64(&cli.status) == statusClosed {
+	if !atomic.CompareAndSwapInt64(&cli.status, 0, statusClosed) {
 		return
 	}
 	cli.logger.Infof("client(%s) conn will be closed, reason(%s)\n", cli, reason)
-	close(cli.bytesSend)
+	close(cli.done)
 	if cli.conn != nil {
 		cli.conn.Close()
 	}
-	atomic.StoreInt64(&cli.status, statusClosed)
+}
+
+// Kick sends a kick frame carrying code and closes the connection, readPump then unregisters it
+func (cli *Client) Kick(code WSRespCode) {
+	if atomic.LoadInt64(&cli.status) == statusClosed {
+		return
+	}
+	cli.logger.Infof("client(%s) kicked, code(%d)", cli, code)
+	b, _ := encodeConnMessage(cli.framing, NewConnResp(code))
+	cli.enqueue(b)
+	if cli.conn != nil {
+		// let writePump flush the notice before the read side fails
+		time.AfterFunc(writeWait/10, func() { cli.conn.Close() })
+	}
+}
+
+// joined returns the room and the table the client is at, both nil when it is at none
+func (cli *Client) joined() (*Room, *view.WsTableEntryReq) {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	return cli.Room, cli.table
 }
 
 func (c *Client) String() string {
-	return fmt.Sprintf("client: conn_id(%s), user_id(%d), ts(%d)", c.connID, c.User.ID, c.CreatedAt)
+	var userID int64
+	if c.User != nil {
+		userID = c.User.ID
+	}
+	return fmt.Sprintf("client: conn_id(%s), user_id(%d), ts(%d)", c.connID, userID, c.CreatedAt)
 }
 
 func (cli *Client) WriteControlFrame() {
@@ -206,15 +251,14 @@ func (cli *Client) readPump() {
 			}
 			break
 		}
-		var wsReq view.WsReq
-		err = json.Unmarshal(message, &wsReq)
+		wsReq, err := decodeReq(mt, message)
 		if err != nil {
 			cli.logger.Error(err)
 			cli.Response(RespDataFormatError)
 			continue
 		}
 		xlog.Debugf("receive message from ws client(%v): wsReq(%+v)\n", cli, wsReq)
-		err = cli.HandlerWsReq(&wsReq)
+		err = cli.HandlerWsReq(wsReq)
 		if err != nil {
 			cli.logger.Error(err)
 			cli.Response(RespDataFormatError)
@@ -236,23 +280,24 @@ func (cli *Client) writePump() {
 		Protocol: 999,
 		Data:     struct{}{},
 	}
-	periodicMsgBytes, _ := json.Marshal(periodicMsg)
+	periodicMsgBytes, _ := encodeResp(cli.framing, &periodicMsg)
+	msgType := cli.framing.messageType()
 
 	for {
 		select {
-		case bs, ok := <-cli.bytesSend:
-			if !ok {
-				cli.logger.Error("bytesSend pipe closed")
-				cli.WriteControlFrame()
-				cli.conn.Close()
-				return
-			}
-			// todo test BinaryMessage改为TextMessage
+		case <-cli.done:
+			cli.logger.Error("client closed")
+			cli.WriteControlFrame()
+			return
+
+		case bs := <-cli.bytesSend:
 			// cli.logger.Debugf("bytesSend get data :%v", string(bs))
-			if err := cli.conn.WriteMessage(websocket.TextMessage, bs); err != nil {
+			cli.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := cli.conn.WriteMessage(msgType, bs); err != nil {
 				cli.logger.Error(err)
 				return
 			}
+			cli.drained()
 
 		case <-ticker.C:
 			cli.conn.SetWriteDeadline(time.Now().Add(writeWait))
@@ -262,7 +307,8 @@ func (cli *Client) writePump() {
 			cli.conn.SetWriteDeadline(time.Time{})
 
 		case <-periodicMsgTicker.C:
-			if err := cli.conn.WriteMessage(websocket.TextMessage, periodicMsgBytes); err != nil {
+			cli.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := cli.conn.WriteMessage(msgType, periodicMsgBytes); err != nil {
 				cli.logger.Error(err)
 				return
 			}
@@ -272,9 +318,17 @@ func (cli *Client) writePump() {
 
 func (cli *Client) HandlerWsReq(wsReq *view.WsReq) error {
 	switch wsReq.Protocol {
-	case 0: // 登录验证
+	case ProtocolAuth: // 登录验证
 		return cli.HandlerAuthReq(wsReq)
-	case 115: // 不接受指定游戏资料
+	case ProtocolJoinTable: // 进入桌台
+		return cli.HandlerJoinTableReq(wsReq)
+	case ProtocolLeaveTable: // 离开桌台
+		return cli.HandlerLeaveTableReq(wsReq)
+	case ProtocolResume: // 断线续传
+		return cli.HandlerResumeReq(wsReq)
+	case ProtocolBetting: // 下注
+		return cli.HandlerBettingReq(wsReq)
+	case ProtocolIgnoreGame: // 不接受指定游戏资料
 		return cli.Handler115Req(wsReq)
 	default:
 		cli.logger.Errorf("HandlerReqReq protocol err, wsReq:%+v, err:(%+v)", wsReq, errors.New("req protocol err"))
@@ -293,55 +347,186 @@ func (cli *Client) HandlerAuthReq(wsReq *view.WsReq) error {
 		cli.logger.Errorf("HandlerAuthReq data err, wsReq:%+v, err:(%+v)", wsReq, err)
 		return errors.New("auth data err")
 	}
-	if ad.Account == "" {
-		err := errors.New("account is empty")
-		cli.logger.Errorf("HandlerAuthReq data err, wsReq:%+v, err:(%+v)", wsReq, err)
+	authResp, agentID, err := cli.authenticate(&ad)
+	if err != nil {
+		cli.logger.Errorf("HandlerAuthReq auth err, account:%s, err:(%+v)", ad.Account, err)
 		resp.Data = view.AuthResp{
 			BOk: false,
 		}
-		respBin, _ := json.Marshal(resp)
-		cli.bytesSend <- respBin
-		return err
+		cli.sendRaw(&resp)
+		return nil
 	}
-	if ad.Password == "" {
-		err := errors.New("password is empty")
-		cli.logger.Errorf("HandlerAuthReq data err, wsReq:%+v, err:(%+v)", wsReq, err)
-		resp.Data = view.AuthResp{
-			BOk:            false,
-			BValidPassword: false,
+
+	cli.logger.UserID = strconv.FormatInt(authResp.MemberID, 10)
+	cli.mgr.BindUser(cli, &view.WsUser{ID: authResp.MemberID})
+	if singleSession(config.Global.WsSessionPolicies, agentID) {
+		if err := cli.mgr.KickUser(context.TODO(), authResp.MemberID, ErrCodeDuplicateLogin, cli.connID); err != nil {
+			cli.logger.Errorf("HandlerAuthReq kick other sessions err, mid:%d, err:(%+v)", authResp.MemberID, err)
 		}
-		respBin, _ := json.Marshal(resp)
-		cli.bytesSend <- respBin
-		return err
 	}
-	userResp, err := cli.mgr.userService.GetUserByAccountAndPwd(context.TODO(), ad.Account, ad.Password)
-	if err != nil {
-		resp.Data = view.AuthResp{
-			BOk: false,
+	if st := cli.replay(); st != nil {
+		authResp.ResumeID = st.id
+	}
+	// auth and resume replies are not numbered, the client has no stream yet
+	resp.Data = authResp
+	cli.sendRaw(&resp)
+	return nil
+}
+
+// authenticate resolves the member of an auth request and its agent by the sid. Account and
+// password, or UniversalToken as sid with an account, are only accepted in ws_dev_mode.
+func (cli *Client) authenticate(ad *view.AuthData) (*view.AuthResp, int64, error) {
+	ctx := context.TODO()
+	devMode := config.Global.WsDevMode
+	switch {
+	case ad.Sid == UniversalToken:
+		if !devMode || ad.Account == "" {
+			return nil, 0, ErrAuthFailed
 		}
-		respBin, _ := json.Marshal(resp)
-		cli.bytesSend <- respBin
+		mem, err := cli.mgr.userService.GetUserByAccount(ctx, ad.Account)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &view.AuthResp{MemberID: mem.UID, Account: mem.Account, UserName: mem.Name, Sid: ad.Sid, BOk: true, BValidPassword: true}, mem.Mem011, nil
+
+	case ad.Sid != "":
+		userResp, err := cli.mgr.userService.GetUserBySid(ctx, ad.Sid)
+		if err != nil {
+			return nil, 0, err
+		}
+		user := userResp.User
+		return &view.AuthResp{MemberID: user.ID, Account: user.User, UserName: user.UserName, Sid: ad.Sid, BOk: true, BValidPassword: true}, user.Mem011, nil
+
+	case devMode && ad.Account != "" && ad.Password != "":
+		userResp, err := cli.mgr.userService.GetUserByAccountAndPwd(ctx, ad.Account, ad.Password)
+		if err != nil {
+			return nil, 0, err
+		}
+		user := userResp.User
+		return &view.AuthResp{MemberID: user.ID, Account: user.User, UserName: user.UserName, BOk: true, BValidPassword: true}, user.Mem011, nil
+	}
+	return nil, 0, ErrAuthFailed
+}
+
+func (cli *Client) Handler115Req(wsReq *view.WsReq) error {
+	resp := view.WsResp{
+		Protocol: wsReq.Protocol,
+	}
+	cli.Send(&resp)
+	return nil
+}
+
+func (cli *Client) HandlerJoinTableReq(wsReq *view.WsReq) error {
+	if cli.User == nil {
+		cli.Response(RespUserOffline)
+		return nil
+	}
+	var jd view.WsJoinTableData
+	bb, _ := json.Marshal(wsReq.Data)
+	if err := json.Unmarshal(bb, &jd); err != nil {
+		cli.logger.Errorf("HandlerJoinTableReq data err, wsReq:%+v, err:(%+v)", wsReq, err)
+		return errors.New("join table data err")
+	}
+	if jd.GroupID <= 0 {
+		err := errors.New("group id is empty")
+		cli.logger.Errorf("HandlerJoinTableReq data err, wsReq:%+v, err:(%+v)", wsReq, err)
 		return err
 	}
-	resp.Data = view.AuthResp{
-		MemberID:       userResp.User.ID,
-		Account:        userResp.User.User,
-		UserName:       userResp.User.UserName,
-		BOk:            true,
-		Sid:            utils.GenerateUserCode(ad.Account, time.Now().Unix()),
-		BValidPassword: true,
+
+	resp := view.WsResp{
+		Protocol: wsReq.Protocol,
+	}
+	table := &view.WsTableEntryReq{
+		MemberID:           cli.User.ID,
+		GameID:             jd.GameID,
+		GroupID:            jd.GroupID,
+		DtBetLimitSelectID: jd.DtBetLimitSelectID,
+	}
+	entry, err := cli.mgr.userService.GetTableEntry(context.TODO(), table)
+	if err != nil {
+		cli.logger.Errorf("HandlerJoinTableReq get table entry err, wsReq:%+v, err:(%+v)", wsReq, err)
+		resp.Data = view.WsTableEntryData{BOk: false, GameID: jd.GameID, GroupID: jd.GroupID}
+		cli.Send(&resp)
+		return nil
+	}
+	room, err := cli.mgr.JoinRoom(cli, strconv.Itoa(jd.GroupID), 0, table)
+	if err != nil {
+		cli.logger.Errorf("HandlerJoinTableReq join room err, wsReq:%+v, err:(%+v)", wsReq, err)
+		resp.Data = view.WsTableEntryData{BOk: false, GameID: jd.GameID, GroupID: jd.GroupID}
+		cli.Send(&resp)
+		return nil
 	}
+	entry.UserCount = room.TotalClients()
+	resp.Data = entry
+	cli.Send(&resp)
+	return nil
+}
 
-	respBin, _ := json.Marshal(resp)
-	cli.bytesSend <- respBin
+func (cli *Client) HandlerLeaveTableReq(wsReq *view.WsReq) error {
+	resp := view.WsResp{
+		Protocol: wsReq.Protocol,
+	}
+	data := view.WsLeaveTableData{}
+	if r := cli.mgr.LeaveRoom(cli); r != nil {
+		data.GroupID, _ = strconv.Atoi(r.ID)
+		data.BOk = true
+	}
+	// left on purpose, a resume must not take the member back to the table
+	if st := cli.replay(); st != nil {
+		st.setTable(nil)
+	}
+	resp.Data = data
+	cli.Send(&resp)
 	return nil
 }
 
-func (cli *Client) Handler115Req(wsReq *view.WsReq) error {
+func (cli *Client) HandlerBettingReq(wsReq *view.WsReq) error {
+	if cli.User == nil {
+		cli.Response(RespUserOffline)
+		return nil
+	}
+	var bd view.WsBettingData
+	bb, _ := json.Marshal(wsReq.Data)
+	if err := json.Unmarshal(bb, &bd); err != nil {
+		cli.logger.Errorf("HandlerBettingReq data err, wsReq:%+v, err:(%+v)", wsReq, err)
+		return errors.New("betting data err")
+	}
+
 	resp := view.WsResp{
 		Protocol: wsReq.Protocol,
 	}
-	respBin, _ := json.Marshal(resp)
-	cli.bytesSend <- respBin
+	_, table := cli.joined()
+	if table == nil {
+		cli.logger.Errorf("HandlerBettingReq not at a table, wsReq:%+v", wsReq)
+		resp.Data = view.WsBettingRespData{BOk: false, BetSerialNumber: bd.BetSerialNumber}
+		cli.Send(&resp)
+		return nil
+	}
+	ret, err := cli.mgr.userService.PlaceBet(context.TODO(), &view.WsPlaceBetReq{
+		Table: table,
+		IP:    cli.ginCtx.ClientIP(),
+		Data:  bd,
+	})
+	if err != nil {
+		cli.logger.Errorf("HandlerBettingReq place bet err, wsReq:%+v, err:(%+v)", wsReq, err)
+		ret = &view.WsBettingRespData{
+			GameID:          table.GameID,
+			GroupID:         table.GroupID,
+			BetSerialNumber: bd.BetSerialNumber,
+		}
+		var cErr *utils.CustomError
+		if errors.As(err, &cErr) {
+			for _, item := range bd.BetArr {
+				ret.BetArr = append(ret.BetArr, view.WsBettingResultItem{
+					BetArea:     item.BetArea,
+					AddBetMoney: item.AddBetMoney,
+					Code:        int(cErr.Code),
+					Msg:         cErr.Message,
+				})
+			}
+		}
+	}
+	resp.Data = ret
+	cli.Send(&resp)
 	return nil
 }
@@ -0,0 +1,311 @@
+package wschannel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go-zrbc/pkg/mq"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const (
+	busChannel        = "wschannel:bus"
+	presenceNodesKey  = "wschannel:nodes"
+	presenceKeyPrefix = "wschannel:presence:"
+	// presenceConnsField holds the node connection count next to the per room counts
+	presenceConnsField = "_conns"
+
+	presenceInterval = 10 * time.Second
+	presenceTTL      = 3 * presenceInterval
+)
+
+const (
+	busKindRoom = "room"
+	busKindUser = "user"
+	busKindKick = "kick"
+)
+
+// busMessage is what nodes exchange over busChannel, every node (the sender too) delivers it locally
+type busMessage struct {
+	Node   string `json:"node"`
+	Kind   string `json:"kind"`
+	RoomID string `json:"room_id,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	// kick: the code of the kick frame and the connection that stays
+	Code    WSRespCode      `json:"code,omitempty"`
+	ConnID  string          `json:"conn_id,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// ClusterStats is the presence of every live node summed up
+type ClusterStats struct {
+	Nodes      []string         `json:"nodes"`
+	TotalConns int64            `json:"total_conns"`
+	Rooms      map[string]int64 `json:"rooms"`
+}
+
+// Bus fans room broadcasts, user messages and kicks out to every ws-channel node through redis pub/sub
+type Bus struct {
+	nodeID   string
+	redisCli *redis.Client
+	srv      *Server
+	now      func() time.Time
+}
+
+func NewBus(srv *Server, redisCli *redis.Client) *Bus {
+	return &Bus{
+		nodeID:   newNodeID(),
+		redisCli: redisCli,
+		srv:      srv,
+		now:      time.Now,
+	}
+}
+
+func newNodeID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return uuid.New().String()
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+func (bus *Bus) NodeID() string {
+	return bus.nodeID
+}
+
+// Start subscribes before returning so nothing published afterwards is missed,
+// then delivers messages and reports presence until ctx is done
+func (bus *Bus) Start(ctx context.Context) error {
+	pubsub := bus.redisCli.Subscribe(ctx, busChannel, mq.ChannelWsKick)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+	go bus.receive(ctx, pubsub)
+	go bus.reportPresence(ctx)
+	return nil
+}
+
+func (bus *Bus) receive(ctx context.Context, pubsub *redis.PubSub) {
+	defer pubsub.Close()
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-ch:
+			if !ok {
+				return
+			}
+			if m.Channel == mq.ChannelWsKick {
+				bus.deliverBatchKick(m.Payload)
+				continue
+			}
+			var msg busMessage
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				xlog.Errorf("error to decode bus message, payload:%s, err:%+v", m.Payload, err)
+				continue
+			}
+			bus.deliver(&msg)
+		}
+	}
+}
+
+func (bus *Bus) deliver(msg *busMessage) {
+	switch msg.Kind {
+	case busKindRoom:
+		bus.srv.deliverToRoom(msg.RoomID, msg.Payload)
+	case busKindUser:
+		bus.srv.deliverToUser(msg.UserID, msg.Payload)
+	case busKindKick:
+		bus.srv.kickLocalUser(msg.UserID, msg.Code, msg.ConnID)
+	default:
+		xlog.Errorf("unknown bus message kind, msg:%+v", msg)
+	}
+}
+
+// deliverBatchKick handles the mq.BatchKickUser the api service publishes on LogoutGame and EnableOrDisableMem
+func (bus *Bus) deliverBatchKick(payload string) {
+	var msg struct {
+		MsgType int              `json:"msg_type"`
+		Payload mq.BatchKickUser `json:"payload"`
+	}
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil || msg.MsgType != mq.MQMsgTypeBatchKickUser {
+		xlog.Errorf("error to decode batch kick, payload:%s, err:%+v", payload, err)
+		return
+	}
+	code := kickReasonCode(msg.Payload.Reason)
+	for _, userID := range msg.Payload.UserIDs {
+		bus.srv.kickLocalUser(userID, code, "")
+	}
+}
+
+func (bus *Bus) publish(ctx context.Context, msg *busMessage) error {
+	msg.Node = bus.nodeID
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return bus.redisCli.Publish(ctx, busChannel, b).Err()
+}
+
+func (bus *Bus) reportPresence(ctx context.Context) {
+	ticker := time.NewTicker(presenceInterval)
+	defer ticker.Stop()
+	for {
+		if err := bus.ReportPresence(ctx); err != nil {
+			xlog.Errorf("error to report ws presence, node:%s, err:%+v", bus.nodeID, err)
+		}
+		select {
+		case <-ctx.Done():
+			bus.redisCli.ZRem(context.Background(), presenceNodesKey, bus.nodeID)
+			bus.redisCli.Del(context.Background(), presenceKeyPrefix+bus.nodeID)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReportPresence writes this node's connection and room counts, they expire unless refreshed
+func (bus *Bus) ReportPresence(ctx context.Context) error {
+	fields := map[string]interface{}{presenceConnsField: bus.srv.TotalConns()}
+	for roomID, total := range bus.srv.roomTotals() {
+		fields[roomID] = total
+	}
+	key := presenceKeyPrefix + bus.nodeID
+	_, err := bus.redisCli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, fields)
+		pipe.Expire(ctx, key, presenceTTL)
+		pipe.ZAdd(ctx, presenceNodesKey, &redis.Z{Score: float64(bus.now().Unix()), Member: bus.nodeID})
+		return nil
+	})
+	return err
+}
+
+// ClusterStats sums the presence of the nodes that reported within presenceTTL
+func (bus *Bus) ClusterStats(ctx context.Context) (*ClusterStats, error) {
+	stale := strconv.FormatInt(bus.now().Add(-presenceTTL).Unix(), 10)
+	if err := bus.redisCli.ZRemRangeByScore(ctx, presenceNodesKey, "-inf", "("+stale).Err(); err != nil {
+		return nil, err
+	}
+	nodes, err := bus.redisCli.ZRange(ctx, presenceNodesKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	ret := &ClusterStats{Nodes: nodes, Rooms: map[string]int64{}}
+	for _, node := range nodes {
+		fields, err := bus.redisCli.HGetAll(ctx, presenceKeyPrefix+node).Result()
+		if err != nil {
+			return nil, err
+		}
+		for field, value := range fields {
+			n, _ := strconv.ParseInt(value, 10, 64)
+			if field == presenceConnsField {
+				ret.TotalConns += n
+				continue
+			}
+			ret.Rooms[field] += n
+		}
+	}
+	return ret, nil
+}
+
+// startBus joins the cluster bus. A node that cannot reach it drops the bus and serves its own
+// clients only, rather than publishing into a bus nobody delivers from.
+func (srv *Server) startBus(ctx context.Context) {
+	if srv.bus == nil {
+		return
+	}
+	if err := srv.bus.Start(ctx); err != nil {
+		xlog.Errorf("error to start ws cluster bus, node:%s, delivering locally only, err:%+v", srv.bus.NodeID(), err)
+		srv.bus = nil
+	}
+}
+
+// BroadcastToRoom sends msg to everyone in roomID on every node
+func (srv *Server) BroadcastToRoom(ctx context.Context, roomID string, msg *view.WsResp) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if srv.bus == nil {
+		srv.deliverToRoom(roomID, b)
+		return nil
+	}
+	return srv.bus.publish(ctx, &busMessage{Kind: busKindRoom, RoomID: roomID, Payload: b})
+}
+
+// SendToUser sends msg to every connection of userID on every node
+func (srv *Server) SendToUser(ctx context.Context, userID int64, msg *view.WsResp) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if srv.bus == nil {
+		srv.deliverToUser(userID, b)
+		return nil
+	}
+	return srv.bus.publish(ctx, &busMessage{Kind: busKindUser, UserID: userID, Payload: b})
+}
+
+// KickUser disconnects every connection of userID on every node but keepConnID
+func (srv *Server) KickUser(ctx context.Context, userID int64, code WSRespCode, keepConnID string) error {
+	if srv.bus == nil {
+		srv.kickLocalUser(userID, code, keepConnID)
+		return nil
+	}
+	return srv.bus.publish(ctx, &busMessage{Kind: busKindKick, UserID: userID, Code: code, ConnID: keepConnID})
+}
+
+func (srv *Server) deliverToRoom(roomID string, msg []byte) {
+	srv.RLock()
+	r, ok := srv.rooms[roomID]
+	srv.RUnlock()
+	if ok {
+		r.broadcast(msg)
+	}
+}
+
+func (srv *Server) deliverToUser(userID int64, msg []byte) {
+	frame, err := decodeFrame(msg)
+	for _, cli := range srv.userClients(userID) {
+		cli.sendFrame(frame, msg, err)
+	}
+}
+
+func (srv *Server) kickLocalUser(userID int64, code WSRespCode, keepConnID string) {
+	for _, cli := range srv.userClients(userID) {
+		if cli.connID != keepConnID {
+			cli.Kick(code)
+		}
+	}
+}
+
+func (srv *Server) userClients(userID int64) []*Client {
+	srv.RLock()
+	defer srv.RUnlock()
+	ret := make([]*Client, 0, len(srv.users[userID]))
+	for _, cli := range srv.users[userID] {
+		ret = append(ret, cli)
+	}
+	return ret
+}
+
+func (srv *Server) roomTotals() map[string]int64 {
+	srv.RLock()
+	defer srv.RUnlock()
+	ret := make(map[string]int64, len(srv.rooms))
+	for id, r := range srv.rooms {
+		ret[id] = int64(r.TotalClients())
+	}
+	return ret
+}
@@ -0,0 +1,183 @@
+package wschannel
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-zrbc/pkg/mq"
+	"go-zrbc/view"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// newClusterNode starts a server whose bus runs against the shared redis at addr
+func newClusterNode(t *testing.T, ctx context.Context, addr, nodeID string) *Server {
+	srv := newTestServer()
+	srv.redisCli = redis.NewClient(&redis.Options{Addr: addr})
+	srv.bus = NewBus(srv, srv.redisCli)
+	srv.bus.nodeID = nodeID
+	if err := srv.bus.Start(ctx); err != nil {
+		t.Fatalf("start bus err:(%+v)", err)
+	}
+	return srv
+}
+
+func newUserClient(srv *Server, connID string, userID int64) *Client {
+	cli := &Client{
+		connID:    connID,
+		mgr:       srv,
+		bytesSend: make(chan []byte, 8),
+		done:      make(chan struct{}),
+		logger:    &Logger{ServiceID: "ws-channel", UUID: connID},
+	}
+	srv.AddClient(cli)
+	srv.BindUser(cli, &view.WsUser{ID: userID})
+	return cli
+}
+
+func recvProtocol(t *testing.T, cli *Client) int {
+	select {
+	case b := <-cli.bytesSend:
+		var resp view.WsResp
+		if err := json.Unmarshal(b, &resp); err != nil {
+			t.Fatalf("decode %s err:(%+v)", b, err)
+		}
+		return resp.Protocol
+	case <-time.After(2 * time.Second):
+		t.Fatalf("client %s got nothing", cli.connID)
+	}
+	return 0
+}
+
+func TestBus_FanOut(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a := newClusterNode(t, ctx, mr.Addr(), "node-a")
+	b := newClusterNode(t, ctx, mr.Addr(), "node-b")
+
+	onA := newUserClient(a, "a1", 1)
+	onB := newUserClient(b, "b1", 2)
+	outside := newUserClient(b, "b2", 3)
+	a.JoinRoom(onA, "101", 0, nil)
+	b.JoinRoom(onB, "101", 0, nil)
+	b.JoinRoom(outside, "102", 0, nil)
+
+	// A room broadcast from node a reaches the room members of node b too
+	if err := a.BroadcastToRoom(ctx, "101", &view.WsResp{Protocol: 21}); err != nil {
+		t.Fatalf("broadcast err:(%+v)", err)
+	}
+	if recvProtocol(t, onA) != 21 || recvProtocol(t, onB) != 21 {
+		t.Fatalf("room broadcast not delivered on both nodes")
+	}
+
+	if err := a.SendToUser(ctx, 3, &view.WsResp{Protocol: 31}); err != nil {
+		t.Fatalf("send to user err:(%+v)", err)
+	}
+	if recvProtocol(t, outside) != 31 {
+		t.Fatalf("user message not delivered on the other node")
+	}
+	if len(onA.bytesSend) != 0 || len(onB.bytesSend) != 0 {
+		t.Fatalf("user message leaked to other users")
+	}
+
+	// A second session of user 2 on node a kicks the one on node b only
+	again := newUserClient(a, "a2", 2)
+	if err := a.KickUser(ctx, 2, ErrCodeDuplicateLogin, again.connID); err != nil {
+		t.Fatalf("kick err:(%+v)", err)
+	}
+	if code := recvKick(t, onB); code != int(ErrCodeDuplicateLogin) {
+		t.Fatalf("kick code:(%d), want duplicate login", code)
+	}
+	if len(again.bytesSend) != 0 {
+		t.Fatalf("kick reached the session that stays")
+	}
+
+	// LogoutGame publishes a batch kick from the api service
+	batch, _ := json.Marshal(mq.MQMsg{
+		MsgType: mq.MQMsgTypeBatchKickUser,
+		Payload: mq.BatchKickUser{UserIDs: []int64{1, 3}, Reason: mq.KickReasonLogout},
+	})
+	mr.Publish(mq.ChannelWsKick, string(batch))
+	if recvKick(t, onA) != int(ErrCodeLoggedOut) || recvKick(t, outside) != int(ErrCodeLoggedOut) {
+		t.Fatalf("batch kick not delivered with the logout code")
+	}
+}
+
+func recvKick(t *testing.T, cli *Client) int {
+	select {
+	case msg := <-cli.bytesSend:
+		var resp ConnMessageResp
+		if err := json.Unmarshal(msg, &resp); err != nil {
+			t.Fatalf("decode %s err:(%+v)", msg, err)
+		}
+		return resp.Code
+	case <-time.After(2 * time.Second):
+		t.Fatalf("client %s got no kick", cli.connID)
+	}
+	return 0
+}
+
+func TestBus_ClusterStats(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a := newClusterNode(t, ctx, mr.Addr(), "node-a")
+	b := newClusterNode(t, ctx, mr.Addr(), "node-b")
+
+	a.JoinRoom(newUserClient(a, "a1", 1), "101", 0, nil)
+	b.JoinRoom(newUserClient(b, "b1", 2), "101", 0, nil)
+	b.JoinRoom(newUserClient(b, "b2", 3), "102", 0, nil)
+	for _, srv := range []*Server{a, b} {
+		if err := srv.bus.ReportPresence(ctx); err != nil {
+			t.Fatalf("report presence err:(%+v)", err)
+		}
+	}
+
+	stats, err := a.bus.ClusterStats(ctx)
+	if err != nil {
+		t.Fatalf("cluster stats err:(%+v)", err)
+	}
+	if len(stats.Nodes) != 2 || stats.TotalConns != 3 || stats.Rooms["101"] != 2 || stats.Rooms["102"] != 1 {
+		t.Fatalf("cluster stats:(%+v)", stats)
+	}
+
+	// A node that stopped reporting drops out once its presence is stale
+	now := time.Now().Add(presenceTTL + time.Second)
+	a.bus.now = func() time.Time { return now }
+	if err := a.bus.ReportPresence(ctx); err != nil {
+		t.Fatalf("report presence err:(%+v)", err)
+	}
+	stats, err = a.bus.ClusterStats(ctx)
+	if err != nil {
+		t.Fatalf("cluster stats err:(%+v)", err)
+	}
+	if len(stats.Nodes) != 1 || stats.TotalConns != 1 || stats.Rooms["101"] != 1 {
+		t.Fatalf("cluster stats after node-b went stale:(%+v)", stats)
+	}
+}
+
+func TestServer_StartBusFailed(t *testing.T) {
+	mr := miniredis.RunT(t)
+	srv := newTestServer()
+	srv.redisCli = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	srv.bus = NewBus(srv, srv.redisCli)
+	mr.Close()
+
+	srv.startBus(context.Background())
+	if srv.bus != nil {
+		t.Fatalf("bus kept after a failed start")
+	}
+	// the node still reaches its own clients
+	cli := newUserClient(srv, "a1", 1)
+	srv.JoinRoom(cli, "101", 0, nil)
+	if err := srv.BroadcastToRoom(context.Background(), "101", &view.WsResp{Protocol: 7}); err != nil {
+		t.Fatalf("broadcast err:(%+v)", err)
+	}
+	if got := recvProtocol(t, cli); got != 7 {
+		t.Fatalf("protocol:(%d), want 7", got)
+	}
+}
@@ -0,0 +1,115 @@
+package wschannel
+
+import (
+	"math/rand"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go-zrbc/config"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+
+	bhttpresp "go-zrbc/pkg/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDrainTimeout = 60 * time.Second
+	drainPollInterval   = 500 * time.Millisecond
+)
+
+// DrainTimeout is ws_drain_timeout, how long a drain waits for the clients to leave
+func DrainTimeout() time.Duration {
+	if t := config.Global.WsDrainTimeout; t > 0 {
+		return time.Duration(t) * time.Second
+	}
+	return defaultDrainTimeout
+}
+
+// Draining reports whether the server stopped taking new connections
+func (srv *Server) Draining() bool {
+	return atomic.LoadInt32(&srv.draining) == 1
+}
+
+// Drained is closed once a drain finished and the server is closed
+func (srv *Server) Drained() <-chan struct{} {
+	return srv.drainDone
+}
+
+// Drain takes the node out of a deploy gracefully. It refuses new upgrades, asks every client
+// to reconnect after a delay spread over the first half of timeout, so they land on other
+// nodes a few at a time, waits until they left or timeout and closes the rest.
+// Only the first call drains, later ones wait for it.
+func (srv *Server) Drain(timeout time.Duration) {
+	if !atomic.CompareAndSwapInt32(&srv.draining, 0, 1) {
+		<-srv.drainDone
+		return
+	}
+	defer close(srv.drainDone)
+
+	clients := srv.localClients()
+	xlog.Infof("ws-channel draining, conns:%d, timeout:%v", len(clients), timeout)
+	spread := int64(timeout / 2)
+	for _, cli := range clients {
+		var delay int64
+		if spread > 0 {
+			delay = rand.Int63n(spread)
+		}
+		cli.Send(&view.WsResp{
+			Protocol: ProtocolReconnect,
+			Data:     view.WsReconnectData{DelayMs: int(time.Duration(delay).Milliseconds())},
+		})
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+wait:
+	for {
+		select {
+		case <-deadline.C:
+			break wait
+		case <-ticker.C:
+			if len(srv.localClients()) == 0 {
+				break wait
+			}
+		}
+	}
+	if left := len(srv.localClients()); left > 0 {
+		xlog.Infof("ws-channel drain timeout, close the %d conns left", left)
+	}
+	srv.Close()
+}
+
+func (srv *Server) localClients() []*Client {
+	srv.RLock()
+	defer srv.RUnlock()
+	ret := make([]*Client, 0, len(srv.clients))
+	for _, cli := range srv.clients {
+		ret = append(ret, cli)
+	}
+	return ret
+}
+
+// AdminDrain starts a drain of this node, the process exits once it finished
+func (srv *Server) AdminDrain(c *gin.Context) {
+	if !srv.Draining() {
+		go srv.Drain(DrainTimeout())
+	}
+	xlog.Infof("ws-channel drain requested by %s", c.GetString("operator"))
+	bhttpresp.JsonResp(c, map[string]interface{}{
+		"draining":    true,
+		"total_conns": srv.TotalConns(),
+	})
+}
+
+func (srv *Server) refuseDraining(c *gin.Context) bool {
+	if !srv.Draining() {
+		return false
+	}
+	bhttpresp.AbortResp(c, http.StatusServiceUnavailable, "ws channel is draining")
+	return true
+}
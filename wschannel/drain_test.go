@@ -0,0 +1,69 @@
+package wschannel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-zrbc/view"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestServer_Drain(t *testing.T) {
+	srv := newTestServer()
+	a := newUserClient(srv, "a1", 1)
+	b := newUserClient(srv, "b1", 2)
+
+	go srv.Drain(2 * time.Second)
+	for _, cli := range []*Client{a, b} {
+		var data view.WsReconnectData
+		resp := recvResp(t, cli, &data)
+		if resp.Protocol != ProtocolReconnect || data.DelayMs < 0 || data.DelayMs >= 1000 {
+			t.Fatalf("reconnect frame:(%+v) data:(%+v), want a delay within half the timeout", resp, data)
+		}
+	}
+	if !srv.Draining() {
+		t.Fatalf("server not draining")
+	}
+
+	// New upgrades are refused while draining
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/15109", nil)
+	srv.EnterLobby(c)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("upgrade while draining status:(%d), want 503", w.Code)
+	}
+
+	srv.RemoveClient(a)
+	srv.RemoveClient(b)
+	select {
+	case <-srv.Drained():
+	case <-time.After(time.Second):
+		t.Fatalf("drain did not finish once the clients left")
+	}
+	select {
+	case <-srv.closeCh:
+	default:
+		t.Fatalf("server not closed after the drain")
+	}
+}
+
+func TestServer_DrainTimeout(t *testing.T) {
+	srv := newTestServer()
+	stuck := newUserClient(srv, "s1", 1)
+
+	start := time.Now()
+	srv.Drain(100 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("drain took %v past its timeout", elapsed)
+	}
+	if atomic.LoadInt64(&stuck.status) != statusClosed {
+		t.Fatalf("client left after the timeout not closed")
+	}
+	// A second drain just waits for the first
+	srv.Drain(time.Hour)
+}
@@ -17,6 +17,7 @@ var (
 	ErrUserAlreadyInScene  = errors.New("user already in the room")
 	ErrRoomFull            = errors.New("room is full")
 	ErrChatSessExpired     = errors.New("chat sess expired")
+	ErrAuthFailed          = errors.New("auth failed")
 )
 
 var (
@@ -51,6 +52,18 @@ func (wcode WSRespCode) String() string {
 
 	case ErrCodeInternalServerError:
 		return "internal server error"
+
+	case ErrCodeKicked:
+		return "kicked"
+
+	case ErrCodeLoggedOut:
+		return "logged out"
+
+	case ErrCodeAccountDisabled:
+		return "account disabled"
+
+	case ErrCodeSlowConsumer:
+		return "slow consumer"
 	}
 
 	return ""
@@ -77,6 +90,10 @@ var (
 	ErrCodeOrderStatusError  WSRespCode = 4005
 	ErrCodeTypeAssertInvalid WSRespCode = 4006
 	ErrCodeSymbolNameNull    WSRespCode = 4007
+	ErrCodeKicked            WSRespCode = 4008
+	ErrCodeLoggedOut         WSRespCode = 4009
+	ErrCodeAccountDisabled   WSRespCode = 4010
+	ErrCodeSlowConsumer      WSRespCode = 4011
 
 	ErrCodeInternalServerError WSRespCode = 5001
 )
@@ -0,0 +1,557 @@
+package wschannel
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+
+	pb "go-zrbc/pkg/protobuf"
+	"go-zrbc/view"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+)
+
+// Framing is how frames of a connection are encoded, picked at the upgrade
+type Framing int
+
+const (
+	FramingJSON     Framing = iota // text frames of WsResp, the default
+	FramingProtobuf                // binary frames of pb.WsFrame
+)
+
+// Subprotocols a client may ask for in Sec-WebSocket-Protocol, ?framing=protobuf does the same
+const (
+	SubprotocolJSON     = "zrbc.json"
+	SubprotocolProtobuf = "zrbc.protobuf"
+)
+
+func negotiateFraming(subprotocol string, query url.Values) Framing {
+	switch subprotocol {
+	case SubprotocolProtobuf:
+		return FramingProtobuf
+	case SubprotocolJSON:
+		return FramingJSON
+	}
+	if query.Get("framing") == "protobuf" {
+		return FramingProtobuf
+	}
+	return FramingJSON
+}
+
+func (f Framing) messageType() int {
+	if f == FramingProtobuf {
+		return websocket.BinaryMessage
+	}
+	return websocket.TextMessage
+}
+
+type pbMessage interface {
+	Marshal() ([]byte, error)
+}
+
+// sharedData is the data of a frame fanned out to many clients, kept as received and
+// converted to protobuf at most once
+type sharedData struct {
+	raw json.RawMessage
+
+	once   sync.Once
+	pbData []byte
+	pbJSON bool
+	pbErr  error
+
+	// the whole frame without seq by framing, see encodeShared
+	frames [2]sharedFrame
+}
+
+type sharedFrame struct {
+	once sync.Once
+	b    []byte
+	err  error
+}
+
+func (d *sharedData) MarshalJSON() ([]byte, error) {
+	if len(d.raw) == 0 {
+		return []byte("null"), nil
+	}
+	return d.raw, nil
+}
+
+func (d *sharedData) protobuf(protocol int) ([]byte, bool, error) {
+	d.once.Do(func() {
+		newData, ok := respDataTypes[protocol]
+		if !ok {
+			d.pbData, d.pbJSON = d.raw, true
+			return
+		}
+		data := newData()
+		if err := json.Unmarshal(d.raw, data); err != nil {
+			d.pbErr = err
+			return
+		}
+		d.pbData, d.pbJSON, d.pbErr = encodeData(data)
+	})
+	return d.pbData, d.pbJSON, d.pbErr
+}
+
+// respDataTypes decodes the JSON data of frames coming from the bus and Kafka
+var respDataTypes = map[int]func() interface{}{
+	ProtocolJoinTable:  func() interface{} { return &view.WsTableEntryData{} },
+	ProtocolLeaveTable: func() interface{} { return &view.WsLeaveTableData{} },
+	ProtocolResume:     func() interface{} { return &view.WsResumeRespData{} },
+	ProtocolReconnect:  func() interface{} { return &view.WsReconnectData{} },
+	ProtocolTableData:  func() interface{} { return &view.WsTableData{} },
+	ProtocolBetting:    func() interface{} { return &view.WsBettingRespData{} },
+	ProtocolGameResult: func() interface{} { return &view.WsGameResultRespData{} },
+	ProtocolPayout:     func() interface{} { return &view.WsPayoutResultData{} },
+	ProtocolBetTime:    func() interface{} { return &view.WsBetTimeData{} },
+	ProtocolGroupList:  func() interface{} { return &view.WsGroupListData{} },
+}
+
+// reqDecoders read the data of binary requests into what the handlers expect
+var reqDecoders = map[int]func([]byte) (interface{}, error){
+	ProtocolAuth: func(b []byte) (interface{}, error) {
+		var m pb.WsAuthData
+		if err := m.Unmarshal(b); err != nil {
+			return nil, err
+		}
+		return view.AuthData{Sid: m.Sid, Account: m.Account, Password: m.Password}, nil
+	},
+	ProtocolJoinTable: func(b []byte) (interface{}, error) {
+		var m pb.WsJoinTableData
+		if err := m.Unmarshal(b); err != nil {
+			return nil, err
+		}
+		data := view.WsJoinTableData{GroupID: int(m.GroupID), GameID: int(m.GameID)}
+		if m.DtBetLimitSelectID != nil {
+			data.DtBetLimitSelectID = make(map[string]int, len(m.DtBetLimitSelectID))
+			for k, v := range m.DtBetLimitSelectID {
+				data.DtBetLimitSelectID[k] = int(v)
+			}
+		}
+		return data, nil
+	},
+	ProtocolResume: func(b []byte) (interface{}, error) {
+		var m pb.WsResumeData
+		if err := m.Unmarshal(b); err != nil {
+			return nil, err
+		}
+		return view.WsResumeData{ResumeID: m.ResumeID, LastSeq: m.LastSeq}, nil
+	},
+	ProtocolBetting: func(b []byte) (interface{}, error) {
+		var m pb.WsBettingData
+		if err := m.Unmarshal(b); err != nil {
+			return nil, err
+		}
+		data := view.WsBettingData{
+			BetSerialNumber: int(m.BetSerialNumber),
+			GameNo:          int(m.GameNo),
+			GameNoRound:     int(m.GameNoRound),
+			Commission:      int(m.Commission),
+		}
+		for _, item := range m.BetArr {
+			data.BetArr = append(data.BetArr, view.WsBettingInfoItem{BetArea: int(item.BetArea), AddBetMoney: int(item.AddBetMoney)})
+		}
+		return data, nil
+	},
+}
+
+// encodeResp encodes resp the way framing sends it
+func encodeResp(framing Framing, resp *view.WsResp) ([]byte, error) {
+	if shared, ok := resp.Data.(*sharedData); ok && resp.Seq != 0 {
+		return encodeShared(framing, resp, shared)
+	}
+	if framing != FramingProtobuf {
+		return json.Marshal(resp)
+	}
+	frame := pb.WsFrame{
+		Protocol: int32(resp.Protocol),
+		Seq:      resp.Seq,
+	}
+	var err error
+	if shared, ok := resp.Data.(*sharedData); ok {
+		frame.Data, frame.Json, err = shared.protobuf(resp.Protocol)
+	} else {
+		frame.Data, frame.Json, err = encodeData(resp.Data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return frame.Marshal()
+}
+
+// encodeShared encodes a fanned out frame once per framing, every client then gets a copy
+// carrying its own seq: first key of the JSON object, or field 2 appended to the protobuf
+// message. Both decode as if the frame was encoded in one go.
+func encodeShared(framing Framing, resp *view.WsResp, shared *sharedData) ([]byte, error) {
+	f := &shared.frames[framing]
+	f.once.Do(func() {
+		f.b, f.err = encodeResp(framing, &view.WsResp{Protocol: resp.Protocol, Data: shared})
+	})
+	if f.err != nil {
+		return nil, f.err
+	}
+	if framing == FramingProtobuf {
+		b := make([]byte, len(f.b), len(f.b)+binary.MaxVarintLen64+1)
+		copy(b, f.b)
+		b = append(b, 0x10)
+		return binary.AppendUvarint(b, uint64(resp.Seq)), nil
+	}
+	b := make([]byte, 0, len(f.b)+24)
+	b = append(b, `{"seq":`...)
+	b = strconv.AppendInt(b, resp.Seq, 10)
+	b = append(b, ',')
+	return append(b, f.b[1:]...), nil
+}
+
+func encodeConnMessage(framing Framing, resp *ConnMessageResp) ([]byte, error) {
+	if framing != FramingProtobuf {
+		return json.Marshal(resp)
+	}
+	frame := pb.WsFrame{
+		Notice: &pb.WsConnMessage{Code: int32(resp.Code), ErrMsg: resp.ErrMsg},
+	}
+	return frame.Marshal()
+}
+
+// encodeData encodes the data of a frame as its protobuf message, data without one goes as JSON
+func encodeData(data interface{}) ([]byte, bool, error) {
+	if data == nil {
+		return nil, false, nil
+	}
+	msg, err := dataToProto(data)
+	if err != nil {
+		return nil, false, err
+	}
+	if msg == nil {
+		b, err := json.Marshal(data)
+		return b, true, err
+	}
+	b, err := msg.Marshal()
+	return b, false, err
+}
+
+// decodeReq reads a client frame, binary ones are pb.WsFrame whatever the framing
+func decodeReq(mt int, message []byte) (*view.WsReq, error) {
+	var wsReq view.WsReq
+	if mt != websocket.BinaryMessage {
+		if err := json.Unmarshal(message, &wsReq); err != nil {
+			return nil, err
+		}
+		return &wsReq, nil
+	}
+	var frame pb.WsFrame
+	if err := frame.Unmarshal(message); err != nil {
+		return nil, err
+	}
+	wsReq.Protocol = int(frame.Protocol)
+	decode, ok := reqDecoders[wsReq.Protocol]
+	if frame.Json || !ok {
+		if len(frame.Data) > 0 {
+			wsReq.Data = json.RawMessage(frame.Data)
+		}
+		return &wsReq, nil
+	}
+	data, err := decode(frame.Data)
+	if err != nil {
+		return nil, err
+	}
+	wsReq.Data = data
+	return &wsReq, nil
+}
+
+func dataToProto(data interface{}) (pbMessage, error) {
+	// handlers pass values as well as pointers
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	} else if v.Kind() != reflect.Ptr {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		data = p.Interface()
+	}
+	switch d := data.(type) {
+	case *view.AuthResp:
+		return &pb.WsAuthResp{
+			MemberID:       d.MemberID,
+			Account:        d.Account,
+			UserName:       d.UserName,
+			Sid:            d.Sid,
+			BOk:            d.BOk,
+			BValidPassword: d.BValidPassword,
+			ResumeID:       d.ResumeID,
+		}, nil
+	case *view.WsTableEntryData:
+		return tableEntryToProto(d)
+	case *view.WsLeaveTableData:
+		return &pb.WsLeaveTableData{GroupID: int32(d.GroupID), BOk: d.BOk}, nil
+	case *view.WsResumeRespData:
+		return &pb.WsResumeRespData{BOk: d.BOk, Resync: d.Resync, ResumeID: d.ResumeID, Seq: d.Seq}, nil
+	case *view.WsReconnectData:
+		return &pb.WsReconnectData{DelayMs: int32(d.DelayMs)}, nil
+	case *view.WsTableData:
+		return tableDataToProto(d), nil
+	case *view.WsBettingRespData:
+		ret := &pb.WsBettingRespData{
+			GameID:          int32(d.GameID),
+			GroupID:         int32(d.GroupID),
+			AreaID:          int32(d.AreaID),
+			AreaType:        int32(d.AreaType),
+			BOk:             d.BOk,
+			BetSerialNumber: int32(d.BetSerialNumber),
+			Balance:         d.Balance,
+		}
+		for _, item := range d.BetArr {
+			ret.BetArr = append(ret.BetArr, &pb.WsBettingResultItem{
+				BetArea:     int32(item.BetArea),
+				AddBetMoney: int32(item.AddBetMoney),
+				BOk:         item.BOk,
+				Code:        int32(item.Code),
+				Msg:         item.Msg,
+			})
+		}
+		return ret, nil
+	case *view.WsGameResultRespData:
+		return &pb.WsGameResultRespData{
+			GameID:        int32(d.GameID),
+			GroupID:       int32(d.GroupID),
+			Result:        int32(d.Result),
+			PlayerScore:   int32(d.PlayerScore),
+			BankerScore:   int32(d.BankerScore),
+			DtCard:        int32Map(d.DtCard),
+			WinBetAreaArr: int32s(d.WinBetAreaArr),
+		}, nil
+	case *view.WsPayoutResultData:
+		ret := &pb.WsPayoutResultData{
+			GameID:       int32(d.GameID),
+			GroupID:      int32(d.GroupID),
+			AreaID:       int32(d.AreaID),
+			AreaType:     int32(d.AreaType),
+			MemberID:     d.MemberID,
+			MoneyWin:     d.MoneyWin.String(),
+			MoneyWinLoss: d.MoneyWinLoss.String(),
+		}
+		if d.DtMoneyWin != nil {
+			ret.DtMoneyWin = make(map[string]string, len(d.DtMoneyWin))
+			for k, v := range d.DtMoneyWin {
+				ret.DtMoneyWin[k] = v.String()
+			}
+		}
+		return ret, nil
+	case *view.WsBetTimeData:
+		ret := &pb.WsBetTimeData{
+			GameID:          int32(d.GameID),
+			GroupID:         int32(d.GroupID),
+			GroupType:       int32(d.GroupType),
+			GameNo:          int32(d.GameNo),
+			GameNoRound:     int32(d.GameNoRound),
+			BetTimeCount:    int32(d.BetTimeCount),
+			TimeMillisecond: int32(d.TimeMillisecond),
+		}
+		if d.BetTimeContent != nil {
+			b, err := json.Marshal(d.BetTimeContent)
+			if err != nil {
+				return nil, err
+			}
+			ret.BetTimeContent = b
+		}
+		return ret, nil
+	case *view.WsBetLimitModifyData:
+		return betLimitModifyToProto(d), nil
+	case *view.WsGroupListData:
+		ret := &pb.WsGroupListData{}
+		for _, g := range d.GroupArr {
+			ret.GroupArr = append(ret.GroupArr, &pb.WsGroupInfo{
+				GroupID:         int32(g.GroupID),
+				GroupType:       int32(g.GroupType),
+				SingleLimit:     int32(g.SingleLimit),
+				TableMinBet:     int32(g.TableMinBet),
+				TableMaxBet:     int32(g.TableMaxBet),
+				TableTieMinBet:  int32(g.TableTieMinBet),
+				TableTieMaxBet:  int32(g.TableTieMaxBet),
+				TablePairMinBet: int32(g.TablePairMinBet),
+				TablePairMaxBet: int32(g.TablePairMaxBet),
+				TableStatus:     int32(g.TableStatus),
+			})
+		}
+		return ret, nil
+	}
+	return nil, nil
+}
+
+func tableEntryToProto(d *view.WsTableEntryData) (*pb.WsTableEntryData, error) {
+	ret := &pb.WsTableEntryData{
+		BOk:            d.BOk,
+		GameID:         int32(d.GameID),
+		GroupID:        int32(d.GroupID),
+		GroupType:      int32(d.GroupType),
+		AreaID:         int32(d.AreaID),
+		AreaType:       int32(d.AreaType),
+		MemberID:       d.MemberID,
+		Account:        d.Account,
+		UserName:       d.UserName,
+		HeadID:         int32(d.HeadID),
+		SeatIDArr:      int32s(d.SeatIDArr),
+		CurrencyCode:   d.CurrencyCode,
+		CurrencyName:   d.CurrencyName,
+		CurrencyRate:   d.CurrencyRate,
+		Balance:        d.Balance,
+		Chips:          d.Chips,
+		BetMilliSecond: int32(d.BetMilliSecond),
+		UserCount:      int32(d.UserCount),
+		MinBet01:       int32(d.MinBet01),
+		MaxBet01:       int32(d.MaxBet01),
+		MinBet02:       int32(d.MinBet02),
+		MaxBet02:       int32(d.MaxBet02),
+		MinBet03:       int32(d.MinBet03),
+		MaxBet03:       int32(d.MaxBet03),
+		MinBet04:       int32(d.MinBet04),
+		MaxBet04:       int32(d.MaxBet04),
+		MinBet05:       int32(d.MinBet05),
+		MaxBet05:       int32(d.MaxBet05),
+		MinBet06:       int32(d.MinBet06),
+		MaxBet06:       int32(d.MaxBet06),
+		MinBet07:       int32(d.MinBet07),
+		MaxBet07:       int32(d.MaxBet07),
+		MinBet08:       int32(d.MinBet08),
+		MaxBet08:       int32(d.MaxBet08),
+		MinBet09:       int32(d.MinBet09),
+		MaxBet09:       int32(d.MaxBet09),
+		MinBet10:       int32(d.MinBet10),
+		MaxBet10:       int32(d.MaxBet10),
+		MinBet11:       int32(d.MinBet11),
+		MaxBet11:       int32(d.MaxBet11),
+		MinBet12:       int32(d.MinBet12),
+		MaxBet12:       int32(d.MaxBet12),
+		MinBet13:       int32(d.MinBet13),
+		MaxBet13:       int32(d.MaxBet13),
+		MinBet14:       int32(d.MinBet14),
+		MaxBet14:       int32(d.MaxBet14),
+	}
+	if d.DtOdds != nil {
+		ret.DtOdds = make(map[string]*pb.WsOdds, len(d.DtOdds))
+		for k, odds := range d.DtOdds {
+			ret.DtOdds[k] = &pb.WsOdds{Odds: decimalStrings(odds)}
+		}
+	}
+	if d.DtCard != nil {
+		b, err := json.Marshal(d.DtCard)
+		if err != nil {
+			return nil, err
+		}
+		ret.DtCard = b
+	}
+	return ret, nil
+}
+
+func tableDataToProto(d *view.WsTableData) *pb.WsTableData {
+	return &pb.WsTableData{
+		GameID:                   int32(d.GameID),
+		GroupID:                  int32(d.GroupID),
+		GroupType:                int32(d.GroupType),
+		GameNo:                   int32(d.GameNo),
+		GameNoRound:              int32(d.GameNoRound),
+		DealerID:                 int32(d.DealerID),
+		DealerName:               d.DealerName,
+		DealerImage:              d.DealerImage,
+		DealerImage2:             d.DealerImage2,
+		Dealer2ID:                int32(d.Dealer2ID),
+		Dealer2Name:              d.Dealer2Name,
+		Dealer2Image:             d.Dealer2Image,
+		Dealer2Image2:            d.Dealer2Image2,
+		BetMilliSecond:           int32(d.BetMilliSecond),
+		BWantToShuffle:           d.BWantToShuffle,
+		BWantToEnd:               d.BWantToEnd,
+		KeyStatus:                int32(d.KeyStatus),
+		GameMode:                 int32(d.GameMode),
+		SingleLimit:              int32(d.SingleLimit),
+		TableMinBet:              int32(d.TableMinBet),
+		TableMaxBet:              int32(d.TableMaxBet),
+		TableTieMinBet:           int32(d.TableTieMinBet),
+		TableTieMaxBet:           int32(d.TableTieMaxBet),
+		TablePairMinBet:          int32(d.TablePairMinBet),
+		TablePairMaxBet:          int32(d.TablePairMaxBet),
+		TableType:                int32(d.TableType),
+		TableStatus:              int32(d.TableStatus),
+		TableSort:                int32(d.TableSort),
+		TableSort2:               int32(d.TableSort2),
+		ReservedTable:            int32(d.ReservedTable),
+		ReservedTableParentIDArr: int32s(d.ReservedTableParentIDArr),
+		ReservedTableMemberIDArr: int32s(d.ReservedTableMemberIDArr),
+		TableDtExtend: &pb.TableDtExtend{
+			NetGroupName:   d.TableDtExtend.NetGroupName,
+			PhoneGroupName: d.TableDtExtend.PhoneGroupName,
+			TableName:      d.TableDtExtend.TableName,
+			NetType:        d.TableDtExtend.NetType,
+			PhoneType:      d.TableDtExtend.PhoneType,
+		},
+	}
+}
+
+func betLimitModifyToProto(d *view.WsBetLimitModifyData) *pb.WsBetLimitModifyData {
+	return &pb.WsBetLimitModifyData{
+		GameID:             int32(d.GameID),
+		MemberID:           d.MemberID,
+		DtBetLimitSelectID: int32Map(d.DtBetLimitSelectID),
+		MinBet01:           int32(d.MinBet01),
+		MaxBet01:           int32(d.MaxBet01),
+		MinBet02:           int32(d.MinBet02),
+		MaxBet02:           int32(d.MaxBet02),
+		MinBet03:           int32(d.MinBet03),
+		MaxBet03:           int32(d.MaxBet03),
+		MinBet04:           int32(d.MinBet04),
+		MaxBet04:           int32(d.MaxBet04),
+		MinBet05:           int32(d.MinBet05),
+		MaxBet05:           int32(d.MaxBet05),
+		MinBet06:           int32(d.MinBet06),
+		MaxBet06:           int32(d.MaxBet06),
+		MinBet07:           int32(d.MinBet07),
+		MaxBet07:           int32(d.MaxBet07),
+		MinBet08:           int32(d.MinBet08),
+		MaxBet08:           int32(d.MaxBet08),
+		MinBet09:           int32(d.MinBet09),
+		MaxBet09:           int32(d.MaxBet09),
+		MinBet10:           int32(d.MinBet10),
+		MaxBet10:           int32(d.MaxBet10),
+		MinBet11:           int32(d.MinBet11),
+		MaxBet11:           int32(d.MaxBet11),
+		MinBet12:           int32(d.MinBet12),
+		MaxBet12:           int32(d.MaxBet12),
+		MinBet13:           int32(d.MinBet13),
+		MaxBet13:           int32(d.MaxBet13),
+		MinBet14:           int32(d.MinBet14),
+		MaxBet14:           int32(d.MaxBet14),
+	}
+}
+
+func int32s(s []int) []int32 {
+	if s == nil {
+		return nil
+	}
+	ret := make([]int32, len(s))
+	for i, v := range s {
+		ret[i] = int32(v)
+	}
+	return ret
+}
+
+func int32Map(m map[string]int) map[string]int32 {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]int32, len(m))
+	for k, v := range m {
+		ret[k] = int32(v)
+	}
+	return ret
+}
+
+func decimalStrings(s []decimal.Decimal) []string {
+	ret := make([]string, len(s))
+	for i, v := range s {
+		ret[i] = v.String()
+	}
+	return ret
+}
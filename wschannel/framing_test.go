@@ -0,0 +1,235 @@
+package wschannel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	pb "go-zrbc/pkg/protobuf"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+)
+
+func TestNegotiateFraming(t *testing.T) {
+	if f := negotiateFraming("", url.Values{}); f != FramingJSON {
+		t.Fatalf("default framing:(%d), want json", f)
+	}
+	if f := negotiateFraming(SubprotocolProtobuf, url.Values{}); f != FramingProtobuf {
+		t.Fatalf("subprotocol framing:(%d), want protobuf", f)
+	}
+	if f := negotiateFraming("", url.Values{"framing": {"protobuf"}}); f != FramingProtobuf {
+		t.Fatalf("query framing:(%d), want protobuf", f)
+	}
+	// An agreed subprotocol beats the query
+	if f := negotiateFraming(SubprotocolJSON, url.Values{"framing": {"protobuf"}}); f != FramingJSON {
+		t.Fatalf("json subprotocol framing:(%d), want json", f)
+	}
+}
+
+func TestEncodeResp_Protobuf(t *testing.T) {
+	entry := &view.WsTableEntryData{
+		BOk:      true,
+		GameID:   101,
+		GroupID:  115,
+		MemberID: 7,
+		Balance:  1000.5,
+		DtOdds:   map[string][]decimal.Decimal{"1": {decimal.RequireFromString("0.95")}},
+		DtCard:   map[string]interface{}{"1": 45},
+		MinBet01: 10,
+		MaxBet14: 5000,
+	}
+	b, err := encodeResp(FramingProtobuf, &view.WsResp{Protocol: ProtocolJoinTable, Data: entry, Seq: 3})
+	if err != nil {
+		t.Fatalf("encode err:(%+v)", err)
+	}
+	var frame pb.WsFrame
+	if err := frame.Unmarshal(b); err != nil {
+		t.Fatalf("decode frame err:(%+v)", err)
+	}
+	var got pb.WsTableEntryData
+	if err := got.Unmarshal(frame.Data); err != nil {
+		t.Fatalf("decode data err:(%+v)", err)
+	}
+	if frame.Protocol != ProtocolJoinTable || frame.Seq != 3 || frame.Json {
+		t.Fatalf("frame:(%+v)", frame)
+	}
+	if !got.BOk || got.GroupID != 115 || got.MemberID != 7 || got.Balance != 1000.5 || got.MinBet01 != 10 || got.MaxBet14 != 5000 ||
+		got.DtOdds["1"].Odds[0] != "0.95" || string(got.DtCard) != `{"1":45}` {
+		t.Fatalf("entry:(%+v)", got)
+	}
+
+	// Data without a message goes as JSON
+	b, _ = encodeResp(FramingProtobuf, &view.WsResp{Protocol: ProtocolHeartbeat, Data: struct{}{}})
+	frame = pb.WsFrame{}
+	frame.Unmarshal(b)
+	if !frame.Json || string(frame.Data) != "{}" {
+		t.Fatalf("heartbeat frame:(%+v)", frame)
+	}
+
+	b, _ = encodeConnMessage(FramingProtobuf, NewConnResp(ErrCodeDuplicateLogin))
+	frame = pb.WsFrame{}
+	frame.Unmarshal(b)
+	if frame.Notice == nil || frame.Notice.Code != int32(ErrCodeDuplicateLogin) {
+		t.Fatalf("notice frame:(%+v)", frame)
+	}
+}
+
+func TestSharedData_Protobuf(t *testing.T) {
+	raw := `{"protocol":25,"data":{"gameID":101,"groupID":115,"result":193,"dtCard":{"1":45},"winBetAreaArr":[1,7]}}`
+	resp, err := decodeFrame([]byte(raw))
+	if err != nil {
+		t.Fatalf("decode err:(%+v)", err)
+	}
+	js, _ := encodeResp(FramingJSON, resp)
+	var back view.WsGameResultResp
+	if err := json.Unmarshal(js, &back); err != nil || back.Data.Result != 193 {
+		t.Fatalf("json frame %s err:(%+v)", js, err)
+	}
+
+	b, err := encodeResp(FramingProtobuf, resp)
+	if err != nil {
+		t.Fatalf("encode err:(%+v)", err)
+	}
+	var frame pb.WsFrame
+	frame.Unmarshal(b)
+	var got pb.WsGameResultRespData
+	if err := got.Unmarshal(frame.Data); err != nil {
+		t.Fatalf("decode data err:(%+v)", err)
+	}
+	if got.Result != 193 || got.DtCard["1"] != 45 || len(got.WinBetAreaArr) != 2 {
+		t.Fatalf("game result:(%+v)", got)
+	}
+}
+
+func TestEncodeShared(t *testing.T) {
+	raw := `{"protocol":25,"data":{"gameID":101,"groupID":115,"result":193,"dtCard":{"1":45},"winBetAreaArr":[1,7]}}`
+	frame, _ := decodeFrame([]byte(raw))
+	for _, framing := range []Framing{FramingJSON, FramingProtobuf} {
+		for _, seq := range []int64{1, 300, 1 << 40} {
+			resp := *frame
+			resp.Seq = seq
+			b, err := encodeResp(framing, &resp)
+			if err != nil {
+				t.Fatalf("encode err:(%+v)", err)
+			}
+			// the same frame encoded in one go, only the seq tells them apart
+			direct := view.WsResp{Protocol: resp.Protocol, Data: &sharedData{raw: frame.Data.(*sharedData).raw}}
+			want, _ := encodeResp(framing, &direct)
+			if framing == FramingJSON {
+				var got, exp view.WsResp
+				json.Unmarshal(b, &got)
+				json.Unmarshal(want, &exp)
+				exp.Seq = seq
+				if got.Seq != seq || fmt.Sprint(got) != fmt.Sprint(exp) {
+					t.Fatalf("json frame %s, want %s", b, want)
+				}
+				continue
+			}
+			var got, exp pb.WsFrame
+			if err := got.Unmarshal(b); err != nil {
+				t.Fatalf("decode frame err:(%+v)", err)
+			}
+			exp.Unmarshal(want)
+			if got.Seq != seq || exp.Seq != 0 || got.Protocol != exp.Protocol || got.Json != exp.Json || string(got.Data) != string(exp.Data) {
+				t.Fatalf("protobuf frame:(%+v), want:(%+v)", got, exp)
+			}
+		}
+	}
+}
+
+func TestDecodeReq_Binary(t *testing.T) {
+	bet := pb.WsBettingData{BetSerialNumber: 9, GameNo: 3, BetArr: []*pb.WsBettingInfoItem{{BetArea: 1, AddBetMoney: 100}}}
+	data, _ := bet.Marshal()
+	frame := pb.WsFrame{Protocol: ProtocolBetting, Data: data}
+	msg, _ := frame.Marshal()
+
+	wsReq, err := decodeReq(websocket.BinaryMessage, msg)
+	if err != nil {
+		t.Fatalf("decode err:(%+v)", err)
+	}
+	got, ok := wsReq.Data.(view.WsBettingData)
+	if wsReq.Protocol != ProtocolBetting || !ok || got.BetSerialNumber != 9 || len(got.BetArr) != 1 || got.BetArr[0].AddBetMoney != 100 {
+		t.Fatalf("betting req:(%+v)", wsReq)
+	}
+
+	// Protocols without a message carry their JSON
+	frame = pb.WsFrame{Protocol: ProtocolIgnoreGame, Data: []byte(`{"gameID":101}`), Json: true}
+	msg, _ = frame.Marshal()
+	wsReq, err = decodeReq(websocket.BinaryMessage, msg)
+	if err != nil {
+		t.Fatalf("decode json err:(%+v)", err)
+	}
+	if raw, ok := wsReq.Data.(json.RawMessage); !ok || string(raw) != `{"gameID":101}` {
+		t.Fatalf("json req:(%+v)", wsReq)
+	}
+
+	if _, err := decodeReq(websocket.BinaryMessage, []byte{0xff, 0xff}); err == nil {
+		t.Fatalf("garbage frame decoded")
+	}
+}
+
+func benchTableData() []byte {
+	data := view.WsTableData{
+		GameID: 101, GroupID: 115, GroupType: 1, GameNo: 240101, GameNoRound: 37,
+		DealerID: 12, DealerName: "Alice", DealerImage: "dealer/12.png", DealerImage2: "dealer/12_2.png",
+		BetMilliSecond: 15000, KeyStatus: 1, GameMode: 1, SingleLimit: 50000,
+		TableMinBet: 10, TableMaxBet: 50000, TableTieMinBet: 10, TableTieMaxBet: 5000,
+		TablePairMinBet: 10, TablePairMaxBet: 5000, TableType: 1, TableStatus: 1, TableSort: 3,
+		ReservedTableParentIDArr: []int{1, 2, 3},
+		TableDtExtend:            view.TableDtExtend{NetGroupName: "B01", PhoneGroupName: "B01", TableName: "百家乐01", NetType: "A", PhoneType: "A"},
+	}
+	b, _ := json.Marshal(view.WsResp{Protocol: ProtocolTableData, Data: data})
+	return b
+}
+
+// BenchmarkBroadcast fans one table data frame out to a room of 1,000 members, wire-B/op is
+// what all of them receive together
+func BenchmarkBroadcast(b *testing.B) {
+	// the per client debug log would dominate
+	level := xlog.LogLevel
+	xlog.LogLevel = "info"
+	defer func() { xlog.LogLevel = level }()
+
+	msg := benchTableData()
+	for _, framing := range []Framing{FramingJSON, FramingProtobuf} {
+		name := map[Framing]string{FramingJSON: "json", FramingProtobuf: "protobuf"}[framing]
+		b.Run(name, func(b *testing.B) {
+			srv := newTestServer()
+			srv.maxUserInWys = 2000
+			var room *Room
+			clients := make([]*Client, 1000)
+			for i := range clients {
+				connID := fmt.Sprintf("c%d", i)
+				cli := &Client{
+					connID:    connID,
+					mgr:       srv,
+					bytesSend: make(chan []byte, 1),
+					done:      make(chan struct{}),
+					framing:   framing,
+					logger:    &Logger{ServiceID: "ws-channel", UUID: connID},
+				}
+				srv.AddClient(cli)
+				srv.BindUser(cli, &view.WsUser{ID: int64(i + 1)})
+				room, _ = srv.JoinRoom(cli, "115", 0, nil)
+				clients[i] = cli
+			}
+
+			var wire int64
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				room.broadcast(msg)
+				b.StopTimer()
+				for _, cli := range clients {
+					wire += int64(len(<-cli.bytesSend))
+				}
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(wire)/float64(b.N), "wire-B/op")
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package wschannel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-zrbc/config"
+	"go-zrbc/pkg/mq"
+	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ConsumeGameEvents feeds the configured game event topics into rooms and user connections.
+// Nodes share one consumer group, each event is read once and fanned out over the bus.
+func (srv *Server) ConsumeGameEvents(ctx context.Context) {
+	cfg := config.Global.Kafka
+	if len(cfg.Brokers) == 0 {
+		xlog.Info("kafka brokers not set, game events disabled")
+		return
+	}
+	topics := cfg.GameEventTopics
+	if len(topics) == 0 {
+		topics = []string{mq.TopicGameEvent}
+	}
+	groupID := cfg.GroupID
+	if groupID == "" {
+		groupID = mq.SharedGroupID
+	}
+	var replayFrom time.Time
+	if cfg.ReplayFrom > 0 {
+		replayFrom = time.Unix(cfg.ReplayFrom, 0)
+		xlog.Infof("replay game events from %s", replayFrom)
+	}
+
+	consumer := mq.NewConsumer(cfg.Brokers, topics, groupID, replayFrom)
+	err := consumer.Run(ctx, func(ctx context.Context, msg kafka.Message) error {
+		return srv.RouteGameEvent(ctx, msg.Value)
+	})
+	if err != nil {
+		xlog.Errorf("error to consume game events, topics:%v, err:%+v", topics, err)
+	}
+}
+
+// RouteGameEvent delivers one event, encoded as the client protocol message, to the room of
+// its table (21 桌况, 38 下注倒数, 25 开奖) or to the member it pays (31 派彩)
+func (srv *Server) RouteGameEvent(ctx context.Context, value []byte) error {
+	var event struct {
+		Protocol int             `json:"protocol"`
+		Data     json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(value, &event); err != nil {
+		return err
+	}
+	var target struct {
+		GroupID  int   `json:"groupID"`
+		MemberID int64 `json:"memberID"`
+	}
+	if err := json.Unmarshal(event.Data, &target); err != nil {
+		return err
+	}
+
+	msg := &view.WsResp{Protocol: event.Protocol, Data: event.Data}
+	switch event.Protocol {
+	case ProtocolTableData, ProtocolBetTime, ProtocolGameResult:
+		if target.GroupID <= 0 {
+			return fmt.Errorf("game event %d without groupID", event.Protocol)
+		}
+		return srv.BroadcastToRoom(ctx, strconv.Itoa(target.GroupID), msg)
+	case ProtocolPayout:
+		if target.MemberID <= 0 {
+			return fmt.Errorf("game event %d without memberID", event.Protocol)
+		}
+		return srv.SendToUser(ctx, target.MemberID, msg)
+	default:
+		return fmt.Errorf("unknown game event protocol %d", event.Protocol)
+	}
+}
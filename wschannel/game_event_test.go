@@ -0,0 +1,52 @@
+package wschannel
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go-zrbc/view"
+)
+
+func TestServer_RouteGameEvent(t *testing.T) {
+	srv := newTestServer()
+	ctx := context.Background()
+	atTable := newUserClient(srv, "a1", 1)
+	elsewhere := newUserClient(srv, "a2", 2)
+	srv.JoinRoom(atTable, "115", 0, nil)
+	srv.JoinRoom(elsewhere, "116", 0, nil)
+
+	result := `{"protocol":25,"data":{"gameID":101,"groupID":115,"result":193,"winBetAreaArr":[1,7,8,13]}}`
+	if err := srv.RouteGameEvent(ctx, []byte(result)); err != nil {
+		t.Fatalf("route result err:(%+v)", err)
+	}
+	var got view.WsGameResultResp
+	if err := json.Unmarshal(<-atTable.bytesSend, &got); err != nil {
+		t.Fatalf("decode result err:(%+v)", err)
+	}
+	if got.Protocol != 25 || got.Data.GroupID != 115 || len(got.Data.WinBetAreaArr) != 4 {
+		t.Fatalf("result:(%+v)", got)
+	}
+	if len(elsewhere.bytesSend) != 0 {
+		t.Fatalf("result leaked to another table")
+	}
+
+	payout := `{"protocol":31,"data":{"gameID":101,"groupID":116,"memberID":2,"moneyWin":195}}`
+	if err := srv.RouteGameEvent(ctx, []byte(payout)); err != nil {
+		t.Fatalf("route payout err:(%+v)", err)
+	}
+	if recvProtocol(t, elsewhere) != 31 || len(atTable.bytesSend) != 0 {
+		t.Fatalf("payout not delivered to its member only")
+	}
+
+	for _, bad := range []string{
+		`{"protocol":21,"data":{"gameID":101}}`,
+		`{"protocol":31,"data":{"groupID":116}}`,
+		`{"protocol":77,"data":{"groupID":116}}`,
+		`not json`,
+	} {
+		if err := srv.RouteGameEvent(ctx, []byte(bad)); err == nil {
+			t.Fatalf("event %s routed, want an error", bad)
+		}
+	}
+}
@@ -7,17 +7,15 @@ import (
 	"go-zrbc/config"
 	"log"
 	"net/http"
-	"os"
-	"os/signal"
 	"sync"
 	"sync/atomic"
-	"syscall"
 
 	bhttp "go-zrbc/pkg/http/handler"
 
 	"go-zrbc/pkg/http/middleware"
 
 	"go-zrbc/pkg/xlog"
+	"go-zrbc/view"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
@@ -29,13 +27,16 @@ import (
 )
 
 const (
-	UniversalToken = "UNIVERSAL_WS_TOKEN_123456" // Universal token for testing/development
+	UniversalToken = "UNIVERSAL_WS_TOKEN_123456" // Universal token for testing/development, sid of any account in ws_dev_mode
 )
 
 type Server struct {
 	addr string
 	sync.RWMutex
 	closeCh chan struct{}
+	// set once Drain started, drainDone is closed when it finished
+	draining  int32
+	drainDone chan struct{}
 
 	maxUserInWys int64
 	totalConns   int64
@@ -43,10 +44,15 @@ type Server struct {
 	rooms map[string]*Room
 
 	clients map[string]*Client
+	// userID: connID: client
+	users map[int64]map[string]*Client
+	// resumeID: stream, kept resumeTTL after its connection left
+	streams map[string]*replayStream
 
 	webService  webSrv.WebService
 	userService pubSrv.PublicApiService
 	redisCli    *redis.Client
+	bus         *Bus
 }
 
 func NewWsServer(addr string, webService webSrv.WebService,
@@ -64,12 +70,16 @@ func NewWsServer(addr string, webService webSrv.WebService,
 		// 暂时没用，看后续有没有用桌台号（groupID)建房间
 		rooms:   make(map[string]*Room, 0),
 		clients: make(map[string]*Client, 0),
+		users:   make(map[int64]map[string]*Client),
+		streams: make(map[string]*replayStream),
 		closeCh: make(chan struct{}),
 
+		drainDone:   make(chan struct{}),
 		webService:  webService,
 		userService: userService,
 		redisCli:    redisCli,
 	}
+	srv.bus = NewBus(srv, redisCli)
 	return srv
 }
 
@@ -90,18 +100,23 @@ func (srv *Server) Run() {
 	// 大厅长连接服务
 	r.GET("/15109", func(c *gin.Context) { srv.EnterLobby(c) })
 	r.GET("/stats", func(c *gin.Context) { srv.LobbyStats(c) })
+	// 部署下线前由此或SIGTERM排空连接
+	r.POST("/admin/drain", middleware.AdminAuth, srv.AdminDrain)
+
+	busCtx, cancelBus := context.WithCancel(context.Background())
+	defer cancelBus()
+	// before serving, clients must never see the bus change
+	srv.startBus(busCtx)
 
 	// go bib.Init()
 	go r.Run(srv.addr)
 
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
-	select {
-	case s := <-ch:
-		xlog.Infof("receive interrupt signal(%v), close ws-channel manager", s)
-		srv.Close()
-		xlog.Infof("ws-channel manager has been closed successful")
-	}
+	go srv.ConsumeGameEvents(busCtx)
+	go srv.runStreamSweeper(busCtx)
+
+	// closed by Drain, on SIGTERM or /admin/drain
+	<-srv.closeCh
+	xlog.Infof("ws-channel manager has been closed successful")
 }
 
 func (srv *Server) Close() {
@@ -135,6 +150,9 @@ func (srv *Server) ServeHome(c *gin.Context) {
 func (srv *Server) EnterLobby(c *gin.Context) {
 	xlog.Infof("<====> enter wys. req header upgrade(%v)\n", c.Request.Header.Get("Upgrade"))
 
+	if srv.refuseDraining(c) {
+		return
+	}
 	cli := NewClient(c, srv)
 	cli.Register()
 }
@@ -147,9 +165,22 @@ type JsonResult struct {
 func (srv *Server) LobbyStats(c *gin.Context) {
 	rid := c.Query("rid")
 	if rid == "" {
+		var cluster *ClusterStats
+		if srv.bus != nil {
+			var err error
+			cluster, err = srv.bus.ClusterStats(c.Request.Context())
+			if err != nil {
+				xlog.Errorf("error to get ws cluster stats, err:%+v", err)
+			}
+		}
 		srv.RLock()
 		defer srv.RUnlock()
-		statsMap := map[string]interface{}{"rooms": srv.rooms}
+		statsMap := map[string]interface{}{
+			"rooms":       srv.rooms,
+			"total_conns": srv.TotalConns(),
+			"cluster":     cluster,
+			"draining":    srv.Draining(),
+		}
 
 		msg, _ := json.Marshal(JsonResult{Code: 200, StatsData: statsMap})
 		c.Writer.Write(msg)
@@ -188,8 +219,61 @@ func (srv *Server) GetOrCreateRoom(ctx context.Context, vid string, rType int) (
 	return r, nil
 }
 
+// JoinRoom moves cli into the room roomID, creating it on first entry
+func (srv *Server) JoinRoom(cli *Client, roomID string, rType int, table *view.WsTableEntryReq) (*Room, error) {
+	srv.Lock()
+	defer srv.Unlock()
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	srv.leaveRoom(cli)
+
+	r, ok := srv.rooms[roomID]
+	if !ok {
+		r = NewRoom(roomID, rType)
+		srv.rooms[roomID] = r
+	}
+	if err := r.AddClient(cli); err != nil {
+		if r.TotalClients() == 0 {
+			delete(srv.rooms, roomID)
+		}
+		if cli.stream != nil {
+			cli.stream.setTable(nil)
+		}
+		return nil, err
+	}
+	cli.Room, cli.table = r, table
+	// kept on the stream for a resume, leaving on disconnect does not clear it
+	if cli.stream != nil {
+		cli.stream.setTable(table)
+	}
+	return r, nil
+}
+
+// LeaveRoom takes cli out of its room and drops the room once it is empty, it returns the room
+// left, nil when cli was in none
+func (srv *Server) LeaveRoom(cli *Client) *Room {
+	srv.Lock()
+	defer srv.Unlock()
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	return srv.leaveRoom(cli)
+}
+
+// leaveRoom is LeaveRoom with srv and cli locked
+func (srv *Server) leaveRoom(cli *Client) *Room {
+	r := cli.Room
+	if r == nil {
+		return nil
+	}
+	cli.Room, cli.table = nil, nil
+	if r.RemoveClient(cli) == 0 && srv.rooms[r.ID] == r {
+		delete(srv.rooms, r.ID)
+	}
+	return r
+}
+
 func (srv *Server) AddClient(cli *Client) error {
-	// todo 检查用户是否已经登录, 目前建立连接时没有用户信息，无法检查，auth的时候再检查，详细见MapUIDAndConn
+	// 建立连接时没有用户信息, 重复登入在auth时由BindUser与单一登入策略处理
 	// local user number limit
 	total := srv.Incr()
 	if total > srv.maxUserInWys {
@@ -202,6 +286,10 @@ func (srv *Server) AddClient(cli *Client) error {
 	return nil
 }
 
+func (srv *Server) TotalConns() int64 {
+	return atomic.LoadInt64(&srv.totalConns)
+}
+
 func (srv *Server) Desc() int64 {
 	return int64(atomic.AddInt64(&srv.totalConns, -1))
 }
@@ -215,4 +303,5 @@ func (srv *Server) RemoveClient(cli *Client) {
 	defer srv.Unlock()
 
 	delete(srv.clients, cli.ConnID())
+	srv.unbindUser(cli)
 }
@@ -0,0 +1,141 @@
+package wschannel
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"go-zrbc/config"
+	"go-zrbc/view"
+)
+
+func newTestServer() *Server {
+	return &Server{
+		maxUserInWys: 100,
+		rooms:        make(map[string]*Room),
+		clients:      make(map[string]*Client),
+		users:        make(map[int64]map[string]*Client),
+		streams:      make(map[string]*replayStream),
+		closeCh:      make(chan struct{}),
+		drainDone:    make(chan struct{}),
+	}
+}
+
+func TestServer_JoinLeaveRoom(t *testing.T) {
+	srv := newTestServer()
+	a := &Client{connID: "a", mgr: srv}
+	b := &Client{connID: "b", mgr: srv}
+
+	if _, err := srv.JoinRoom(a, "101", 0, nil); err != nil {
+		t.Fatalf("join err:(%+v)", err)
+	}
+	room, err := srv.JoinRoom(b, "101", 0, nil)
+	if err != nil {
+		t.Fatalf("join err:(%+v)", err)
+	}
+	if room.TotalClients() != 2 || room.Total != 2 {
+		t.Fatalf("room clients:(%d) total:(%d), want 2", room.TotalClients(), room.Total)
+	}
+
+	// Switching tables leaves the previous room first
+	if _, err := srv.JoinRoom(a, "102", 0, nil); err != nil {
+		t.Fatalf("switch err:(%+v)", err)
+	}
+	if room.TotalClients() != 1 || a.Room.ID != "102" {
+		t.Fatalf("after switch room 101 clients:(%d), a in:(%s)", room.TotalClients(), a.Room.ID)
+	}
+
+	srv.LeaveRoom(a)
+	srv.LeaveRoom(b)
+	srv.LeaveRoom(b)
+	if len(srv.rooms) != 0 || a.Room != nil {
+		t.Fatalf("rooms after everyone left:(%+v)", srv.rooms)
+	}
+}
+
+func TestServer_JoinLeaveRoomConcurrent(t *testing.T) {
+	srv := newTestServer()
+	a := &Client{connID: "a", mgr: srv}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			table := &view.WsTableEntryReq{GroupID: 100 + i}
+			srv.JoinRoom(a, strconv.Itoa(table.GroupID), 0, table)
+		}(i)
+		go func() {
+			defer wg.Done()
+			srv.LeaveRoom(a)
+			// room and table always change together
+			if r, table := a.joined(); (r == nil) != (table == nil) || (r != nil && r.ID != strconv.Itoa(table.GroupID)) {
+				t.Errorf("client at room:(%+v) table:(%+v)", r, table)
+			}
+		}()
+	}
+	wg.Wait()
+
+	srv.LeaveRoom(a)
+	if len(srv.rooms) != 0 {
+		t.Fatalf("rooms after a left:(%+v)", srv.rooms)
+	}
+}
+
+func TestServer_JoinRoomFull(t *testing.T) {
+	srv := newTestServer()
+	a := &Client{connID: "a", mgr: srv}
+	room, err := srv.JoinRoom(a, "101", 0, nil)
+	if err != nil {
+		t.Fatalf("join err:(%+v)", err)
+	}
+	room.MaxUserInRoom = 1
+
+	b := &Client{connID: "b", mgr: srv}
+	if _, err := srv.JoinRoom(b, "101", 0, nil); err != ErrRoomFull {
+		t.Fatalf("join full room err:(%+v), want ErrRoomFull", err)
+	}
+	if room.Total != 1 || b.Room != nil {
+		t.Fatalf("full room total:(%d), b room:(%+v)", room.Total, b.Room)
+	}
+}
+
+func TestServer_BindUser(t *testing.T) {
+	srv := newTestServer()
+	a := &Client{connID: "a", mgr: srv}
+	b := &Client{connID: "b", mgr: srv}
+	srv.AddClient(a)
+	srv.AddClient(b)
+	srv.BindUser(a, &view.WsUser{ID: 1})
+	srv.BindUser(b, &view.WsUser{ID: 1})
+	if got := srv.userClients(1); len(got) != 2 {
+		t.Fatalf("user 1 clients:(%d), want 2", len(got))
+	}
+
+	// Re-auth as another member moves the connection
+	srv.BindUser(b, &view.WsUser{ID: 2})
+	if len(srv.userClients(1)) != 1 || len(srv.userClients(2)) != 1 {
+		t.Fatalf("index after re-auth:(%+v)", srv.users)
+	}
+	srv.RemoveClient(a)
+	srv.RemoveClient(b)
+	if len(srv.users) != 0 {
+		t.Fatalf("index after disconnect:(%+v)", srv.users)
+	}
+}
+
+func TestSingleSession(t *testing.T) {
+	policies := []config.WsSessionPolicy{
+		{AgentID: 0, SingleSession: false},
+		{AgentID: 9, SingleSession: true},
+	}
+	if !singleSession(nil, 9) {
+		t.Fatalf("no policy, want single session")
+	}
+	if singleSession(policies, 8) {
+		t.Fatalf("agent 8 follows the default, want multiple sessions")
+	}
+	if !singleSession(policies, 9) {
+		t.Fatalf("agent 9 policy, want single session")
+	}
+}
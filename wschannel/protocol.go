@@ -0,0 +1,18 @@
+package wschannel
+
+// 大厅长连接协议号, 与旧版客户端一致
+const (
+	ProtocolAuth       = 0   // 登录验证
+	ProtocolJoinTable  = 10  // 进入桌台
+	ProtocolLeaveTable = 11  // 离开桌台
+	ProtocolResume     = 12  // 断线续传
+	ProtocolReconnect  = 13  // 节点下线, 请客户端重连
+	ProtocolTableData  = 21  // 桌况
+	ProtocolBetting    = 22  // 下注
+	ProtocolGameResult = 25  // 开奖结果
+	ProtocolPayout     = 31  // 派彩
+	ProtocolGroupList  = 35  // 桌台列表
+	ProtocolBetTime    = 38  // 下注倒数
+	ProtocolIgnoreGame = 115 // 不接受指定游戏资料
+	ProtocolHeartbeat  = 999 // 心跳
+)
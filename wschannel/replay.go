@@ -0,0 +1,304 @@
+package wschannel
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-zrbc/view"
+)
+
+const (
+	// frames kept per stream for resume, at most replayBufferBytes of them
+	replayBufferSize  = 256
+	replayBufferBytes = 64 << 10
+	// how long a stream outlives its connection waiting for a resume
+	resumeTTL = 2 * time.Minute
+)
+
+type replayFrame struct {
+	seq int64
+	b   []byte
+}
+
+// replayStream numbers the frames sent on a connection and keeps the latest ones, so a client
+// reconnecting with its resume id gets the frames it missed
+type replayStream struct {
+	sync.Mutex
+	id    string
+	owner int64
+	// frames are kept encoded, a resume needs the same framing
+	framing Framing
+	seq     int64
+	// oldest first, at most replayBufferSize taking size bytes
+	frames []replayFrame
+	size   int
+	// table the member sat at, taken back on resume
+	table *view.WsTableEntryReq
+	// client writing to the stream, nil once it disconnected at detachedAt
+	client     *Client
+	detachedAt time.Time
+}
+
+func newReplayStream(id string, owner int64, framing Framing) *replayStream {
+	return &replayStream{
+		id:      id,
+		owner:   owner,
+		framing: framing,
+	}
+}
+
+// stamp gives resp the next seq and keeps its encoding, st must be locked
+func (st *replayStream) stamp(resp *view.WsResp) ([]byte, error) {
+	resp.Seq = st.seq + 1
+	b, err := encodeResp(st.framing, resp)
+	if err != nil {
+		return nil, err
+	}
+	st.seq++
+	st.frames = append(st.frames, replayFrame{seq: st.seq, b: b})
+	st.size += len(b)
+	drop := 0
+	for len(st.frames)-drop > replayBufferSize || (st.size > replayBufferBytes && len(st.frames)-drop > 1) {
+		st.size -= len(st.frames[drop].b)
+		drop++
+	}
+	if drop > 0 {
+		n := copy(st.frames, st.frames[drop:])
+		clear(st.frames[n:])
+		st.frames = st.frames[:n]
+	}
+	return b, nil
+}
+
+func (st *replayStream) setTable(table *view.WsTableEntryReq) {
+	st.Lock()
+	defer st.Unlock()
+	st.table = table
+}
+
+// since returns the frames after lastSeq, false when some of them are no longer kept. st must be locked.
+func (st *replayStream) since(lastSeq int64) ([][]byte, bool) {
+	if lastSeq > st.seq || lastSeq < 0 {
+		return nil, false
+	}
+	if lastSeq == st.seq {
+		return nil, true
+	}
+	if len(st.frames) == 0 || st.frames[0].seq > lastSeq+1 {
+		return nil, false
+	}
+	var ret [][]byte
+	for _, f := range st.frames {
+		if f.seq > lastSeq {
+			ret = append(ret, f.b)
+		}
+	}
+	return ret, true
+}
+
+// Send numbers resp on the client's stream and queues it. A frame that does not fit the send queue
+// is dropped here but stays in the stream, the client sees the gap and resumes.
+func (cli *Client) Send(resp *view.WsResp) {
+	st := cli.replay()
+	if st == nil {
+		cli.sendRaw(resp)
+		return
+	}
+	st.Lock()
+	defer st.Unlock()
+	b, err := st.stamp(resp)
+	if err != nil {
+		cli.logger.Errorf("client(%s) encode frame err, protocol:%d, err:(%+v)", cli, resp.Protocol, err)
+		return
+	}
+	cli.enqueue(b)
+}
+
+// sendRaw queues resp without a seq
+func (cli *Client) sendRaw(resp *view.WsResp) {
+	b, err := encodeResp(cli.framing, resp)
+	if err != nil {
+		cli.logger.Errorf("client(%s) encode frame err, protocol:%d, err:(%+v)", cli, resp.Protocol, err)
+		return
+	}
+	cli.enqueue(b)
+}
+
+// sendFrame sends a frame fanned out to many clients, decoded once by decodeFrame.
+// Frames that failed to decode go out as they are.
+func (cli *Client) sendFrame(frame *view.WsResp, msg []byte, decodeErr error) {
+	if decodeErr != nil {
+		cli.enqueue(msg)
+		return
+	}
+	resp := *frame
+	cli.Send(&resp)
+}
+
+// replay returns the stream of the client, nil until a user is bound
+func (cli *Client) replay() *replayStream {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	return cli.stream
+}
+
+// setStream must be called with srv locked
+func (cli *Client) setStream(st *replayStream) {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	cli.stream = st
+}
+
+// attachStream opens a stream for the connection of a freshly bound user, srv must be locked
+func (srv *Server) attachStream(cli *Client) {
+	if cli.stream != nil {
+		srv.detachStream(cli)
+	}
+	st := newReplayStream(cli.connID, cli.User.ID, cli.framing)
+	st.client = cli
+	srv.streams[st.id] = st
+	cli.setStream(st)
+}
+
+// detachStream keeps the stream of a leaving client for resumeTTL, srv must be locked
+func (srv *Server) detachStream(cli *Client) {
+	st := cli.stream
+	if st == nil {
+		return
+	}
+	st.Lock()
+	if st.client == cli {
+		st.client = nil
+		st.detachedAt = time.Now()
+	}
+	st.Unlock()
+	cli.setStream(nil)
+}
+
+// TakeStream moves the stream resumeID of the same member and framing onto cli, nil if it is gone
+func (srv *Server) TakeStream(cli *Client, resumeID string) *replayStream {
+	srv.Lock()
+	defer srv.Unlock()
+	st, ok := srv.streams[resumeID]
+	if !ok || cli.User == nil || st.owner != cli.User.ID || st.framing != cli.framing {
+		return nil
+	}
+	if st == cli.stream {
+		return st
+	}
+	if own := cli.stream; own != nil {
+		delete(srv.streams, own.id)
+	}
+	st.Lock()
+	prev := st.client
+	st.client = cli
+	st.detachedAt = time.Time{}
+	st.Unlock()
+	if prev != nil && prev != cli {
+		prev.setStream(nil)
+	}
+	cli.setStream(st)
+	return st
+}
+
+// sweepStreams drops the streams nobody resumed within resumeTTL
+func (srv *Server) sweepStreams(now time.Time) {
+	srv.Lock()
+	defer srv.Unlock()
+	for id, st := range srv.streams {
+		st.Lock()
+		expired := st.client == nil && now.Sub(st.detachedAt) > resumeTTL
+		st.Unlock()
+		if expired {
+			delete(srv.streams, id)
+		}
+	}
+}
+
+func (srv *Server) runStreamSweeper(ctx context.Context) {
+	ticker := time.NewTicker(resumeTTL / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			srv.sweepStreams(now)
+		}
+	}
+}
+
+func (cli *Client) HandlerResumeReq(wsReq *view.WsReq) error {
+	if cli.User == nil {
+		cli.Response(RespUserOffline)
+		return nil
+	}
+	var rd view.WsResumeData
+	bb, _ := json.Marshal(wsReq.Data)
+	if err := json.Unmarshal(bb, &rd); err != nil {
+		cli.logger.Errorf("HandlerResumeReq data err, wsReq:%+v, err:(%+v)", wsReq, err)
+		return err
+	}
+
+	resp := view.WsResp{
+		Protocol: wsReq.Protocol,
+	}
+	st := cli.mgr.TakeStream(cli, rd.ResumeID)
+	if st == nil {
+		data := view.WsResumeRespData{Resync: true}
+		if own := cli.replay(); own != nil {
+			data.ResumeID = own.id
+		}
+		resp.Data = data
+		cli.sendRaw(&resp)
+		return nil
+	}
+
+	st.Lock()
+	frames, ok := st.since(rd.LastSeq)
+	// the answer and the frames must all fit the queue, a resync beats a replay with holes
+	if ok && len(frames)+1 > cap(cli.bytesSend)-len(cli.bytesSend) {
+		frames, ok = nil, false
+	}
+	resp.Data = view.WsResumeRespData{
+		BOk:      ok,
+		Resync:   !ok,
+		ResumeID: st.id,
+		Seq:      st.seq,
+	}
+	cli.sendRaw(&resp)
+	for _, b := range frames {
+		if !cli.enqueue(b) {
+			resp.Data = view.WsResumeRespData{Resync: true, ResumeID: st.id, Seq: st.seq}
+			cli.sendRaw(&resp)
+			break
+		}
+	}
+	table := st.table
+	st.Unlock()
+
+	// back to the table the member sat at, after the replay so room frames come in order
+	if r, _ := cli.joined(); r == nil && table != nil {
+		if _, err := cli.mgr.JoinRoom(cli, strconv.Itoa(table.GroupID), 0, table); err != nil {
+			cli.logger.Errorf("HandlerResumeReq rejoin table err, group:%d, err:(%+v)", table.GroupID, err)
+			resp.Data = view.WsResumeRespData{Resync: true, ResumeID: st.id}
+			cli.sendRaw(&resp)
+		}
+	}
+	return nil
+}
+
+// decodeFrame reads a marshalled WsResp keeping its data as is, so every client encodes it cheaply
+func decodeFrame(b []byte) (*view.WsResp, error) {
+	var frame struct {
+		Protocol int             `json:"protocol"`
+		Data     json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &frame); err != nil {
+		return nil, err
+	}
+	return &view.WsResp{Protocol: frame.Protocol, Data: &sharedData{raw: frame.Data}}, nil
+}
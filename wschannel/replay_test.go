@@ -0,0 +1,199 @@
+package wschannel
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-zrbc/view"
+)
+
+func recvResp(t *testing.T, cli *Client, data interface{}) *view.WsResp {
+	select {
+	case b := <-cli.bytesSend:
+		resp := view.WsResp{Data: data}
+		if err := json.Unmarshal(b, &resp); err != nil {
+			t.Fatalf("decode %s err:(%+v)", b, err)
+		}
+		return &resp
+	case <-time.After(2 * time.Second):
+		t.Fatalf("client %s got nothing", cli.connID)
+	}
+	return nil
+}
+
+func TestReplayStream_Since(t *testing.T) {
+	st := newReplayStream("s", 1, FramingJSON)
+	for i := 0; i < replayBufferSize+10; i++ {
+		st.stamp(&view.WsResp{Protocol: ProtocolTableData})
+	}
+	if st.seq != replayBufferSize+10 || len(st.frames) != replayBufferSize || st.frames[0].seq != 11 {
+		t.Fatalf("seq:(%d) frames:(%d) oldest:(%d)", st.seq, len(st.frames), st.frames[0].seq)
+	}
+
+	frames, ok := st.since(st.seq - 2)
+	if !ok || len(frames) != 2 {
+		t.Fatalf("gap of 2 got:(%d) ok:(%t)", len(frames), ok)
+	}
+	var last view.WsResp
+	json.Unmarshal(frames[1], &last)
+	if last.Seq != st.seq {
+		t.Fatalf("last replayed seq:(%d), want:(%d)", last.Seq, st.seq)
+	}
+	if frames, ok := st.since(st.seq); !ok || len(frames) != 0 {
+		t.Fatalf("up to date got:(%d) ok:(%t)", len(frames), ok)
+	}
+	// The oldest kept frame is 11, so a client at 10 can still catch up but one at 9 cannot
+	if _, ok := st.since(10); !ok {
+		t.Fatalf("gap starting at the oldest frame not replayable")
+	}
+	if _, ok := st.since(9); ok {
+		t.Fatalf("gap older than the buffer replayable")
+	}
+	if _, ok := st.since(st.seq + 1); ok {
+		t.Fatalf("seq ahead of the stream replayable")
+	}
+}
+
+func TestClient_Resume(t *testing.T) {
+	srv := newTestServer()
+	old := newUserClient(srv, "c1", 1)
+	if old.stream == nil || old.stream.id != "c1" {
+		t.Fatalf("bound client has no stream")
+	}
+	for i := 0; i < 3; i++ {
+		srv.deliverToUser(1, []byte(`{"protocol":31,"data":{"memberID":1}}`))
+	}
+	for want := int64(1); want <= 3; want++ {
+		if resp := recvResp(t, old, nil); resp.Seq != want {
+			t.Fatalf("frame seq:(%d), want:(%d)", resp.Seq, want)
+		}
+	}
+	st := old.stream
+	srv.RemoveClient(old)
+	// Sent while the member was away, kept for the resume
+	st.Lock()
+	st.stamp(&view.WsResp{Protocol: ProtocolPayout})
+	st.Unlock()
+
+	// Another member cannot take the stream
+	other := newUserClient(srv, "c2", 2)
+	other.HandlerResumeReq(&view.WsReq{Protocol: ProtocolResume, Data: view.WsResumeData{ResumeID: "c1", LastSeq: 1}})
+	var data view.WsResumeRespData
+	recvResp(t, other, &data)
+	if data.BOk || !data.Resync || data.ResumeID != "c2" {
+		t.Fatalf("foreign resume:(%+v), want a resync on its own stream", data)
+	}
+
+	cli := newUserClient(srv, "c3", 1)
+	cli.HandlerResumeReq(&view.WsReq{Protocol: ProtocolResume, Data: view.WsResumeData{ResumeID: "c1", LastSeq: 1}})
+	data = view.WsResumeRespData{}
+	if resp := recvResp(t, cli, &data); resp.Seq != 0 || !data.BOk || data.Resync || data.ResumeID != "c1" || data.Seq != 4 {
+		t.Fatalf("resume:(%+v) seq:(%d)", data, resp.Seq)
+	}
+	for want := int64(2); want <= 4; want++ {
+		if resp := recvResp(t, cli, nil); resp.Seq != want {
+			t.Fatalf("replayed seq:(%d), want:(%d)", resp.Seq, want)
+		}
+	}
+	if _, ok := srv.streams["c3"]; ok {
+		t.Fatalf("fresh stream of the resumed connection kept")
+	}
+	// The stream carries on numbering where it stopped
+	srv.deliverToUser(1, []byte(`{"protocol":31,"data":{"memberID":1}}`))
+	if resp := recvResp(t, cli, nil); resp.Seq != 5 {
+		t.Fatalf("next seq:(%d), want 5", resp.Seq)
+	}
+
+	srv.RemoveClient(other)
+	srv.sweepStreams(time.Now().Add(resumeTTL + time.Second))
+	if _, ok := srv.streams["c2"]; ok {
+		t.Fatalf("expired stream kept")
+	}
+	if _, ok := srv.streams["c1"]; !ok {
+		t.Fatalf("stream in use swept")
+	}
+}
+
+func TestReplayStream_StampBytes(t *testing.T) {
+	st := newReplayStream("s", 1, FramingJSON)
+	big := string(make([]byte, 1024))
+	for i := 0; i < 100; i++ {
+		st.stamp(&view.WsResp{Protocol: ProtocolTableData, Data: big})
+	}
+	if st.size > replayBufferBytes || len(st.frames) >= 100 {
+		t.Fatalf("frames:(%d) size:(%d), want at most %d bytes", len(st.frames), st.size, replayBufferBytes)
+	}
+	size := 0
+	for _, f := range st.frames {
+		size += len(f.b)
+	}
+	if size != st.size || st.frames[len(st.frames)-1].seq != 100 {
+		t.Fatalf("size:(%d) counted:(%d) newest:(%d)", st.size, size, st.frames[len(st.frames)-1].seq)
+	}
+}
+
+func TestClient_ResumeRejoinsTable(t *testing.T) {
+	srv := newTestServer()
+	old := newUserClient(srv, "c1", 1)
+	table := &view.WsTableEntryReq{GameID: 1, GroupID: 115}
+	if _, err := srv.JoinRoom(old, "115", 0, table); err != nil {
+		t.Fatalf("join err:(%+v)", err)
+	}
+	// The connection drops, unregister takes it out of the room
+	srv.LeaveRoom(old)
+	srv.RemoveClient(old)
+	if _, ok := srv.rooms["115"]; ok {
+		t.Fatalf("empty room kept")
+	}
+
+	cli := newUserClient(srv, "c2", 1)
+	cli.HandlerResumeReq(&view.WsReq{Protocol: ProtocolResume, Data: view.WsResumeData{ResumeID: "c1"}})
+	var data view.WsResumeRespData
+	recvResp(t, cli, &data)
+	if !data.BOk || data.ResumeID != "c1" {
+		t.Fatalf("resume:(%+v)", data)
+	}
+	room, got := cli.joined()
+	if room == nil || room.ID != "115" || got != table {
+		t.Fatalf("resumed client at room:(%v) table:(%+v), want 115", room, got)
+	}
+	if r := srv.rooms["115"]; r == nil || r.TotalClients() != 1 {
+		t.Fatalf("resumed client not in the room")
+	}
+
+	// Leaving the table on purpose is not undone by the next resume
+	srv.LeaveRoom(cli)
+	cli.stream.setTable(nil)
+	srv.RemoveClient(cli)
+	again := newUserClient(srv, "c3", 1)
+	again.HandlerResumeReq(&view.WsReq{Protocol: ProtocolResume, Data: view.WsResumeData{ResumeID: "c1"}})
+	recvResp(t, again, nil)
+	if room, _ := again.joined(); room != nil {
+		t.Fatalf("client rejoined a table it left")
+	}
+}
+
+func TestClient_ResumeQueueFull(t *testing.T) {
+	srv := newTestServer()
+	old := newUserClient(srv, "c1", 1)
+	st := old.stream
+	srv.RemoveClient(old)
+	st.Lock()
+	for i := 0; i < cap(old.bytesSend); i++ {
+		st.stamp(&view.WsResp{Protocol: ProtocolPayout})
+	}
+	st.Unlock()
+
+	// newUserClient queues 8 frames at most, the 8 missed ones and the answer do not fit
+	cli := newUserClient(srv, "c2", 1)
+	cli.HandlerResumeReq(&view.WsReq{Protocol: ProtocolResume, Data: view.WsResumeData{ResumeID: "c1"}})
+	var data view.WsResumeRespData
+	recvResp(t, cli, &data)
+	if data.BOk || !data.Resync || data.ResumeID != "c1" || data.Seq != int64(cap(old.bytesSend)) {
+		t.Fatalf("resume on a full queue:(%+v), want a resync", data)
+	}
+	if n := len(cli.bytesSend); n != 0 {
+		t.Fatalf("%d frames queued after a resync", n)
+	}
+}
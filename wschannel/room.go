@@ -58,17 +58,25 @@ func (r *Room) GetClientByConnID(connID string) *Client {
 }
 
 func (r *Room) BroadcastToAllClients(cli *Client, wsmsg *view.WsResp) error {
-	r.RLock()
-	defer r.RUnlock()
 	msg, _ := json.Marshal(wsmsg)
+	r.broadcast(msg)
+	return nil
+}
+
+// broadcast sends msg to a snapshot of the clients, the room stays unlocked while sending since
+// a client is locked before its room when it joins or leaves
+func (r *Room) broadcast(msg []byte) {
+	r.RLock()
+	clients := make([]*Client, 0, len(r.clients))
 	for _, c := range r.clients {
-		xlog.Infof("BroadcastToAllClients cli:%+v", c)
-		select {
-		case c.bytesSend <- msg:
-		default:
-		}
+		clients = append(clients, c)
+	}
+	r.RUnlock()
+	frame, err := decodeFrame(msg)
+	for _, c := range clients {
+		xlog.Debugf("BroadcastToAllClients cli:%+v", c)
+		c.sendFrame(frame, msg, err)
 	}
-	return nil
 }
 
 func (r *Room) Desc() int64 {
@@ -83,6 +91,7 @@ func (r *Room) AddClient(cli *Client) error {
 	// local room user number limit
 	total := r.Incr()
 	if total > r.MaxUserInRoom {
+		r.Desc()
 		return ErrRoomFull
 	}
 	r.Lock()
@@ -92,13 +101,14 @@ func (r *Room) AddClient(cli *Client) error {
 	return nil
 }
 
-func (r *Room) RemoveClient(cli *Client) {
+// RemoveClient returns how many clients are left in the room
+func (r *Room) RemoveClient(cli *Client) int {
 	r.Lock()
 	defer r.Unlock()
 
-	delete(r.clients, cli.ConnID())
-	if len(r.clients) == 0 {
-		delete(cli.mgr.rooms, cli.Room.ID)
+	if _, ok := r.clients[cli.ConnID()]; ok {
+		delete(r.clients, cli.ConnID())
+		r.Desc()
 	}
-	r.Desc()
+	return len(r.clients)
 }
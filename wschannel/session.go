@@ -0,0 +1,64 @@
+package wschannel
+
+import (
+	"go-zrbc/config"
+	"go-zrbc/pkg/mq"
+	"go-zrbc/view"
+)
+
+// singleSession reports whether members of agentID keep only their newest connection.
+// An agent policy beats the default one (agent 0), without any policy it is on.
+func singleSession(policies []config.WsSessionPolicy, agentID int64) bool {
+	single, found := true, false
+	for _, policy := range policies {
+		if policy.AgentID == agentID {
+			return policy.SingleSession
+		}
+		if policy.AgentID == 0 && !found {
+			single, found = policy.SingleSession, true
+		}
+	}
+	return single
+}
+
+// kickReasonCodes maps the reasons of mq.BatchKickUser to the code of the kick frame
+var kickReasonCodes = map[int]WSRespCode{
+	mq.KickReasonLogout:   ErrCodeLoggedOut,
+	mq.KickReasonDisabled: ErrCodeAccountDisabled,
+}
+
+func kickReasonCode(reason int) WSRespCode {
+	if code, ok := kickReasonCodes[reason]; ok {
+		return code
+	}
+	return ErrCodeKicked
+}
+
+// BindUser attaches the authenticated user to cli and indexes the connection under it
+func (srv *Server) BindUser(cli *Client, user *view.WsUser) {
+	srv.Lock()
+	defer srv.Unlock()
+	srv.unbindUser(cli)
+	cli.User = user
+	conns, ok := srv.users[user.ID]
+	if !ok {
+		conns = make(map[string]*Client)
+		srv.users[user.ID] = conns
+	}
+	conns[cli.connID] = cli
+	srv.attachStream(cli)
+}
+
+// unbindUser must be called with srv locked
+func (srv *Server) unbindUser(cli *Client) {
+	if cli.User == nil {
+		return
+	}
+	srv.detachStream(cli)
+	if conns, ok := srv.users[cli.User.ID]; ok {
+		delete(conns, cli.connID)
+		if len(conns) == 0 {
+			delete(srv.users, cli.User.ID)
+		}
+	}
+}